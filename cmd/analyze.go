@@ -3,10 +3,12 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 	"time"
 
 	"github.com/AvogadroSG1/civic-summary/internal/domain"
 	"github.com/AvogadroSG1/civic-summary/internal/output"
+	"github.com/AvogadroSG1/civic-summary/internal/service"
 	"github.com/spf13/cobra"
 )
 
@@ -14,7 +16,8 @@ var analyzeCmd = &cobra.Command{
 	Use:   "analyze <video-id>",
 	Short: "Generate a summary for a specific video",
 	Long: `Phase 3 only: sends the transcript to the configured language model and
-generates a citizen-friendly markdown summary.
+generates a citizen-friendly markdown summary. The meeting agenda is included
+when the video description links one or the body sets agenda_url_pattern.
 
 Requires a transcript file to already exist in the output directory, and an API
 key in the environment variable named by llm.api_key_env.`,
@@ -57,16 +60,17 @@ key in the environment variable named by llm.api_key_env.`,
 				cfg.FinalizedDir(body), meeting.DateFolder(), videoID)
 		}
 
-		transcriptContent, err := os.ReadFile(transcriptPath)
+		transcript, err := service.LoadTranscript(transcriptPath, domain.TranscriptSourceCaptions)
 		if err != nil {
 			return fmt.Errorf("reading transcript: %w", err)
 		}
 
-		transcript := domain.Transcript{
-			Content: string(transcriptContent),
-			Path:    transcriptPath,
-			Source:  domain.TranscriptSourceCaptions,
-		}
+		ytdlp := buildYtDlp(cfg)
+		transcript = service.NewTranscriptionService(nil, nil).ApplyVocabulary(meeting, body, transcript)
+		transcript = buildDiarizationService(cfg, buildVideoSourceFor(ytdlp)).
+			Diarize(cmd.Context(), meeting, body, transcript, filepath.Dir(transcriptPath))
+		transcript, meeting.Window = service.TrimToMeeting(meeting, body, transcript)
+		meeting.Agenda = buildAgendaService(cfg, ytdlp).Find(cmd.Context(), meeting, body)
 
 		summary, err := buildAnalysisService(cfg).Analyze(cmd.Context(), meeting, transcript, body)
 		if err != nil {
@@ -90,7 +94,7 @@ key in the environment variable named by llm.api_key_env.`,
 func init() {
 	analyzeCmd.Flags().String("body", "", "body slug")
 	analyzeCmd.Flags().String("date", "", "meeting date (YYYY-MM-DD)")
-	analyzeCmd.Flags().String("transcript", "", "path to transcript file")
+	analyzeCmd.Flags().String("transcript", "", "path to transcript file (SRT, WebVTT, whisper JSON, or plain text)")
 	analyzeCmd.Flags().String("output", "", "output file path (default: stdout)")
 	_ = analyzeCmd.MarkFlagRequired("body")
 	_ = analyzeCmd.MarkFlagRequired("date")
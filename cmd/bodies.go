@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/AvogadroSG1/civic-summary/internal/domain"
 	"github.com/AvogadroSG1/civic-summary/internal/output"
 	"github.com/spf13/cobra"
 )
@@ -26,7 +27,7 @@ var bodiesListCmd = &cobra.Command{
 		output.Info("Configured bodies:")
 		for slug, body := range cfg.Bodies {
 			fmt.Printf("  %s: %s\n", slug, body.Name)
-			fmt.Printf("    Source: %s\n", body.DiscoveryURL())
+			fmt.Printf("    Source: %s (%s)\n", body.DiscoveryURL(), body.SourceType())
 			fmt.Printf("    Template: %s\n", body.PromptTemplate)
 			fmt.Println()
 		}
@@ -53,6 +54,7 @@ var bodiesShowCmd = &cobra.Command{
 
 		output.Banner(body.Name)
 		fmt.Printf("  Slug:             %s\n", body.Slug)
+		fmt.Printf("  Source Type:      %s\n", body.SourceType())
 		if body.PlaylistID != "" {
 			fmt.Printf("  Playlist ID:      %s\n", body.PlaylistID)
 		}
@@ -63,19 +65,125 @@ var bodiesShowCmd = &cobra.Command{
 		fmt.Printf("  Output Subdir:    %s\n", body.OutputSubdir)
 		fmt.Printf("  Filename Pattern: %s\n", body.FilenamePattern)
 		fmt.Printf("  Date Regex:       %s\n", body.TitleDateRegex)
+		if len(body.TitleInclude) > 0 {
+			fmt.Printf("  Title Include:    %s\n", strings.Join(body.TitleInclude, ", "))
+		}
+		if len(body.TitleExclude) > 0 {
+			fmt.Printf("  Title Exclude:    %s\n", strings.Join(body.TitleExclude, ", "))
+		}
 		fmt.Printf("  Prompt Template:  %s\n", body.PromptTemplate)
 		fmt.Printf("  Author:           %s\n", body.Author)
 		fmt.Printf("  Tags:             %s\n", strings.Join(body.Tags, ", "))
-		if len(body.MeetingTypes) > 0 {
-			fmt.Printf("  Meeting Types:    %s\n", strings.Join(body.MeetingTypes, ", "))
+		printMeetingTypes(body)
+		if body.AgendaURLPattern != "" {
+			fmt.Printf("  Agenda Pattern:   %s\n", body.AgendaURLPattern)
+		}
+		if body.BackfillFrom != "" {
+			fmt.Printf("  Backfill From:    %s\n", body.BackfillFrom)
 		}
 		fmt.Printf("  Output Dir:       %s\n", cfg.BodyOutputDir(body))
 		fmt.Printf("  Finalized Dir:    %s\n", cfg.FinalizedDir(body))
+		captions := body.CaptionPolicy()
+		fmt.Printf("  Captions:         %s; %s; %s; min quality %d\n",
+			strings.Join(captions.Kinds, ", "), strings.Join(captions.Languages, ", "),
+			strings.Join(captions.Formats, ", "), captions.Threshold())
+		if body.Diarize {
+			fmt.Printf("  Diarize:          yes (%s)\n", cfg.Tools.Diarizer)
+		}
+		if len(body.Vocabulary) > 0 {
+			fmt.Printf("  Vocabulary:       %d terms\n", len(body.Vocabulary))
+		}
+		printRoster(body.Roster)
+		fmt.Println()
+		fmt.Println("  Validation:")
+		printValidationRules(body.ValidationRules())
+		for _, rule := range body.MeetingTypes {
+			if rule.Validation != nil {
+				fmt.Println()
+				fmt.Printf("  Validation for %s:\n", rule.Name)
+				printValidationRules(body.ForMeetingType(rule.Name).ValidationRules())
+			}
+		}
 
 		return nil
 	},
 }
 
+// printMeetingTypes lists the body's meeting type rules in the order they are
+// tried, with whatever each one overrides.
+func printMeetingTypes(body domain.Body) {
+	if len(body.MeetingTypes) == 0 {
+		return
+	}
+	fmt.Println("  Meeting Types:")
+	for _, rule := range body.MeetingTypes {
+		match := "title contains name"
+		if rule.Match != "" {
+			match = "/" + rule.Match + "/"
+		}
+		fmt.Printf("    %s: %s, tag %s\n", rule.Name, match, rule.TagName())
+		if rule.PromptTemplate != "" {
+			fmt.Printf("      Prompt Template: %s\n", rule.PromptTemplate)
+		}
+		if rule.ChunkTemplate != "" {
+			fmt.Printf("      Chunk Template:  %s\n", rule.ChunkTemplate)
+		}
+	}
+	fmt.Printf("    (then built-in Work Session, Special Meeting, Evening Meeting; else %s)\n", domain.DefaultMeetingType)
+}
+
+// printRoster lists the body's officials with their seats and aliases.
+func printRoster(roster domain.Roster) {
+	if len(roster) == 0 {
+		return
+	}
+	fmt.Println("  Roster:")
+	for _, m := range roster {
+		line := m.Label()
+		if m.Seat != "" {
+			line += " (" + m.Seat + ")"
+		}
+		if len(m.Aliases) > 0 {
+			line += "; also " + strings.Join(m.Aliases, ", ")
+		}
+		fmt.Printf("    %s\n", line)
+	}
+}
+
+// printValidationRules prints a body's effective validation rules, with
+// severities shown wherever they differ from error.
+func printValidationRules(rules domain.ValidationRules) {
+	minWords, warnWords := rules.Words()
+
+	fmt.Printf("    Required Headings: %s\n", formatTextRules(rules.RequiredHeadings))
+	fmt.Printf("    Min Words:         %d\n", minWords)
+	fmt.Printf("    Warn Words:        %d\n", warnWords)
+	if rules.FullLengthMinutes != nil && *rules.FullLengthMinutes > 0 {
+		fmt.Printf("    Full Length:       %d min (shorter meetings scale word counts down)\n", *rules.FullLengthMinutes)
+	}
+	fmt.Printf("    Frontmatter Keys:  %s\n", strings.Join(rules.RequiredFrontmatter, ", "))
+	fmt.Printf("    Forbidden Phrases: %s\n", formatTextRules(rules.ForbiddenPhrases))
+	checks := make([]string, 0, len(rules.Checks))
+	for _, name := range domain.CheckNames() {
+		checks = append(checks, fmt.Sprintf("%s=%s", name, rules.Check(name)))
+	}
+	fmt.Printf("    Checks:            %s\n", strings.Join(checks, ", "))
+}
+
+func formatTextRules(rules []domain.TextRule) string {
+	if len(rules) == 0 {
+		return "(none)"
+	}
+	parts := make([]string, len(rules))
+	for i, r := range rules {
+		parts[i] = r.String()
+		if r.Level() != domain.RuleError {
+			parts[i] += fmt.Sprintf(" (%s)", r.Level())
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
 func init() {
 	bodiesCmd.AddCommand(bodiesListCmd)
 	bodiesCmd.AddCommand(bodiesShowCmd)
@@ -15,9 +15,11 @@ var crossrefCmd = &cobra.Command{
 	Use:   "crossref <file>",
 	Short: "Add Obsidian wikilinks to a summary file",
 	Long: `Phase 4 only: scans a summary for date references and converts them
-to Obsidian wikilinks when matching summaries exist.`,
-	Example: `  civic-summary crossref summary.md --body=hagerstown --date=2025-02-04`,
-	Args:    cobra.ExactArgs(1),
+to Obsidian wikilinks when matching summaries exist. With --video, also links
+each [HH:MM:SS] timestamp to that moment in the meeting video.`,
+	Example: `  civic-summary crossref summary.md --body=hagerstown --date=2025-02-04
+  civic-summary crossref summary.md --body=hagerstown --date=2025-02-04 --video=abc123 -i`,
+	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		filePath := args[0]
 
@@ -45,13 +47,18 @@ to Obsidian wikilinks when matching summaries exist.`,
 			return fmt.Errorf("reading file: %w", err)
 		}
 
+		videoID, _ := cmd.Flags().GetString("video")
 		meeting := domain.Meeting{
+			VideoID:     videoID,
 			MeetingDate: meetingDate,
 			BodySlug:    body.Slug,
 		}
 
 		crossref := service.NewCrossReferenceService(cfg)
 		result := crossref.AddCrossReferences(string(content), meeting, body)
+		if videoID != "" {
+			result = service.NewTimestampLinkService().AddTimestampLinks(result, meeting, body)
+		}
 
 		inPlace, _ := cmd.Flags().GetBool("in-place")
 		if inPlace {
@@ -70,6 +77,7 @@ to Obsidian wikilinks when matching summaries exist.`,
 func init() {
 	crossrefCmd.Flags().String("body", "", "body slug")
 	crossrefCmd.Flags().String("date", "", "meeting date of the document (YYYY-MM-DD)")
+	crossrefCmd.Flags().String("video", "", "video ID to link timestamps to")
 	crossrefCmd.Flags().BoolP("in-place", "i", false, "modify file in place")
 	_ = crossrefCmd.MarkFlagRequired("body")
 	_ = crossrefCmd.MarkFlagRequired("date")
@@ -2,7 +2,9 @@ package cmd
 
 import (
 	"fmt"
+	"time"
 
+	"github.com/AvogadroSG1/civic-summary/internal/domain"
 	"github.com/AvogadroSG1/civic-summary/internal/executor"
 	"github.com/AvogadroSG1/civic-summary/internal/output"
 	"github.com/AvogadroSG1/civic-summary/internal/service"
@@ -12,9 +14,24 @@ import (
 var discoverCmd = &cobra.Command{
 	Use:   "discover",
 	Short: "Find unprocessed videos from a body's video source",
-	Long:  `Phase 1 only: lists videos from the configured source that don't have finalized summaries.`,
+	Long: `Phase 1 only: lists videos from the configured source that are not yet in the body's ledger.
+
+By default only meetings from the current year are listed (plus the previous
+year during January), or from the body's backfill_from date if set. Use
+--since/--until or --year to choose a different range. Results are oldest-first.
+
+Same-date meetings are shown with the number they would be given. Discover
+changes nothing: the numbers are kept in the body's Automation/sequences.json,
+and a summary renamed when a new upload means it must gain a suffix, only by
+"process".
+
+--explain lists every video in the source instead, with the title_date_regex
+pattern that dated it or the reason it was skipped (a title filter, no date,
+too short, outside the range, or already in the ledger). It changes nothing.`,
 	Example: `  civic-summary discover --body=hagerstown
-  civic-summary discover --body=bocc`,
+  civic-summary discover --body=hagerstown --explain
+  civic-summary discover --body=bocc --year=2023
+  civic-summary discover --body=bocc --since=2022-07-01 --until=2022-12-31`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		cfg, err := loadConfig()
 		if err != nil {
@@ -26,31 +43,68 @@ var discoverCmd = &cobra.Command{
 			return err
 		}
 
-		commander := executor.NewOsCommander()
-		ytdlp := executor.NewYtDlpExecutor(commander, cfg.Tools.YtDlp)
-		discovery := service.NewDiscoveryService(ytdlp, cfg)
+		requested, err := dateRangeFromFlags(cmd)
+		if err != nil {
+			return err
+		}
+
+		ytdlp := executor.NewYtDlpExecutor(executor.NewOsCommander(), cfg.Tools.YtDlp)
+		discovery := service.NewDiscoveryService(buildVideoSourceFor(ytdlp), cfg)
 
-		meetings, err := discovery.DiscoverNewMeetings(cmd.Context(), body)
+		window := body.DiscoveryWindow(requested, time.Now())
+		if explain, _ := cmd.Flags().GetBool("explain"); explain {
+			return explainDiscovery(cmd, discovery, body, window)
+		}
+
+		meetings, err := discovery.DiscoverNewMeetings(cmd.Context(), body, window)
 		if err != nil {
 			return err
 		}
 
 		if len(meetings) == 0 {
-			output.Success("No new videos for %s", body.Name)
+			output.Success("No new videos for %s (%s)", body.Name, window)
 			return nil
 		}
 
 		output.Info("Found %d new video(s) for %s:", len(meetings), body.Name)
 		for _, m := range meetings {
-			fmt.Printf("  %s | %s | %s\n", m.VideoID, m.ISODate(), m.Title)
+			fmt.Printf("  %s | %s | %s | %s\n", m.VideoID, m.ISODate(), m.MeetingType, m.Title)
 		}
 
 		return nil
 	},
 }
 
+// explainDiscovery prints what discovery makes of every listed video.
+func explainDiscovery(cmd *cobra.Command, discovery *service.DiscoveryService, body domain.Body, window domain.DateRange) error {
+	decisions, err := discovery.Explain(cmd.Context(), body, window)
+	if err != nil {
+		return err
+	}
+
+	newCount := 0
+	for _, d := range decisions {
+		date := "----------"
+		if !d.Meeting.MeetingDate.IsZero() {
+			date = d.Meeting.ISODate()
+		}
+		fmt.Printf("  %s | %s | %s\n", d.Video.ID, date, d.Video.Title)
+		if d.Skipped != "" {
+			fmt.Printf("      skipped: %s\n", d.Skipped)
+			continue
+		}
+		fmt.Printf("      new %s, dated by %s\n", d.Meeting.MeetingType, d.DatedBy)
+		newCount++
+	}
+
+	output.Info("%d of %d video(s) for %s are new (%s)", newCount, len(decisions), body.Name, window)
+	return nil
+}
+
 func init() {
 	discoverCmd.Flags().String("body", "", "body slug to discover")
 	_ = discoverCmd.MarkFlagRequired("body")
+	discoverCmd.Flags().Bool("explain", false, "show how every listed video was dated or why it was skipped")
+	addDateRangeFlags(discoverCmd)
 	rootCmd.AddCommand(discoverCmd)
 }
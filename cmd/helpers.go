@@ -2,15 +2,26 @@ package cmd
 
 import (
 	"fmt"
+	"net/http"
+	"time"
 
 	"github.com/AvogadroSG1/civic-summary/internal/config"
 	"github.com/AvogadroSG1/civic-summary/internal/domain"
 	"github.com/AvogadroSG1/civic-summary/internal/executor"
 	"github.com/AvogadroSG1/civic-summary/internal/llm"
 	"github.com/AvogadroSG1/civic-summary/internal/service"
+	"github.com/AvogadroSG1/civic-summary/internal/source"
+	"github.com/AvogadroSG1/civic-summary/internal/transcriber"
 	"github.com/spf13/cobra"
 )
 
+const (
+	// agendaFetchTimeout bounds each agenda download.
+	agendaFetchTimeout = time.Minute
+	// sourceFetchTimeout bounds each feed or URL listing download.
+	sourceFetchTimeout = time.Minute
+)
+
 // loadConfig loads and validates the application configuration.
 func loadConfig() (*config.Config, error) {
 	cfg, err := config.Load(cfgFile)
@@ -32,50 +43,115 @@ func getBody(cmd *cobra.Command, cfg *config.Config) (domain.Body, error) {
 	return cfg.GetBody(slug)
 }
 
-// buildExecutors creates all executor instances from config.
-func buildExecutors(cfg *config.Config) (*executor.YtDlpExecutor, *executor.WhisperExecutor) {
-	commander := executor.NewOsCommander()
+// addDateRangeFlags registers the --since, --until, and --year flags that
+// select which meetings discovery returns.
+func addDateRangeFlags(cmd *cobra.Command) {
+	cmd.Flags().String("since", "", "only meetings on or after this date (YYYY-MM-DD)")
+	cmd.Flags().String("until", "", "only meetings on or before this date (YYYY-MM-DD)")
+	cmd.Flags().Int("year", 0, "only meetings in this year (cannot combine with --since/--until)")
+}
 
-	ytdlp := executor.NewYtDlpExecutor(commander, cfg.Tools.YtDlp)
+// dateRangeFromFlags reads the flags registered by addDateRangeFlags. A zero
+// range means none were given and each body's default window applies.
+func dateRangeFromFlags(cmd *cobra.Command) (domain.DateRange, error) {
+	since, _ := cmd.Flags().GetString("since")
+	until, _ := cmd.Flags().GetString("until")
+	year, _ := cmd.Flags().GetInt("year")
+	return domain.ParseDateRange(since, until, year)
+}
 
-	var whisper *executor.WhisperExecutor
-	if cfg.Tools.Whisper != "" && cfg.Tools.WhisperModel != "" {
-		whisper = executor.NewWhisperExecutor(commander, cfg.Tools.Whisper, cfg.Tools.WhisperModel)
+// buildYtDlp creates the yt-dlp executor from config.
+func buildYtDlp(cfg *config.Config) *executor.YtDlpExecutor {
+	return executor.NewYtDlpExecutor(executor.NewOsCommander(), cfg.Tools.YtDlp)
+}
+
+// buildTranscriberFor returns a resolver that builds the speech-to-text
+// backend for a body, honouring any per-body override of the global
+// transcriber block. Bodies without a backend get nil, which disables the
+// Whisper fallback. With tools.ffmpeg set, audio is prepared and split into
+// segments before it reaches the backend.
+func buildTranscriberFor(cfg *config.Config) service.TranscriberFor {
+	commander := executor.NewOsCommander()
+	return func(body domain.Body) (transcriber.Transcriber, error) {
+		sttCfg := cfg.ResolveTranscriber(body)
+		if !sttCfg.Enabled() {
+			return nil, nil
+		}
+		stt, err := transcriber.New(sttCfg, commander)
+		if err != nil || cfg.Tools.FFmpeg == "" {
+			return stt, err
+		}
+		return transcriber.NewSegmented(stt, executor.NewFFmpegExecutor(commander, cfg.Tools.FFmpeg), cfg.Audio), nil
 	}
+}
 
-	return ytdlp, whisper
+// buildVideoSourceFor returns a resolver that builds the video source selected
+// by each body's source.type.
+func buildVideoSourceFor(ytdlp *executor.YtDlpExecutor) service.VideoSourceFor {
+	httpClient := &http.Client{Timeout: sourceFetchTimeout}
+	return func(body domain.Body) (source.VideoSource, error) {
+		return source.New(body, ytdlp, httpClient)
+	}
 }
 
 // buildLLMClientFor returns a resolver that builds the language-model client for
 // a body, honouring any per-body override of the global llm block. The client is
 // built lazily so that commands which never analyse a meeting do not require an
-// API key.
+// API key. Requests are capped per provider by concurrency.llm_requests.
 func buildLLMClientFor(cfg *config.Config) service.LLMClientFor {
-	return func(body domain.Body) (llm.Client, error) {
+	resolver := func(body domain.Body) (llm.Client, error) {
 		return llm.New(cfg.ResolveLLM(body))
 	}
+	return service.LimitLLMRequests(resolver, cfg, cfg.Concurrency.LLMRequests)
 }
 
 // buildAnalysisService creates an AnalysisService wired to the configured
 // provider. Both the full pipeline and the standalone analyze command use it.
 func buildAnalysisService(cfg *config.Config) *service.AnalysisService {
-	return service.NewAnalysisService(buildLLMClientFor(cfg), cfg.TemplateDir())
+	return service.NewAnalysisService(buildLLMClientFor(cfg), cfg.ResolveLLM, cfg.TemplateDir())
+}
+
+// buildAgendaService creates an AgendaService. PDF extraction is disabled when
+// tools.pdftotext is empty.
+func buildAgendaService(cfg *config.Config, ytdlp *executor.YtDlpExecutor) *service.AgendaService {
+	var pdftotext *executor.PdfToTextExecutor
+	if cfg.Tools.PdfToText != "" {
+		pdftotext = executor.NewPdfToTextExecutor(executor.NewOsCommander(), cfg.Tools.PdfToText)
+	}
+	return service.NewAgendaService(buildVideoSourceFor(ytdlp), pdftotext, &http.Client{Timeout: agendaFetchTimeout})
+}
+
+// buildDiarizationService creates a DiarizationService. Diarization is
+// disabled when tools.diarizer is empty.
+func buildDiarizationService(cfg *config.Config, sources service.VideoSourceFor) *service.DiarizationService {
+	var diarizer *executor.DiarizerExecutor
+	if cfg.Tools.Diarizer != "" {
+		diarizer = executor.NewDiarizerExecutor(executor.NewOsCommander(), cfg.Tools.Diarizer, cfg.Tools.DiarizerArgs)
+	}
+	return service.NewDiarizationService(sources, diarizer)
 }
 
 // buildPipeline creates a fully-wired PipelineOrchestrator.
 func buildPipeline(cfg *config.Config) *service.PipelineOrchestrator {
-	ytdlp, whisper := buildExecutors(cfg)
+	ytdlp := buildYtDlp(cfg)
+
+	sources := buildVideoSourceFor(ytdlp)
 
-	discovery := service.NewDiscoveryService(ytdlp, cfg)
-	transcription := service.NewTranscriptionService(ytdlp, whisper)
+	discovery := service.NewDiscoveryService(sources, cfg)
+	transcription := service.NewTranscriptionService(sources, buildTranscriberFor(cfg))
+	diarization := buildDiarizationService(cfg, sources)
+	agendas := buildAgendaService(cfg, ytdlp)
 	analysis := buildAnalysisService(cfg)
 	crossref := service.NewCrossReferenceService(cfg)
+	timestamps := service.NewTimestampLinkService()
 	validation := service.NewValidationService()
 	quarantine := service.NewQuarantineService(cfg)
 	index := service.NewIndexService(cfg)
+	checkpoints := service.NewCheckpointService(cfg)
+	deferrals := service.NewDeferralService(cfg)
 
 	return service.NewPipelineOrchestrator(
-		discovery, transcription, analysis, crossref,
-		validation, quarantine, index, cfg,
+		discovery, transcription, diarization, agendas, analysis, crossref,
+		timestamps, validation, quarantine, index, checkpoints, deferrals, cfg,
 	)
 }
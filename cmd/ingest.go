@@ -0,0 +1,107 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/AvogadroSG1/civic-summary/internal/executor"
+	"github.com/AvogadroSG1/civic-summary/internal/output"
+	"github.com/AvogadroSG1/civic-summary/internal/service"
+	"github.com/AvogadroSG1/civic-summary/internal/source"
+	"github.com/spf13/cobra"
+)
+
+var ingestCmd = &cobra.Command{
+	Use:   "ingest <file>",
+	Short: "Summarize a local recording or transcript",
+	Long: `Runs a local file through transcription, analysis, cross-referencing, and
+validation, then writes the summary alongside the body's other meetings.
+
+The file may be a recording (MP4, MOV, MP3, M4A, WAV, and similar), which is
+transcribed with Whisper, or a transcript (SRT, WebVTT, whisper JSON, or plain
+text), which is used as-is. The meeting date comes from --date, or else from
+the file name (the body's title_date_regex, then dates like 2025-03-04 or
+20250304), or else the file's modification time.
+
+Ingested files are recorded in the body's ledger, so a watch folder
+(source.type: folder) does not summarize them again. To process a watch
+folder, run "civic-summary process" for its body.`,
+	Example: `  civic-summary ingest ~/Downloads/GMT20250304-180000_Recording.mp4 --body=planning
+  civic-summary ingest board-2025-03-11.srt --body=school-board --type="Work Session"
+  civic-summary ingest meeting.m4a --body=planning --date=2025-03-18 --force`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := loadConfig()
+		if err != nil {
+			return err
+		}
+
+		body, err := getBody(cmd, cfg)
+		if err != nil {
+			return err
+		}
+
+		video, err := source.LocalVideo(args[0])
+		if err != nil {
+			return err
+		}
+		if title, _ := cmd.Flags().GetString("title"); title != "" {
+			video.Title = title
+		}
+
+		ytdlp := executor.NewYtDlpExecutor(executor.NewOsCommander(), cfg.Tools.YtDlp)
+		discovery := service.NewDiscoveryService(buildVideoSourceFor(ytdlp), cfg)
+		meeting, err := discovery.MeetingFor(video, body)
+		if err != nil {
+			return err
+		}
+
+		if dateStr, _ := cmd.Flags().GetString("date"); dateStr != "" {
+			date, err := time.Parse("2006-01-02", dateStr)
+			if err != nil {
+				return fmt.Errorf("invalid date format: %w", err)
+			}
+			meeting.MeetingDate = date
+		}
+		if meetingType, _ := cmd.Flags().GetString("type"); meetingType != "" {
+			meeting.MeetingType = meetingType
+		}
+
+		// The meeting is only numbered once it is known to be new, and gives
+		// its number back if it cannot be summarized, so neither a refused nor
+		// a failed ingest renumbers the other meetings on its date.
+		force, _ := cmd.Flags().GetBool("force")
+		if discovery.IsProcessed(meeting, body) && !force {
+			meeting.Sequence = discovery.CurrentSequence(meeting, body)
+			return fmt.Errorf("%s was already summarized (%s); use --force to summarize it again",
+				video.Title, discovery.SummaryPath(meeting, body))
+		}
+		meeting, assigned, err := discovery.AssignSequence(meeting, body)
+		if err != nil {
+			return err
+		}
+
+		output.Info("Ingesting: %s (%s, %s)", video.Title, meeting.ISODate(), meeting.MeetingType)
+		if err := buildPipeline(cfg).ProcessMeeting(cmd.Context(), meeting, body); err != nil {
+			if assigned {
+				if rerr := discovery.ReleaseSequence(meeting, body); rerr != nil {
+					output.Warning("%v", rerr)
+				}
+			}
+			return err
+		}
+
+		output.Success("Summary written to %s", discovery.SummaryPath(meeting, body))
+		return nil
+	},
+}
+
+func init() {
+	ingestCmd.Flags().String("body", "", "body slug")
+	ingestCmd.Flags().String("date", "", "meeting date (YYYY-MM-DD; default: from the file name or modification time)")
+	ingestCmd.Flags().String("title", "", "meeting title (default: the file name)")
+	ingestCmd.Flags().String("type", "", "meeting type (default: detected from the title)")
+	ingestCmd.Flags().Bool("force", false, "summarize the file even if it was already ingested")
+	_ = ingestCmd.MarkFlagRequired("body")
+	rootCmd.AddCommand(ingestCmd)
+}
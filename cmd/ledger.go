@@ -0,0 +1,234 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/AvogadroSG1/civic-summary/internal/domain"
+	"github.com/AvogadroSG1/civic-summary/internal/executor"
+	"github.com/AvogadroSG1/civic-summary/internal/output"
+	"github.com/AvogadroSG1/civic-summary/internal/service"
+	"github.com/spf13/cobra"
+)
+
+var ledgerCmd = &cobra.Command{
+	Use:   "ledger",
+	Short: "Manage the processed-meetings ledger",
+	Long: `View and edit the ledger of meetings each body has processed.
+
+Discovery skips any video in the ledger, whatever its status: done (a summary
+was written), quarantined (waiting for a retry), skipped (removed from
+quarantine without a summary), or ignored. Renaming, moving, or deleting a
+summary therefore does not bring its meeting back; use "ledger forget" to have
+a meeting processed again.
+
+The ledger is kept in the body's Automation/ledger.json. The first discovery
+for a body builds it from the existing finalized summaries.`,
+}
+
+var ledgerListCmd = &cobra.Command{
+	Use:   "list [status]",
+	Short: "List ledger entries, optionally only those with one status",
+	Example: `  civic-summary ledger list --body=hagerstown
+  civic-summary ledger list ignored --body=hagerstown`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := loadConfig()
+		if err != nil {
+			return err
+		}
+
+		body, err := getBody(cmd, cfg)
+		if err != nil {
+			return err
+		}
+
+		var status domain.LedgerStatus
+		if len(args) > 0 {
+			if status, err = domain.ParseLedgerStatus(args[0]); err != nil {
+				return err
+			}
+		}
+
+		entries, err := service.NewLedgerService(cfg).List(body)
+		if err != nil {
+			return err
+		}
+
+		shown := 0
+		for _, e := range entries {
+			if status != "" && e.Status != status {
+				continue
+			}
+			date := e.MeetingDate
+			if date == "" {
+				date = "----------"
+			}
+			fmt.Printf("  %s | %s | %-11s | %s\n", date, e.VideoID, e.Status, e.OutputPath)
+			shown++
+		}
+
+		if shown == 0 {
+			output.Success("No ledger entries for %s", body.Name)
+		}
+		return nil
+	},
+}
+
+var ledgerForgetCmd = &cobra.Command{
+	Use:   "forget <video-id>",
+	Short: "Remove a meeting from the ledger so it is processed again",
+	Long: `Removes a video's ledger entry. The next "process" run treats the meeting
+as new and summarizes it again, overwriting any summary at its output path.`,
+	Example: `  civic-summary ledger forget abc123 --body=hagerstown`,
+	Args:    cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := loadConfig()
+		if err != nil {
+			return err
+		}
+
+		body, err := getBody(cmd, cfg)
+		if err != nil {
+			return err
+		}
+
+		if err := service.NewLedgerService(cfg).Forget(body, args[0]); err != nil {
+			return err
+		}
+
+		output.Success("Forgot %s; it will be processed on the next run", args[0])
+		return nil
+	},
+}
+
+var ledgerIgnoreCmd = &cobra.Command{
+	Use:   "ignore <video-id>",
+	Short: "Mark a meeting so it is never processed",
+	Long: `Marks a video as ignored, such as a test stream or a duplicate upload.
+The video need not have been discovered yet.`,
+	Example: `  civic-summary ledger ignore abc123 --body=hagerstown`,
+	Args:    cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := loadConfig()
+		if err != nil {
+			return err
+		}
+
+		body, err := getBody(cmd, cfg)
+		if err != nil {
+			return err
+		}
+
+		if err := service.NewLedgerService(cfg).SetStatus(body, args[0], domain.LedgerIgnored); err != nil {
+			return err
+		}
+
+		output.Success("Ignoring %s", args[0])
+		return nil
+	},
+}
+
+var ledgerImportCmd = &cobra.Command{
+	Use:   "import",
+	Short: "Add existing finalized summaries to the ledger",
+	Long: `Scans the body's finalized summaries and records each one as done,
+matching it to a video in the body's source listing by file name or by the
+source URL in its frontmatter. Existing ledger entries are left unchanged.
+
+Discovery runs this once automatically; run it again after copying in
+summaries produced elsewhere.`,
+	Example: `  civic-summary ledger import --body=hagerstown`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := loadConfig()
+		if err != nil {
+			return err
+		}
+
+		body, err := getBody(cmd, cfg)
+		if err != nil {
+			return err
+		}
+
+		ytdlp := executor.NewYtDlpExecutor(executor.NewOsCommander(), cfg.Tools.YtDlp)
+		discovery := service.NewDiscoveryService(buildVideoSourceFor(ytdlp), cfg)
+		result, err := discovery.ImportLedger(cmd.Context(), body)
+		if err != nil {
+			return err
+		}
+
+		output.Success("Added %d summaries to the ledger for %s", result.Added, body.Name)
+		if len(result.Unmatched) > 0 {
+			output.Warning("%d summaries could not be matched to a video:", len(result.Unmatched))
+			for _, path := range result.Unmatched {
+				fmt.Printf("  %s\n", path)
+			}
+		}
+		return nil
+	},
+}
+
+var ledgerCheckCmd = &cobra.Command{
+	Use:   "check",
+	Short: "Find summaries whose same-date sequence has changed",
+	Long: `Compares each processed meeting's sequence with the persisted same-date
+order. A mismatch usually means a solo meeting, written without a suffix,
+must become "-1" because a second meeting for its date was uploaded.
+
+Discovery repairs these automatically; pass --fix to repair them now.
+Summaries renamed by hand keep their names and only their ledger entries
+change.`,
+	Example: `  civic-summary ledger check --body=bocc
+  civic-summary ledger check --body=bocc --fix`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := loadConfig()
+		if err != nil {
+			return err
+		}
+
+		body, err := getBody(cmd, cfg)
+		if err != nil {
+			return err
+		}
+
+		sequences := service.NewSequenceService(cfg, service.NewLedgerService(cfg))
+		fix, _ := cmd.Flags().GetBool("fix")
+		if fix {
+			changes, err := sequences.Repair(body)
+			for _, c := range changes {
+				if c.Missing {
+					output.Warning("Renumbered %s", c)
+					continue
+				}
+				output.Success("Renumbered %s", c)
+			}
+			return err
+		}
+
+		changes, err := sequences.Check(body)
+		if err != nil {
+			return err
+		}
+		if len(changes) == 0 {
+			output.Success("All summary sequences for %s are current", body.Name)
+			return nil
+		}
+		output.Warning("%d summaries need renumbering (run with --fix):", len(changes))
+		for _, c := range changes {
+			fmt.Printf("  %s\n    %s -> %s\n", c, c.OldPath, c.NewPath)
+		}
+		return nil
+	},
+}
+
+func init() {
+	ledgerCmd.PersistentFlags().String("body", "", "body slug")
+	_ = ledgerCmd.MarkPersistentFlagRequired("body")
+
+	ledgerCmd.AddCommand(ledgerListCmd)
+	ledgerCmd.AddCommand(ledgerForgetCmd)
+	ledgerCmd.AddCommand(ledgerIgnoreCmd)
+	ledgerCmd.AddCommand(ledgerImportCmd)
+	ledgerCheckCmd.Flags().Bool("fix", false, "rename summaries and update the ledger")
+	ledgerCmd.AddCommand(ledgerCheckCmd)
+	rootCmd.AddCommand(ledgerCmd)
+}
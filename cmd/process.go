@@ -3,7 +3,9 @@ package cmd
 import (
 	"fmt"
 
+	"github.com/AvogadroSG1/civic-summary/internal/domain"
 	"github.com/AvogadroSG1/civic-summary/internal/output"
+	"github.com/AvogadroSG1/civic-summary/internal/service"
 	"github.com/spf13/cobra"
 )
 
@@ -13,10 +15,21 @@ var processCmd = &cobra.Command{
 	Long: `Runs all 5 pipeline stages (discovery, transcription, analysis,
 cross-referencing, validation) for one or all configured bodies.
 
-Without --body, processes all configured bodies sequentially.`,
+Without --body, processes all configured bodies. The concurrency block in the
+config bounds how many bodies, meetings, transcriptions, and LLM requests run
+at once; the defaults process everything one at a time.
+
+A livestream is skipped until it has ended. A YouTube meeting without captions
+yet is deferred and checked again after captions.recheck_minutes; Whisper is
+used only once captions.grace_hours have passed since it was released.
+
+Discovery covers the current year (plus the previous year during January), or
+each body's backfill_from date onward. Use --since/--until or --year to
+backfill a specific range; meetings are processed oldest-first.`,
 	Example: `  civic-summary process --body=hagerstown
   civic-summary process --all
-  civic-summary process --body=hagerstown --dry-run`,
+  civic-summary process --body=hagerstown --dry-run
+  civic-summary process --body=bocc --year=2023`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		cfg, err := loadConfig()
 		if err != nil {
@@ -24,6 +37,11 @@ Without --body, processes all configured bodies sequentially.`,
 		}
 
 		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		window, err := dateRangeFromFlags(cmd)
+		if err != nil {
+			return err
+		}
+		opts := service.ProcessOptions{DryRun: dryRun, Window: window}
 		bodySlug, _ := cmd.Flags().GetString("body")
 		all, _ := cmd.Flags().GetBool("all")
 
@@ -34,11 +52,11 @@ Without --body, processes all configured bodies sequentially.`,
 			if err != nil {
 				return err
 			}
-			stats, err := pipeline.ProcessBody(cmd.Context(), body, dryRun)
+			stats, err := pipeline.ProcessBody(cmd.Context(), body, opts)
 			if err != nil {
 				return err
 			}
-			printStats(body.Name, stats.Discovered, stats.Processed, stats.Failed, stats.Quarantined)
+			printStats(body.Name, stats)
 			output.NotifyCompletion(body.Name, stats.Processed, stats.Failed, stats.Quarantined)
 			return nil
 		}
@@ -47,30 +65,32 @@ Without --body, processes all configured bodies sequentially.`,
 			return fmt.Errorf("multiple bodies configured; use --body=<slug> or --all")
 		}
 
-		allStats, err := pipeline.ProcessAll(cmd.Context(), dryRun)
+		allStats, err := pipeline.ProcessAll(cmd.Context(), opts)
 		if err != nil {
 			return err
 		}
 
 		for slug, stats := range allStats {
-			printStats(slug, stats.Discovered, stats.Processed, stats.Failed, stats.Quarantined)
+			printStats(slug, stats)
 		}
 
 		return nil
 	},
 }
 
-func printStats(name string, discovered, processed, failed, quarantined int) {
+func printStats(name string, stats *domain.ProcessingStats) {
 	output.Banner(fmt.Sprintf("Summary: %s", name))
-	fmt.Printf("  Discovered:  %d\n", discovered)
-	fmt.Printf("  Processed:   %d\n", processed)
-	fmt.Printf("  Failed:      %d\n", failed)
-	fmt.Printf("  Quarantined: %d\n", quarantined)
+	fmt.Printf("  Discovered:  %d\n", stats.Discovered)
+	fmt.Printf("  Processed:   %d\n", stats.Processed)
+	fmt.Printf("  Failed:      %d\n", stats.Failed)
+	fmt.Printf("  Quarantined: %d\n", stats.Quarantined)
+	fmt.Printf("  Deferred:    %d\n", stats.Deferred)
 }
 
 func init() {
 	processCmd.Flags().String("body", "", "body slug to process")
 	processCmd.Flags().Bool("all", false, "process all configured bodies")
 	processCmd.Flags().Bool("dry-run", false, "show what would be processed without executing")
+	addDateRangeFlags(processCmd)
 	rootCmd.AddCommand(processCmd)
 }
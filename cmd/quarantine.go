@@ -3,6 +3,7 @@ package cmd
 import (
 	"fmt"
 
+	"github.com/AvogadroSG1/civic-summary/internal/domain"
 	"github.com/AvogadroSG1/civic-summary/internal/output"
 	"github.com/AvogadroSG1/civic-summary/internal/service"
 	"github.com/spf13/cobra"
@@ -76,19 +77,22 @@ var quarantineRetryCmd = &cobra.Command{
 			// Retry specific video.
 			output.Info("Retrying video %s...", args[0])
 			// The pipeline's retryQuarantined handles the logic.
-			_, err := pipeline.ProcessBody(cmd.Context(), body, false)
+			_, err := pipeline.ProcessBody(cmd.Context(), body, service.ProcessOptions{})
 			return err
 		}
 
 		// Retry all quarantined.
-		_, err = pipeline.ProcessBody(cmd.Context(), body, false)
+		_, err = pipeline.ProcessBody(cmd.Context(), body, service.ProcessOptions{})
 		return err
 	},
 }
 
 var quarantineRemoveCmd = &cobra.Command{
-	Use:     "remove <video-id>",
-	Short:   "Remove a meeting from quarantine",
+	Use:   "remove <video-id>",
+	Short: "Remove a meeting from quarantine",
+	Long: `Removes a meeting from quarantine without summarizing it. The meeting is
+marked skipped in the ledger, so discovery does not pick it up again; use
+"ledger forget" to have it processed anew.`,
 	Example: `  civic-summary quarantine remove abc123 --body=hagerstown`,
 	Args:    cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
@@ -106,8 +110,11 @@ var quarantineRemoveCmd = &cobra.Command{
 		if err := quarantine.Remove(body, args[0]); err != nil {
 			return err
 		}
+		if err := service.NewLedgerService(cfg).SetStatus(body, args[0], domain.LedgerSkipped); err != nil {
+			return err
+		}
 
-		output.Success("Removed %s from quarantine", args[0])
+		output.Success("Removed %s from quarantine; it is marked skipped in the ledger", args[0])
 		return nil
 	},
 }
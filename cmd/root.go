@@ -2,7 +2,10 @@
 package cmd
 
 import (
+	"context"
 	"os"
+	"os/signal"
+	"syscall"
 
 	"github.com/AvogadroSG1/civic-summary/internal/output"
 	"github.com/spf13/cobra"
@@ -27,9 +30,13 @@ and validation.`,
 	},
 }
 
-// Execute runs the root command.
+// Execute runs the root command. An interrupt or SIGTERM cancels the command's
+// context so in-flight meetings stop cleanly and keep their checkpoints.
 func Execute() {
-	if err := rootCmd.Execute(); err != nil {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	err := rootCmd.ExecuteContext(ctx)
+	stop()
+	if err != nil {
 		os.Exit(1)
 	}
 }
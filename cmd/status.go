@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/AvogadroSG1/civic-summary/internal/domain"
 	"github.com/AvogadroSG1/civic-summary/internal/llm"
@@ -16,15 +17,19 @@ import (
 var statusCmd = &cobra.Command{
 	Use:   "status",
 	Short: "Show processing status for configured bodies",
-	Long: `Reports finalized and quarantined summary counts per body, and checks
-that the configured language model is reachable.
+	Long: `Reports finalized, ledger, quarantined, and deferred counts per body, and
+checks that the configured language model and transcriber are available.
+Deferred meetings are waiting for their source to publish captions, not failed.
 
 The model check sends one minimal request per distinct provider and model, which
 catches a bad API key or model name before a run wastes a transcription. Pass
---skip-llm-check to stay offline.`,
+--skip-llm-check to stay offline.
+
+The transcriber check runs a CLI backend's --help, or asks an HTTP backend for
+its model list; nothing is transcribed. Pass --skip-transcriber-check to skip it.`,
 	Example: `  civic-summary status
   civic-summary status --body=hagerstown
-  civic-summary status --skip-llm-check`,
+  civic-summary status --skip-llm-check --skip-transcriber-check`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		cfg, err := loadConfig()
 		if err != nil {
@@ -43,7 +48,11 @@ catches a bad API key or model name before a run wastes a transcription. Pass
 		}
 
 		quarantine := service.NewQuarantineService(cfg)
+		deferrals := service.NewDeferralService(cfg)
+		ledger := service.NewLedgerService(cfg)
 		skipLLM, _ := cmd.Flags().GetBool("skip-llm-check")
+		skipTranscriber, _ := cmd.Flags().GetBool("skip-transcriber-check")
+		transcribers := buildTranscriberFor(cfg)
 
 		for slug := range bodies {
 			body, _ := cfg.GetBody(slug)
@@ -54,6 +63,19 @@ catches a bad API key or model name before a run wastes a transcription. Pass
 			summaryCount := countSummaries(finalizedDir)
 			fmt.Printf("  Finalized summaries: %d\n", summaryCount)
 
+			// Summarize the ledger by status.
+			if ledgerEntries, err := ledger.List(body); err == nil {
+				counts := make(map[domain.LedgerStatus]int)
+				for _, e := range ledgerEntries {
+					counts[e.Status]++
+				}
+				fmt.Printf("  Ledger:              %d done, %d quarantined, %d skipped, %d ignored\n",
+					counts[domain.LedgerDone], counts[domain.LedgerQuarantined],
+					counts[domain.LedgerSkipped], counts[domain.LedgerIgnored])
+			} else {
+				output.Failure("Ledger: %v", err)
+			}
+
 			// Count quarantined items.
 			entries, _ := quarantine.ListQuarantined(body)
 			fmt.Printf("  Quarantined:         %d\n", len(entries))
@@ -62,7 +84,20 @@ catches a bad API key or model name before a run wastes a transcription. Pass
 					e.VideoID, e.MeetingDate, e.RetryCount, e.Error)
 			}
 
+			// List meetings waiting for captions.
+			if deferred, err := deferrals.List(body); err == nil {
+				fmt.Printf("  Deferred:            %d\n", len(deferred))
+				for _, d := range deferred {
+					fmt.Printf("    - %s (date: %s, recheck: %s, waiting since: %s, checks: %d)\n",
+						d.VideoID, d.MeetingDate, d.RecheckAt.Format(time.DateTime),
+						d.DeferredAt.Format(time.DateTime), d.Checks)
+				}
+			} else {
+				output.Failure("Deferred: %v", err)
+			}
+
 			reportLLM(cmd.Context(), cfg.ResolveLLM(body), skipLLM)
+			reportTranscriber(cmd.Context(), cfg.ResolveTranscriber(body), transcribers, body, skipTranscriber)
 
 			fmt.Println()
 		}
@@ -97,6 +132,36 @@ func reportLLM(ctx context.Context, llmCfg domain.LLMConfig, skip bool) {
 	output.Success("Model check: reachable")
 }
 
+// reportTranscriber prints a body's resolved speech-to-text backend and, unless
+// skipped, whether it is available.
+func reportTranscriber(ctx context.Context, sttCfg domain.TranscriberConfig, transcribers service.TranscriberFor, body domain.Body, skip bool) {
+	fmt.Printf("  Transcriber:         %s\n", sttCfg.Describe())
+	if !sttCfg.Enabled() {
+		fmt.Printf("  Transcriber check:   none configured; meetings without captions fail\n")
+		return
+	}
+	if sttCfg.Backend == domain.TranscriberHTTP {
+		fmt.Printf("  Transcriber URL:     %s\n", sttCfg.BaseURL)
+	}
+
+	if skip {
+		fmt.Printf("  Transcriber check:   skipped\n")
+		return
+	}
+
+	stt, err := transcribers(body)
+	if err != nil {
+		output.Failure("Transcriber check: %v", err)
+		return
+	}
+	if err := stt.Check(ctx); err != nil {
+		output.Failure("Transcriber check: %v", err)
+		return
+	}
+
+	output.Success("Transcriber check: available")
+}
+
 func countSummaries(dir string) int {
 	count := 0
 	entries, err := os.ReadDir(dir)
@@ -116,5 +181,6 @@ func countSummaries(dir string) int {
 func init() {
 	statusCmd.Flags().String("body", "", "body slug (default: all)")
 	statusCmd.Flags().Bool("skip-llm-check", false, "skip the language model reachability probe")
+	statusCmd.Flags().Bool("skip-transcriber-check", false, "skip the transcriber availability check")
 	rootCmd.AddCommand(statusCmd)
 }
@@ -4,7 +4,6 @@ import (
 	"fmt"
 
 	"github.com/AvogadroSG1/civic-summary/internal/domain"
-	"github.com/AvogadroSG1/civic-summary/internal/executor"
 	"github.com/AvogadroSG1/civic-summary/internal/output"
 	"github.com/AvogadroSG1/civic-summary/internal/service"
 	"github.com/spf13/cobra"
@@ -13,7 +12,12 @@ import (
 var transcribeCmd = &cobra.Command{
 	Use:   "transcribe <video-id>",
 	Short: "Get transcript for a specific video",
-	Long:  `Phase 2 only: downloads captions or runs Whisper to produce an SRT transcript.`,
+	Long: `Phase 2 only: downloads captions or runs Whisper to produce an SRT transcript.
+For a body with a vocabulary, the corrections analysis would make are saved
+beside it for review.
+
+The video ID is the one printed by discover. For feed and urls sources the
+recording's URL is looked up in the body's source listing.`,
 	Example: `  civic-summary transcribe abc123 --body=hagerstown
   civic-summary transcribe xyz789 --body=bocc --output-dir=/tmp`,
 	Args: cobra.ExactArgs(1),
@@ -35,20 +39,14 @@ var transcribeCmd = &cobra.Command{
 			outputDir = cfg.FinalizedDir(body)
 		}
 
-		commander := executor.NewOsCommander()
-		ytdlp := executor.NewYtDlpExecutor(commander, cfg.Tools.YtDlp)
-		var whisper *executor.WhisperExecutor
-		if cfg.Tools.Whisper != "" {
-			whisper = executor.NewWhisperExecutor(commander, cfg.Tools.Whisper, cfg.Tools.WhisperModel)
-		}
-		transcription := service.NewTranscriptionService(ytdlp, whisper)
+		transcription := service.NewTranscriptionService(buildVideoSourceFor(buildYtDlp(cfg)), buildTranscriberFor(cfg))
 
 		meeting := domain.Meeting{
 			VideoID:  videoID,
 			BodySlug: body.Slug,
 		}
 
-		transcript, err := transcription.Transcribe(cmd.Context(), meeting, outputDir)
+		transcript, err := transcription.Transcribe(cmd.Context(), meeting, body, outputDir)
 		if err != nil {
 			return err
 		}
@@ -59,6 +57,10 @@ var transcribeCmd = &cobra.Command{
 
 		output.Success("Transcript saved: %s (%d words, source: %s)",
 			transcript.Path, transcript.WordCount(), transcript.Source)
+		if len(body.Vocabulary) > 0 {
+			transcription.ApplyVocabulary(meeting, body, transcript)
+			output.Info("Vocabulary corrections: %s", service.CorrectionReportPath(transcript.Path))
+		}
 		fmt.Println(transcript.Path)
 
 		return nil
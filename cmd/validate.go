@@ -3,7 +3,9 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"time"
 
+	"github.com/AvogadroSG1/civic-summary/internal/domain"
 	"github.com/AvogadroSG1/civic-summary/internal/output"
 	"github.com/AvogadroSG1/civic-summary/internal/service"
 	"github.com/spf13/cobra"
@@ -13,9 +15,17 @@ var validateCmd = &cobra.Command{
 	Use:   "validate <file>",
 	Short: "Validate a summary file against quality requirements",
 	Long: `Phase 5 only: checks frontmatter, required sections, word count,
-timestamps, and model meta-commentary.`,
+timestamps, and model meta-commentary against the body's validation rules
+(see "bodies show" for the effective rules).
+
+With --transcript, also checks the summary against the meeting transcript:
+timestamps past its end, and amounts, numbers, vote tallies, and names that
+cannot be found near the time they cite. Unsupported claims are reported at
+the body's faithfulness severity, or as warnings when the body leaves the
+check off.`,
 	Example: `  civic-summary validate summary.md --body=hagerstown
-  civic-summary validate *.md --body=bocc`,
+  civic-summary validate *.md --body=bocc
+  civic-summary validate summary.md --body=hagerstown --transcript=abc123.en.srt`,
 	Args: cobra.MinimumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		cfg, err := loadConfig()
@@ -28,6 +38,22 @@ timestamps, and model meta-commentary.`,
 			return err
 		}
 
+		var transcript *domain.Transcript
+		if path, _ := cmd.Flags().GetString("transcript"); path != "" {
+			t, err := service.LoadTranscript(path, domain.TranscriptSourceCaptions)
+			if err != nil {
+				return fmt.Errorf("reading transcript: %w", err)
+			}
+			transcript = &t
+		}
+
+		// Asking for a transcript check runs the faithfulness check even
+		// when the body leaves it off.
+		faithfulness := body.ValidationRules().Check(domain.CheckFaithfulness)
+		if faithfulness == domain.RuleOff {
+			faithfulness = domain.RuleWarning
+		}
+
 		validation := service.NewValidationService()
 		hasErrors := false
 
@@ -39,7 +65,15 @@ timestamps, and model meta-commentary.`,
 				continue
 			}
 
-			result := validation.Validate(string(content), body)
+			var duration time.Duration
+			if transcript != nil {
+				duration = transcript.Duration()
+			}
+			result := validation.Validate(string(content), body, duration)
+			if transcript != nil {
+				validation.ValidateTimestamps(string(content), *transcript, body, result)
+				validation.ValidateFaithfulness(string(content), *transcript, faithfulness, result)
+			}
 
 			fmt.Printf("\n--- %s ---\n", filePath)
 			if result.IsValid() {
@@ -64,6 +98,7 @@ timestamps, and model meta-commentary.`,
 
 func init() {
 	validateCmd.Flags().String("body", "", "body slug for body-specific validation rules")
+	validateCmd.Flags().String("transcript", "", "transcript (SRT, WebVTT, whisper JSON, or text) to check the summary's claims against")
 	_ = validateCmd.MarkFlagRequired("body")
 	rootCmd.AddCommand(validateCmd)
 }
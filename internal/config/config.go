@@ -6,9 +6,12 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"reflect"
 	"slices"
+	"time"
 
 	"github.com/AvogadroSG1/civic-summary/internal/domain"
+	"github.com/go-viper/mapstructure/v2"
 	"github.com/spf13/viper"
 )
 
@@ -18,26 +21,95 @@ import (
 const (
 	defaultModel          = "claude-opus-5"
 	defaultMaxTokens      = 16000
+	defaultContextWindow  = 200000
 	defaultTimeoutSeconds = 900
 	defaultLLMRetries     = 2
 )
 
 // Config holds all application configuration.
 type Config struct {
-	OutputDir        string                 `mapstructure:"output_dir"`
-	LogRetentionDays int                    `mapstructure:"log_retention_days"`
-	MaxRetries       int                    `mapstructure:"max_retries"`
-	BackoffDelays    []int                  `mapstructure:"backoff_delays"`
-	Tools            ToolsConfig            `mapstructure:"tools"`
-	LLM              domain.LLMConfig       `mapstructure:"llm"`
-	Bodies           map[string]domain.Body `mapstructure:"bodies"`
+	OutputDir        string                   `mapstructure:"output_dir"`
+	LogRetentionDays int                      `mapstructure:"log_retention_days"`
+	MaxRetries       int                      `mapstructure:"max_retries"`
+	BackoffDelays    []int                    `mapstructure:"backoff_delays"`
+	RepairRounds     int                      `mapstructure:"repair_rounds"`
+	Tools            ToolsConfig              `mapstructure:"tools"`
+	Concurrency      ConcurrencyConfig        `mapstructure:"concurrency"`
+	Captions         CaptionsConfig           `mapstructure:"captions"`
+	Transcriber      domain.TranscriberConfig `mapstructure:"transcriber"`
+	Audio            domain.AudioConfig       `mapstructure:"audio"`
+	LLM              domain.LLMConfig         `mapstructure:"llm"`
+	Bodies           map[string]domain.Body   `mapstructure:"bodies"`
 }
 
 // ToolsConfig holds paths to external tool binaries.
 type ToolsConfig struct {
-	YtDlp        string `mapstructure:"ytdlp"`
+	YtDlp string `mapstructure:"ytdlp"`
+	// Whisper and WhisperModel configure the openai-whisper backend when the
+	// transcriber block sets no backend. They predate the transcriber block.
 	Whisper      string `mapstructure:"whisper"`
 	WhisperModel string `mapstructure:"whisper_model"`
+	// PdfToText extracts text from PDF agendas. Empty disables PDF extraction;
+	// the agenda URL is still passed to the prompt.
+	PdfToText string `mapstructure:"pdftotext"`
+	// Diarizer labels speaker turns for bodies that set diarize. It is run as
+	// "<diarizer> [diarizer_args...] <audio> <output.rttm>" and must write RTTM,
+	// as a small wrapper around pyannote.audio does. Empty disables diarization.
+	Diarizer     string   `mapstructure:"diarizer"`
+	DiarizerArgs []string `mapstructure:"diarizer_args"`
+	// FFmpeg prepares audio for the transcriber as the audio block
+	// describes. Empty hands the downloaded audio to the transcriber as is.
+	FFmpeg string `mapstructure:"ffmpeg"`
+}
+
+// ConcurrencyConfig bounds how much work runs in parallel. Every limit
+// defaults to 1, which processes bodies and meetings one at a time.
+type ConcurrencyConfig struct {
+	// Bodies is how many bodies are processed at once.
+	Bodies int `mapstructure:"bodies"`
+	// Meetings is how many meetings per body are in flight at once.
+	Meetings int `mapstructure:"meetings"`
+	// Transcriptions is how many caption downloads or Whisper runs happen at
+	// once across all bodies. yt-dlp and Whisper are CPU and bandwidth bound.
+	Transcriptions int `mapstructure:"transcriptions"`
+	// LLMRequests is how many model requests are in flight at once per
+	// provider, across all bodies sharing that provider.
+	LLMRequests int `mapstructure:"llm_requests"`
+}
+
+// CaptionsConfig controls waiting for captions that a source has not
+// published yet. YouTube generates automatic captions some time after a
+// video, or a livestream's recording, goes up; until then a meeting is
+// deferred rather than sent to Whisper.
+type CaptionsConfig struct {
+	// GraceHours is how long after a meeting was released, or, when only its
+	// upload day is known, first deferred, Whisper is used instead. Zero
+	// never defers.
+	GraceHours int `mapstructure:"grace_hours"`
+	// RecheckMinutes is how long a deferred meeting waits before captions
+	// are looked for again.
+	RecheckMinutes int `mapstructure:"recheck_minutes"`
+}
+
+// Grace returns GraceHours as a duration.
+func (c CaptionsConfig) Grace() time.Duration {
+	return time.Duration(c.GraceHours) * time.Hour
+}
+
+// Recheck returns RecheckMinutes as a duration.
+func (c CaptionsConfig) Recheck() time.Duration {
+	return time.Duration(c.RecheckMinutes) * time.Minute
+}
+
+// validate rejects negative durations.
+func (c CaptionsConfig) validate() error {
+	if c.GraceHours < 0 {
+		return fmt.Errorf("captions.grace_hours must not be negative, got %d", c.GraceHours)
+	}
+	if c.RecheckMinutes < 0 {
+		return fmt.Errorf("captions.recheck_minutes must not be negative, got %d", c.RecheckMinutes)
+	}
+	return nil
 }
 
 // Load reads configuration from the config file and environment variables.
@@ -53,11 +125,27 @@ func Load(configPath string) (*Config, error) {
 	v.SetDefault("log_retention_days", 90)
 	v.SetDefault("max_retries", 3)
 	v.SetDefault("backoff_delays", []int{5, 20, 60})
+	v.SetDefault("repair_rounds", 2)
 	v.SetDefault("tools.ytdlp", "yt-dlp")
+	v.SetDefault("tools.pdftotext", "pdftotext")
+	v.SetDefault("concurrency.bodies", 1)
+	v.SetDefault("concurrency.meetings", 1)
+	v.SetDefault("concurrency.transcriptions", 1)
+	v.SetDefault("concurrency.llm_requests", 1)
+	v.SetDefault("captions.grace_hours", 24)
+	v.SetDefault("captions.recheck_minutes", 60)
+	v.SetDefault("audio.normalize", true)
+	v.SetDefault("audio.trim_silence", true)
+	v.SetDefault("audio.silence_threshold_db", -50)
+	v.SetDefault("audio.min_silence_seconds", 5)
+	v.SetDefault("audio.segment_minutes", 30)
+	v.SetDefault("audio.overlap_seconds", 10)
+	v.SetDefault("audio.parallel", 2)
 	v.SetDefault("llm.provider", domain.ProviderAnthropic)
 	v.SetDefault("llm.model", defaultModel)
 	v.SetDefault("llm.max_tokens", defaultMaxTokens)
 	v.SetDefault("llm.max_tokens_field", domain.MaxTokensFieldModern)
+	v.SetDefault("llm.context_window", defaultContextWindow)
 	v.SetDefault("llm.timeout_seconds", defaultTimeoutSeconds)
 	v.SetDefault("llm.max_retries", defaultLLMRetries)
 	v.SetDefault("llm.stream", true)
@@ -68,15 +156,30 @@ func Load(configPath string) (*Config, error) {
 	_ = v.BindEnv("output_dir")
 	_ = v.BindEnv("log_retention_days")
 	_ = v.BindEnv("max_retries")
+	_ = v.BindEnv("repair_rounds")
 	_ = v.BindEnv("tools.ytdlp", "CIVIC_SUMMARY_YTDLP")
 	_ = v.BindEnv("tools.whisper", "CIVIC_SUMMARY_WHISPER")
 	_ = v.BindEnv("tools.whisper_model", "CIVIC_SUMMARY_WHISPER_MODEL")
+	_ = v.BindEnv("tools.pdftotext", "CIVIC_SUMMARY_PDFTOTEXT")
+	_ = v.BindEnv("tools.diarizer", "CIVIC_SUMMARY_DIARIZER")
+	_ = v.BindEnv("tools.ffmpeg", "CIVIC_SUMMARY_FFMPEG")
+	_ = v.BindEnv("concurrency.bodies", "CIVIC_SUMMARY_CONCURRENCY_BODIES")
+	_ = v.BindEnv("concurrency.meetings", "CIVIC_SUMMARY_CONCURRENCY_MEETINGS")
+	_ = v.BindEnv("concurrency.transcriptions", "CIVIC_SUMMARY_CONCURRENCY_TRANSCRIPTIONS")
+	_ = v.BindEnv("concurrency.llm_requests", "CIVIC_SUMMARY_CONCURRENCY_LLM_REQUESTS")
+	_ = v.BindEnv("captions.grace_hours", "CIVIC_SUMMARY_CAPTIONS_GRACE_HOURS")
+	_ = v.BindEnv("captions.recheck_minutes", "CIVIC_SUMMARY_CAPTIONS_RECHECK_MINUTES")
+	_ = v.BindEnv("transcriber.backend", "CIVIC_SUMMARY_TRANSCRIBER_BACKEND")
+	_ = v.BindEnv("transcriber.binary", "CIVIC_SUMMARY_TRANSCRIBER_BINARY")
+	_ = v.BindEnv("transcriber.model", "CIVIC_SUMMARY_TRANSCRIBER_MODEL")
+	_ = v.BindEnv("transcriber.base_url", "CIVIC_SUMMARY_TRANSCRIBER_BASE_URL")
 	_ = v.BindEnv("llm.provider", "CIVIC_SUMMARY_LLM_PROVIDER")
 	_ = v.BindEnv("llm.model", "CIVIC_SUMMARY_LLM_MODEL")
 	_ = v.BindEnv("llm.base_url", "CIVIC_SUMMARY_LLM_BASE_URL")
 	_ = v.BindEnv("llm.api_key_env", "CIVIC_SUMMARY_LLM_API_KEY_ENV")
 	_ = v.BindEnv("llm.max_tokens", "CIVIC_SUMMARY_LLM_MAX_TOKENS")
 	_ = v.BindEnv("llm.max_tokens_field", "CIVIC_SUMMARY_LLM_MAX_TOKENS_FIELD")
+	_ = v.BindEnv("llm.context_window", "CIVIC_SUMMARY_LLM_CONTEXT_WINDOW")
 
 	if configPath != "" {
 		v.SetConfigFile(configPath)
@@ -97,13 +200,21 @@ func Load(configPath string) (*Config, error) {
 	}
 
 	var cfg Config
-	if err := v.Unmarshal(&cfg); err != nil {
+	if err := v.Unmarshal(&cfg, decodeHook); err != nil {
 		return nil, fmt.Errorf("unmarshaling config: %w", err)
 	}
 
-	// Inject slugs from map keys into each body.
+	// Inject slugs from map keys into each body, and read vocabulary files.
 	for slug, body := range cfg.Bodies {
 		body.Slug = slug
+		if body.VocabularyFile != "" {
+			body.VocabularyFile = resolvePath(v.ConfigFileUsed(), body.VocabularyFile)
+			terms, err := loadVocabulary(body.VocabularyFile)
+			if err != nil {
+				return nil, fmt.Errorf("body %q: %w", slug, err)
+			}
+			body.Vocabulary = append(body.Vocabulary, terms...)
+		}
 		cfg.Bodies[slug] = body
 	}
 
@@ -114,6 +225,62 @@ func Load(configPath string) (*Config, error) {
 	return &cfg, nil
 }
 
+// decodeHook converts config values into their Go types. shorthandHook runs
+// before the string-to-slice hook, which would otherwise split a regex at its
+// commas.
+var decodeHook = viper.DecodeHook(mapstructure.ComposeDecodeHookFunc(
+	shorthandHook,
+	mapstructure.StringToTimeDurationHookFunc(),
+	mapstructure.StringToSliceHookFunc(","),
+))
+
+// resolvePath resolves a path from the config against the config file's
+// directory, or the working directory when no file was read.
+func resolvePath(configFile, path string) string {
+	if filepath.IsAbs(path) || configFile == "" {
+		return path
+	}
+	return filepath.Join(filepath.Dir(configFile), path)
+}
+
+// loadVocabulary reads the `terms` list of a vocabulary file. Entries take the
+// same forms as a body's vocabulary block.
+func loadVocabulary(path string) (domain.Vocabulary, error) {
+	v := viper.New()
+	v.SetConfigFile(path)
+	v.SetConfigType("yaml")
+	if err := v.ReadInConfig(); err != nil {
+		return nil, fmt.Errorf("reading vocabulary file: %w", err)
+	}
+	var terms domain.Vocabulary
+	if err := v.UnmarshalKey("terms", &terms, decodeHook); err != nil {
+		return nil, fmt.Errorf("parsing vocabulary file %s: %w", path, err)
+	}
+	return terms, nil
+}
+
+// shorthandHook expands the config's string shorthands: title_date_regex may
+// be a single regex rather than a list, a title_date_regex entry a bare regex
+// rather than a map, a meeting_types or roster entry just a name, and a
+// vocabulary entry just a term.
+func shorthandHook(_ reflect.Type, t reflect.Type, data any) (any, error) {
+	s, ok := data.(string)
+	if !ok {
+		return data, nil
+	}
+	switch t {
+	case reflect.TypeOf(domain.TitlePatterns{}):
+		return []any{s}, nil
+	case reflect.TypeOf(domain.TitlePattern{}):
+		return map[string]any{"regex": s}, nil
+	case reflect.TypeOf(domain.MeetingTypeRule{}), reflect.TypeOf(domain.RosterMember{}):
+		return map[string]any{"name": s}, nil
+	case reflect.TypeOf(domain.VocabularyTerm{}):
+		return map[string]any{"term": s}, nil
+	}
+	return data, nil
+}
+
 // ResolveLLM returns the language-model configuration for a body: the global
 // llm block with the body's override applied, plus the defaults that depend on
 // other values and so cannot be expressed as static viper defaults.
@@ -127,6 +294,28 @@ func (c *Config) ResolveLLM(body domain.Body) domain.LLMConfig {
 	return resolved
 }
 
+// ResolveTranscriber returns the speech-to-text configuration for a body: the
+// global transcriber block with the body's override applied and the backend's
+// defaults filled in. Without a transcriber backend, tools.whisper selects
+// openai-whisper, as it did before the transcriber block existed. The body's
+// vocabulary terms follow the transcriber's own vocabulary in the prompt.
+func (c *Config) ResolveTranscriber(body domain.Body) domain.TranscriberConfig {
+	base := c.Transcriber
+	if !base.Enabled() && c.Tools.Whisper != "" {
+		base.Backend = domain.TranscriberOpenAIWhisper
+		base.Binary = c.Tools.Whisper
+		base.Model = c.Tools.WhisperModel
+	}
+	resolved := body.Transcriber.Apply(base).WithDefaults()
+	resolved.Vocabulary = slices.Clone(resolved.Vocabulary)
+	for _, term := range body.Vocabulary.Terms() {
+		if !slices.Contains(resolved.Vocabulary, term) {
+			resolved.Vocabulary = append(resolved.Vocabulary, term)
+		}
+	}
+	return resolved
+}
+
 // Validate checks that required configuration fields are present.
 func (c *Config) Validate() error {
 	if c.OutputDir == "" {
@@ -135,9 +324,32 @@ func (c *Config) Validate() error {
 	if len(c.Bodies) == 0 {
 		return fmt.Errorf("at least one body must be configured")
 	}
+	if c.RepairRounds < 0 {
+		return fmt.Errorf("repair_rounds must not be negative, got %d", c.RepairRounds)
+	}
+	if err := c.Concurrency.validate(); err != nil {
+		return err
+	}
+	if err := c.Captions.validate(); err != nil {
+		return err
+	}
+	if c.Tools.FFmpeg != "" {
+		if err := c.Audio.Validate(); err != nil {
+			return err
+		}
+	}
 	for slug, body := range c.Bodies {
-		if body.PlaylistID == "" && body.VideoSourceURL == "" {
-			return fmt.Errorf("body %q: playlist_id or video_source_url is required", slug)
+		switch body.SourceType() {
+		case domain.SourceYouTube:
+			if body.PlaylistID == "" && body.VideoSourceURL == "" && body.Source.URL == "" {
+				return fmt.Errorf("body %q: playlist_id or video_source_url is required", slug)
+			}
+		case domain.SourceFeed, domain.SourceURLList, domain.SourceFolder:
+			if body.Source.URL == "" {
+				return fmt.Errorf("body %q: source.url is required for source type %q", slug, body.SourceType())
+			}
+		default:
+			return fmt.Errorf("body %q: unknown source type %q; supported: %v", slug, body.Source.Type, domain.SourceTypes())
 		}
 		if body.OutputSubdir == "" {
 			return fmt.Errorf("body %q: output_subdir is required", slug)
@@ -145,8 +357,8 @@ func (c *Config) Validate() error {
 		if body.FilenamePattern == "" {
 			return fmt.Errorf("body %q: filename_pattern is required", slug)
 		}
-		if body.TitleDateRegex == "" {
-			return fmt.Errorf("body %q: title_date_regex is required", slug)
+		if err := body.ValidateTitleRules(); err != nil {
+			return fmt.Errorf("body %q: %w", slug, err)
 		}
 		if body.PromptTemplate == "" {
 			return fmt.Errorf("body %q: prompt_template is required", slug)
@@ -154,9 +366,62 @@ func (c *Config) Validate() error {
 		if len(body.Tags) == 0 {
 			return fmt.Errorf("body %q: at least one tag is required", slug)
 		}
+		if _, err := body.BackfillDate(); err != nil {
+			return fmt.Errorf("body %q: %w", slug, err)
+		}
+		if err := body.Captions.Validate(); err != nil {
+			return fmt.Errorf("body %q: %w", slug, err)
+		}
+		if body.MinDurationMinutes < 0 {
+			return fmt.Errorf("body %q: min_duration_minutes must not be negative", slug)
+		}
+		if _, err := body.AgendaURLFor(time.Now()); err != nil {
+			return fmt.Errorf("body %q: %w", slug, err)
+		}
+		if err := body.ValidationRules().Validate(); err != nil {
+			return fmt.Errorf("body %q: %w", slug, err)
+		}
+		if err := body.ValidateMeetingTypes(); err != nil {
+			return fmt.Errorf("body %q: %w", slug, err)
+		}
 		if err := validateLLM(c.ResolveLLM(body)); err != nil {
 			return fmt.Errorf("body %q: %w", slug, err)
 		}
+		if err := c.ResolveTranscriber(body).Validate(); err != nil {
+			return fmt.Errorf("body %q: %w", slug, err)
+		}
+		if err := body.Boundaries.Validate(); err != nil {
+			return fmt.Errorf("body %q: %w", slug, err)
+		}
+		if err := body.Vocabulary.Validate(); err != nil {
+			return fmt.Errorf("body %q: %w", slug, err)
+		}
+		if err := body.Roster.Validate(); err != nil {
+			return fmt.Errorf("body %q: %w", slug, err)
+		}
+		if body.Diarize && c.Tools.Diarizer == "" {
+			return fmt.Errorf("body %q: diarize requires tools.diarizer", slug)
+		}
+	}
+	return nil
+}
+
+// validate rejects negative limits. Zero is allowed and treated as 1, so a
+// Config built without viper defaults still runs sequentially.
+func (c ConcurrencyConfig) validate() error {
+	limits := []struct {
+		name  string
+		value int
+	}{
+		{"bodies", c.Bodies},
+		{"meetings", c.Meetings},
+		{"transcriptions", c.Transcriptions},
+		{"llm_requests", c.LLMRequests},
+	}
+	for _, l := range limits {
+		if l.value < 0 {
+			return fmt.Errorf("concurrency.%s must not be negative, got %d", l.name, l.value)
+		}
 	}
 	return nil
 }
@@ -178,6 +443,12 @@ func validateLLM(cfg domain.LLMConfig) error {
 		return fmt.Errorf("llm.max_tokens_field %q is not supported; supported: %v",
 			cfg.MaxTokensField, domain.MaxTokensFields())
 	}
+	if cfg.ContextWindow < 0 {
+		return fmt.Errorf("llm.context_window must not be negative, got %d", cfg.ContextWindow)
+	}
+	if cfg.ContextWindow > 0 && cfg.ContextWindow <= cfg.MaxTokens {
+		return fmt.Errorf("llm.context_window (%d) must exceed llm.max_tokens (%d)", cfg.ContextWindow, cfg.MaxTokens)
+	}
 	return nil
 }
 
@@ -196,6 +467,7 @@ func (c *Config) BodySlugs() []string {
 	for slug := range c.Bodies {
 		slugs = append(slugs, slug)
 	}
+	slices.Sort(slugs)
 	return slugs
 }
 
@@ -214,6 +486,36 @@ func (c *Config) QuarantineDir(body domain.Body) string {
 	return filepath.Join(c.BodyOutputDir(body), "Automation", "quarantine")
 }
 
+// StateDir returns the directory holding per-meeting pipeline checkpoints for
+// a body.
+func (c *Config) StateDir(body domain.Body) string {
+	return filepath.Join(c.BodyOutputDir(body), "Automation", "state")
+}
+
+// RepairDir returns the directory holding the drafts rejected during
+// validation repair, kept so failed and repaired summaries can be inspected.
+func (c *Config) RepairDir(body domain.Body) string {
+	return filepath.Join(c.BodyOutputDir(body), "Automation", "repairs")
+}
+
+// LedgerPath returns the file recording which meetings a body has processed,
+// ignored, or quarantined.
+func (c *Config) LedgerPath(body domain.Body) string {
+	return filepath.Join(c.BodyOutputDir(body), "Automation", "ledger.json")
+}
+
+// SequencesPath returns the file recording the order in which each date's
+// meetings were first seen, from which same-date sequence numbers come.
+func (c *Config) SequencesPath(body domain.Body) string {
+	return filepath.Join(c.BodyOutputDir(body), "Automation", "sequences.json")
+}
+
+// DeferredPath returns the file listing a body's meetings that are waiting
+// for captions.
+func (c *Config) DeferredPath(body domain.Body) string {
+	return filepath.Join(c.BodyOutputDir(body), "Automation", "deferred.json")
+}
+
 // LogDir returns the log directory for a body.
 func (c *Config) LogDir(body domain.Body) string {
 	return filepath.Join(c.BodyOutputDir(body), "Automation", "logs")
@@ -40,6 +40,7 @@ func TestLoad_ValidConfig(t *testing.T) {
 	assert.Equal(t, 90, cfg.LogRetentionDays)
 	assert.Equal(t, 3, cfg.MaxRetries)
 	assert.Equal(t, []int{1, 2, 3}, cfg.BackoffDelays)
+	assert.Equal(t, 2, cfg.RepairRounds)
 	assert.Equal(t, "yt-dlp", cfg.Tools.YtDlp)
 }
 
@@ -88,6 +89,7 @@ bodies:
 		"api_key_env should default from the provider")
 	assert.Equal(t, 16000, resolved.MaxTokens)
 	assert.Equal(t, domain.MaxTokensFieldModern, resolved.MaxTokensField)
+	assert.Equal(t, 200000, resolved.ContextWindow)
 	assert.Equal(t, 900, resolved.TimeoutSeconds)
 	assert.Equal(t, 15*time.Minute, resolved.Timeout())
 	assert.Equal(t, 2, resolved.MaxRetries)
@@ -179,9 +181,7 @@ func TestLoad_BodySlugs(t *testing.T) {
 	require.NoError(t, err)
 
 	slugs := cfg.BodySlugs()
-	assert.Len(t, slugs, 2)
-	assert.Contains(t, slugs, "hagerstown")
-	assert.Contains(t, slugs, "bocc")
+	assert.Equal(t, []string{"bocc", "hagerstown"}, slugs, "slugs are sorted for a stable processing order")
 }
 
 func TestLoad_Paths(t *testing.T) {
@@ -222,7 +222,7 @@ func TestValidate_VideoSourceURLOnly(t *testing.T) {
 				VideoSourceURL:  "https://www.youtube.com/@example/streams",
 				OutputSubdir:    "Test Output",
 				FilenamePattern: "Test-{{.MeetingDate}}",
-				TitleDateRegex:  `^(\d{4}-\d{2}-\d{2})`,
+				TitleDateRegex:  domain.TitlePatterns{{Regex: `^(\d{4}-\d{2}-\d{2})`}},
 				PromptTemplate:  "test.prompt.tmpl",
 				Tags:            []string{"Test"},
 			},
@@ -242,8 +242,8 @@ func TestValidate_LLM(t *testing.T) {
 	}{
 		{
 			name:     "unsupported provider",
-			override: &domain.LLMOverride{Provider: ptr("gemini")},
-			wantErr:  `llm.provider "gemini" is not supported`,
+			override: &domain.LLMOverride{Provider: ptr("mistral")},
+			wantErr:  `llm.provider "mistral" is not supported`,
 		},
 		{
 			name:     "empty model",
@@ -260,6 +260,16 @@ func TestValidate_LLM(t *testing.T) {
 			override: &domain.LLMOverride{MaxTokensField: ptr("output_tokens")},
 			wantErr:  `llm.max_tokens_field "output_tokens" is not supported`,
 		},
+		{
+			name:     "negative context_window",
+			override: &domain.LLMOverride{ContextWindow: ptr(-1)},
+			wantErr:  "llm.context_window must not be negative",
+		},
+		{
+			name:     "context_window not above max_tokens",
+			override: &domain.LLMOverride{ContextWindow: ptr(16000)},
+			wantErr:  "llm.context_window (16000) must exceed llm.max_tokens (16000)",
+		},
 	}
 
 	for _, tt := range tests {
@@ -272,7 +282,7 @@ func TestValidate_LLM(t *testing.T) {
 						PlaylistID:      "PLtest",
 						OutputSubdir:    "Test Output",
 						FilenamePattern: "Test-{{.MeetingDate}}",
-						TitleDateRegex:  `^(\d{4}-\d{2}-\d{2})`,
+						TitleDateRegex:  domain.TitlePatterns{{Regex: `^(\d{4}-\d{2}-\d{2})`}},
 						PromptTemplate:  "test.prompt.tmpl",
 						Tags:            []string{"Test"},
 						LLM:             tt.override,
@@ -299,7 +309,7 @@ func TestValidate_NeitherPlaylistIDNorVideoSourceURL(t *testing.T) {
 			"test": {
 				OutputSubdir:    "Test Output",
 				FilenamePattern: "Test-{{.MeetingDate}}",
-				TitleDateRegex:  `^(\d{4}-\d{2}-\d{2})`,
+				TitleDateRegex:  domain.TitlePatterns{{Regex: `^(\d{4}-\d{2}-\d{2})`}},
 				PromptTemplate:  "test.prompt.tmpl",
 				Tags:            []string{"Test"},
 			},
@@ -320,7 +330,7 @@ func TestValidate_BothPlaylistIDAndVideoSourceURL(t *testing.T) {
 				VideoSourceURL:  "https://www.youtube.com/@example/streams",
 				OutputSubdir:    "Test Output",
 				FilenamePattern: "Test-{{.MeetingDate}}",
-				TitleDateRegex:  `^(\d{4}-\d{2}-\d{2})`,
+				TitleDateRegex:  domain.TitlePatterns{{Regex: `^(\d{4}-\d{2}-\d{2})`}},
 				PromptTemplate:  "test.prompt.tmpl",
 				Tags:            []string{"Test"},
 			},
@@ -329,3 +339,809 @@ func TestValidate_BothPlaylistIDAndVideoSourceURL(t *testing.T) {
 	err := cfg.Validate()
 	assert.NoError(t, err)
 }
+
+func TestValidate_Source(t *testing.T) {
+	tests := []struct {
+		name    string
+		source  domain.SourceConfig
+		wantErr string
+	}{
+		{"feed with url", domain.SourceConfig{Type: domain.SourceFeed, URL: "https://example.gov/meetings.rss"}, ""},
+		{"urls with local file", domain.SourceConfig{Type: domain.SourceURLList, URL: "/srv/council-videos.txt"}, ""},
+		{"youtube from source url", domain.SourceConfig{Type: domain.SourceYouTube, URL: "https://www.youtube.com/@example/streams"}, ""},
+		{"feed without url", domain.SourceConfig{Type: domain.SourceFeed}, `source.url is required for source type "feed"`},
+		{"folder without url", domain.SourceConfig{Type: domain.SourceFolder}, `source.url is required for source type "folder"`},
+		{"unknown type", domain.SourceConfig{Type: "granicus", URL: "https://example.granicus.com"}, `unknown source type "granicus"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.Config{
+				OutputDir: "/tmp",
+				LLM:       validLLM(),
+				Bodies: map[string]domain.Body{
+					"test": {
+						Source:          tt.source,
+						OutputSubdir:    "Test Output",
+						FilenamePattern: "Test-{{.MeetingDate}}",
+						TitleDateRegex:  domain.TitlePatterns{{Regex: `^(\d{4}-\d{2}-\d{2})`}},
+						PromptTemplate:  "test.prompt.tmpl",
+						Tags:            []string{"Test"},
+					},
+				},
+			}
+
+			err := cfg.Validate()
+
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestLoad_ConcurrencyDefaults(t *testing.T) {
+	cfg, err := config.Load(fixtureConfig(t))
+	require.NoError(t, err)
+
+	assert.Equal(t, config.ConcurrencyConfig{
+		Bodies:         1,
+		Meetings:       1,
+		Transcriptions: 1,
+		LLMRequests:    1,
+	}, cfg.Concurrency)
+}
+
+func TestLoad_ConcurrencyEnvOverride(t *testing.T) {
+	t.Setenv("CIVIC_SUMMARY_CONCURRENCY_MEETINGS", "4")
+	t.Setenv("CIVIC_SUMMARY_CONCURRENCY_LLM_REQUESTS", "2")
+
+	cfg, err := config.Load(fixtureConfig(t))
+	require.NoError(t, err)
+
+	assert.Equal(t, 4, cfg.Concurrency.Meetings)
+	assert.Equal(t, 2, cfg.Concurrency.LLMRequests)
+	assert.Equal(t, 1, cfg.Concurrency.Bodies)
+}
+
+func TestValidate_NegativeConcurrency(t *testing.T) {
+	cfg := &config.Config{
+		OutputDir:   "/tmp",
+		LLM:         validLLM(),
+		Concurrency: config.ConcurrencyConfig{Transcriptions: -1},
+		Bodies: map[string]domain.Body{
+			"test": {
+				PlaylistID:      "PLtest123",
+				OutputSubdir:    "Test Output",
+				FilenamePattern: "Test-{{.MeetingDate}}",
+				TitleDateRegex:  domain.TitlePatterns{{Regex: `^(\d{4}-\d{2}-\d{2})`}},
+				PromptTemplate:  "test.prompt.tmpl",
+				Tags:            []string{"Test"},
+			},
+		},
+	}
+	err := cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "concurrency.transcriptions must not be negative")
+}
+
+func TestLoad_CaptionsDefaults(t *testing.T) {
+	t.Setenv("CIVIC_SUMMARY_CAPTIONS_RECHECK_MINUTES", "15")
+
+	cfg, err := config.Load(fixtureConfig(t))
+	require.NoError(t, err)
+
+	assert.Equal(t, config.CaptionsConfig{GraceHours: 24, RecheckMinutes: 15}, cfg.Captions)
+	assert.Equal(t, 24*time.Hour, cfg.Captions.Grace())
+	assert.Equal(t, 15*time.Minute, cfg.Captions.Recheck())
+}
+
+func TestValidate_NegativeCaptionsGrace(t *testing.T) {
+	cfg := &config.Config{
+		OutputDir: "/tmp",
+		LLM:       validLLM(),
+		Captions:  config.CaptionsConfig{GraceHours: -1},
+		Bodies: map[string]domain.Body{
+			"test": {
+				PlaylistID:      "PLtest123",
+				OutputSubdir:    "Test Output",
+				FilenamePattern: "Test-{{.MeetingDate}}",
+				TitleDateRegex:  domain.TitlePatterns{{Regex: `^(\d{4}-\d{2}-\d{2})`}},
+				PromptTemplate:  "test.prompt.tmpl",
+				Tags:            []string{"Test"},
+			},
+		},
+	}
+	err := cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "captions.grace_hours must not be negative")
+}
+
+func TestLoad_AudioDefaults(t *testing.T) {
+	t.Setenv("CIVIC_SUMMARY_FFMPEG", "/usr/bin/ffmpeg")
+
+	cfg, err := config.Load(fixtureConfig(t))
+	require.NoError(t, err)
+
+	assert.Equal(t, "/usr/bin/ffmpeg", cfg.Tools.FFmpeg)
+	assert.Equal(t, domain.AudioConfig{
+		Normalize:          true,
+		TrimSilence:        true,
+		SilenceThresholdDB: -50,
+		MinSilenceSeconds:  5,
+		SegmentMinutes:     30,
+		OverlapSeconds:     10,
+		Parallel:           2,
+	}, cfg.Audio)
+}
+
+func TestValidate_InvalidAudio(t *testing.T) {
+	cfg := &config.Config{
+		OutputDir: "/tmp",
+		LLM:       validLLM(),
+		Audio:     domain.AudioConfig{SegmentMinutes: 1, OverlapSeconds: 90, Parallel: 1},
+		Bodies: map[string]domain.Body{
+			"test": {
+				PlaylistID:      "PLtest123",
+				OutputSubdir:    "Test Output",
+				FilenamePattern: "Test-{{.MeetingDate}}",
+				TitleDateRegex:  domain.TitlePatterns{{Regex: `^(\d{4}-\d{2}-\d{2})`}},
+				PromptTemplate:  "test.prompt.tmpl",
+				Tags:            []string{"Test"},
+			},
+		},
+	}
+	require.NoError(t, cfg.Validate(), "the audio block is unused without tools.ffmpeg")
+
+	cfg.Tools.FFmpeg = "ffmpeg"
+	err := cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "audio.overlap_seconds (90) must be shorter than a segment")
+}
+
+func TestValidate_NegativeRepairRounds(t *testing.T) {
+	cfg := &config.Config{
+		OutputDir:    "/tmp",
+		LLM:          validLLM(),
+		RepairRounds: -1,
+		Bodies: map[string]domain.Body{
+			"test": {
+				PlaylistID:      "PLtest123",
+				OutputSubdir:    "Test Output",
+				FilenamePattern: "Test-{{.MeetingDate}}",
+				TitleDateRegex:  domain.TitlePatterns{{Regex: `^(\d{4}-\d{2}-\d{2})`}},
+				PromptTemplate:  "test.prompt.tmpl",
+				Tags:            []string{"Test"},
+			},
+		},
+	}
+	err := cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "repair_rounds must not be negative")
+}
+
+func TestValidate_InvalidBackfillFrom(t *testing.T) {
+	cfg := &config.Config{
+		OutputDir: "/tmp",
+		LLM:       validLLM(),
+		Bodies: map[string]domain.Body{
+			"test": {
+				PlaylistID:      "PLtest123",
+				OutputSubdir:    "Test Output",
+				FilenamePattern: "Test-{{.MeetingDate}}",
+				TitleDateRegex:  domain.TitlePatterns{{Regex: `^(\d{4}-\d{2}-\d{2})`}},
+				PromptTemplate:  "test.prompt.tmpl",
+				Tags:            []string{"Test"},
+				BackfillFrom:    "2024/01/01",
+			},
+		},
+	}
+	err := cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `body "test": backfill_from`)
+}
+
+func TestValidate_NegativeMinDuration(t *testing.T) {
+	cfg := &config.Config{
+		OutputDir: "/tmp",
+		LLM:       validLLM(),
+		Bodies: map[string]domain.Body{
+			"test": {
+				PlaylistID:         "PLtest123",
+				OutputSubdir:       "Test Output",
+				FilenamePattern:    "Test-{{.MeetingDate}}",
+				TitleDateRegex:     domain.TitlePatterns{{Regex: `^(\d{4}-\d{2}-\d{2})`}},
+				PromptTemplate:     "test.prompt.tmpl",
+				Tags:               []string{"Test"},
+				MinDurationMinutes: -5,
+			},
+		},
+	}
+	err := cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `body "test": min_duration_minutes must not be negative`)
+}
+
+func TestValidate_InvalidAgendaURLPattern(t *testing.T) {
+	cfg := &config.Config{
+		OutputDir: "/tmp",
+		LLM:       validLLM(),
+		Bodies: map[string]domain.Body{
+			"test": {
+				PlaylistID:       "PLtest123",
+				OutputSubdir:     "Test Output",
+				FilenamePattern:  "Test-{{.MeetingDate}}",
+				TitleDateRegex:   domain.TitlePatterns{{Regex: `^(\d{4}-\d{2}-\d{2})`}},
+				PromptTemplate:   "test.prompt.tmpl",
+				Tags:             []string{"Test"},
+				AgendaURLPattern: "https://example.gov/{{.Date}}.pdf",
+			},
+		},
+	}
+	err := cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `body "test": rendering agenda_url_pattern`)
+}
+
+func TestLoad_BodyValidationRules(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`
+output_dir: /tmp/validation-test
+bodies:
+  planning:
+    playlist_id: PLtest
+    output_subdir: Out
+    filename_pattern: "Planning-{{.MeetingDate}}"
+    title_date_regex: '^(\d{4}-\d{2}-\d{2})'
+    prompt_template: planning.prompt.tmpl
+    tags: [Planning]
+    validation:
+      required_headings:
+        - text: "## Site Plans"
+        - pattern: '^## Adjourn'
+          severity: warning
+      min_words: 300
+      required_frontmatter: [date, source]
+      forbidden_phrases:
+        - text: "As an AI"
+      checks:
+        footer: "off"
+`), 0o644))
+
+	cfg, err := config.Load(path)
+	require.NoError(t, err)
+
+	body, err := cfg.GetBody("planning")
+	require.NoError(t, err)
+	rules := body.ValidationRules()
+
+	assert.Equal(t, []domain.TextRule{
+		{Text: "## Site Plans"},
+		{Pattern: "^## Adjourn", Severity: domain.RuleWarning},
+	}, rules.RequiredHeadings)
+	minWords, warnWords := rules.Words()
+	assert.Equal(t, 300, minWords)
+	assert.Equal(t, 1000, warnWords, "unset fields keep their defaults")
+	assert.Equal(t, []string{"date", "source"}, rules.RequiredFrontmatter)
+	assert.Equal(t, []domain.TextRule{{Text: "As an AI"}}, rules.ForbiddenPhrases)
+	assert.Equal(t, domain.RuleOff, rules.Check(domain.CheckFooter))
+	assert.Equal(t, domain.RuleWarning, rules.Check(domain.CheckTimestamps))
+}
+
+func TestLoad_TitleDateRegexForms(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`
+output_dir: /tmp/title-test
+bodies:
+  single:
+    playlist_id: PLtest
+    output_subdir: Single
+    filename_pattern: "Single-{{.MeetingDate}}"
+    title_date_regex: '^([A-Z][a-z]+ \d{1,2}, \d{4})'
+    prompt_template: single.prompt.tmpl
+    tags: [Single]
+  listed:
+    playlist_id: PLtest
+    output_subdir: Listed
+    filename_pattern: "Listed-{{.MeetingDate}}"
+    title_date_regex:
+      - '^([A-Z][a-z]+ \d{1,2}, \d{4})'
+      - regex: '(\d{2}/\d{2}/\d{4})'
+        layouts: ["01/02/2006"]
+      - '(?P<day>\d{1,2}) (?P<month>[A-Za-z]+) (?P<year>\d{4})'
+    title_exclude: ['(?i)promo', '(?i)ribbon cutting']
+    prompt_template: listed.prompt.tmpl
+    tags: [Listed]
+`), 0o644))
+
+	cfg, err := config.Load(path)
+	require.NoError(t, err)
+
+	single, _ := cfg.GetBody("single")
+	assert.Equal(t, domain.TitlePatterns{{Regex: `^([A-Z][a-z]+ \d{1,2}, \d{4})`}}, single.TitleDateRegex,
+		"a single regex is not split at its comma")
+
+	listed, _ := cfg.GetBody("listed")
+	assert.Equal(t, domain.TitlePatterns{
+		{Regex: `^([A-Z][a-z]+ \d{1,2}, \d{4})`},
+		{Regex: `(\d{2}/\d{2}/\d{4})`, Layouts: []string{"01/02/2006"}},
+		{Regex: `(?P<day>\d{1,2}) (?P<month>[A-Za-z]+) (?P<year>\d{4})`},
+	}, listed.TitleDateRegex)
+	assert.Equal(t, []string{"(?i)promo", "(?i)ribbon cutting"}, listed.TitleExclude)
+}
+
+func TestValidate_InvalidTitleRules(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(*domain.Body)
+		wantErr string
+	}{
+		{"missing", func(b *domain.Body) { b.TitleDateRegex = nil }, "title_date_regex is required"},
+		{"no group", func(b *domain.Body) { b.TitleDateRegex = domain.TitlePatterns{{Regex: `\d{4}`}} }, "needs a capture group"},
+		{
+			"partial named groups",
+			func(b *domain.Body) { b.TitleDateRegex = domain.TitlePatterns{{Regex: `(?P<year>\d{4})-(\d{2})`}} },
+			"named groups need all of year, month, and day",
+		},
+		{"bad exclude", func(b *domain.Body) { b.TitleExclude = []string{"("} }, "title_exclude[0]: invalid regex"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body := domain.Body{
+				PlaylistID:      "PLtest123",
+				OutputSubdir:    "Test Output",
+				FilenamePattern: "Test-{{.MeetingDate}}",
+				TitleDateRegex:  domain.TitlePatterns{{Regex: `^(\d{4}-\d{2}-\d{2})`}},
+				PromptTemplate:  "test.prompt.tmpl",
+				Tags:            []string{"Test"},
+			}
+			tt.modify(&body)
+			cfg := &config.Config{OutputDir: "/tmp", LLM: validLLM(), Bodies: map[string]domain.Body{"test": body}}
+
+			err := cfg.Validate()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestValidate_InvalidValidationRules(t *testing.T) {
+	cfg := &config.Config{
+		OutputDir: "/tmp",
+		LLM:       validLLM(),
+		Bodies: map[string]domain.Body{
+			"test": {
+				PlaylistID:      "PLtest123",
+				OutputSubdir:    "Test Output",
+				FilenamePattern: "Test-{{.MeetingDate}}",
+				TitleDateRegex:  domain.TitlePatterns{{Regex: `^(\d{4}-\d{2}-\d{2})`}},
+				PromptTemplate:  "test.prompt.tmpl",
+				Tags:            []string{"Test"},
+				Validation: &domain.ValidationRules{
+					RequiredHeadings: []domain.TextRule{{Pattern: "## (unclosed"}},
+				},
+			},
+		},
+	}
+	err := cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `body "test": validation.required_headings[0]: invalid pattern`)
+}
+
+func TestLoad_MeetingTypes(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`
+output_dir: /tmp/types-test
+bodies:
+  council:
+    playlist_id: PLtest
+    output_subdir: Council
+    filename_pattern: "Council-{{.MeetingDate}}"
+    title_date_regex: '^([A-Z][a-z]+ \d{1,2}, \d{4})'
+    prompt_template: council.prompt.tmpl
+    tags: [Council]
+    meeting_types:
+      - Closed Session
+      - name: Public Hearing
+        match: '(?i)public hearing|zoning appeal'
+        tag: Hearing
+        prompt_template: hearing.prompt.tmpl
+        validation:
+          min_words: 200
+`), 0o644))
+
+	cfg, err := config.Load(path)
+	require.NoError(t, err)
+
+	body, _ := cfg.GetBody("council")
+	minWords := 200
+	assert.Equal(t, []domain.MeetingTypeRule{
+		{Name: "Closed Session"},
+		{
+			Name:           "Public Hearing",
+			Match:          `(?i)public hearing|zoning appeal`,
+			Tag:            "Hearing",
+			PromptTemplate: "hearing.prompt.tmpl",
+			Validation:     &domain.ValidationRules{MinWords: &minWords},
+		},
+	}, body.MeetingTypes)
+}
+
+func TestValidate_InvalidMeetingType(t *testing.T) {
+	cfg := &config.Config{
+		OutputDir: "/tmp",
+		LLM:       validLLM(),
+		Bodies: map[string]domain.Body{
+			"test": {
+				PlaylistID:      "PLtest123",
+				OutputSubdir:    "Test Output",
+				FilenamePattern: "Test-{{.MeetingDate}}",
+				TitleDateRegex:  domain.TitlePatterns{{Regex: `^(\d{4}-\d{2}-\d{2})`}},
+				PromptTemplate:  "test.prompt.tmpl",
+				Tags:            []string{"Test"},
+				MeetingTypes:    []domain.MeetingTypeRule{{Name: "Hearing", Match: "(unclosed"}},
+			},
+		},
+	}
+	err := cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `body "test": meeting_types[0]: invalid match`)
+}
+
+func TestLoad_BodyCaptionPolicy(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`
+output_dir: /tmp/captions-test
+bodies:
+  council:
+    playlist_id: PLtest
+    output_subdir: Council
+    filename_pattern: "Council-{{.MeetingDate}}"
+    title_date_regex: '^([A-Z][a-z]+ \d{1,2}, \d{4})'
+    prompt_template: council.prompt.tmpl
+    tags: [Council]
+    captions:
+      kinds: [manual]
+      languages: [es, en]
+      min_quality: 60
+`), 0o644))
+
+	cfg, err := config.Load(path)
+	require.NoError(t, err)
+
+	body, _ := cfg.GetBody("council")
+	policy := body.CaptionPolicy()
+	assert.Equal(t, []string{domain.CaptionManual}, policy.Kinds)
+	assert.Equal(t, []string{"es", "en"}, policy.Languages)
+	assert.Equal(t, []string{"srt", "vtt"}, policy.Formats, "unset fields keep the defaults")
+	assert.Equal(t, 60, policy.Threshold())
+}
+
+func TestLoad_BodyRoster(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`
+output_dir: /tmp/roster-test
+tools:
+  diarizer: /usr/local/bin/diarize-rttm
+  diarizer_args: [--device, cpu]
+bodies:
+  council:
+    playlist_id: PLtest
+    output_subdir: Council
+    filename_pattern: "Council-{{.MeetingDate}}"
+    title_date_regex: '^([A-Z][a-z]+ \d{1,2}, \d{4})'
+    prompt_template: council.prompt.tmpl
+    tags: [Council]
+    diarize: true
+    roster:
+      - name: Keith Bruchey
+        title: Mayor
+      - name: Tekesha Martinez
+        title: Councilmember
+        seat: At-large
+        aliases: [Tiki Martinez]
+      - Kristin Aleshire
+`), 0o644))
+
+	cfg, err := config.Load(path)
+	require.NoError(t, err)
+
+	assert.Equal(t, "/usr/local/bin/diarize-rttm", cfg.Tools.Diarizer)
+	assert.Equal(t, []string{"--device", "cpu"}, cfg.Tools.DiarizerArgs)
+	body, _ := cfg.GetBody("council")
+	assert.True(t, body.Diarize)
+	assert.Equal(t, domain.Roster{
+		{Name: "Keith Bruchey", Title: "Mayor"},
+		{Name: "Tekesha Martinez", Title: "Councilmember", Seat: "At-large", Aliases: []string{"Tiki Martinez"}},
+		{Name: "Kristin Aleshire"},
+	}, body.Roster)
+}
+
+func TestValidate_DiarizeWithoutDiarizer(t *testing.T) {
+	cfg := &config.Config{
+		OutputDir: "/tmp",
+		LLM:       validLLM(),
+		Bodies: map[string]domain.Body{
+			"test": {
+				PlaylistID:      "PLtest123",
+				OutputSubdir:    "Test Output",
+				FilenamePattern: "Test-{{.MeetingDate}}",
+				TitleDateRegex:  domain.TitlePatterns{{Regex: `^(\d{4}-\d{2}-\d{2})`}},
+				PromptTemplate:  "test.prompt.tmpl",
+				Tags:            []string{"Test"},
+				Diarize:         true,
+			},
+		},
+	}
+
+	err := cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "diarize requires tools.diarizer")
+
+	cfg.Tools.Diarizer = "diarize-rttm"
+	require.NoError(t, cfg.Validate())
+}
+
+func TestLoad_BodyVocabulary(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "vocab"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "vocab", "council.yaml"), []byte(`
+terms:
+  - term: Antietam
+    variants: [anteetam, and tee tam]
+  - Hagerstown
+`), 0o644))
+	path := filepath.Join(dir, "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`
+output_dir: /tmp/vocabulary-test
+transcriber:
+  backend: whisper.cpp
+  model: /opt/models/ggml-medium.en.bin
+  vocabulary: [Hagerstown]
+bodies:
+  council:
+    playlist_id: PLtest
+    output_subdir: Council
+    filename_pattern: "Council-{{.MeetingDate}}"
+    title_date_regex: '^(\d{4}-\d{2}-\d{2})'
+    prompt_template: council.prompt.tmpl
+    tags: [Council]
+    vocabulary:
+      - term: Bruchey
+        variants: [brewskie]
+    vocabulary_file: vocab/council.yaml
+`), 0o644))
+
+	cfg, err := config.Load(path)
+	require.NoError(t, err)
+
+	body, _ := cfg.GetBody("council")
+	assert.Equal(t, filepath.Join(dir, "vocab", "council.yaml"), body.VocabularyFile, "resolved against the config file")
+	assert.Equal(t, domain.Vocabulary{
+		{Term: "Bruchey", Variants: []string{"brewskie"}},
+		{Term: "Antietam", Variants: []string{"anteetam", "and tee tam"}},
+		{Term: "Hagerstown"},
+	}, body.Vocabulary)
+	assert.Equal(t, []string{"Hagerstown", "Bruchey", "Antietam"}, cfg.ResolveTranscriber(body).Vocabulary,
+		"body terms follow the transcriber's, without repeats")
+	assert.Equal(t, []string{"Hagerstown"}, cfg.Transcriber.Vocabulary, "the global block is not modified")
+}
+
+func TestLoad_MissingVocabularyFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`
+output_dir: /tmp/vocabulary-test
+bodies:
+  council:
+    playlist_id: PLtest
+    output_subdir: Council
+    filename_pattern: "Council-{{.MeetingDate}}"
+    title_date_regex: '^(\d{4}-\d{2}-\d{2})'
+    prompt_template: council.prompt.tmpl
+    tags: [Council]
+    vocabulary_file: missing.yaml
+`), 0o644))
+
+	_, err := config.Load(path)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `body "council": reading vocabulary file`)
+}
+
+func TestValidate_InvalidVocabulary(t *testing.T) {
+	cfg := &config.Config{
+		OutputDir: "/tmp",
+		LLM:       validLLM(),
+		Bodies: map[string]domain.Body{
+			"test": {
+				PlaylistID:      "PLtest123",
+				OutputSubdir:    "Test Output",
+				FilenamePattern: "Test-{{.MeetingDate}}",
+				TitleDateRegex:  domain.TitlePatterns{{Regex: `^(\d{4}-\d{2}-\d{2})`}},
+				PromptTemplate:  "test.prompt.tmpl",
+				Tags:            []string{"Test"},
+				Vocabulary:      domain.Vocabulary{{Variants: []string{"anteetam"}}},
+			},
+		},
+	}
+
+	err := cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `body "test": vocabulary[0]: term is required`)
+}
+
+func TestLoad_BodyBoundaries(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`
+output_dir: /tmp/boundaries-test
+bodies:
+  council:
+    playlist_id: PLtest
+    output_subdir: Council
+    filename_pattern: "Council-{{.MeetingDate}}"
+    title_date_regex: '^(\d{4}-\d{2}-\d{2})'
+    prompt_template: council.prompt.tmpl
+    tags: [Council]
+    boundaries:
+      call_to_order: ['\bthe chair recognizes\b']
+      max_gap_seconds: 600
+  commission:
+    playlist_id: PLtest2
+    output_subdir: Commission
+    filename_pattern: "Commission-{{.MeetingDate}}"
+    title_date_regex: '^(\d{4}-\d{2}-\d{2})'
+    prompt_template: council.prompt.tmpl
+    tags: [Commission]
+    boundaries:
+      enabled: false
+`), 0o644))
+
+	cfg, err := config.Load(path)
+	require.NoError(t, err)
+
+	council, _ := cfg.GetBody("council")
+	boundaries := council.MeetingBoundaries()
+	assert.Equal(t, []string{`\bthe chair recognizes\b`}, boundaries.CallToOrder)
+	assert.Equal(t, domain.DefaultMeetingBoundaries().Adjournment, boundaries.Adjournment)
+	assert.Equal(t, 600, *boundaries.MaxGapSeconds)
+	assert.True(t, *boundaries.Enabled)
+
+	commission, _ := cfg.GetBody("commission")
+	assert.False(t, *commission.MeetingBoundaries().Enabled)
+}
+
+func TestValidate_InvalidBoundaries(t *testing.T) {
+	cfg := &config.Config{
+		OutputDir: "/tmp",
+		LLM:       validLLM(),
+		Bodies: map[string]domain.Body{
+			"test": {
+				PlaylistID:      "PLtest123",
+				OutputSubdir:    "Test Output",
+				FilenamePattern: "Test-{{.MeetingDate}}",
+				TitleDateRegex:  domain.TitlePatterns{{Regex: `^(\d{4}-\d{2}-\d{2})`}},
+				PromptTemplate:  "test.prompt.tmpl",
+				Tags:            []string{"Test"},
+				Boundaries:      domain.MeetingBoundaries{Adjournment: []string{"adjourn("}},
+			},
+		},
+	}
+
+	err := cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `body "test": boundaries.adjournment[0]`)
+}
+
+func TestValidate_InvalidCaptionPolicy(t *testing.T) {
+	cfg := &config.Config{
+		OutputDir: "/tmp",
+		LLM:       validLLM(),
+		Bodies: map[string]domain.Body{
+			"test": {
+				PlaylistID:      "PLtest123",
+				OutputSubdir:    "Test Output",
+				FilenamePattern: "Test-{{.MeetingDate}}",
+				TitleDateRegex:  domain.TitlePatterns{{Regex: `^(\d{4}-\d{2}-\d{2})`}},
+				PromptTemplate:  "test.prompt.tmpl",
+				Tags:            []string{"Test"},
+				Captions:        domain.CaptionPolicy{Kinds: []string{"burned-in"}},
+			},
+		},
+	}
+	err := cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `body "test": captions.kinds[0]: unknown kind "burned-in"`)
+}
+
+func TestResolveTranscriber(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`
+output_dir: /tmp/transcriber-test
+transcriber:
+  backend: whisper.cpp
+  model: /opt/models/ggml-medium.en.bin
+  threads: 6
+  vocabulary: [Hagerstown, Potomac Street]
+bodies:
+  council:
+    playlist_id: PLtest
+    output_subdir: Council
+    filename_pattern: "Council-{{.MeetingDate}}"
+    title_date_regex: '^(\d{4}-\d{2}-\d{2})'
+    prompt_template: council.prompt.tmpl
+    tags: [Council]
+  consejo:
+    playlist_id: PLtest2
+    output_subdir: Consejo
+    filename_pattern: "Consejo-{{.MeetingDate}}"
+    title_date_regex: '^(\d{4}-\d{2}-\d{2})'
+    prompt_template: council.prompt.tmpl
+    tags: [Consejo]
+    transcriber:
+      backend: http
+      base_url: http://localhost:8000/v1
+      language: es
+`), 0o644))
+
+	cfg, err := config.Load(path)
+	require.NoError(t, err)
+
+	council, _ := cfg.GetBody("council")
+	assert.Equal(t, domain.TranscriberConfig{
+		Backend:    domain.TranscriberWhisperCpp,
+		Binary:     "whisper-cli",
+		Model:      "/opt/models/ggml-medium.en.bin",
+		Language:   "en",
+		Threads:    6,
+		Vocabulary: []string{"Hagerstown", "Potomac Street"},
+	}, cfg.ResolveTranscriber(council))
+
+	consejo, _ := cfg.GetBody("consejo")
+	resolved := cfg.ResolveTranscriber(consejo)
+	assert.Equal(t, domain.TranscriberHTTP, resolved.Backend)
+	assert.Equal(t, "whisper-1", resolved.Model)
+	assert.Equal(t, "es", resolved.Language)
+	assert.Equal(t, "http://localhost:8000/v1", resolved.BaseURL)
+	assert.Equal(t, []string{"Hagerstown", "Potomac Street"}, resolved.Vocabulary)
+}
+
+func TestResolveTranscriber_LegacyWhisperTool(t *testing.T) {
+	cfg := &config.Config{Tools: config.ToolsConfig{Whisper: "/usr/local/bin/whisper", WhisperModel: "small"}}
+
+	resolved := cfg.ResolveTranscriber(domain.Body{})
+
+	assert.Equal(t, domain.TranscriberOpenAIWhisper, resolved.Backend)
+	assert.Equal(t, "/usr/local/bin/whisper", resolved.Binary)
+	assert.Equal(t, "small", resolved.Model)
+	assert.False(t, (&config.Config{}).ResolveTranscriber(domain.Body{}).Enabled(), "no whisper, no transcriber")
+}
+
+func TestValidate_InvalidTranscriber(t *testing.T) {
+	cfg := &config.Config{
+		OutputDir:   "/tmp",
+		LLM:         validLLM(),
+		Transcriber: domain.TranscriberConfig{Backend: domain.TranscriberWhisperCpp},
+		Bodies: map[string]domain.Body{
+			"test": {
+				PlaylistID:      "PLtest123",
+				OutputSubdir:    "Test Output",
+				FilenamePattern: "Test-{{.MeetingDate}}",
+				TitleDateRegex:  domain.TitlePatterns{{Regex: `^(\d{4}-\d{2}-\d{2})`}},
+				PromptTemplate:  "test.prompt.tmpl",
+				Tags:            []string{"Test"},
+			},
+		},
+	}
+	err := cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `body "test": transcriber.model is required for whisper.cpp`)
+}
@@ -0,0 +1,53 @@
+package domain
+
+import (
+	"bytes"
+	"fmt"
+	"text/template"
+	"time"
+)
+
+// Agenda is the published agenda for a meeting. Either field may be empty: an
+// agenda can be linked without its text being extractable.
+type Agenda struct {
+	URL  string
+	Text string
+}
+
+// IsEmpty returns true if no agenda was found.
+func (a Agenda) IsEmpty() bool {
+	return a.URL == "" && a.Text == ""
+}
+
+// AgendaURLData holds the fields available to a body's agenda_url_pattern.
+type AgendaURLData struct {
+	MeetingDate string // 2006-01-02
+	DateCompact string // 20060102
+	Year        string // 2006
+	Month       string // 01
+	Day         string // 02
+}
+
+// AgendaURLFor renders the body's agenda_url_pattern for a meeting date. It
+// returns "" when no pattern is configured.
+func (b Body) AgendaURLFor(date time.Time) (string, error) {
+	if b.AgendaURLPattern == "" {
+		return "", nil
+	}
+	tmpl, err := template.New("agenda_url_pattern").Option("missingkey=error").Parse(b.AgendaURLPattern)
+	if err != nil {
+		return "", fmt.Errorf("parsing agenda_url_pattern: %w", err)
+	}
+	data := AgendaURLData{
+		MeetingDate: date.Format("2006-01-02"),
+		DateCompact: date.Format("20060102"),
+		Year:        date.Format("2006"),
+		Month:       date.Format("01"),
+		Day:         date.Format("02"),
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("rendering agenda_url_pattern: %w", err)
+	}
+	return buf.String(), nil
+}
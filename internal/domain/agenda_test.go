@@ -0,0 +1,47 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/AvogadroSG1/civic-summary/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBody_AgendaURLFor(t *testing.T) {
+	date := time.Date(2025, 2, 4, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		pattern string
+		want    string
+	}{
+		{"unset", "", ""},
+		{"iso date", "https://example.gov/agendas/{{.MeetingDate}}.pdf", "https://example.gov/agendas/2025-02-04.pdf"},
+		{"compact date", "https://example.gov/a/{{.DateCompact}}", "https://example.gov/a/20250204"},
+		{"parts", "https://example.gov/{{.Year}}/{{.Month}}/{{.Day}}/agenda.html", "https://example.gov/2025/02/04/agenda.html"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := domain.Body{AgendaURLPattern: tt.pattern}.AgendaURLFor(date)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestBody_AgendaURLFor_Invalid(t *testing.T) {
+	date := time.Date(2025, 2, 4, 0, 0, 0, 0, time.UTC)
+
+	_, err := domain.Body{AgendaURLPattern: "https://example.gov/{{.MeetingDate"}.AgendaURLFor(date)
+	assert.ErrorContains(t, err, "parsing agenda_url_pattern")
+
+	_, err = domain.Body{AgendaURLPattern: "https://example.gov/{{.Weekday}}"}.AgendaURLFor(date)
+	assert.ErrorContains(t, err, "rendering agenda_url_pattern")
+}
+
+func TestAgenda_IsEmpty(t *testing.T) {
+	assert.True(t, domain.Agenda{}.IsEmpty())
+	assert.False(t, domain.Agenda{URL: "https://example.gov/agenda.pdf"}.IsEmpty())
+}
@@ -0,0 +1,161 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// AudioConfig controls how meeting audio is prepared for speech-to-text when
+// tools.ffmpeg is set: loudness normalization, trimming of the silence before
+// and after the meeting, and splitting long recordings into overlapping
+// segments that are transcribed in parallel.
+type AudioConfig struct {
+	// Normalize evens out the loudness of each segment, so quiet microphones
+	// at the far end of the dais are not lost.
+	Normalize bool `yaml:"normalize" mapstructure:"normalize"`
+	// TrimSilence skips silence at the start and end of the recording, such
+	// as a dead stream before the meeting is called to order.
+	TrimSilence bool `yaml:"trim_silence" mapstructure:"trim_silence"`
+	// SilenceThresholdDB is the level, in dB, below which audio counts as
+	// silence. Defaults to -50.
+	SilenceThresholdDB int `yaml:"silence_threshold_db" mapstructure:"silence_threshold_db"`
+	// MinSilenceSeconds is how long a quiet stretch must last to count as
+	// silence. Defaults to 5.
+	MinSilenceSeconds int `yaml:"min_silence_seconds" mapstructure:"min_silence_seconds"`
+	// SegmentMinutes is the longest stretch of audio transcribed in one run.
+	// Zero transcribes the recording whole. Defaults to 30.
+	SegmentMinutes int `yaml:"segment_minutes" mapstructure:"segment_minutes"`
+	// OverlapSeconds is how much consecutive segments overlap, so a sentence
+	// cut at a boundary is heard whole in one of them. Defaults to 10.
+	OverlapSeconds int `yaml:"overlap_seconds" mapstructure:"overlap_seconds"`
+	// Parallel is how many segments of one recording are transcribed at
+	// once. Defaults to 2.
+	Parallel int `yaml:"parallel" mapstructure:"parallel"`
+}
+
+// SegmentLength returns SegmentMinutes as a duration.
+func (c AudioConfig) SegmentLength() time.Duration {
+	return time.Duration(c.SegmentMinutes) * time.Minute
+}
+
+// Overlap returns OverlapSeconds as a duration.
+func (c AudioConfig) Overlap() time.Duration {
+	return time.Duration(c.OverlapSeconds) * time.Second
+}
+
+// MinSilence returns MinSilenceSeconds as a duration.
+func (c AudioConfig) MinSilence() time.Duration {
+	return time.Duration(c.MinSilenceSeconds) * time.Second
+}
+
+// Validate rejects negative settings and an overlap as long as a segment.
+func (c AudioConfig) Validate() error {
+	if c.MinSilenceSeconds < 0 {
+		return fmt.Errorf("audio.min_silence_seconds must not be negative, got %d", c.MinSilenceSeconds)
+	}
+	if c.SegmentMinutes < 0 {
+		return fmt.Errorf("audio.segment_minutes must not be negative, got %d", c.SegmentMinutes)
+	}
+	if c.OverlapSeconds < 0 {
+		return fmt.Errorf("audio.overlap_seconds must not be negative, got %d", c.OverlapSeconds)
+	}
+	if c.SegmentMinutes > 0 && c.Overlap() >= c.SegmentLength() {
+		return fmt.Errorf("audio.overlap_seconds (%d) must be shorter than a segment", c.OverlapSeconds)
+	}
+	if c.Parallel < 1 {
+		return fmt.Errorf("audio.parallel must be at least 1, got %d", c.Parallel)
+	}
+	return nil
+}
+
+// Silence is a quiet stretch of a recording, as reported by ffmpeg's
+// silencedetect filter.
+type Silence struct {
+	Start time.Duration
+	End   time.Duration
+}
+
+// silenceEdge is how close to either end of a recording a silence must reach
+// to count as leading or trailing; silencedetect rarely reports exactly 0.
+const silenceEdge = time.Second
+
+// SpeechBounds returns the part of a recording between its leading and
+// trailing silence. A recording that is silent throughout is returned whole,
+// leaving the transcriber to find nothing in it.
+func SpeechBounds(duration time.Duration, silences []Silence) (start, end time.Duration) {
+	start, end = 0, duration
+	if len(silences) == 0 {
+		return start, end
+	}
+	if first := silences[0]; first.Start < silenceEdge {
+		start = first.End
+	}
+	if last := silences[len(silences)-1]; last.End > duration-silenceEdge {
+		end = last.Start
+	}
+	if start >= end {
+		return 0, duration
+	}
+	return start, end
+}
+
+// AudioSegment is a span of a recording transcribed on its own. Index is
+// 1-based.
+type AudioSegment struct {
+	Index int
+	Start time.Duration
+	End   time.Duration
+}
+
+// Duration returns the segment's length.
+func (s AudioSegment) Duration() time.Duration {
+	return s.End - s.Start
+}
+
+// PlanSegments splits the span from start to end into segments no longer
+// than length, each starting overlap before the previous one ends. A
+// non-positive length, or a span that fits in one, gives a single segment.
+func PlanSegments(start, end, length, overlap time.Duration) []AudioSegment {
+	if length <= 0 || end-start <= length {
+		return []AudioSegment{{Index: 1, Start: start, End: end}}
+	}
+	var segments []AudioSegment
+	for s := start; ; s += length - overlap {
+		e := min(s+length, end)
+		segments = append(segments, AudioSegment{Index: len(segments) + 1, Start: s, End: e})
+		if e >= end {
+			return segments
+		}
+	}
+}
+
+// StitchTranscripts joins the transcripts of consecutive segments into one
+// on the recording's timeline. Each part's cues are shifted by its segment's
+// start; where two segments overlap, cues starting before the middle of the
+// overlap come from the earlier part and the rest from the later one, so
+// nothing said there appears twice. Cues are renumbered from 1.
+func StitchTranscripts(segments []AudioSegment, parts []Transcript) Transcript {
+	var cues []Cue
+	for i, seg := range segments {
+		if i >= len(parts) {
+			break
+		}
+		from, until := seg.Start, seg.End
+		if i > 0 {
+			from = (segments[i-1].End + seg.Start) / 2
+		}
+		if i < len(segments)-1 {
+			until = (seg.End + segments[i+1].Start) / 2
+		}
+		for _, c := range parts[i].Cues {
+			c.Start += seg.Start
+			c.End += seg.Start
+			if c.Start < from || (c.Start >= until && i < len(segments)-1) {
+				continue
+			}
+			c.Index = len(cues) + 1
+			cues = append(cues, c)
+		}
+	}
+	return Transcript{Cues: cues, Source: TranscriptSourceWhisper, Format: TranscriptFormatSRT}
+}
@@ -0,0 +1,100 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/AvogadroSG1/civic-summary/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSpeechBounds(t *testing.T) {
+	hour := time.Hour
+
+	start, end := domain.SpeechBounds(hour, []domain.Silence{
+		{Start: 0, End: 20 * time.Minute},
+		{Start: 30 * time.Minute, End: 30*time.Minute + 8*time.Second},
+		{Start: 55 * time.Minute, End: hour},
+	})
+	assert.Equal(t, 20*time.Minute, start, "leading dead stream is trimmed")
+	assert.Equal(t, 55*time.Minute, end, "trailing silence is trimmed")
+
+	start, end = domain.SpeechBounds(hour, []domain.Silence{{Start: 10 * time.Minute, End: 11 * time.Minute}})
+	assert.Equal(t, time.Duration(0), start, "a pause mid-meeting is kept")
+	assert.Equal(t, hour, end)
+
+	start, end = domain.SpeechBounds(hour, []domain.Silence{{Start: 0, End: hour}})
+	assert.Equal(t, time.Duration(0), start, "a silent recording is kept whole")
+	assert.Equal(t, hour, end)
+}
+
+func TestPlanSegments(t *testing.T) {
+	minute := time.Minute
+
+	segments := domain.PlanSegments(5*minute, 75*minute, 30*minute, 10*time.Second)
+	assert.Equal(t, []domain.AudioSegment{
+		{Index: 1, Start: 5 * minute, End: 35 * minute},
+		{Index: 2, Start: 35*minute - 10*time.Second, End: 65*minute - 10*time.Second},
+		{Index: 3, Start: 65*minute - 20*time.Second, End: 75 * minute},
+	}, segments)
+
+	assert.Equal(t, []domain.AudioSegment{{Index: 1, Start: 0, End: 20 * minute}},
+		domain.PlanSegments(0, 20*minute, 30*minute, 10*time.Second), "short recordings are one segment")
+	assert.Equal(t, []domain.AudioSegment{{Index: 1, Start: 0, End: 4 * time.Hour}},
+		domain.PlanSegments(0, 4*time.Hour, 0, 0), "no segment length")
+}
+
+func TestStitchTranscripts(t *testing.T) {
+	segments := []domain.AudioSegment{
+		{Index: 1, Start: 60 * time.Second, End: 120 * time.Second},
+		{Index: 2, Start: 110 * time.Second, End: 170 * time.Second},
+	}
+	parts := []domain.Transcript{
+		{Cues: []domain.Cue{
+			{Index: 1, Start: 0, End: 5 * time.Second, Text: "Call to order."},
+			{Index: 2, Start: 52 * time.Second, End: 56 * time.Second, Text: "The motion carries."},
+			{Index: 3, Start: 57 * time.Second, End: 60 * time.Second, Text: "Next item,"},
+		}},
+		{Cues: []domain.Cue{
+			{Index: 1, Start: 2 * time.Second, End: 6 * time.Second, Text: "motion carries."},
+			{Index: 2, Start: 6 * time.Second, End: 10 * time.Second, Text: "Next item, public comment."},
+			{Index: 3, Start: 30 * time.Second, End: 34 * time.Second, Text: "Adjourned."},
+		}},
+	}
+
+	stitched := domain.StitchTranscripts(segments, parts)
+
+	assert.Equal(t, []domain.Cue{
+		{Index: 1, Start: 60 * time.Second, End: 65 * time.Second, Text: "Call to order."},
+		{Index: 2, Start: 112 * time.Second, End: 116 * time.Second, Text: "The motion carries."},
+		{Index: 3, Start: 116 * time.Second, End: 120 * time.Second, Text: "Next item, public comment."},
+		{Index: 4, Start: 140 * time.Second, End: 144 * time.Second, Text: "Adjourned."},
+	}, stitched.Cues, "times are on the recording's timeline and the overlap is heard once")
+	assert.Equal(t, domain.TranscriptSourceWhisper, stitched.Source)
+}
+
+func TestAudioConfig_Validate(t *testing.T) {
+	valid := domain.AudioConfig{SegmentMinutes: 30, OverlapSeconds: 10, Parallel: 2}
+	require.NoError(t, valid.Validate())
+
+	tests := []struct {
+		name    string
+		mutate  func(*domain.AudioConfig)
+		wantErr string
+	}{
+		{"negative segment", func(c *domain.AudioConfig) { c.SegmentMinutes = -1 }, "audio.segment_minutes must not be negative"},
+		{"negative overlap", func(c *domain.AudioConfig) { c.OverlapSeconds = -1 }, "audio.overlap_seconds must not be negative"},
+		{"overlap too long", func(c *domain.AudioConfig) { c.SegmentMinutes = 1; c.OverlapSeconds = 60 }, "must be shorter than a segment"},
+		{"no parallelism", func(c *domain.AudioConfig) { c.Parallel = 0 }, "audio.parallel must be at least 1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := valid
+			tt.mutate(&cfg)
+			err := cfg.Validate()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
@@ -1,30 +1,120 @@
 // Package domain defines the core types for the civic-summary pipeline.
 package domain
 
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// DefaultRepairTemplate is the repair template used when a body sets none.
+const DefaultRepairTemplate = "repair.tmpl"
+
 // Body represents a government entity whose meetings are processed.
 // Bodies are loaded from configuration and are immutable at runtime.
 type Body struct {
-	Slug            string   `yaml:"slug" mapstructure:"slug"`
-	Name            string   `yaml:"name" mapstructure:"name"`
-	PlaylistID      string   `yaml:"playlist_id" mapstructure:"playlist_id"`
-	VideoSourceURL  string   `yaml:"video_source_url" mapstructure:"video_source_url"`
-	OutputSubdir    string   `yaml:"output_subdir" mapstructure:"output_subdir"`
-	FilenamePattern string   `yaml:"filename_pattern" mapstructure:"filename_pattern"`
-	TitleDateRegex  string   `yaml:"title_date_regex" mapstructure:"title_date_regex"`
-	Tags            []string `yaml:"tags" mapstructure:"tags"`
-	PromptTemplate  string   `yaml:"prompt_template" mapstructure:"prompt_template"`
-	MeetingTypes    []string `yaml:"meeting_types" mapstructure:"meeting_types"`
-	Author          string   `yaml:"author" mapstructure:"author"`
-	FooterText      string   `yaml:"footer_text" mapstructure:"footer_text"`
+	Slug            string        `yaml:"slug" mapstructure:"slug"`
+	Name            string        `yaml:"name" mapstructure:"name"`
+	PlaylistID      string        `yaml:"playlist_id" mapstructure:"playlist_id"`
+	VideoSourceURL  string        `yaml:"video_source_url" mapstructure:"video_source_url"`
+	Source          SourceConfig  `yaml:"source" mapstructure:"source"`
+	OutputSubdir    string        `yaml:"output_subdir" mapstructure:"output_subdir"`
+	FilenamePattern string        `yaml:"filename_pattern" mapstructure:"filename_pattern"`
+	TitleDateRegex  TitlePatterns `yaml:"title_date_regex" mapstructure:"title_date_regex"`
+	Tags            []string      `yaml:"tags" mapstructure:"tags"`
+	PromptTemplate  string        `yaml:"prompt_template" mapstructure:"prompt_template"`
+	// MeetingTypes classify meetings by title, in order. In the config an
+	// entry is a rule, or just a name matched anywhere in the title.
+	MeetingTypes []MeetingTypeRule `yaml:"meeting_types" mapstructure:"meeting_types"`
+	Author       string            `yaml:"author" mapstructure:"author"`
+	FooterText   string            `yaml:"footer_text" mapstructure:"footer_text"`
+
+	// ChunkTemplate summarizes one segment of a transcript too long for the
+	// model's context window. Defaults to the prompt template's name with
+	// ".prompt.tmpl" replaced by ".chunk.tmpl".
+	ChunkTemplate string `yaml:"chunk_template" mapstructure:"chunk_template"`
+
+	// RepairTemplate asks the model to fix a summary that failed validation.
+	// Defaults to DefaultRepairTemplate, which is shared by all bodies.
+	RepairTemplate string `yaml:"repair_template" mapstructure:"repair_template"`
+
+	// BackfillFrom (YYYY-MM-DD) is the earliest meeting date processed when no
+	// date range is given on the command line, so a new body's archive is
+	// backfilled instead of only the current year.
+	BackfillFrom string `yaml:"backfill_from" mapstructure:"backfill_from"`
+
+	// TitleInclude and TitleExclude are regular expressions that filter the
+	// listing by title before any date is parsed. A video must match one
+	// TitleInclude entry, when any are set, and no TitleExclude entry, so
+	// promos and ribbon cuttings can be dropped.
+	TitleInclude []string `yaml:"title_include" mapstructure:"title_include"`
+	TitleExclude []string `yaml:"title_exclude" mapstructure:"title_exclude"`
+
+	// MinDurationMinutes skips listed videos shorter than this, such as
+	// announcements and meeting clips. Videos whose duration the source does
+	// not report are kept. Zero keeps every video.
+	MinDurationMinutes int `yaml:"min_duration_minutes" mapstructure:"min_duration_minutes"`
+
+	// AgendaURLPattern is a text/template for the agenda URL of a meeting,
+	// used when the video description does not link one. See AgendaURLData.
+	AgendaURLPattern string `yaml:"agenda_url_pattern" mapstructure:"agenda_url_pattern"`
+
+	// Captions chooses the caption track to download and the quality below
+	// which Whisper is used instead. Unset fields keep their defaults.
+	Captions CaptionPolicy `yaml:"captions" mapstructure:"captions"`
+
+	// Validation overrides the default summary validation rules. Unset fields
+	// keep their defaults.
+	Validation *ValidationRules `yaml:"validation" mapstructure:"validation"`
 
 	// LLM optionally overrides the global llm block for this body, so one
 	// body can use a larger-context or cheaper model than the rest.
 	LLM *LLMOverride `yaml:"llm" mapstructure:"llm"`
+
+	// Transcriber optionally overrides the global transcriber block, so a
+	// body can use another language, model, or vocabulary.
+	Transcriber *TranscriberOverride `yaml:"transcriber" mapstructure:"transcriber"`
+
+	// Roster lists the body's officials. Diarized speakers are named from it,
+	// prompt templates receive it, and validation flags officials in a
+	// summary who are not on it. In the config an entry may be just a name.
+	Roster Roster `yaml:"roster" mapstructure:"roster"`
+
+	// Diarize labels who is speaking in each transcript line, using the
+	// diarization tool configured under tools.diarizer.
+	Diarize bool `yaml:"diarize" mapstructure:"diarize"`
+
+	// Vocabulary lists local proper nouns and jargon with the ways captions
+	// mangle them. Transcripts are corrected to the canonical spellings
+	// before analysis, and the terms are added to the Whisper prompt. In the
+	// config an entry may be just a term, which only fixes its case.
+	Vocabulary Vocabulary `yaml:"vocabulary" mapstructure:"vocabulary"`
+
+	// VocabularyFile is a YAML file whose `terms` list is appended to
+	// Vocabulary, so a long list can be kept and shared outside the config.
+	// A relative path is resolved against the config file's directory.
+	VocabularyFile string `yaml:"vocabulary_file" mapstructure:"vocabulary_file"`
+
+	// Boundaries finds the call to order and adjournment in a transcript, so
+	// analysis sees only the meeting. Unset, the defaults apply.
+	Boundaries MeetingBoundaries `yaml:"boundaries" mapstructure:"boundaries"`
+}
+
+// SourceType returns the body's video source type, defaulting to youtube.
+func (b Body) SourceType() string {
+	if b.Source.Type == "" {
+		return SourceYouTube
+	}
+	return b.Source.Type
 }
 
-// DiscoveryURL returns the URL used to discover videos for this body.
-// If VideoSourceURL is set, it takes precedence over PlaylistID.
+// DiscoveryURL returns the URL used to discover videos for this body:
+// source.url if set, otherwise VideoSourceURL, otherwise the YouTube playlist.
 func (b Body) DiscoveryURL() string {
+	if b.Source.URL != "" {
+		return b.Source.URL
+	}
 	if b.VideoSourceURL != "" {
 		return b.VideoSourceURL
 	}
@@ -33,5 +123,90 @@ func (b Body) DiscoveryURL() string {
 
 // VideoURL returns the full YouTube watch URL for a given video ID.
 func (b Body) VideoURL(videoID string) string {
-	return "https://www.youtube.com/watch?v=" + videoID
+	return YouTubeVideoURL(videoID)
+}
+
+// MeetingVideoURL returns the URL of a meeting's recording: the one its
+// source listed, or for YouTube bodies the watch URL built from its ID. It is
+// empty for other sources when the meeting was not discovered in this run,
+// and for local files, which have no page to link to.
+func (b Body) MeetingVideoURL(meeting Meeting) string {
+	if IsLocalFile(meeting.VideoURL) {
+		return ""
+	}
+	if meeting.VideoURL != "" {
+		return meeting.VideoURL
+	}
+	if b.SourceType() == SourceYouTube {
+		return b.VideoURL(meeting.VideoID)
+	}
+	return ""
+}
+
+// TooShort reports whether a video with a known duration falls below the
+// body's minimum meeting length.
+func (b Body) TooShort(duration time.Duration) bool {
+	return b.MinDurationMinutes > 0 && duration > 0 &&
+		duration < time.Duration(b.MinDurationMinutes)*time.Minute
+}
+
+// BackfillDate parses BackfillFrom. It returns the zero time when unset.
+func (b Body) BackfillDate() (time.Time, error) {
+	if b.BackfillFrom == "" {
+		return time.Time{}, nil
+	}
+	date, err := time.Parse(isoDate, b.BackfillFrom)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("backfill_from %q: expected YYYY-MM-DD", b.BackfillFrom)
+	}
+	return date, nil
+}
+
+// DiscoveryWindow returns the range of meeting dates to process. An explicit
+// requested range wins. Otherwise the window opens at BackfillFrom, or failing
+// that at the start of the current year; through January the previous year is
+// included too, so December meetings uploaded late are not missed.
+func (b Body) DiscoveryWindow(requested DateRange, now time.Time) DateRange {
+	if !requested.IsZero() {
+		return requested
+	}
+	if since, err := b.BackfillDate(); err == nil && !since.IsZero() {
+		return DateRange{Since: since}
+	}
+	year := now.AddDate(0, -1, 0).Year()
+	return DateRange{Since: time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)}
+}
+
+// ValidationRules returns the body's effective validation rules.
+func (b Body) ValidationRules() ValidationRules {
+	return b.Validation.Effective()
+}
+
+// CaptionPolicy returns the body's effective caption policy.
+func (b Body) CaptionPolicy() CaptionPolicy {
+	return b.Captions.Effective()
+}
+
+// MeetingBoundaries returns the body's effective meeting boundaries.
+func (b Body) MeetingBoundaries() MeetingBoundaries {
+	return b.Boundaries.Effective()
+}
+
+// RepairTemplateName returns the repair template filename for this body.
+func (b Body) RepairTemplateName() string {
+	if b.RepairTemplate != "" {
+		return b.RepairTemplate
+	}
+	return DefaultRepairTemplate
+}
+
+// ChunkTemplateName returns the chunk template filename for this body.
+func (b Body) ChunkTemplateName() string {
+	if b.ChunkTemplate != "" {
+		return b.ChunkTemplate
+	}
+	if base, ok := strings.CutSuffix(b.PromptTemplate, ".prompt.tmpl"); ok {
+		return base + ".chunk.tmpl"
+	}
+	return strings.TrimSuffix(b.PromptTemplate, filepath.Ext(b.PromptTemplate)) + ".chunk.tmpl"
 }
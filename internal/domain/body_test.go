@@ -2,6 +2,7 @@ package domain_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/AvogadroSG1/civic-summary/internal/domain"
 	"github.com/stretchr/testify/assert"
@@ -31,9 +32,99 @@ func TestBody_DiscoveryURL(t *testing.T) {
 		assert.Equal(t, "https://www.youtube.com/@example/videos", b.DiscoveryURL())
 		assert.Empty(t, b.PlaylistID)
 	})
+
+	t.Run("SourceURLTakesPrecedence", func(t *testing.T) {
+		b := domain.Body{
+			PlaylistID: "PLJXxCe9GA2fEf4TIVzTH2O-kFJlS8VVgQ",
+			Source:     domain.SourceConfig{Type: domain.SourceFeed, URL: "https://example.gov/meetings.rss"},
+		}
+		assert.Equal(t, "https://example.gov/meetings.rss", b.DiscoveryURL())
+	})
+}
+
+func TestBody_SourceType(t *testing.T) {
+	assert.Equal(t, domain.SourceYouTube, domain.Body{}.SourceType())
+	assert.Equal(t, domain.SourceFeed, domain.Body{Source: domain.SourceConfig{Type: domain.SourceFeed}}.SourceType())
 }
 
 func TestBody_VideoURL(t *testing.T) {
 	b := domain.Body{}
 	assert.Equal(t, "https://www.youtube.com/watch?v=abc123", b.VideoURL("abc123"))
 }
+
+func TestBody_MeetingVideoURL(t *testing.T) {
+	youtube := domain.Body{}
+	feed := domain.Body{Source: domain.SourceConfig{Type: domain.SourceFeed}}
+	listed := domain.Meeting{VideoID: "a1b2", VideoURL: "https://example.gov/council.mp4"}
+
+	assert.Equal(t, "https://example.gov/council.mp4", feed.MeetingVideoURL(listed))
+	assert.Equal(t, "https://www.youtube.com/watch?v=abc123", youtube.MeetingVideoURL(domain.Meeting{VideoID: "abc123"}))
+	assert.Empty(t, feed.MeetingVideoURL(domain.Meeting{VideoID: "a1b2"}))
+	assert.Empty(t, youtube.MeetingVideoURL(domain.Meeting{VideoID: "zoom", VideoURL: "/srv/recordings/zoom.mp4"}),
+		"local files have no page to link to")
+}
+
+func TestIsLocalFile(t *testing.T) {
+	assert.True(t, domain.IsLocalFile("/srv/recordings/council.mp4"))
+	assert.True(t, domain.IsLocalFile("recordings/council.mp4"))
+	assert.True(t, domain.IsLocalFile("file:///srv/recordings/council.mp4"))
+	assert.False(t, domain.IsLocalFile("https://vimeo.com/123"))
+	assert.False(t, domain.IsLocalFile(""))
+}
+
+func TestBody_DiscoveryWindow(t *testing.T) {
+	october := time.Date(2026, time.October, 16, 12, 0, 0, 0, time.UTC)
+	january := time.Date(2026, time.January, 2, 12, 0, 0, 0, time.UTC)
+
+	t.Run("DefaultsToCurrentYear", func(t *testing.T) {
+		got := domain.Body{}.DiscoveryWindow(domain.DateRange{}, october)
+		assert.Equal(t, domain.DateRange{Since: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)}, got)
+	})
+
+	t.Run("IncludesPreviousYearInJanuary", func(t *testing.T) {
+		got := domain.Body{}.DiscoveryWindow(domain.DateRange{}, january)
+		assert.Equal(t, domain.DateRange{Since: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)}, got)
+	})
+
+	t.Run("BackfillFrom", func(t *testing.T) {
+		got := domain.Body{BackfillFrom: "2019-07-01"}.DiscoveryWindow(domain.DateRange{}, october)
+		assert.Equal(t, domain.DateRange{Since: time.Date(2019, 7, 1, 0, 0, 0, 0, time.UTC)}, got)
+	})
+
+	t.Run("RequestedRangeWins", func(t *testing.T) {
+		requested := domain.YearRange(2022)
+		got := domain.Body{BackfillFrom: "2019-07-01"}.DiscoveryWindow(requested, october)
+		assert.Equal(t, requested, got)
+	})
+}
+
+func TestBody_BackfillDate(t *testing.T) {
+	d, err := domain.Body{}.BackfillDate()
+	assert.NoError(t, err)
+	assert.True(t, d.IsZero())
+
+	_, err = domain.Body{BackfillFrom: "July 2019"}.BackfillDate()
+	assert.ErrorContains(t, err, "expected YYYY-MM-DD")
+}
+
+func TestBody_RepairTemplateName(t *testing.T) {
+	assert.Equal(t, domain.DefaultRepairTemplate, domain.Body{PromptTemplate: "bocc.prompt.tmpl"}.RepairTemplateName())
+	assert.Equal(t, "fix.tmpl", domain.Body{RepairTemplate: "fix.tmpl"}.RepairTemplateName())
+}
+
+func TestBody_ChunkTemplateName(t *testing.T) {
+	tests := []struct {
+		name string
+		body domain.Body
+		want string
+	}{
+		{"derived from prompt template", domain.Body{PromptTemplate: "bocc.prompt.tmpl"}, "bocc.chunk.tmpl"},
+		{"other extension", domain.Body{PromptTemplate: "council.tmpl"}, "council.chunk.tmpl"},
+		{"explicit", domain.Body{PromptTemplate: "bocc.prompt.tmpl", ChunkTemplate: "notes.tmpl"}, "notes.tmpl"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.body.ChunkTemplateName())
+		})
+	}
+}
@@ -0,0 +1,281 @@
+package domain
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// defaultMaxGapSeconds is how long a break in the captions must last to
+// separate the meeting from pre-roll or trailing chatter.
+const defaultMaxGapSeconds = 300
+
+// edgeRunWords is the most words a stretch of captions cut off from the rest
+// by a long gap may hold and still be trimmed as pre-roll or trailing chatter
+// rather than kept as part of the meeting.
+const edgeRunWords = 100
+
+// noiseCuePattern matches cues with no speech in them: sound descriptions
+// such as [Music] or (applause), and music notes.
+var noiseCuePattern = regexp.MustCompile(`^(?:\[[^\]]*\]|\([^)]*\)|[♪♫\s-])*$`)
+
+// MeetingBoundaries finds where the meeting itself starts and ends within a
+// recording's transcript, so pre-roll loops and dead air after adjournment
+// are left out of the prompt. Patterns are regular expressions matched
+// without regard to case; setting a list replaces the defaults. Unset fields
+// take the defaults from DefaultMeetingBoundaries.
+type MeetingBoundaries struct {
+	// Enabled turns detection on or off. Defaults to on.
+	Enabled *bool `yaml:"enabled" mapstructure:"enabled"`
+	// CallToOrder matches the chair opening the meeting. The first match in
+	// the first half of the recording starts the meeting.
+	CallToOrder []string `yaml:"call_to_order" mapstructure:"call_to_order"`
+	// Reconvene matches the chair reopening the meeting after a recess, such
+	// as "call us back to order". A call to order that also matches is not
+	// taken as the start, so a mis-captioned opening does not leave the
+	// meeting to begin after its first recess.
+	Reconvene []string `yaml:"reconvene" mapstructure:"reconvene"`
+	// Adjournment matches the chair closing the meeting. The last match in
+	// the second half of the recording ends it.
+	Adjournment []string `yaml:"adjournment" mapstructure:"adjournment"`
+	// Filler matches holding text captioned before or after the meeting,
+	// such as "the stream will begin shortly".
+	Filler []string `yaml:"filler" mapstructure:"filler"`
+	// MaxGapSeconds is how long a break in the captions separates a short
+	// stretch of speech at either end, such as a sound check or a
+	// closed-session notice, from the meeting when no phrase marks it. Zero
+	// turns the gap check off.
+	MaxGapSeconds *int `yaml:"max_gap_seconds" mapstructure:"max_gap_seconds"`
+}
+
+// DefaultMeetingBoundaries returns the boundaries used when a body sets none.
+func DefaultMeetingBoundaries() MeetingBoundaries {
+	enabled := true
+	maxGap := defaultMaxGapSeconds
+	return MeetingBoundaries{
+		Enabled: &enabled,
+		CallToOrder: []string{
+			`\b(?:call|calling|called|come|came|bring|brought)\b.{0,40}\bto order\b`,
+			`\bmeeting is (?:now )?in session\b`,
+		},
+		Reconvene: []string{
+			`\bback (?:in)?to (?:order|session)\b`,
+			`\breconven(?:e|ed|ing)\b`,
+		},
+		Adjournment: []string{
+			`\b(?:meeting|session|we) (?:is|are|stands?) adjourned\b`,
+			`\bwe(?:'re| are) adjourned\b`,
+			`\b(?:i|we) (?:will )?adjourn (?:the|this) meeting\b`,
+		},
+		Filler: []string{
+			`\b(?:stream|meeting|broadcast) will (?:begin|start|resume)\b`,
+			`\bplease stand by\b`,
+		},
+		MaxGapSeconds: &maxGap,
+	}
+}
+
+// Effective returns the boundaries with unset fields taken from the defaults.
+func (b MeetingBoundaries) Effective() MeetingBoundaries {
+	def := DefaultMeetingBoundaries()
+	if b.Enabled != nil {
+		def.Enabled = b.Enabled
+	}
+	if len(b.CallToOrder) > 0 {
+		def.CallToOrder = b.CallToOrder
+	}
+	if len(b.Reconvene) > 0 {
+		def.Reconvene = b.Reconvene
+	}
+	if len(b.Adjournment) > 0 {
+		def.Adjournment = b.Adjournment
+	}
+	if len(b.Filler) > 0 {
+		def.Filler = b.Filler
+	}
+	if b.MaxGapSeconds != nil {
+		def.MaxGapSeconds = b.MaxGapSeconds
+	}
+	return def
+}
+
+// Validate checks that every pattern compiles and the gap is not negative.
+func (b MeetingBoundaries) Validate() error {
+	for name, patterns := range map[string][]string{
+		"call_to_order": b.CallToOrder,
+		"reconvene":     b.Reconvene,
+		"adjournment":   b.Adjournment,
+		"filler":        b.Filler,
+	} {
+		for i, p := range patterns {
+			if _, err := compileBoundaryPattern(p); err != nil {
+				return fmt.Errorf("boundaries.%s[%d]: %w", name, i, err)
+			}
+		}
+	}
+	if b.MaxGapSeconds != nil && *b.MaxGapSeconds < 0 {
+		return fmt.Errorf("boundaries.max_gap_seconds must not be negative, got %d", *b.MaxGapSeconds)
+	}
+	return nil
+}
+
+// MeetingWindow is the span of a recording the meeting itself occupies.
+// Start and End are offsets on the recording's timeline. First and Last are
+// the indexes into the transcript's cues of the meeting's first and last
+// cue. StartReason and EndReason say how each end was found: "call to
+// order", "adjournment", "gap", or "speech" for the first or last cue with
+// speech in it.
+type MeetingWindow struct {
+	Start       time.Duration
+	End         time.Duration
+	First       int
+	Last        int
+	StartReason string
+	EndReason   string
+}
+
+// Found reports whether a window was detected.
+func (w MeetingWindow) Found() bool {
+	return w.End > w.Start
+}
+
+// Duration returns the meeting's length.
+func (w MeetingWindow) Duration() time.Duration {
+	return w.End - w.Start
+}
+
+// Detect finds the meeting within a timed transcript. Cues with no speech or
+// only filler are skipped at either end. The call to order and adjournment
+// phrases mark the start and end where they are found; otherwise a short
+// stretch of speech separated from the rest by a long gap is dropped. It
+// reports false for an untimed transcript or one with no speech, and when
+// detection is disabled. The boundaries are used as given; pass
+// Body.MeetingBoundaries for a body's effective ones.
+func (b MeetingBoundaries) Detect(t Transcript) (MeetingWindow, bool) {
+	if b.Enabled != nil && !*b.Enabled {
+		return MeetingWindow{}, false
+	}
+	callToOrder := compileBoundaryPatterns(b.CallToOrder)
+	reconvene := compileBoundaryPatterns(b.Reconvene)
+	adjournment := compileBoundaryPatterns(b.Adjournment)
+	filler := compileBoundaryPatterns(b.Filler)
+
+	var speech []int
+	for i, c := range t.Cues {
+		if !c.HasTiming() || noiseCuePattern.MatchString(c.Text) || matchesAny(filler, cueLine(c)) {
+			continue
+		}
+		speech = append(speech, i)
+	}
+	if len(speech) == 0 {
+		return MeetingWindow{}, false
+	}
+
+	w := MeetingWindow{First: speech[0], Last: speech[len(speech)-1], StartReason: "speech", EndReason: "speech"}
+	if b.MaxGapSeconds != nil && *b.MaxGapSeconds > 0 {
+		w = trimEdgeRuns(t.Cues, speech, time.Duration(*b.MaxGapSeconds)*time.Second, w)
+	}
+
+	midpoint := (t.Cues[speech[0]].Start + t.Cues[speech[len(speech)-1]].End) / 2
+	for _, i := range speech {
+		if t.Cues[i].Start > midpoint {
+			break
+		}
+		if line := cueLine(t.Cues[i]); matchesAny(callToOrder, line) && !matchesAny(reconvene, line) {
+			w.First, w.StartReason = i, "call to order"
+			break
+		}
+	}
+	for j := len(speech) - 1; j >= 0; j-- {
+		i := speech[j]
+		if t.Cues[i].Start < midpoint || i < w.First {
+			break
+		}
+		if matchesAny(adjournment, cueLine(t.Cues[i])) {
+			w.Last, w.EndReason = i, "adjournment"
+			break
+		}
+	}
+
+	w.Start, w.End = t.Cues[w.First].Start, t.Cues[w.Last].End
+	return w, w.Found()
+}
+
+// trimEdgeRuns splits the speech cues into runs at gaps of at least maxGap
+// and drops short runs from either end, leaving at least one run.
+func trimEdgeRuns(cues []Cue, speech []int, maxGap time.Duration, w MeetingWindow) MeetingWindow {
+	var runs [][]int
+	start := 0
+	for k := 1; k <= len(speech); k++ {
+		if k == len(speech) || cues[speech[k]].Start-cues[speech[k-1]].End >= maxGap {
+			runs = append(runs, speech[start:k])
+			start = k
+		}
+	}
+
+	first, last := 0, len(runs)-1
+	for first < last && runWords(cues, runs[first]) < edgeRunWords {
+		first++
+	}
+	for last > first && runWords(cues, runs[last]) < edgeRunWords {
+		last--
+	}
+	if first > 0 {
+		w.First, w.StartReason = runs[first][0], "gap"
+	}
+	if last < len(runs)-1 {
+		run := runs[last]
+		w.Last, w.EndReason = run[len(run)-1], "gap"
+	}
+	return w
+}
+
+// runWords counts the words in a run of cues.
+func runWords(cues []Cue, run []int) int {
+	n := 0
+	for _, i := range run {
+		n += len(strings.Fields(cues[i].Text))
+	}
+	return n
+}
+
+// Within returns the transcript cut down to a meeting window. The cues keep
+// their times on the recording's timeline.
+func (t Transcript) Within(w MeetingWindow) Transcript {
+	if !w.Found() || w.First < 0 || w.Last >= len(t.Cues) || w.First > w.Last {
+		return t
+	}
+	t.Cues = append([]Cue(nil), t.Cues[w.First:w.Last+1]...)
+	return t
+}
+
+// cueLine joins a cue's caption rows, so a phrase broken across rows matches.
+func cueLine(c Cue) string {
+	return strings.Join(strings.Fields(c.Text), " ")
+}
+
+func compileBoundaryPattern(p string) (*regexp.Regexp, error) {
+	return regexp.Compile(`(?i)` + p)
+}
+
+// compileBoundaryPatterns compiles validated patterns, skipping any that do
+// not compile.
+func compileBoundaryPatterns(patterns []string) []*regexp.Regexp {
+	compiled := make([]*regexp.Regexp, 0, len(patterns))
+	for _, p := range patterns {
+		if re, err := compileBoundaryPattern(p); err == nil {
+			compiled = append(compiled, re)
+		}
+	}
+	return compiled
+}
+
+func matchesAny(patterns []*regexp.Regexp, s string) bool {
+	for _, re := range patterns {
+		if re.MatchString(s) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,135 @@
+package domain_test
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/AvogadroSG1/civic-summary/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// timedCues builds a transcript with one cue at each offset.
+func timedCues(lines map[time.Duration]string) domain.Transcript {
+	offsets := make([]time.Duration, 0, len(lines))
+	for at := range lines {
+		offsets = append(offsets, at)
+	}
+	slices.Sort(offsets)
+	var cues []domain.Cue
+	for i, at := range offsets {
+		cues = append(cues, domain.Cue{Index: i + 1, Start: at, End: at + 5*time.Second, Text: lines[at]})
+	}
+	return domain.Transcript{Cues: cues}
+}
+
+func TestMeetingBoundaries_Detect_Phrases(t *testing.T) {
+	transcript := timedCues(map[time.Duration]string{
+		0:                              "[Music]",
+		30 * time.Second:               "The stream will begin shortly.",
+		20 * time.Minute:               "Testing, one two.",
+		21 * time.Minute:               "Good evening. I'd like to call this\nmeeting to order.",
+		30 * time.Minute:               "Item one, the budget.",
+		80 * time.Minute:               "Motion to adjourn? Second. All in favor.",
+		81 * time.Minute:               "The meeting is adjourned.",
+		82 * time.Minute:               "The council will now meet in closed session.",
+		82*time.Minute + 5*time.Second: "♪ ♪",
+	})
+
+	window, ok := domain.DefaultMeetingBoundaries().Detect(transcript)
+	require.True(t, ok)
+
+	assert.Equal(t, 21*time.Minute, window.Start)
+	assert.Equal(t, 81*time.Minute+5*time.Second, window.End)
+	assert.Equal(t, "call to order", window.StartReason)
+	assert.Equal(t, "adjournment", window.EndReason)
+	assert.Equal(t, 60*time.Minute+5*time.Second, window.Duration())
+
+	trimmed := transcript.Within(window)
+	require.Len(t, trimmed.Cues, 4)
+	assert.Equal(t, 21*time.Minute, trimmed.Cues[0].Start, "cues keep the recording's timeline")
+	assert.Equal(t, "The meeting is adjourned.", trimmed.Cues[3].Text)
+	assert.Len(t, transcript.Cues, 9, "the original is not modified")
+}
+
+func TestMeetingBoundaries_Detect_IgnoresReconvening(t *testing.T) {
+	transcript := timedCues(map[time.Duration]string{
+		0:                "Good evening. I'd like to call this meeting to odor.",
+		1 * time.Minute:  "Item one, the budget.",
+		2 * time.Minute:  "We'll take a short recess.",
+		3 * time.Minute:  "I'll call us back to order.",
+		6 * time.Minute:  "Item two, the parks plan.",
+		10 * time.Minute: "The meeting is adjourned.",
+	})
+
+	window, ok := domain.DefaultMeetingBoundaries().Detect(transcript)
+	require.True(t, ok)
+
+	assert.Equal(t, time.Duration(0), window.Start, "the meeting before the recess is kept")
+	assert.Equal(t, "speech", window.StartReason)
+}
+
+func TestMeetingBoundaries_Detect_Gaps(t *testing.T) {
+	meeting := strings.Repeat("word ", 60)
+	transcript := timedCues(map[time.Duration]string{
+		0:                "Can you hear me now?",
+		15 * time.Minute: meeting,
+		16 * time.Minute: meeting,
+		50 * time.Minute: meeting,
+		51 * time.Minute: meeting,
+		60 * time.Minute: "Reminder: the next work session is Tuesday.",
+	})
+
+	window, ok := domain.DefaultMeetingBoundaries().Detect(transcript)
+	require.True(t, ok)
+
+	assert.Equal(t, 15*time.Minute, window.Start)
+	assert.Equal(t, 51*time.Minute+5*time.Second, window.End)
+	assert.Equal(t, "gap", window.StartReason)
+	assert.Equal(t, "gap", window.EndReason)
+
+	noGaps := domain.MeetingBoundaries{MaxGapSeconds: new(int)}.Effective()
+	window, ok = noGaps.Detect(transcript)
+	require.True(t, ok)
+	assert.Equal(t, time.Duration(0), window.Start)
+	assert.Equal(t, "speech", window.StartReason)
+}
+
+func TestMeetingBoundaries_Detect_NotFound(t *testing.T) {
+	boundaries := domain.DefaultMeetingBoundaries()
+
+	_, ok := boundaries.Detect(domain.Transcript{Cues: domain.ParsePlainText("I call this meeting to order.")})
+	assert.False(t, ok, "untimed")
+
+	_, ok = boundaries.Detect(timedCues(map[time.Duration]string{0: "[Music]", time.Minute: "Please stand by."}))
+	assert.False(t, ok, "no speech")
+
+	disabled := false
+	_, ok = domain.MeetingBoundaries{Enabled: &disabled}.Effective().Detect(timedCues(map[time.Duration]string{0: "Call to order."}))
+	assert.False(t, ok, "disabled")
+}
+
+func TestMeetingBoundaries_Effective(t *testing.T) {
+	custom := domain.MeetingBoundaries{CallToOrder: []string{`^the chair recognizes`}}.Effective()
+
+	assert.Equal(t, []string{`^the chair recognizes`}, custom.CallToOrder, "a list replaces the defaults")
+	assert.Equal(t, domain.DefaultMeetingBoundaries().Adjournment, custom.Adjournment)
+	assert.True(t, *custom.Enabled)
+	assert.Equal(t, 300, *custom.MaxGapSeconds)
+}
+
+func TestMeetingBoundaries_Validate(t *testing.T) {
+	require.NoError(t, domain.DefaultMeetingBoundaries().Validate())
+	require.NoError(t, domain.MeetingBoundaries{}.Validate())
+
+	err := domain.MeetingBoundaries{Adjournment: []string{`adjourn(`}}.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "boundaries.adjournment[0]")
+
+	negative := -1
+	err = domain.MeetingBoundaries{MaxGapSeconds: &negative}.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "boundaries.max_gap_seconds must not be negative")
+}
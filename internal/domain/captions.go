@@ -0,0 +1,228 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Caption track kinds: subtitles uploaded by the channel, typically by the
+// clerk, and captions the platform generated by speech recognition.
+const (
+	CaptionManual = "manual"
+	CaptionAuto   = "auto"
+)
+
+// CaptionKinds returns the valid caption track kinds.
+func CaptionKinds() []string {
+	return []string{CaptionManual, CaptionAuto}
+}
+
+// CaptionFormats returns the caption formats that can be requested; both
+// parse into timed cues.
+func CaptionFormats() []string {
+	return []string{string(TranscriptFormatSRT), string(TranscriptFormatVTT)}
+}
+
+// defaultMinCaptionQuality is the quality score below which captions are
+// replaced with a Whisper transcript when a body sets no threshold.
+const defaultMinCaptionQuality = 40
+
+// CaptionTrack is one caption track a recording offers.
+type CaptionTrack struct {
+	Kind     string
+	Language string
+	// Formats are the formats the track is offered in, or empty when the
+	// listing does not say.
+	Formats []string
+}
+
+// CaptionPolicy chooses which of a recording's caption tracks to use, and
+// when captions are too poor to use at all. Unset fields take the defaults
+// from DefaultCaptionPolicy.
+type CaptionPolicy struct {
+	// Kinds are the track kinds to use, most preferred first.
+	Kinds []string `yaml:"kinds" mapstructure:"kinds"`
+	// Languages are language codes, most preferred first. "es" also matches
+	// regional tracks such as "es-419".
+	Languages []string `yaml:"languages" mapstructure:"languages"`
+	// Formats are the formats to download, most preferred first.
+	Formats []string `yaml:"formats" mapstructure:"formats"`
+	// MinQuality is the CaptionQuality score, 0 to 100, below which Whisper
+	// is used instead when it is configured. Zero accepts any captions.
+	MinQuality *int `yaml:"min_quality" mapstructure:"min_quality"`
+}
+
+// DefaultCaptionPolicy returns the policy used when a body sets none:
+// uploaded English subtitles, then automatic ones, preferring SRT.
+func DefaultCaptionPolicy() CaptionPolicy {
+	minQuality := defaultMinCaptionQuality
+	return CaptionPolicy{
+		Kinds:      []string{CaptionManual, CaptionAuto},
+		Languages:  []string{"en"},
+		Formats:    []string{string(TranscriptFormatSRT), string(TranscriptFormatVTT)},
+		MinQuality: &minQuality,
+	}
+}
+
+// Effective returns the policy with unset fields taken from the defaults.
+func (p CaptionPolicy) Effective() CaptionPolicy {
+	def := DefaultCaptionPolicy()
+	if len(p.Kinds) > 0 {
+		def.Kinds = p.Kinds
+	}
+	if len(p.Languages) > 0 {
+		def.Languages = p.Languages
+	}
+	if len(p.Formats) > 0 {
+		def.Formats = p.Formats
+	}
+	if p.MinQuality != nil {
+		def.MinQuality = p.MinQuality
+	}
+	return def
+}
+
+// Threshold returns the minimum quality score, or zero when unset.
+func (p CaptionPolicy) Threshold() int {
+	if p.MinQuality == nil {
+		return 0
+	}
+	return *p.MinQuality
+}
+
+// Validate checks the policy's kinds, languages, formats, and threshold.
+func (p CaptionPolicy) Validate() error {
+	for i, kind := range p.Kinds {
+		if !slices.Contains(CaptionKinds(), kind) {
+			return fmt.Errorf("captions.kinds[%d]: unknown kind %q; supported: %v", i, kind, CaptionKinds())
+		}
+	}
+	for i, lang := range p.Languages {
+		if strings.TrimSpace(lang) == "" {
+			return fmt.Errorf("captions.languages[%d] is empty", i)
+		}
+	}
+	for i, format := range p.Formats {
+		if !slices.Contains(CaptionFormats(), format) {
+			return fmt.Errorf("captions.formats[%d]: unknown format %q; supported: %v", i, format, CaptionFormats())
+		}
+	}
+	if q := p.Threshold(); q < 0 || q > 100 {
+		return fmt.Errorf("captions.min_quality must be between 0 and 100, got %d", q)
+	}
+	return nil
+}
+
+// Select returns the track to download and the format to request: the first
+// track, by kind and then by language preference, offered in one of the
+// policy's formats. A track whose formats are unknown is assumed to offer the
+// first. An exact language match is preferred to a regional one.
+func (p CaptionPolicy) Select(tracks []CaptionTrack) (CaptionTrack, string, bool) {
+	for _, kind := range p.Kinds {
+		for _, lang := range p.Languages {
+			for _, exact := range []bool{true, false} {
+				for _, track := range tracks {
+					if track.Kind != kind || !languageMatches(track.Language, lang, exact) {
+						continue
+					}
+					if format, ok := p.format(track); ok {
+						return track, format, true
+					}
+				}
+			}
+		}
+	}
+	return CaptionTrack{}, "", false
+}
+
+// format returns the first of the policy's formats the track offers.
+func (p CaptionPolicy) format(track CaptionTrack) (string, bool) {
+	if len(track.Formats) == 0 && len(p.Formats) > 0 {
+		return p.Formats[0], true
+	}
+	for _, format := range p.Formats {
+		if slices.Contains(track.Formats, format) {
+			return format, true
+		}
+	}
+	return "", false
+}
+
+// languageMatches compares a track's language code with a wanted one, either
+// exactly or, failing that, as a regional variant ("en" matches "en-US").
+func languageMatches(track, want string, exact bool) bool {
+	if exact {
+		return strings.EqualFold(track, want)
+	}
+	return len(track) > len(want) && strings.EqualFold(track[:len(want)+1], want+"-")
+}
+
+// CaptionQuality measures how usable a caption transcript is for a recording
+// of known length.
+type CaptionQuality struct {
+	// WordsPerMinute is spoken words per minute of the recording. Captions
+	// that stop partway through, or miss speakers, fall well short of the
+	// 100 or more of continuous speech.
+	WordsPerMinute float64
+	// CuesPerMinute is caption cues per minute of the recording. A few long
+	// cues spanning minutes are usually a broken or placeholder track.
+	CuesPerMinute float64
+	// RepetitionRatio is the fraction of cues repeating an earlier cue's text,
+	// as in "[Music]" filler or speech recognition caught in a loop.
+	RepetitionRatio float64
+	// Score combines the three, from 0 to 100.
+	Score int
+}
+
+// Targets at which each CaptionQuality measure earns full marks.
+const (
+	targetWordsPerMinute = 80
+	targetCuesPerMinute  = 4
+	// maxRepetitionRatio is the repetition ratio that scores zero.
+	maxRepetitionRatio = 0.5
+)
+
+// String renders the score with its measures.
+func (q CaptionQuality) String() string {
+	return fmt.Sprintf("%d (%.0f words/min, %.1f cues/min, %.0f%% repeated)",
+		q.Score, q.WordsPerMinute, q.CuesPerMinute, q.RepetitionRatio*100)
+}
+
+// Quality scores the transcript against the recording's duration. Each
+// measure is scaled to 0-1 against its target and the score is their product,
+// so captions poor in any one respect score low. It reports false when the
+// duration is unknown.
+func (t Transcript) Quality(duration time.Duration) (CaptionQuality, bool) {
+	minutes := duration.Minutes()
+	if minutes <= 0 {
+		return CaptionQuality{}, false
+	}
+
+	cues := t.MergedCues()
+	seen := make(map[string]bool, len(cues))
+	repeated := 0
+	for _, c := range cues {
+		text := strings.ToLower(strings.Join(strings.Fields(c.Text), " "))
+		if seen[text] {
+			repeated++
+		}
+		seen[text] = true
+	}
+
+	q := CaptionQuality{
+		WordsPerMinute: float64(t.WordCount()) / minutes,
+		CuesPerMinute:  float64(len(cues)) / minutes,
+	}
+	if len(cues) > 0 {
+		q.RepetitionRatio = float64(repeated) / float64(len(cues))
+	}
+
+	score := math.Min(1, q.WordsPerMinute/targetWordsPerMinute) *
+		math.Min(1, q.CuesPerMinute/targetCuesPerMinute) *
+		math.Max(0, 1-q.RepetitionRatio/maxRepetitionRatio)
+	q.Score = int(math.Round(score * 100))
+	return q, true
+}
@@ -0,0 +1,164 @@
+package domain_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/AvogadroSG1/civic-summary/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCaptionPolicy_Effective(t *testing.T) {
+	def := domain.CaptionPolicy{}.Effective()
+	assert.Equal(t, []string{domain.CaptionManual, domain.CaptionAuto}, def.Kinds)
+	assert.Equal(t, []string{"en"}, def.Languages)
+	assert.Equal(t, []string{"srt", "vtt"}, def.Formats)
+	assert.Equal(t, 40, def.Threshold())
+
+	zero := 0
+	custom := domain.CaptionPolicy{Languages: []string{"es"}, MinQuality: &zero}.Effective()
+	assert.Equal(t, []string{"es"}, custom.Languages)
+	assert.Equal(t, []string{domain.CaptionManual, domain.CaptionAuto}, custom.Kinds, "unset fields keep the defaults")
+	assert.Equal(t, 0, custom.Threshold(), "an explicit zero is kept")
+}
+
+func TestCaptionPolicy_Validate(t *testing.T) {
+	tooHigh := 101
+	tests := []struct {
+		name    string
+		policy  domain.CaptionPolicy
+		wantErr string
+	}{
+		{"empty", domain.CaptionPolicy{}, ""},
+		{"default", domain.DefaultCaptionPolicy(), ""},
+		{"unknown kind", domain.CaptionPolicy{Kinds: []string{"auto", "burned-in"}}, `captions.kinds[1]: unknown kind "burned-in"`},
+		{"empty language", domain.CaptionPolicy{Languages: []string{" "}}, "captions.languages[0] is empty"},
+		{"unknown format", domain.CaptionPolicy{Formats: []string{"ttml"}}, `captions.formats[0]: unknown format "ttml"`},
+		{"quality out of range", domain.CaptionPolicy{MinQuality: &tooHigh}, "between 0 and 100"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.policy.Validate()
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestCaptionPolicy_Select(t *testing.T) {
+	tracks := []domain.CaptionTrack{
+		{Kind: domain.CaptionAuto, Language: "en", Formats: []string{"vtt", "ttml"}},
+		{Kind: domain.CaptionAuto, Language: "es", Formats: []string{"vtt"}},
+		{Kind: domain.CaptionManual, Language: "en-US", Formats: []string{"vtt", "srt"}},
+		{Kind: domain.CaptionManual, Language: "en", Formats: []string{"ttml"}},
+	}
+
+	tests := []struct {
+		name       string
+		policy     domain.CaptionPolicy
+		wantKind   string
+		wantLang   string
+		wantFormat string
+		wantOK     bool
+	}{
+		{"default prefers uploaded subtitles", domain.CaptionPolicy{}, domain.CaptionManual, "en-US", "srt", true},
+		{"format order", domain.CaptionPolicy{Formats: []string{"vtt", "srt"}}, domain.CaptionManual, "en-US", "vtt", true},
+		{"automatic first", domain.CaptionPolicy{Kinds: []string{domain.CaptionAuto, domain.CaptionManual}}, domain.CaptionAuto, "en", "vtt", true},
+		{"language order", domain.CaptionPolicy{Languages: []string{"es", "en"}}, domain.CaptionManual, "en-US", "srt", true},
+		{"automatic spanish", domain.CaptionPolicy{Kinds: []string{domain.CaptionAuto}, Languages: []string{"es"}}, domain.CaptionAuto, "es", "vtt", true},
+		{"no match", domain.CaptionPolicy{Languages: []string{"fr"}}, "", "", "", false},
+		{"no acceptable format", domain.CaptionPolicy{Kinds: []string{domain.CaptionAuto}, Formats: []string{"srt"}}, "", "", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			track, format, ok := tt.policy.Effective().Select(tracks)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.wantKind, track.Kind)
+			assert.Equal(t, tt.wantLang, track.Language)
+			assert.Equal(t, tt.wantFormat, format)
+		})
+	}
+
+	t.Run("exact language before regional", func(t *testing.T) {
+		track, _, ok := domain.DefaultCaptionPolicy().Select([]domain.CaptionTrack{
+			{Kind: domain.CaptionAuto, Language: "en-GB"},
+			{Kind: domain.CaptionAuto, Language: "en"},
+			{Kind: domain.CaptionAuto, Language: "eng"},
+		})
+		require.True(t, ok)
+		assert.Equal(t, "en", track.Language)
+	})
+
+	t.Run("unlisted formats take the first", func(t *testing.T) {
+		_, format, ok := domain.DefaultCaptionPolicy().Select([]domain.CaptionTrack{{Kind: domain.CaptionAuto, Language: "en"}})
+		require.True(t, ok)
+		assert.Equal(t, "srt", format)
+	})
+}
+
+// captionTranscript builds an SRT transcript with one cue every interval,
+// each cue's text produced by text(i).
+func captionTranscript(t *testing.T, cues int, interval time.Duration, text func(i int) string) domain.Transcript {
+	t.Helper()
+	var b strings.Builder
+	for i := range cues {
+		start := time.Duration(i) * interval
+		fmt.Fprintf(&b, "%d\n%s --> %s\n%s\n\n", i+1, srtTime(start), srtTime(start+interval), text(i))
+	}
+	transcript, err := domain.ParseTranscript(b.String(), "captions.srt", domain.TranscriptSourceCaptions)
+	require.NoError(t, err)
+	return transcript
+}
+
+func srtTime(d time.Duration) string {
+	return fmt.Sprintf("%02d:%02d:%02d,%03d", int(d.Hours()), int(d.Minutes())%60, int(d.Seconds())%60, d.Milliseconds()%1000)
+}
+
+func TestTranscript_Quality(t *testing.T) {
+	sentence := func(i int) string {
+		return fmt.Sprintf("item %d was moved seconded and carried by the council members present", i)
+	}
+
+	t.Run("continuous speech", func(t *testing.T) {
+		q, ok := captionTranscript(t, 600, 6*time.Second, sentence).Quality(time.Hour)
+		require.True(t, ok)
+		assert.Equal(t, 100, q.Score)
+		assert.InDelta(t, 10, q.CuesPerMinute, 0.01)
+		assert.Zero(t, q.RepetitionRatio)
+	})
+
+	t.Run("stops partway through", func(t *testing.T) {
+		q, ok := captionTranscript(t, 60, 6*time.Second, sentence).Quality(time.Hour)
+		require.True(t, ok)
+		assert.Less(t, q.Score, 40)
+	})
+
+	t.Run("recognition loop", func(t *testing.T) {
+		q, ok := captionTranscript(t, 600, 6*time.Second, func(i int) string {
+			if i%3 == 0 {
+				return sentence(i)
+			}
+			return "[Music]"
+		}).Quality(time.Hour)
+		require.True(t, ok)
+		assert.InDelta(t, 0.665, q.RepetitionRatio, 0.01, "single-row repeats are not merged away")
+		assert.Less(t, q.Score, 40)
+	})
+
+	t.Run("unknown duration", func(t *testing.T) {
+		_, ok := captionTranscript(t, 10, 6*time.Second, sentence).Quality(0)
+		assert.False(t, ok)
+	})
+
+	t.Run("string", func(t *testing.T) {
+		q := domain.CaptionQuality{WordsPerMinute: 92.4, CuesPerMinute: 3.25, RepetitionRatio: 0.1, Score: 65}
+		assert.Equal(t, "65 (92 words/min, 3.2 cues/min, 10% repeated)", q.String())
+	})
+}
@@ -0,0 +1,85 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// isoDate is the layout used for dates on the command line and in config.
+const isoDate = "2006-01-02"
+
+// DateRange is an inclusive range of meeting dates used to select which
+// discovered meetings are processed. A zero Since or Until leaves that end
+// open; the zero DateRange matches every date.
+type DateRange struct {
+	Since time.Time
+	Until time.Time
+}
+
+// YearRange returns the range covering every day of year.
+func YearRange(year int) DateRange {
+	return DateRange{
+		Since: time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC),
+		Until: time.Date(year, time.December, 31, 0, 0, 0, 0, time.UTC),
+	}
+}
+
+// ParseDateRange builds a range from the --since, --until, and --year flags.
+// Dates are YYYY-MM-DD. A non-zero year cannot be combined with since or until.
+func ParseDateRange(since, until string, year int) (DateRange, error) {
+	if year != 0 {
+		if since != "" || until != "" {
+			return DateRange{}, fmt.Errorf("--year cannot be combined with --since or --until")
+		}
+		return YearRange(year), nil
+	}
+
+	var r DateRange
+	var err error
+	if since != "" {
+		if r.Since, err = time.Parse(isoDate, since); err != nil {
+			return DateRange{}, fmt.Errorf("parsing --since %q: expected YYYY-MM-DD", since)
+		}
+	}
+	if until != "" {
+		if r.Until, err = time.Parse(isoDate, until); err != nil {
+			return DateRange{}, fmt.Errorf("parsing --until %q: expected YYYY-MM-DD", until)
+		}
+	}
+	if !r.Since.IsZero() && !r.Until.IsZero() && r.Until.Before(r.Since) {
+		return DateRange{}, fmt.Errorf("--until %s is before --since %s", until, since)
+	}
+	return r, nil
+}
+
+// IsZero reports whether the range is unbounded at both ends.
+func (r DateRange) IsZero() bool {
+	return r.Since.IsZero() && r.Until.IsZero()
+}
+
+// Contains reports whether date falls within the range, inclusive.
+func (r DateRange) Contains(date time.Time) bool {
+	if !r.Since.IsZero() && date.Before(r.Since) {
+		return false
+	}
+	if !r.Until.IsZero() && date.After(r.Until) {
+		return false
+	}
+	return true
+}
+
+// String renders the range for logs, e.g. "2025-01-01..2025-12-31" or
+// "2024-06-01..". An unbounded range renders as "all".
+func (r DateRange) String() string {
+	if r.IsZero() {
+		return "all"
+	}
+	var since, until string
+	if !r.Since.IsZero() {
+		since = r.Since.Format(isoDate)
+	}
+	if !r.Until.IsZero() {
+		until = r.Until.Format(isoDate)
+	}
+	return since + ".." + until
+}
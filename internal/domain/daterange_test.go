@@ -0,0 +1,69 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/AvogadroSG1/civic-summary/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func date(s string) time.Time {
+	t, err := time.Parse("2006-01-02", s)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func TestParseDateRange(t *testing.T) {
+	tests := []struct {
+		name    string
+		since   string
+		until   string
+		year    int
+		want    domain.DateRange
+		wantErr string
+	}{
+		{name: "none", want: domain.DateRange{}},
+		{name: "since only", since: "2024-06-01", want: domain.DateRange{Since: date("2024-06-01")}},
+		{name: "until only", until: "2024-06-30", want: domain.DateRange{Until: date("2024-06-30")}},
+		{name: "both", since: "2024-06-01", until: "2024-06-30", want: domain.DateRange{Since: date("2024-06-01"), Until: date("2024-06-30")}},
+		{name: "year", year: 2023, want: domain.DateRange{Since: date("2023-01-01"), Until: date("2023-12-31")}},
+		{name: "year with since", year: 2023, since: "2023-02-01", wantErr: "cannot be combined"},
+		{name: "bad since", since: "06/01/2024", wantErr: "expected YYYY-MM-DD"},
+		{name: "bad until", until: "June 2024", wantErr: "expected YYYY-MM-DD"},
+		{name: "inverted", since: "2024-06-30", until: "2024-06-01", wantErr: "is before"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := domain.ParseDateRange(tt.since, tt.until, tt.year)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestDateRange_Contains(t *testing.T) {
+	r := domain.DateRange{Since: date("2024-06-01"), Until: date("2024-06-30")}
+
+	assert.True(t, r.Contains(date("2024-06-01")), "since is inclusive")
+	assert.True(t, r.Contains(date("2024-06-30")), "until is inclusive")
+	assert.False(t, r.Contains(date("2024-05-31")))
+	assert.False(t, r.Contains(date("2024-07-01")))
+	assert.True(t, domain.DateRange{}.Contains(date("1999-01-01")), "zero range matches everything")
+	assert.True(t, domain.DateRange{Since: date("2024-06-01")}.Contains(date("2030-01-01")))
+}
+
+func TestDateRange_String(t *testing.T) {
+	assert.Equal(t, "all", domain.DateRange{}.String())
+	assert.Equal(t, "2023-01-01..2023-12-31", domain.YearRange(2023).String())
+	assert.Equal(t, "2024-06-01..", domain.DateRange{Since: date("2024-06-01")}.String())
+	assert.Equal(t, "..2024-06-30", domain.DateRange{Until: date("2024-06-30")}.String())
+}
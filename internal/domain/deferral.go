@@ -0,0 +1,31 @@
+package domain
+
+import "time"
+
+// DeferredMeeting is a meeting whose transcription is waiting for the source
+// to publish captions, typically a livestream that ended recently. Unlike a
+// quarantined meeting it has not failed: discovery keeps finding it, and the
+// pipeline tries again once RecheckAt has passed.
+type DeferredMeeting struct {
+	VideoID     string `json:"video_id"`
+	Title       string `json:"title,omitempty"`
+	MeetingDate string `json:"meeting_date"`
+	Reason      string `json:"reason"`
+	// DeferredAt is when the meeting was first deferred. The grace period
+	// before Whisper is used instead is counted from it only when the source
+	// does not say when the recording was released.
+	DeferredAt time.Time `json:"deferred_at"`
+	RecheckAt  time.Time `json:"recheck_at"`
+	// Checks is how many times captions have been looked for.
+	Checks int `json:"checks"`
+}
+
+// Due reports whether the meeting should be tried again at now.
+func (d DeferredMeeting) Due(now time.Time) bool {
+	return !now.Before(d.RecheckAt)
+}
+
+// Deferrals is a body's deferred meetings as stored on disk.
+type Deferrals struct {
+	Entries []DeferredMeeting `json:"entries"`
+}
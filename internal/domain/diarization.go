@@ -0,0 +1,107 @@
+package domain
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SpeakerTurn is a span of a recording attributed to one speaker by a
+// diarization tool. Speaker is the tool's label, such as "SPEAKER_02".
+type SpeakerTurn struct {
+	Speaker string
+	Start   time.Duration
+	End     time.Duration
+}
+
+// ParseRTTM parses diarization output in RTTM, the format pyannote and most
+// other diarization tools write. Each SPEAKER line reads
+//
+//	SPEAKER <file> <channel> <onset> <duration> <NA> <NA> <speaker> <NA> <NA>
+//
+// with times in seconds. Other line types are ignored. Turns are returned in
+// order of onset.
+func ParseRTTM(content string) ([]SpeakerTurn, error) {
+	var turns []SpeakerTurn
+	for i, line := range strings.Split(content, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 || fields[0] != "SPEAKER" {
+			continue
+		}
+		if len(fields) < 8 {
+			return nil, fmt.Errorf("rttm line %d: expected at least 8 fields, got %d", i+1, len(fields))
+		}
+		onset, err := strconv.ParseFloat(fields[3], 64)
+		if err != nil {
+			return nil, fmt.Errorf("rttm line %d: onset %q: %w", i+1, fields[3], err)
+		}
+		duration, err := strconv.ParseFloat(fields[4], 64)
+		if err != nil {
+			return nil, fmt.Errorf("rttm line %d: duration %q: %w", i+1, fields[4], err)
+		}
+		turns = append(turns, SpeakerTurn{
+			Speaker: fields[7],
+			Start:   secondsToDuration(onset),
+			End:     secondsToDuration(onset + duration),
+		})
+	}
+	slices.SortStableFunc(turns, func(a, b SpeakerTurn) int { return cmp.Compare(a.Start, b.Start) })
+	return turns, nil
+}
+
+// WithSpeakers returns a copy of the transcript with each timed cue labeled
+// with the speaker whose turns overlap it most. Cues no turn overlaps, and
+// untimed cues, keep their current label.
+func (t Transcript) WithSpeakers(turns []SpeakerTurn) Transcript {
+	cues := slices.Clone(t.Cues)
+	for i, c := range cues {
+		if !c.HasTiming() {
+			continue
+		}
+		overlap := make(map[string]time.Duration)
+		best := ""
+		for _, turn := range turns {
+			if turn.Start >= c.End {
+				break
+			}
+			if d := min(c.End, turn.End) - max(c.Start, turn.Start); d > 0 {
+				overlap[turn.Speaker] += d
+				if best == "" || overlap[turn.Speaker] > overlap[best] {
+					best = turn.Speaker
+				}
+			}
+		}
+		if best != "" {
+			cues[i].Speaker = best
+		}
+	}
+	t.Cues = cues
+	return t
+}
+
+// RelabelSpeakers returns a copy of the transcript with speaker labels
+// replaced through names. Labels not in names are kept.
+func (t Transcript) RelabelSpeakers(names map[string]string) Transcript {
+	cues := slices.Clone(t.Cues)
+	for i, c := range cues {
+		if name, ok := names[c.Speaker]; ok {
+			cues[i].Speaker = name
+		}
+	}
+	t.Cues = cues
+	return t
+}
+
+// Speakers returns the distinct speaker labels in order of first appearance.
+func (t Transcript) Speakers() []string {
+	var out []string
+	for _, c := range t.Cues {
+		if c.Speaker != "" && !slices.Contains(out, c.Speaker) {
+			out = append(out, c.Speaker)
+		}
+	}
+	return out
+}
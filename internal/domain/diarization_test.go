@@ -0,0 +1,60 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/AvogadroSG1/civic-summary/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRTTM(t *testing.T) {
+	content := `SPEAKER meeting 1 12.50 3.25 <NA> <NA> SPEAKER_01 <NA> <NA>
+SPEAKER meeting 1 0.00 12.50 <NA> <NA> SPEAKER_00 <NA> <NA>
+LEXEME meeting 1 0.10 0.20 hello lex SPEAKER_00 <NA> <NA>
+`
+	turns, err := domain.ParseRTTM(content)
+	require.NoError(t, err)
+
+	assert.Equal(t, []domain.SpeakerTurn{
+		{Speaker: "SPEAKER_00", Start: 0, End: 12500 * time.Millisecond},
+		{Speaker: "SPEAKER_01", Start: 12500 * time.Millisecond, End: 15750 * time.Millisecond},
+	}, turns)
+}
+
+func TestParseRTTM_Malformed(t *testing.T) {
+	_, err := domain.ParseRTTM("SPEAKER meeting 1 abc 1.0 <NA> <NA> SPEAKER_00 <NA> <NA>")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "rttm line 1: onset")
+
+	_, err = domain.ParseRTTM("SPEAKER meeting 1 0.0")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "expected at least 8 fields")
+}
+
+func TestTranscript_WithSpeakers(t *testing.T) {
+	transcript := domain.Transcript{Cues: []domain.Cue{
+		{Index: 1, Start: 0, End: 4 * time.Second, Text: "The meeting will come to order."},
+		{Index: 2, Start: 4 * time.Second, End: 10 * time.Second, Text: "Thank you, Madam President."},
+		{Index: 3, Start: 20 * time.Second, End: 22 * time.Second, Text: "Silence."},
+		{Index: 4, Text: "untimed"},
+	}}
+	turns := []domain.SpeakerTurn{
+		{Speaker: "A", Start: 0, End: 5 * time.Second},
+		{Speaker: "B", Start: 5 * time.Second, End: 12 * time.Second},
+	}
+
+	labeled := transcript.WithSpeakers(turns)
+
+	assert.Equal(t, "A", labeled.Cues[0].Speaker)
+	assert.Equal(t, "B", labeled.Cues[1].Speaker, "the speaker overlapping the cue most")
+	assert.Empty(t, labeled.Cues[2].Speaker, "no turn overlaps")
+	assert.Empty(t, labeled.Cues[3].Speaker, "untimed")
+	assert.Empty(t, transcript.Cues[0].Speaker, "the original is not modified")
+	assert.Equal(t, []string{"A", "B"}, labeled.Speakers())
+
+	named := labeled.RelabelSpeakers(map[string]string{"A": "Council President Kristin Aleshire"})
+	assert.Equal(t, "[00:00:00] Council President Kristin Aleshire: The meeting will come to order.", named.Cues[0].Compact())
+	assert.Equal(t, "[00:00:04] B: Thank you, Madam President.", named.Cues[1].Compact())
+}
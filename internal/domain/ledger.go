@@ -0,0 +1,79 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// LedgerStatus records what became of a meeting the pipeline has seen.
+type LedgerStatus string
+
+const (
+	// LedgerDone means a summary was written to OutputPath.
+	LedgerDone LedgerStatus = "done"
+	// LedgerSkipped means the meeting failed and was removed from quarantine
+	// without a summary.
+	LedgerSkipped LedgerStatus = "skipped"
+	// LedgerIgnored means an operator asked for the meeting never to be
+	// processed, such as a test stream or a duplicate upload.
+	LedgerIgnored LedgerStatus = "ignored"
+	// LedgerQuarantined means every attempt failed and the meeting is waiting
+	// in quarantine for a retry.
+	LedgerQuarantined LedgerStatus = "quarantined"
+)
+
+// LedgerStatuses returns the valid ledger statuses, for validation messages
+// and help text.
+func LedgerStatuses() []LedgerStatus {
+	return []LedgerStatus{LedgerDone, LedgerSkipped, LedgerIgnored, LedgerQuarantined}
+}
+
+// ParseLedgerStatus validates a status name.
+func ParseLedgerStatus(s string) (LedgerStatus, error) {
+	for _, status := range LedgerStatuses() {
+		if string(status) == s {
+			return status, nil
+		}
+	}
+	return "", fmt.Errorf("unknown ledger status %q (want one of %v)", s, LedgerStatuses())
+}
+
+// LedgerEntry is one meeting in a body's processed-meetings ledger. Discovery
+// skips any video with an entry, whatever its status, so a summary that is
+// renamed, moved, or deleted does not bring its meeting back.
+type LedgerEntry struct {
+	VideoID  string `json:"video_id"`
+	VideoURL string `json:"video_url,omitempty"`
+	// MeetingDate is YYYY-MM-DD, empty for a video ignored before discovery
+	// ever parsed it.
+	MeetingDate string       `json:"meeting_date,omitempty"`
+	Sequence    int          `json:"sequence"`
+	OutputPath  string       `json:"output_path,omitempty"`
+	Status      LedgerStatus `json:"status"`
+	CreatedAt   time.Time    `json:"created_at"`
+	UpdatedAt   time.Time    `json:"updated_at"`
+}
+
+// NewLedgerEntry describes a meeting with the given status. The timestamps
+// are left for the ledger to set.
+func NewLedgerEntry(meeting Meeting, status LedgerStatus, outputPath string) LedgerEntry {
+	entry := LedgerEntry{
+		VideoID:    meeting.VideoID,
+		VideoURL:   meeting.VideoURL,
+		Sequence:   meeting.Sequence,
+		OutputPath: outputPath,
+		Status:     status,
+	}
+	if !meeting.MeetingDate.IsZero() {
+		entry.MeetingDate = meeting.ISODate()
+	}
+	return entry
+}
+
+// Ledger is a body's processed-meetings ledger as stored on disk.
+type Ledger struct {
+	// ImportedAt is when existing summaries were last imported; nil until
+	// the first import, which discovery runs automatically.
+	ImportedAt *time.Time    `json:"imported_at,omitempty"`
+	Entries    []LedgerEntry `json:"entries"`
+}
@@ -0,0 +1,41 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/AvogadroSG1/civic-summary/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLedgerStatus(t *testing.T) {
+	status, err := domain.ParseLedgerStatus("ignored")
+	require.NoError(t, err)
+	assert.Equal(t, domain.LedgerIgnored, status)
+
+	_, err = domain.ParseLedgerStatus("finished")
+	assert.ErrorContains(t, err, "unknown ledger status")
+}
+
+func TestNewLedgerEntry(t *testing.T) {
+	meeting := domain.Meeting{
+		VideoID:     "abc123",
+		VideoURL:    "https://www.youtube.com/watch?v=abc123",
+		MeetingDate: time.Date(2025, 2, 4, 0, 0, 0, 0, time.UTC),
+		Sequence:    2,
+	}
+
+	entry := domain.NewLedgerEntry(meeting, domain.LedgerDone, "/out/summary.md")
+	assert.Equal(t, domain.LedgerEntry{
+		VideoID:     "abc123",
+		VideoURL:    "https://www.youtube.com/watch?v=abc123",
+		MeetingDate: "2025-02-04",
+		Sequence:    2,
+		OutputPath:  "/out/summary.md",
+		Status:      domain.LedgerDone,
+	}, entry)
+
+	assert.Empty(t, domain.NewLedgerEntry(domain.Meeting{VideoID: "x"}, domain.LedgerIgnored, "").MeetingDate,
+		"an undated meeting has no date")
+}
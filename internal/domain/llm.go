@@ -11,6 +11,9 @@ const (
 	// ProviderOpenAI speaks the OpenAI Chat Completions API
 	// (POST /v1/chat/completions).
 	ProviderOpenAI = "openai"
+	// ProviderGemini speaks the Google Gemini API
+	// (POST /v1beta/models/{model}:generateContent).
+	ProviderGemini = "gemini"
 )
 
 // Supported values for LLMConfig.MaxTokensField.
@@ -26,7 +29,7 @@ const (
 // Providers returns the supported provider identifiers, for validation messages
 // and help text.
 func Providers() []string {
-	return []string{ProviderAnthropic, ProviderOpenAI}
+	return []string{ProviderAnthropic, ProviderOpenAI, ProviderGemini}
 }
 
 // MaxTokensFields returns the supported LLMConfig.MaxTokensField values.
@@ -37,16 +40,21 @@ func MaxTokensFields() []string {
 // DefaultAPIKeyEnv returns the conventional environment variable holding the
 // API key for a provider.
 func DefaultAPIKeyEnv(provider string) string {
-	if provider == ProviderOpenAI {
+	switch provider {
+	case ProviderOpenAI:
 		return "OPENAI_API_KEY"
+	case ProviderGemini:
+		return "GEMINI_API_KEY"
+	default:
+		return "ANTHROPIC_API_KEY"
 	}
-	return "ANTHROPIC_API_KEY"
 }
 
 // LLMConfig is the resolved language-model configuration for one body: the
 // global block with any per-body override already applied.
 type LLMConfig struct {
-	// Provider selects the wire protocol. See ProviderAnthropic/ProviderOpenAI.
+	// Provider selects the wire protocol. See ProviderAnthropic, ProviderOpenAI,
+	// and ProviderGemini.
 	Provider string `yaml:"provider" mapstructure:"provider"`
 	// Model is the model identifier, passed to the provider verbatim.
 	Model string `yaml:"model" mapstructure:"model"`
@@ -60,14 +68,19 @@ type LLMConfig struct {
 	// also covers reasoning tokens, so it needs headroom beyond the summary.
 	MaxTokens int `yaml:"max_tokens" mapstructure:"max_tokens"`
 	// MaxTokensField selects which OpenAI output-limit field to send. Ignored
-	// by the Anthropic provider.
+	// by the Anthropic and Gemini providers.
 	MaxTokensField string `yaml:"max_tokens_field" mapstructure:"max_tokens_field"`
+	// ContextWindow is the model's total context size in tokens, including
+	// MaxTokens of output. Transcripts that would not fit are summarized in
+	// chunks. Zero means unknown: the prompt is sent whole.
+	ContextWindow int `yaml:"context_window" mapstructure:"context_window"`
 	// Temperature is sent only when non-nil. Current Claude models reject
 	// sampling parameters with HTTP 400, so it stays unset unless configured.
 	Temperature *float64 `yaml:"temperature" mapstructure:"temperature"`
 	// TimeoutSeconds bounds a single request, including SDK-level retries.
 	TimeoutSeconds int `yaml:"timeout_seconds" mapstructure:"timeout_seconds"`
-	// MaxRetries is how many times the provider SDK retries transient failures.
+	// MaxRetries is how many times the provider client retries transient
+	// failures.
 	MaxRetries int `yaml:"max_retries" mapstructure:"max_retries"`
 	// Stream requests a streamed response, which avoids HTTP timeouts on large
 	// outputs. Disable it for compatible servers with unreliable SSE support.
@@ -102,6 +115,7 @@ type LLMOverride struct {
 	APIKeyEnv      *string  `yaml:"api_key_env" mapstructure:"api_key_env"`
 	MaxTokens      *int     `yaml:"max_tokens" mapstructure:"max_tokens"`
 	MaxTokensField *string  `yaml:"max_tokens_field" mapstructure:"max_tokens_field"`
+	ContextWindow  *int     `yaml:"context_window" mapstructure:"context_window"`
 	Temperature    *float64 `yaml:"temperature" mapstructure:"temperature"`
 	TimeoutSeconds *int     `yaml:"timeout_seconds" mapstructure:"timeout_seconds"`
 	MaxRetries     *int     `yaml:"max_retries" mapstructure:"max_retries"`
@@ -123,6 +137,7 @@ func (o *LLMOverride) Apply(base LLMConfig) LLMConfig {
 	override(&merged.APIKeyEnv, o.APIKeyEnv)
 	override(&merged.MaxTokens, o.MaxTokens)
 	override(&merged.MaxTokensField, o.MaxTokensField)
+	override(&merged.ContextWindow, o.ContextWindow)
 	override(&merged.TimeoutSeconds, o.TimeoutSeconds)
 	override(&merged.MaxRetries, o.MaxRetries)
 	override(&merged.Stream, o.Stream)
@@ -142,3 +157,14 @@ func override[T any](target *T, value *T) {
 		*target = *value
 	}
 }
+
+// InputBudget returns how many prompt tokens fit alongside MaxTokens of output,
+// less reserve for estimation error. It returns 0 when ContextWindow is unknown,
+// and never less than 1 otherwise, so a window too small for any prompt is
+// reported rather than mistaken for an unknown one.
+func (c LLMConfig) InputBudget(reserve int) int {
+	if c.ContextWindow <= 0 {
+		return 0
+	}
+	return max(c.ContextWindow-c.MaxTokens-reserve, 1)
+}
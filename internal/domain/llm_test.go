@@ -9,7 +9,7 @@ import (
 )
 
 func TestProviders(t *testing.T) {
-	assert.Equal(t, []string{"anthropic", "openai"}, domain.Providers())
+	assert.Equal(t, []string{"anthropic", "openai", "gemini"}, domain.Providers())
 }
 
 func TestMaxTokensFields(t *testing.T) {
@@ -19,6 +19,7 @@ func TestMaxTokensFields(t *testing.T) {
 func TestDefaultAPIKeyEnv(t *testing.T) {
 	assert.Equal(t, "ANTHROPIC_API_KEY", domain.DefaultAPIKeyEnv(domain.ProviderAnthropic))
 	assert.Equal(t, "OPENAI_API_KEY", domain.DefaultAPIKeyEnv(domain.ProviderOpenAI))
+	assert.Equal(t, "GEMINI_API_KEY", domain.DefaultAPIKeyEnv(domain.ProviderGemini))
 	assert.Equal(t, "ANTHROPIC_API_KEY", domain.DefaultAPIKeyEnv(""))
 }
 
@@ -34,6 +35,13 @@ func TestLLMConfig_Describe(t *testing.T) {
 	assert.Equal(t, "anthropic/claude-opus-5", cfg.Describe())
 }
 
+func TestLLMConfig_InputBudget(t *testing.T) {
+	assert.Equal(t, 182000, domain.LLMConfig{ContextWindow: 200000, MaxTokens: 16000}.InputBudget(2000))
+	assert.Zero(t, domain.LLMConfig{MaxTokens: 16000}.InputBudget(2000), "unknown window means no budget")
+	assert.Equal(t, 1, domain.LLMConfig{ContextWindow: 17000, MaxTokens: 16000}.InputBudget(2000),
+		"an exhausted budget must not read as unknown")
+}
+
 // base returns a fully populated global LLM block for merge tests.
 func base() domain.LLMConfig {
 	temperature := 0.2
@@ -44,6 +52,7 @@ func base() domain.LLMConfig {
 		APIKeyEnv:      "ANTHROPIC_API_KEY",
 		MaxTokens:      16000,
 		MaxTokensField: domain.MaxTokensFieldModern,
+		ContextWindow:  200000,
 		Temperature:    &temperature,
 		TimeoutSeconds: 900,
 		MaxRetries:     2,
@@ -75,9 +84,17 @@ func TestLLMOverride_Apply_ReplacesSetFieldsOnly(t *testing.T) {
 	assert.Equal(t, domain.ProviderAnthropic, merged.Provider)
 	assert.Equal(t, "https://api.anthropic.com", merged.BaseURL)
 	assert.Equal(t, 900, merged.TimeoutSeconds)
+	assert.Equal(t, 200000, merged.ContextWindow)
 	assert.Equal(t, "global system", merged.SystemPrompt)
 }
 
+func TestLLMOverride_Apply_ReplacesContextWindow(t *testing.T) {
+	window := 1000000
+	merged := (&domain.LLMOverride{ContextWindow: &window}).Apply(base())
+
+	assert.Equal(t, 1000000, merged.ContextWindow)
+}
+
 // TestLLMOverride_Apply_HonoursExplicitZeroValues is the reason the override
 // type uses pointers: a body must be able to clear an inherited base_url or
 // turn streaming off, which a plain-value merge could not express.
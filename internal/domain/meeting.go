@@ -8,12 +8,24 @@ import (
 // Meeting is the aggregate root representing a single government meeting.
 // It tracks a video through the pipeline from discovery to finalized summary.
 type Meeting struct {
-	VideoID     string
-	Title       string
+	VideoID string
+	Title   string
+	// VideoURL is the recording's page or media URL as listed by the body's
+	// video source; see Body.MeetingVideoURL.
+	VideoURL    string
 	MeetingDate time.Time
 	MeetingType string
 	BodySlug    string
 	Sequence    int // 0 = solo meeting on its date, 1+ = disambiguated same-date meetings
+	Agenda      Agenda
+	// Published is when the source says the recording was uploaded; zero
+	// when it does not report one. It orders new same-date meetings.
+	Published time.Time
+	// Details is the recording metadata reported by the video source.
+	Details VideoDetails
+	// Window is where the meeting itself starts and ends in the recording,
+	// once found in its transcript; zero until then.
+	Window MeetingWindow
 }
 
 // SequenceSuffix returns the filename suffix for same-date disambiguation.
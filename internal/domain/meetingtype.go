@@ -0,0 +1,154 @@
+package domain
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// DefaultMeetingType is the type of a meeting no rule matches.
+const DefaultMeetingType = "Regular Session"
+
+// MeetingTypeRule classifies meetings by video title. A type can carry its own
+// tag, prompt template, and validation rules, so a budget work session and a
+// regular voting meeting are summarized differently.
+type MeetingTypeRule struct {
+	// Name is the type's display name, such as "Public Hearing".
+	Name string `yaml:"name" mapstructure:"name"`
+	// Match is a regular expression tested against the video title. Empty
+	// matches titles that contain Name, ignoring case.
+	Match string `yaml:"match" mapstructure:"match"`
+	// Tag is added to the summary's tags. Defaults to Name with spaces
+	// replaced by hyphens.
+	Tag string `yaml:"tag" mapstructure:"tag"`
+	// PromptTemplate replaces the body's prompt template for meetings of
+	// this type. The chunk template stays the body's unless ChunkTemplate is
+	// set too.
+	PromptTemplate string `yaml:"prompt_template" mapstructure:"prompt_template"`
+	ChunkTemplate  string `yaml:"chunk_template" mapstructure:"chunk_template"`
+	// Validation overrides the body's validation rules for meetings of this
+	// type. Unset fields keep the body's values.
+	Validation *ValidationRules `yaml:"validation" mapstructure:"validation"`
+}
+
+// defaultMeetingTypes classify titles that no configured rule matches.
+var defaultMeetingTypes = []MeetingTypeRule{
+	{Name: "Work Session", Match: `(?i)work session`},
+	{Name: "Special Meeting", Match: `(?i)special`},
+	{Name: "Evening Meeting", Match: `(?i)evening`},
+}
+
+// Matches reports whether the rule matches a video title. An invalid Match
+// never matches; config validation rejects those before they get here.
+func (r MeetingTypeRule) Matches(title string) bool {
+	if r.Match == "" {
+		return strings.Contains(strings.ToLower(title), strings.ToLower(r.Name))
+	}
+	re, err := regexp.Compile(r.Match)
+	if err != nil {
+		return false
+	}
+	return re.MatchString(title)
+}
+
+// TagName returns the tag added to summaries of this type.
+func (r MeetingTypeRule) TagName() string {
+	if r.Tag != "" {
+		return r.Tag
+	}
+	return strings.ReplaceAll(r.Name, " ", "-")
+}
+
+// validate checks that the rule has a name and that Match and Tag are
+// usable.
+func (r MeetingTypeRule) validate() error {
+	if r.Name == "" {
+		return fmt.Errorf("name is required")
+	}
+	if r.Match != "" {
+		if _, err := regexp.Compile(r.Match); err != nil {
+			return fmt.Errorf("invalid match %q: %w", r.Match, err)
+		}
+	}
+	if strings.Contains(r.TagName(), " ") {
+		return fmt.Errorf("tag %q must not contain spaces", r.TagName())
+	}
+	return nil
+}
+
+// DetectMeetingType returns the type of the first of the body's meeting types
+// whose rule matches the title, then of the built-in Work Session, Special
+// Meeting, and Evening Meeting rules, and otherwise DefaultMeetingType.
+func (b Body) DetectMeetingType(title string) string {
+	for _, rules := range [][]MeetingTypeRule{b.MeetingTypes, defaultMeetingTypes} {
+		for _, rule := range rules {
+			if rule.Matches(title) {
+				return rule.Name
+			}
+		}
+	}
+	return DefaultMeetingType
+}
+
+// MeetingTypeRule returns the body's rule for a meeting type name.
+func (b Body) MeetingTypeRule(name string) (MeetingTypeRule, bool) {
+	for _, rule := range b.MeetingTypes {
+		if strings.EqualFold(rule.Name, name) {
+			return rule, true
+		}
+	}
+	return MeetingTypeRule{}, false
+}
+
+// MeetingTypeTag returns the tag added to summaries of a meeting type: the
+// rule's tag when the body configures one, otherwise the hyphenated name. An
+// unknown (empty) type is tagged as DefaultMeetingType.
+func (b Body) MeetingTypeTag(name string) string {
+	if name == "" {
+		name = DefaultMeetingType
+	}
+	if rule, ok := b.MeetingTypeRule(name); ok {
+		return rule.TagName()
+	}
+	return MeetingTypeRule{Name: name}.TagName()
+}
+
+// ForMeetingType returns the body as it applies to meetings of the named
+// type: with the type's prompt template and validation overrides, if its
+// rule sets any. The body is returned unchanged for other types.
+func (b Body) ForMeetingType(name string) Body {
+	rule, ok := b.MeetingTypeRule(name)
+	if !ok {
+		return b
+	}
+	if rule.PromptTemplate != "" {
+		if rule.ChunkTemplate == "" {
+			rule.ChunkTemplate = b.ChunkTemplateName()
+		}
+		b.PromptTemplate = rule.PromptTemplate
+	}
+	if rule.ChunkTemplate != "" {
+		b.ChunkTemplate = rule.ChunkTemplate
+	}
+	if rule.Validation != nil {
+		rules := rule.Validation.ApplyTo(b.ValidationRules())
+		b.Validation = &rules
+	}
+	return b
+}
+
+// ValidateMeetingTypes checks each of the body's meeting type rules,
+// including its validation overrides as applied to the body's own rules.
+func (b Body) ValidateMeetingTypes() error {
+	for i, rule := range b.MeetingTypes {
+		if err := rule.validate(); err != nil {
+			return fmt.Errorf("meeting_types[%d]: %w", i, err)
+		}
+		if rule.Validation != nil {
+			if err := rule.Validation.ApplyTo(b.ValidationRules()).Validate(); err != nil {
+				return fmt.Errorf("meeting_types[%d] (%s): %w", i, rule.Name, err)
+			}
+		}
+	}
+	return nil
+}
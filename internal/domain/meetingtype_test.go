@@ -0,0 +1,108 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/AvogadroSG1/civic-summary/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBody_DetectMeetingType(t *testing.T) {
+	body := domain.Body{MeetingTypes: []domain.MeetingTypeRule{
+		{Name: "Public Hearing", Match: `(?i)public hearing|zoning appeal`},
+		{Name: "Budget Work Session", Match: `(?i)budget.*work session`},
+		{Name: "Closed Session"},
+	}}
+
+	tests := []struct {
+		title string
+		want  string
+	}{
+		{"March 3, 2025 - Zoning Appeal", "Public Hearing"},
+		{"Budget Work Session - March 4, 2025", "Budget Work Session"},
+		{"CLOSED SESSION March 5, 2025", "Closed Session"},
+		{"Mayor & Council Work Session", "Work Session"},
+		{"Special Meeting of the Council", "Special Meeting"},
+		{"Regular Meeting - March 6, 2025", domain.DefaultMeetingType},
+	}
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			assert.Equal(t, tt.want, body.DetectMeetingType(tt.title))
+		})
+	}
+}
+
+func TestBody_MeetingTypeTag(t *testing.T) {
+	body := domain.Body{MeetingTypes: []domain.MeetingTypeRule{
+		{Name: "Public Hearing", Tag: "Hearing"},
+		{Name: "Closed Session"},
+	}}
+
+	assert.Equal(t, "Hearing", body.MeetingTypeTag("Public Hearing"))
+	assert.Equal(t, "Hearing", body.MeetingTypeTag("public hearing"))
+	assert.Equal(t, "Closed-Session", body.MeetingTypeTag("Closed Session"))
+	assert.Equal(t, "Work-Session", body.MeetingTypeTag("Work Session"))
+	assert.Equal(t, "Regular-Session", body.MeetingTypeTag(""))
+}
+
+func TestBody_ForMeetingType(t *testing.T) {
+	minWords := 150
+	body := domain.Body{
+		PromptTemplate: "council.prompt.tmpl",
+		MeetingTypes: []domain.MeetingTypeRule{
+			{
+				Name:           "Public Hearing",
+				PromptTemplate: "hearing.prompt.tmpl",
+				Validation: &domain.ValidationRules{
+					MinWords: &minWords,
+					Checks:   map[string]domain.RuleSeverity{domain.CheckFooter: domain.RuleOff},
+				},
+			},
+			{Name: "Closed Session"},
+		},
+	}
+
+	hearing := body.ForMeetingType("Public Hearing")
+	assert.Equal(t, "hearing.prompt.tmpl", hearing.PromptTemplate)
+	assert.Equal(t, "council.chunk.tmpl", hearing.ChunkTemplateName(),
+		"the chunk template stays the body's")
+	minW, warnW := hearing.ValidationRules().Words()
+	assert.Equal(t, 150, minW)
+	assert.Equal(t, 1000, warnW, "unset fields keep the body's rules")
+	assert.Equal(t, domain.RuleOff, hearing.ValidationRules().Check(domain.CheckFooter))
+
+	assert.Equal(t, body, body.ForMeetingType("Closed Session"), "a rule without overrides changes nothing")
+	assert.Equal(t, body, body.ForMeetingType("Work Session"))
+	assert.Nil(t, body.Validation, "the body itself is not modified")
+}
+
+func TestBody_ValidateMeetingTypes(t *testing.T) {
+	negative := -1
+	tests := []struct {
+		name    string
+		rule    domain.MeetingTypeRule
+		wantErr string
+	}{
+		{"valid", domain.MeetingTypeRule{Name: "Public Hearing", Match: `(?i)hearing`}, ""},
+		{"missing name", domain.MeetingTypeRule{Match: `(?i)hearing`}, "meeting_types[0]: name is required"},
+		{"bad match", domain.MeetingTypeRule{Name: "Hearing", Match: `(`}, "invalid match"},
+		{"tag with spaces", domain.MeetingTypeRule{Name: "Hearing", Tag: "Public Hearing"}, "must not contain spaces"},
+		{
+			"bad validation",
+			domain.MeetingTypeRule{Name: "Hearing", Validation: &domain.ValidationRules{MinWords: &negative}},
+			"meeting_types[0] (Hearing):",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := domain.Body{MeetingTypes: []domain.MeetingTypeRule{tt.rule}}.ValidateMeetingTypes()
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
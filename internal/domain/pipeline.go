@@ -1,5 +1,7 @@
 package domain
 
+import "time"
+
 // PipelineStage represents a stage in the processing pipeline.
 type PipelineStage string
 
@@ -11,20 +13,100 @@ const (
 	StageValidation    PipelineStage = "validation"
 )
 
-// PipelineResult tracks the outcome of processing a single meeting.
+// MeetingStages returns the per-meeting stages in execution order. Discovery is
+// excluded because it runs once per body rather than once per meeting.
+func MeetingStages() []PipelineStage {
+	return []PipelineStage{StageTranscription, StageAnalysis, StageCrossRef, StageValidation}
+}
+
+// StageCheckpoint records a completed stage and the artifact it produced.
+type StageCheckpoint struct {
+	// Artifact is the path of the file the stage produced: the transcript for
+	// transcription, the raw model output for analysis, and the
+	// cross-referenced content for crossref.
+	Artifact string `json:"artifact"`
+	// Detail carries stage-specific metadata, such as the transcript source.
+	Detail      string    `json:"detail,omitempty"`
+	CompletedAt time.Time `json:"completed_at"`
+}
+
+// PipelineResult is the persisted progress record for a single meeting, keyed
+// by body slug and video ID. Each completed stage is checkpointed with its
+// artifact so that a retry resumes from the first incomplete stage instead of
+// re-downloading captions or re-running Whisper.
 type PipelineResult struct {
-	Meeting    Meeting
-	Stage      PipelineStage
-	Success    bool
-	Error      error
-	OutputPath string
+	VideoID     string                            `json:"video_id"`
+	BodySlug    string                            `json:"body_slug"`
+	MeetingDate string                            `json:"meeting_date"`
+	Sequence    int                               `json:"sequence"`
+	Checkpoints map[PipelineStage]StageCheckpoint `json:"checkpoints"`
+	Error       string                            `json:"error,omitempty"`
+	UpdatedAt   time.Time                         `json:"updated_at"`
+}
+
+// NewPipelineResult creates an empty progress record for a meeting.
+func NewPipelineResult(meeting Meeting) *PipelineResult {
+	return &PipelineResult{
+		VideoID:     meeting.VideoID,
+		BodySlug:    meeting.BodySlug,
+		MeetingDate: meeting.ISODate(),
+		Sequence:    meeting.Sequence,
+		Checkpoints: make(map[PipelineStage]StageCheckpoint),
+	}
+}
+
+// Checkpoint returns the checkpoint for a stage, if that stage has completed.
+func (r *PipelineResult) Checkpoint(stage PipelineStage) (StageCheckpoint, bool) {
+	cp, ok := r.Checkpoints[stage]
+	return cp, ok
+}
+
+// Complete records a stage as finished with the given artifact.
+func (r *PipelineResult) Complete(stage PipelineStage, artifact, detail string) {
+	if r.Checkpoints == nil {
+		r.Checkpoints = make(map[PipelineStage]StageCheckpoint)
+	}
+	r.Checkpoints[stage] = StageCheckpoint{
+		Artifact:    artifact,
+		Detail:      detail,
+		CompletedAt: time.Now(),
+	}
+}
+
+// ResetFrom discards the checkpoint for stage and every later stage, so the
+// next attempt reruns them. Artifacts of earlier stages are kept.
+func (r *PipelineResult) ResetFrom(stage PipelineStage) {
+	reset := false
+	for _, s := range MeetingStages() {
+		if s == stage {
+			reset = true
+		}
+		if reset {
+			delete(r.Checkpoints, s)
+		}
+	}
+}
+
+// NextStage returns the first stage without a checkpoint, or "" when every
+// stage has completed.
+func (r *PipelineResult) NextStage() PipelineStage {
+	for _, s := range MeetingStages() {
+		if _, ok := r.Checkpoints[s]; !ok {
+			return s
+		}
+	}
+	return ""
 }
 
 // ProcessingStats tracks aggregate pipeline statistics.
 type ProcessingStats struct {
+	// Discovered counts the new meetings due to be processed; those still
+	// waiting out a deferral are counted in Deferred instead.
 	Discovered  int
 	Skipped     int
 	Processed   int
 	Failed      int
 	Quarantined int
+	// Deferred counts meetings waiting for their source to publish captions.
+	Deferred int
 }
@@ -0,0 +1,79 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/AvogadroSG1/civic-summary/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMeetingStages(t *testing.T) {
+	assert.Equal(t, []domain.PipelineStage{
+		domain.StageTranscription,
+		domain.StageAnalysis,
+		domain.StageCrossRef,
+		domain.StageValidation,
+	}, domain.MeetingStages())
+}
+
+func TestNewPipelineResult(t *testing.T) {
+	m := domain.Meeting{
+		VideoID:     "abc123",
+		BodySlug:    "hagerstown",
+		MeetingDate: time.Date(2025, 2, 4, 0, 0, 0, 0, time.UTC),
+		Sequence:    2,
+	}
+
+	r := domain.NewPipelineResult(m)
+
+	assert.Equal(t, "abc123", r.VideoID)
+	assert.Equal(t, "hagerstown", r.BodySlug)
+	assert.Equal(t, "2025-02-04", r.MeetingDate)
+	assert.Equal(t, 2, r.Sequence)
+	assert.Empty(t, r.Checkpoints)
+	assert.Equal(t, domain.StageTranscription, r.NextStage())
+}
+
+func TestPipelineResult_CompleteAdvancesNextStage(t *testing.T) {
+	r := domain.NewPipelineResult(domain.Meeting{VideoID: "abc123"})
+
+	r.Complete(domain.StageTranscription, "/tmp/abc123.srt", "captions")
+	assert.Equal(t, domain.StageAnalysis, r.NextStage())
+
+	cp, ok := r.Checkpoint(domain.StageTranscription)
+	assert.True(t, ok)
+	assert.Equal(t, "/tmp/abc123.srt", cp.Artifact)
+	assert.Equal(t, "captions", cp.Detail)
+	assert.False(t, cp.CompletedAt.IsZero())
+
+	r.Complete(domain.StageAnalysis, "a", "")
+	r.Complete(domain.StageCrossRef, "c", "")
+	r.Complete(domain.StageValidation, "", "")
+	assert.Equal(t, domain.PipelineStage(""), r.NextStage())
+}
+
+func TestPipelineResult_ResetFromKeepsEarlierStages(t *testing.T) {
+	r := domain.NewPipelineResult(domain.Meeting{VideoID: "abc123"})
+	r.Complete(domain.StageTranscription, "t", "")
+	r.Complete(domain.StageAnalysis, "a", "")
+	r.Complete(domain.StageCrossRef, "c", "")
+
+	r.ResetFrom(domain.StageAnalysis)
+
+	_, ok := r.Checkpoint(domain.StageTranscription)
+	assert.True(t, ok)
+	_, ok = r.Checkpoint(domain.StageAnalysis)
+	assert.False(t, ok)
+	_, ok = r.Checkpoint(domain.StageCrossRef)
+	assert.False(t, ok)
+	assert.Equal(t, domain.StageAnalysis, r.NextStage())
+}
+
+func TestPipelineResult_CompleteOnZeroValue(t *testing.T) {
+	var r domain.PipelineResult
+
+	r.Complete(domain.StageTranscription, "t", "")
+
+	assert.Equal(t, domain.StageAnalysis, r.NextStage())
+}
@@ -6,6 +6,9 @@ import "time"
 // for later retry.
 type QuarantineEntry struct {
 	VideoID       string    `json:"video_id"`
+	VideoURL      string    `json:"video_url,omitempty"`
+	Title         string    `json:"title,omitempty"`
+	MeetingType   string    `json:"meeting_type,omitempty"`
 	MeetingDate   string    `json:"meeting_date"`
 	BodySlug      string    `json:"body_slug"`
 	Sequence      int       `json:"sequence"`
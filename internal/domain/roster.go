@@ -0,0 +1,276 @@
+package domain
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// defaultOfficialTitles introduce an official's name in a transcript or
+// summary, as in "Councilmember Martinez". Titles on a body's roster are
+// added to these.
+var defaultOfficialTitles = []string{
+	"Mayor", "Vice Mayor",
+	"Council President", "Council Vice President",
+	"Councilmember", "Council Member", "Councilman", "Councilwoman",
+	"Commissioner", "Commission President", "Commission Vice President",
+	"Alderman", "Alderwoman",
+}
+
+// nameSuffixes follow a surname without being one.
+var nameSuffixes = map[string]bool{
+	"jr": true, "jr.": true, "sr": true, "sr.": true, "ii": true, "iii": true, "iv": true,
+}
+
+// honorifics may precede a name or a bare title, as in "Mr. Mayor".
+var honorifics = []string{"Mr.", "Mr", "Mrs.", "Mrs", "Ms.", "Ms", "Madam", "Madame"}
+
+// rosterNameWord matches one word of a name: "Martinez", "O'Neil",
+// "McDonald", or "Smith-Jones".
+const rosterNameWord = `(?:[A-Z]['’])?[A-Z][a-z]+(?:[A-Z][a-z]+)?(?:-[A-Z][a-z]+)?`
+
+// RosterMember is one official on a body's roster.
+type RosterMember struct {
+	// Name is the member's full name as it should appear in summaries.
+	Name string `yaml:"name" mapstructure:"name"`
+	// Title is the member's office, e.g. "Councilmember" or "Mayor".
+	Title string `yaml:"title" mapstructure:"title"`
+	// Seat is the ward, district, or "At-large" the member holds.
+	Seat string `yaml:"seat" mapstructure:"seat"`
+	// Aliases are other ways the member is referred to, such as a nickname
+	// ("Bill Smith") or a form of address ("Madam President").
+	Aliases []string `yaml:"aliases" mapstructure:"aliases"`
+}
+
+// Surname returns the last word of the member's name, skipping suffixes
+// such as "Jr.".
+func (m RosterMember) Surname() string {
+	words := strings.Fields(strings.ReplaceAll(m.Name, ",", " "))
+	for len(words) > 1 && nameSuffixes[strings.ToLower(words[len(words)-1])] {
+		words = words[:len(words)-1]
+	}
+	if len(words) == 0 {
+		return ""
+	}
+	return words[len(words)-1]
+}
+
+// Label returns the member's title and name, e.g. "Mayor Keith Bruchey", as
+// used for a speaker in a diarized transcript.
+func (m RosterMember) Label() string {
+	if m.Title == "" {
+		return m.Name
+	}
+	return m.Title + " " + m.Name
+}
+
+// matches reports whether name is the member's full name, surname, or one of
+// their aliases, ignoring case.
+func (m RosterMember) matches(name string) bool {
+	if strings.EqualFold(name, m.Name) || strings.EqualFold(name, m.Surname()) {
+		return true
+	}
+	for _, alias := range m.Aliases {
+		if strings.EqualFold(name, alias) {
+			return true
+		}
+	}
+	return false
+}
+
+// Roster lists a body's officials, so speakers and mentions can be mapped to
+// real names and names the model invents can be caught.
+type Roster []RosterMember
+
+// Validate checks that every member has a name, and that no name or alias is
+// shared by two members.
+func (r Roster) Validate() error {
+	seen := make(map[string]int)
+	claim := func(i int, key, what string) error {
+		k := strings.ToLower(strings.Join(strings.Fields(key), " "))
+		if j, ok := seen[k]; ok && j != i {
+			return fmt.Errorf("roster[%d]: %s %q is already used by roster[%d]", i, what, key, j)
+		}
+		seen[k] = i
+		return nil
+	}
+	for i, m := range r {
+		if strings.TrimSpace(m.Name) == "" {
+			return fmt.Errorf("roster[%d]: name is required", i)
+		}
+		if err := claim(i, m.Name, "name"); err != nil {
+			return err
+		}
+		for j, alias := range m.Aliases {
+			if strings.TrimSpace(alias) == "" {
+				return fmt.Errorf("roster[%d].aliases[%d] is empty", i, j)
+			}
+			if err := claim(i, alias, "alias"); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Titles returns the titles that introduce an official's name: the defaults
+// plus every title on the roster, longest first so that "Council President"
+// is preferred to "Council".
+func (r Roster) Titles() []string {
+	titles := slices.Clone(defaultOfficialTitles)
+	for _, m := range r {
+		if m.Title != "" && !slices.ContainsFunc(titles, func(t string) bool { return strings.EqualFold(t, m.Title) }) {
+			titles = append(titles, m.Title)
+		}
+	}
+	slices.SortStableFunc(titles, func(a, b string) int { return len(b) - len(a) })
+	return titles
+}
+
+// OfficialMention is a title followed by a name, such as "Councilmember
+// Martinez", found in text.
+type OfficialMention struct {
+	Title string
+	Name  string
+	// Start and End are the mention's byte offsets in the text.
+	Start, End int
+}
+
+// String renders the mention as it appeared.
+func (m OfficialMention) String() string {
+	return m.Title + " " + m.Name
+}
+
+// Mentions returns every title followed by a one- or two-word name in text.
+// A possessive "'s" is not part of the name.
+func (r Roster) Mentions(text string) []OfficialMention {
+	pattern := regexp.MustCompile(`\b(` + alternation(r.Titles()) + `)\s+(` +
+		rosterNameWord + `(?:\s+` + rosterNameWord + `)?)(?:['’]s)?\b`)
+
+	var out []OfficialMention
+	for _, m := range pattern.FindAllStringSubmatchIndex(text, -1) {
+		out = append(out, OfficialMention{
+			Title: text[m[2]:m[3]],
+			Name:  text[m[4]:m[5]],
+			Start: m[0],
+			End:   m[1],
+		})
+	}
+	return out
+}
+
+// Lookup returns the member a mention refers to. The mention may be a full
+// name, a surname, or an alias, compared without case, and may start with an
+// honorific or title; "Madam Mayor" or a bare title refers to the only member
+// holding it. A title also settles which of two members sharing a surname is
+// meant. Lookup reports false when no member, or more than one, matches.
+func (r Roster) Lookup(mention string) (RosterMember, bool) {
+	mention = strings.Join(strings.Fields(mention), " ")
+	mention = strings.TrimSuffix(strings.TrimSuffix(mention, "'s"), "’s")
+
+	for _, m := range r {
+		if strings.EqualFold(mention, m.Name) || slices.ContainsFunc(m.Aliases, func(a string) bool { return strings.EqualFold(mention, a) }) {
+			return m, true
+		}
+	}
+
+	title, name := r.splitTitle(stripHonorific(mention))
+	var candidates []RosterMember
+	for _, m := range r {
+		switch {
+		case name == "":
+			if title != "" && strings.EqualFold(m.Title, title) {
+				candidates = append(candidates, m)
+			}
+		case m.matches(name), strings.EqualFold(lastWord(name), m.Surname()),
+			strings.EqualFold(strings.Fields(name)[0], m.Surname()):
+			// The first word alone is the surname when the mention ran on
+			// into a capitalized word, as in "Mayor Bruchey Monday".
+			candidates = append(candidates, m)
+		}
+	}
+	if len(candidates) > 1 && title != "" && name != "" {
+		candidates = slices.DeleteFunc(candidates, func(m RosterMember) bool {
+			return !strings.EqualFold(m.Title, title)
+		})
+	}
+	if len(candidates) != 1 {
+		return RosterMember{}, false
+	}
+	return candidates[0], true
+}
+
+// LastReferenced returns the member referred to last in text: by title and
+// name ("Councilmember Martinez"), by a form of address for a title only one
+// member holds ("Madam Mayor"), or by alias. A chair hands the floor over
+// this way, so the member named at the end of one turn usually speaks next.
+func (r Roster) LastReferenced(text string) (RosterMember, bool) {
+	var found RosterMember
+	end := -1
+	consider := func(mention string, at int) {
+		if at <= end {
+			return
+		}
+		if m, ok := r.Lookup(mention); ok {
+			found, end = m, at
+		}
+	}
+
+	for _, m := range r.Mentions(text) {
+		consider(m.String(), m.End)
+	}
+	address := regexp.MustCompile(`\b(?:Mr\.?|Madam|Madame)\s+(?:` + alternation(r.Titles()) + `)\b`)
+	for _, loc := range address.FindAllStringIndex(text, -1) {
+		consider(text[loc[0]:loc[1]], loc[1])
+	}
+	for _, m := range r {
+		for _, alias := range m.Aliases {
+			re := regexp.MustCompile(`(?i)\b` + alternation([]string{alias}) + `\b`)
+			if locs := re.FindAllStringIndex(text, -1); len(locs) > 0 {
+				consider(alias, locs[len(locs)-1][1])
+			}
+		}
+	}
+	return found, end >= 0
+}
+
+// splitTitle separates a leading title from the rest of a mention.
+func (r Roster) splitTitle(mention string) (title, rest string) {
+	for _, t := range r.Titles() {
+		if strings.EqualFold(mention, t) {
+			return t, ""
+		}
+		if len(mention) > len(t) && strings.EqualFold(mention[:len(t)+1], t+" ") {
+			return t, strings.TrimSpace(mention[len(t)+1:])
+		}
+	}
+	return "", mention
+}
+
+// stripHonorific drops a leading "Mr.", "Madam", or similar.
+func stripHonorific(mention string) string {
+	for _, h := range honorifics {
+		if rest, ok := strings.CutPrefix(mention, h+" "); ok {
+			return strings.TrimSpace(rest)
+		}
+	}
+	return mention
+}
+
+func lastWord(s string) string {
+	words := strings.Fields(s)
+	if len(words) == 0 {
+		return ""
+	}
+	return words[len(words)-1]
+}
+
+// alternation joins literal strings into a regular expression alternation.
+func alternation(words []string) string {
+	quoted := make([]string, len(words))
+	for i, w := range words {
+		quoted[i] = strings.ReplaceAll(regexp.QuoteMeta(w), " ", `\s+`)
+	}
+	return strings.Join(quoted, "|")
+}
@@ -0,0 +1,119 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/AvogadroSG1/civic-summary/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testRoster() domain.Roster {
+	return domain.Roster{
+		{Name: "Keith Bruchey", Title: "Mayor"},
+		{Name: "Tekesha Martinez", Title: "Councilmember", Seat: "At-large"},
+		{Name: "Kristin Aleshire", Title: "Council President", Aliases: []string{"Madam President"}},
+		{Name: "William Smith Jr.", Title: "Councilmember", Aliases: []string{"Bill Smith"}},
+		{Name: "Robert Smith", Title: "City Manager"},
+	}
+}
+
+func TestRosterMember_Surname(t *testing.T) {
+	assert.Equal(t, "Martinez", domain.RosterMember{Name: "Tekesha Martinez"}.Surname())
+	assert.Equal(t, "Smith", domain.RosterMember{Name: "William Smith, Jr."}.Surname())
+	assert.Equal(t, "Cher", domain.RosterMember{Name: "Cher"}.Surname())
+}
+
+func TestRoster_Lookup(t *testing.T) {
+	roster := testRoster()
+
+	tests := []struct {
+		mention string
+		want    string // "" when no single member matches
+	}{
+		{"Tekesha Martinez", "Tekesha Martinez"},
+		{"Councilmember Martinez", "Tekesha Martinez"},
+		{"councilmember MARTINEZ's", "Tekesha Martinez"},
+		{"Bill Smith", "William Smith Jr."},
+		{"Madam President", "Kristin Aleshire"},
+		{"Mr. Mayor", "Keith Bruchey"},
+		{"Mayor", "Keith Bruchey"},
+		{"Mayor Bruchey Monday", "Keith Bruchey"},
+		// Two Smiths: the title settles it, the surname alone does not.
+		{"Councilmember Smith", "William Smith Jr."},
+		{"City Manager Smith", "Robert Smith"},
+		{"Smith", ""},
+		{"Councilmember", ""},
+		{"Councilmember Martines", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.mention, func(t *testing.T) {
+			member, ok := roster.Lookup(tt.mention)
+			assert.Equal(t, tt.want != "", ok)
+			assert.Equal(t, tt.want, member.Name)
+		})
+	}
+}
+
+func TestRoster_Mentions(t *testing.T) {
+	roster := testRoster()
+	text := "Council President Kristin Aleshire recognized Councilmember Martinez's motion; City Manager Smith and the Commissioners agreed."
+
+	var got []string
+	for _, m := range roster.Mentions(text) {
+		got = append(got, m.String())
+	}
+	assert.Equal(t, []string{"Council President Kristin Aleshire", "Councilmember Martinez", "City Manager Smith"}, got)
+}
+
+func TestRoster_LastReferenced(t *testing.T) {
+	roster := testRoster()
+
+	member, ok := roster.LastReferenced("Thank you, Mayor Bruchey. Councilmember Martinez?")
+	require.True(t, ok)
+	assert.Equal(t, "Tekesha Martinez", member.Name)
+
+	member, ok = roster.LastReferenced("I yield back, Madam President.")
+	require.True(t, ok)
+	assert.Equal(t, "Kristin Aleshire", member.Name)
+
+	member, ok = roster.LastReferenced("Thank you, Mr. Mayor.")
+	require.True(t, ok)
+	assert.Equal(t, "Keith Bruchey", member.Name)
+
+	_, ok = roster.LastReferenced("Next item on the agenda.")
+	assert.False(t, ok)
+}
+
+func TestRoster_Validate(t *testing.T) {
+	require.NoError(t, testRoster().Validate())
+	require.NoError(t, domain.Roster(nil).Validate())
+
+	err := domain.Roster{{Title: "Mayor"}}.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "roster[0]: name is required")
+
+	err = domain.Roster{{Name: "Jane Doe"}, {Name: "jane  doe"}}.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "already used by roster[0]")
+
+	err = domain.Roster{{Name: "Jane Doe", Aliases: []string{""}}}.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "roster[0].aliases[0] is empty")
+}
+
+func TestRoster_Titles(t *testing.T) {
+	titles := testRoster().Titles()
+	assert.Contains(t, titles, "City Manager", "roster titles are added")
+	assert.Contains(t, titles, "Commissioner", "defaults are kept")
+	assert.Less(t, indexOf(titles, "Council President"), indexOf(titles, "Mayor"), "longest first")
+}
+
+func indexOf(list []string, s string) int {
+	for i, v := range list {
+		if v == s {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,135 @@
+package domain
+
+import (
+	"strings"
+	"time"
+)
+
+// Supported video source types, as they appear under a body's source.type.
+const (
+	// SourceYouTube lists a YouTube playlist or channel with yt-dlp.
+	SourceYouTube = "youtube"
+	// SourceFeed reads an RSS or Atom feed whose items carry media enclosures,
+	// as published by podcast hosts and many meeting-video platforms.
+	SourceFeed = "feed"
+	// SourceURLList reads a listing of video URLs, one "URL | Title" per line,
+	// for archives with no feed: Vimeo, Granicus, Swagit, or plain MP4 files.
+	SourceURLList = "urls"
+	// SourceFolder watches a local directory, such as a shared drive where
+	// Zoom recordings, audio files, or transcripts are dropped.
+	SourceFolder = "folder"
+)
+
+// SourceTypes returns the supported source types, for validation messages and
+// help text.
+func SourceTypes() []string {
+	return []string{SourceYouTube, SourceFeed, SourceURLList, SourceFolder}
+}
+
+// SourceConfig selects where a body's meeting recordings are published.
+type SourceConfig struct {
+	// Type is one of SourceTypes. Defaults to youtube.
+	Type string `yaml:"type" mapstructure:"type"`
+	// URL is the playlist or channel URL (youtube), the feed URL (feed), or
+	// the listing's URL or local file path (urls), or the directory to watch
+	// (folder). For youtube it may be left empty in favor of the body's
+	// playlist_id or video_source_url.
+	URL string `yaml:"url" mapstructure:"url"`
+}
+
+// Video is one recording listed by a body's video source.
+type Video struct {
+	// ID identifies the video within its source and names its files, so it
+	// must be stable across listings and safe in a filename.
+	ID    string
+	Title string
+	// URL is the video's page or media URL, or the path of a local file.
+	URL string
+	// Published is when the source says the video was published, or zero when
+	// it does not say.
+	Published time.Time
+	// Details holds whatever else the source reports about the recording.
+	Details VideoDetails
+}
+
+// Live statuses reported by yt-dlp for a recording.
+const (
+	LiveStatusNotLive  = "not_live"
+	LiveStatusUpcoming = "is_upcoming"
+	LiveStatusLive     = "is_live"
+	LiveStatusWasLive  = "was_live"
+	LiveStatusPostLive = "post_live"
+)
+
+// VideoDetails is recording metadata beyond the title and date. Every field
+// is optional: a source fills in what it knows and leaves the rest zero.
+type VideoDetails struct {
+	Duration time.Duration
+	// Released is when the recording became available, to the second: for a
+	// livestream, when the stream began. It is zero when the source reports
+	// only the day, as a flat YouTube listing does.
+	Released time.Time
+	// LiveStatus is one of the LiveStatus constants, or empty when unknown.
+	LiveStatus  string
+	Description string
+	Channel     string
+	Chapters    []Chapter
+	// Captions and AutoCaptions list the language codes of the uploaded and
+	// automatically generated caption tracks.
+	Captions     []string
+	AutoCaptions []string
+}
+
+// HasCaptions reports whether any caption track, uploaded or automatic, is
+// known to exist.
+func (d VideoDetails) HasCaptions() bool {
+	return len(d.Captions) > 0 || len(d.AutoCaptions) > 0
+}
+
+// Merge returns d with every field that more sets replacing d's, so fuller
+// metadata fetched later can be laid over what a listing reported.
+func (d VideoDetails) Merge(more VideoDetails) VideoDetails {
+	if more.Duration != 0 {
+		d.Duration = more.Duration
+	}
+	if !more.Released.IsZero() {
+		d.Released = more.Released
+	}
+	if more.LiveStatus != "" {
+		d.LiveStatus = more.LiveStatus
+	}
+	if more.Description != "" {
+		d.Description = more.Description
+	}
+	if more.Channel != "" {
+		d.Channel = more.Channel
+	}
+	if len(more.Chapters) > 0 {
+		d.Chapters = more.Chapters
+	}
+	if len(more.Captions) > 0 {
+		d.Captions = more.Captions
+	}
+	if len(more.AutoCaptions) > 0 {
+		d.AutoCaptions = more.AutoCaptions
+	}
+	return d
+}
+
+// Chapter is a titled span of a recording, as marked by its uploader.
+type Chapter struct {
+	Title string
+	Start time.Duration
+	End   time.Duration
+}
+
+// YouTubeVideoURL returns the watch URL for a YouTube video ID.
+func YouTubeVideoURL(videoID string) string {
+	return "https://www.youtube.com/watch?v=" + videoID
+}
+
+// IsLocalFile reports whether a recording URL names a file on disk rather
+// than a web page: a path, or a file:// URL.
+func IsLocalFile(url string) bool {
+	return url != "" && (strings.HasPrefix(url, "file://") || !strings.Contains(url, "://"))
+}
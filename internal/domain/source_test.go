@@ -0,0 +1,36 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/AvogadroSG1/civic-summary/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVideoDetails_Merge(t *testing.T) {
+	listed := domain.VideoDetails{
+		Duration:   90 * time.Minute,
+		LiveStatus: domain.LiveStatusWasLive,
+		Channel:    "City of Hagerstown",
+	}
+	fetched := domain.VideoDetails{
+		Released:     time.Date(2025, 2, 4, 23, 0, 0, 0, time.UTC),
+		Description:  "Agenda: https://example.gov/agenda.pdf",
+		Chapters:     []domain.Chapter{{Title: "Call to Order", End: 95 * time.Second}},
+		AutoCaptions: []string{"en"},
+	}
+
+	merged := listed.Merge(fetched)
+
+	assert.Equal(t, domain.VideoDetails{
+		Duration:     90 * time.Minute,
+		Released:     time.Date(2025, 2, 4, 23, 0, 0, 0, time.UTC),
+		LiveStatus:   domain.LiveStatusWasLive,
+		Description:  "Agenda: https://example.gov/agenda.pdf",
+		Channel:      "City of Hagerstown",
+		Chapters:     []domain.Chapter{{Title: "Call to Order", End: 95 * time.Second}},
+		AutoCaptions: []string{"en"},
+	}, merged)
+	assert.Equal(t, listed, listed.Merge(domain.VideoDetails{}), "unset fields leave the listing's values")
+}
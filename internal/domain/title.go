@@ -0,0 +1,114 @@
+package domain
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Named capture groups a TitlePattern may use instead of Layouts.
+const (
+	TitleGroupYear  = "year"
+	TitleGroupMonth = "month"
+	TitleGroupDay   = "day"
+)
+
+// TitlePattern finds a meeting date in a video title. Regex either captures
+// the whole date in its first group, which is parsed with Layouts, or names
+// groups year, month, and day, as in
+// `(?P<day>\d{1,2}) (?P<month>[A-Za-z]+) (?P<year>\d{4})`.
+type TitlePattern struct {
+	Regex string `yaml:"regex" mapstructure:"regex"`
+	// Layouts are Go time layouts for the captured date, such as
+	// "01/02/2006" or "2 January 2006", tried in order. Empty tries the
+	// built-in formats.
+	Layouts []string `yaml:"layouts" mapstructure:"layouts"`
+}
+
+// String renders the pattern's regex between slashes.
+func (p TitlePattern) String() string {
+	return "/" + p.Regex + "/"
+}
+
+// Compile compiles Regex and checks that it captures a date: named groups
+// year, month, and day, all three, or at least one unnamed group.
+func (p TitlePattern) Compile() (*regexp.Regexp, error) {
+	re, err := regexp.Compile(p.Regex)
+	if err != nil {
+		return nil, fmt.Errorf("invalid regex %q: %w", p.Regex, err)
+	}
+	named := 0
+	for _, group := range []string{TitleGroupYear, TitleGroupMonth, TitleGroupDay} {
+		if re.SubexpIndex(group) >= 0 {
+			named++
+		}
+	}
+	switch {
+	case named == 3 && len(p.Layouts) > 0:
+		return nil, fmt.Errorf("regex %q: layouts cannot be combined with named groups", p.Regex)
+	case named == 3:
+	case named > 0:
+		return nil, fmt.Errorf("regex %q: named groups need all of year, month, and day", p.Regex)
+	case re.NumSubexp() == 0:
+		return nil, fmt.Errorf("regex %q: needs a capture group around the date", p.Regex)
+	}
+	return re, nil
+}
+
+// TitlePatterns is a body's title_date_regex: the patterns tried, in order, to
+// date a video from its title. In the config it is a single regex, or a list
+// whose entries are regexes or maps with regex and layouts keys.
+type TitlePatterns []TitlePattern
+
+// String renders the patterns as a comma-separated list.
+func (ps TitlePatterns) String() string {
+	parts := make([]string, len(ps))
+	for i, p := range ps {
+		parts[i] = p.String()
+		if len(p.Layouts) > 0 {
+			parts[i] += " (" + strings.Join(p.Layouts, ", ") + ")"
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// Validate checks that there is at least one pattern and that each compiles.
+func (ps TitlePatterns) Validate() error {
+	if len(ps) == 0 {
+		return fmt.Errorf("title_date_regex is required")
+	}
+	for i, p := range ps {
+		if _, err := p.Compile(); err != nil {
+			return fmt.Errorf("title_date_regex[%d]: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// validateTitleFilters checks that each title_include and title_exclude
+// entry compiles.
+func (b Body) validateTitleFilters() error {
+	lists := []struct {
+		name    string
+		filters []string
+	}{
+		{"title_include", b.TitleInclude},
+		{"title_exclude", b.TitleExclude},
+	}
+	for _, list := range lists {
+		for i, f := range list.filters {
+			if _, err := regexp.Compile(f); err != nil {
+				return fmt.Errorf("%s[%d]: invalid regex %q: %w", list.name, i, f, err)
+			}
+		}
+	}
+	return nil
+}
+
+// ValidateTitleRules checks the body's title date patterns and filters.
+func (b Body) ValidateTitleRules() error {
+	if err := b.TitleDateRegex.Validate(); err != nil {
+		return err
+	}
+	return b.validateTitleFilters()
+}
@@ -0,0 +1,238 @@
+package domain
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Supported speech-to-text backends, as they appear in configuration.
+const (
+	// TranscriberOpenAIWhisper runs the openai-whisper Python CLI.
+	TranscriberOpenAIWhisper = "openai-whisper"
+	// TranscriberWhisperCpp runs whisper.cpp's whisper-cli.
+	TranscriberWhisperCpp = "whisper.cpp"
+	// TranscriberFasterWhisper runs whisper-ctranslate2, the faster-whisper
+	// CLI that accepts openai-whisper's flags.
+	TranscriberFasterWhisper = "faster-whisper"
+	// TranscriberHTTP posts audio to an OpenAI-compatible
+	// POST /v1/audio/transcriptions endpoint.
+	TranscriberHTTP = "http"
+)
+
+// TranscriberBackends returns the supported backend identifiers, for
+// validation messages and help text.
+func TranscriberBackends() []string {
+	return []string{TranscriberOpenAIWhisper, TranscriberWhisperCpp, TranscriberFasterWhisper, TranscriberHTTP}
+}
+
+// defaultTranscriberBinaries are the CLI each backend runs when no binary is
+// configured. The HTTP backend runs none.
+var defaultTranscriberBinaries = map[string]string{
+	TranscriberOpenAIWhisper: "whisper",
+	TranscriberWhisperCpp:    "whisper-cli",
+	TranscriberFasterWhisper: "whisper-ctranslate2",
+}
+
+// Defaults for the transcriber block.
+const (
+	defaultTranscriberModel          = "medium"
+	defaultTranscriberHTTPModel      = "whisper-1"
+	defaultTranscriberBaseURL        = "https://api.openai.com/v1"
+	defaultTranscriberLanguage       = "en"
+	defaultTranscriberTimeoutSeconds = 1800
+	// defaultTranscriberMaxUploadMB is OpenAI's limit on one upload to
+	// /v1/audio/transcriptions.
+	defaultTranscriberMaxUploadMB = 25
+)
+
+// TranscriberConfig is the resolved speech-to-text configuration for one body:
+// the global transcriber block with any per-body override already applied.
+type TranscriberConfig struct {
+	// Backend selects the transcriber. Empty disables transcription, so a
+	// meeting without captions fails. See TranscriberBackends.
+	Backend string `yaml:"backend" mapstructure:"backend"`
+	// Binary is the CLI to run. Defaults to the backend's usual command.
+	Binary string `yaml:"binary" mapstructure:"binary"`
+	// Model is a model name for openai-whisper, faster-whisper, and HTTP
+	// endpoints, and the path of a ggml model file for whisper.cpp.
+	Model string `yaml:"model" mapstructure:"model"`
+	// Language is the spoken language's code. Defaults to "en".
+	Language string `yaml:"language" mapstructure:"language"`
+	// Threads caps the CPU threads a CLI backend uses. Zero leaves it to the
+	// backend.
+	Threads int `yaml:"threads" mapstructure:"threads"`
+	// Vocabulary lists names and terms the recording is likely to contain,
+	// such as council members and street names. They are passed to the
+	// model as its initial prompt, which biases it toward those spellings.
+	Vocabulary []string `yaml:"vocabulary" mapstructure:"vocabulary"`
+	// BaseURL is the HTTP backend's API root, up to but not including
+	// /audio/transcriptions. Defaults to OpenAI's.
+	BaseURL string `yaml:"base_url" mapstructure:"base_url"`
+	// APIKeyEnv names the environment variable holding the HTTP backend's API
+	// key. Empty sends no key, as most local servers expect.
+	APIKeyEnv string `yaml:"api_key_env" mapstructure:"api_key_env"`
+	// TimeoutSeconds bounds one HTTP transcription request.
+	TimeoutSeconds int `yaml:"timeout_seconds" mapstructure:"timeout_seconds"`
+	// MaxUploadMB is the largest audio file, in megabytes, the HTTP backend
+	// uploads. Larger files are refused before the upload starts rather than
+	// rejected by the server after it. Defaults to 25, OpenAI's limit.
+	MaxUploadMB int `yaml:"max_upload_mb" mapstructure:"max_upload_mb"`
+}
+
+// Enabled reports whether a backend is configured.
+func (c TranscriberConfig) Enabled() bool {
+	return c.Backend != ""
+}
+
+// WithDefaults returns the config with the backend's default binary, model,
+// language, endpoint, and timeout filled in where unset.
+func (c TranscriberConfig) WithDefaults() TranscriberConfig {
+	if !c.Enabled() {
+		return c
+	}
+	if c.Binary == "" {
+		c.Binary = defaultTranscriberBinaries[c.Backend]
+	}
+	if c.Model == "" {
+		switch c.Backend {
+		case TranscriberOpenAIWhisper, TranscriberFasterWhisper:
+			c.Model = defaultTranscriberModel
+		case TranscriberHTTP:
+			c.Model = defaultTranscriberHTTPModel
+		}
+	}
+	if c.Language == "" {
+		c.Language = defaultTranscriberLanguage
+	}
+	if c.Backend == TranscriberHTTP {
+		if c.BaseURL == "" {
+			c.BaseURL = defaultTranscriberBaseURL
+		}
+		if c.TimeoutSeconds == 0 {
+			c.TimeoutSeconds = defaultTranscriberTimeoutSeconds
+		}
+		if c.MaxUploadMB == 0 {
+			c.MaxUploadMB = defaultTranscriberMaxUploadMB
+		}
+	}
+	return c
+}
+
+// maxPromptLength caps the initial prompt. Whisper keeps only the last 224
+// tokens of a prompt, roughly this many characters, so terms past it would be
+// silently dropped from the front.
+const maxPromptLength = 800
+
+// Prompt returns the vocabulary as an initial prompt, or "" when there is none.
+// Terms that would take the prompt past Whisper's limit are left out.
+func (c TranscriberConfig) Prompt() string {
+	var b strings.Builder
+	for _, term := range c.Vocabulary {
+		sep := ""
+		if b.Len() > 0 {
+			sep = ", "
+		}
+		if b.Len()+len(sep)+len(term) > maxPromptLength {
+			break
+		}
+		b.WriteString(sep)
+		b.WriteString(term)
+	}
+	return b.String()
+}
+
+// Timeout returns TimeoutSeconds as a duration. A non-positive value means no
+// client-side timeout.
+func (c TranscriberConfig) Timeout() time.Duration {
+	if c.TimeoutSeconds <= 0 {
+		return 0
+	}
+	return time.Duration(c.TimeoutSeconds) * time.Second
+}
+
+// MaxUploadBytes returns MaxUploadMB in bytes for the HTTP backend, and zero,
+// meaning no limit, for the CLI backends, which read the file in place.
+func (c TranscriberConfig) MaxUploadBytes() int64 {
+	if c.Backend != TranscriberHTTP || c.MaxUploadMB <= 0 {
+		return 0
+	}
+	return int64(c.MaxUploadMB) << 20
+}
+
+// Describe returns a short "backend/model" label for logs and status output.
+func (c TranscriberConfig) Describe() string {
+	if !c.Enabled() {
+		return "none"
+	}
+	return c.Backend + "/" + c.Model
+}
+
+// Validate checks a resolved config. A disabled config is valid.
+func (c TranscriberConfig) Validate() error {
+	if !c.Enabled() {
+		return nil
+	}
+	if !slices.Contains(TranscriberBackends(), c.Backend) {
+		return fmt.Errorf("transcriber.backend: unknown backend %q; supported: %v", c.Backend, TranscriberBackends())
+	}
+	if c.Model == "" && c.Backend == TranscriberWhisperCpp {
+		return fmt.Errorf("transcriber.model is required for whisper.cpp: the path of a ggml model file")
+	}
+	if c.Model == "" {
+		return fmt.Errorf("transcriber.model is required")
+	}
+	if c.Threads < 0 {
+		return fmt.Errorf("transcriber.threads must not be negative, got %d", c.Threads)
+	}
+	if c.TimeoutSeconds < 0 {
+		return fmt.Errorf("transcriber.timeout_seconds must not be negative, got %d", c.TimeoutSeconds)
+	}
+	if c.MaxUploadMB < 0 {
+		return fmt.Errorf("transcriber.max_upload_mb must not be negative, got %d", c.MaxUploadMB)
+	}
+	return nil
+}
+
+// TranscriberOverride is a per-body override of the global transcriber block.
+// As with LLMOverride, every field is a pointer so that omitting one inherits
+// the global value.
+type TranscriberOverride struct {
+	Backend        *string   `yaml:"backend" mapstructure:"backend"`
+	Binary         *string   `yaml:"binary" mapstructure:"binary"`
+	Model          *string   `yaml:"model" mapstructure:"model"`
+	Language       *string   `yaml:"language" mapstructure:"language"`
+	Threads        *int      `yaml:"threads" mapstructure:"threads"`
+	Vocabulary     *[]string `yaml:"vocabulary" mapstructure:"vocabulary"`
+	BaseURL        *string   `yaml:"base_url" mapstructure:"base_url"`
+	APIKeyEnv      *string   `yaml:"api_key_env" mapstructure:"api_key_env"`
+	TimeoutSeconds *int      `yaml:"timeout_seconds" mapstructure:"timeout_seconds"`
+	MaxUploadMB    *int      `yaml:"max_upload_mb" mapstructure:"max_upload_mb"`
+}
+
+// Apply returns base with every field set on o overriding it. Switching
+// backend drops the base's binary and model, which belong to the old one,
+// unless the override sets them too.
+func (o *TranscriberOverride) Apply(base TranscriberConfig) TranscriberConfig {
+	if o == nil {
+		return base
+	}
+
+	merged := base
+	if o.Backend != nil && *o.Backend != base.Backend {
+		merged.Binary = ""
+		merged.Model = ""
+	}
+	override(&merged.Backend, o.Backend)
+	override(&merged.Binary, o.Binary)
+	override(&merged.Model, o.Model)
+	override(&merged.Language, o.Language)
+	override(&merged.Threads, o.Threads)
+	override(&merged.Vocabulary, o.Vocabulary)
+	override(&merged.BaseURL, o.BaseURL)
+	override(&merged.APIKeyEnv, o.APIKeyEnv)
+	override(&merged.TimeoutSeconds, o.TimeoutSeconds)
+	override(&merged.MaxUploadMB, o.MaxUploadMB)
+	return merged
+}
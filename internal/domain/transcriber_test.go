@@ -0,0 +1,101 @@
+package domain_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/AvogadroSG1/civic-summary/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTranscriberConfig_WithDefaults(t *testing.T) {
+	tests := []struct {
+		backend    string
+		wantBinary string
+		wantModel  string
+	}{
+		{domain.TranscriberOpenAIWhisper, "whisper", "medium"},
+		{domain.TranscriberFasterWhisper, "whisper-ctranslate2", "medium"},
+		{domain.TranscriberWhisperCpp, "whisper-cli", ""},
+		{domain.TranscriberHTTP, "", "whisper-1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.backend, func(t *testing.T) {
+			cfg := domain.TranscriberConfig{Backend: tt.backend}.WithDefaults()
+			assert.Equal(t, tt.wantBinary, cfg.Binary)
+			assert.Equal(t, tt.wantModel, cfg.Model)
+			assert.Equal(t, "en", cfg.Language)
+		})
+	}
+
+	http := domain.TranscriberConfig{Backend: domain.TranscriberHTTP}.WithDefaults()
+	assert.Equal(t, "https://api.openai.com/v1", http.BaseURL)
+	assert.Positive(t, http.Timeout())
+	assert.Equal(t, int64(25<<20), http.MaxUploadBytes())
+	assert.Zero(t, domain.TranscriberConfig{Backend: domain.TranscriberWhisperCpp, MaxUploadMB: 25}.MaxUploadBytes(), "CLI backends upload nothing")
+
+	assert.Equal(t, domain.TranscriberConfig{}, domain.TranscriberConfig{}.WithDefaults(), "disabled stays empty")
+	assert.Equal(t, "none", domain.TranscriberConfig{}.Describe())
+}
+
+func TestTranscriberConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     domain.TranscriberConfig
+		wantErr string
+	}{
+		{"disabled", domain.TranscriberConfig{}, ""},
+		{"openai-whisper", domain.TranscriberConfig{Backend: domain.TranscriberOpenAIWhisper, Model: "small"}, ""},
+		{"unknown backend", domain.TranscriberConfig{Backend: "vosk", Model: "small"}, `unknown backend "vosk"`},
+		{"whisper.cpp without model", domain.TranscriberConfig{Backend: domain.TranscriberWhisperCpp}, "ggml model file"},
+		{"negative upload limit", domain.TranscriberConfig{Backend: domain.TranscriberHTTP, Model: "whisper-1", MaxUploadMB: -1}, "max_upload_mb must not be negative"},
+		{"negative threads", domain.TranscriberConfig{Backend: domain.TranscriberOpenAIWhisper, Model: "small", Threads: -1}, "threads must not be negative"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestTranscriberOverride_Apply(t *testing.T) {
+	base := domain.TranscriberConfig{
+		Backend:    domain.TranscriberWhisperCpp,
+		Binary:     "/opt/whisper-cli",
+		Model:      "/opt/ggml-medium.en.bin",
+		Threads:    4,
+		Vocabulary: []string{"Hagerstown"},
+	}
+
+	var none *domain.TranscriberOverride
+	assert.Equal(t, base, none.Apply(base))
+
+	language := "es"
+	vocabulary := []string{"Keller"}
+	merged := (&domain.TranscriberOverride{Language: &language, Vocabulary: &vocabulary}).Apply(base)
+	assert.Equal(t, "es", merged.Language)
+	assert.Equal(t, []string{"Keller"}, merged.Vocabulary)
+	assert.Equal(t, "/opt/ggml-medium.en.bin", merged.Model, "unset fields are inherited")
+	assert.Equal(t, 4, merged.Threads)
+
+	backend := domain.TranscriberHTTP
+	switched := (&domain.TranscriberOverride{Backend: &backend}).Apply(base).WithDefaults()
+	assert.Equal(t, "whisper-1", switched.Model, "another backend does not inherit the model")
+	assert.Empty(t, switched.Binary)
+	assert.Equal(t, 4, switched.Threads)
+}
+
+func TestTranscriberConfig_Prompt(t *testing.T) {
+	assert.Empty(t, domain.TranscriberConfig{}.Prompt())
+	assert.Equal(t, "Hagerstown, Antietam", domain.TranscriberConfig{Vocabulary: []string{"Hagerstown", "Antietam"}}.Prompt())
+
+	long := domain.TranscriberConfig{Vocabulary: []string{strings.Repeat("a", 790), "Hagerstown", "Keller"}}
+	assert.Equal(t, strings.Repeat("a", 790), long.Prompt(), "terms past the limit are left out")
+}
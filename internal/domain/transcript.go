@@ -1,6 +1,15 @@
 package domain
 
-import "strings"
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
 
 // TranscriptSource indicates how the transcript was obtained.
 type TranscriptSource string
@@ -10,22 +19,391 @@ const (
 	TranscriptSourceWhisper  TranscriptSource = "whisper"
 )
 
-// Transcript is a value object containing the SRT-format text for a meeting.
+// TranscriptFormat identifies the file format a transcript was parsed from.
+type TranscriptFormat string
+
+const (
+	TranscriptFormatSRT         TranscriptFormat = "srt"
+	TranscriptFormatVTT         TranscriptFormat = "vtt"
+	TranscriptFormatWhisperJSON TranscriptFormat = "whisper-json"
+	// TranscriptFormatText is untimed plain text, one cue per line.
+	TranscriptFormatText TranscriptFormat = "text"
+)
+
+var (
+	// cueTimingPattern matches an SRT or WebVTT timing line. Hours are optional
+	// in WebVTT, and the millisecond separator is "," in SRT and "." in WebVTT.
+	cueTimingPattern = regexp.MustCompile(`^((?:\d+:)?\d{1,2}:\d{2}[,.]\d{1,3})\s*-->\s*((?:\d+:)?\d{1,2}:\d{2}[,.]\d{1,3})`)
+	cueTagPattern    = regexp.MustCompile(`<[^>]*>`)
+)
+
+// Cue is one timed caption. Start and End are offsets from the start of the
+// video; both are zero for untimed plain-text cues.
+type Cue struct {
+	Index int
+	Start time.Duration
+	End   time.Duration
+	Text  string
+	// Speaker labels who is speaking once the transcript has been diarized,
+	// e.g. "Mayor Keith Bruchey" or "Speaker 3". It is empty otherwise.
+	Speaker string
+}
+
+// HasTiming reports whether the cue carries a time span.
+func (c Cue) HasTiming() bool {
+	return c.End > 0
+}
+
+// Compact renders the cue as a single "[HH:MM:SS] text" prompt line, or just
+// the text when the cue is untimed. A diarized cue's text is prefixed with
+// its speaker: "[HH:MM:SS] Speaker: text".
+func (c Cue) Compact() string {
+	text := strings.Join(strings.Fields(c.Text), " ")
+	if c.Speaker != "" {
+		text = c.Speaker + ": " + text
+	}
+	if !c.HasTiming() {
+		return text
+	}
+	return "[" + FormatTimestamp(c.Start) + "] " + text
+}
+
+// Transcript is a parsed meeting transcript. Path is the file it was read
+// from; Cues are what downstream stages reason about.
 type Transcript struct {
-	Content string
-	Path    string
-	Source  TranscriptSource
+	Cues   []Cue
+	Path   string
+	Source TranscriptSource
+	Format TranscriptFormat
+}
+
+// ParseTranscript parses transcript file content. The format is detected from
+// the path's extension and the content itself; see DetectTranscriptFormat.
+func ParseTranscript(content, path string, source TranscriptSource) (Transcript, error) {
+	format := DetectTranscriptFormat(path, content)
+
+	var cues []Cue
+	switch format {
+	case TranscriptFormatWhisperJSON:
+		var err error
+		if cues, err = ParseWhisperJSON(content); err != nil {
+			return Transcript{}, err
+		}
+	case TranscriptFormatVTT:
+		cues = ParseVTT(content)
+	case TranscriptFormatSRT:
+		cues = ParseSRT(content)
+	default:
+		cues = ParsePlainText(content)
+	}
+
+	return Transcript{Cues: cues, Path: path, Source: source, Format: format}, nil
+}
+
+// DetectTranscriptFormat picks a parser for content. A .json file is whisper
+// JSON; otherwise the content decides, because caption downloads are not
+// always what their extension claims: a WEBVTT header means WebVTT, timing
+// lines mean SRT, and anything else is plain text.
+func DetectTranscriptFormat(path, content string) TranscriptFormat {
+	trimmed := strings.TrimSpace(strings.TrimPrefix(content, "\ufeff"))
+	switch {
+	case strings.EqualFold(filepath.Ext(path), ".json"), strings.HasPrefix(trimmed, "{"):
+		return TranscriptFormatWhisperJSON
+	case strings.HasPrefix(trimmed, "WEBVTT"):
+		return TranscriptFormatVTT
+	case strings.Contains(trimmed, "-->"):
+		return TranscriptFormatSRT
+	default:
+		return TranscriptFormatText
+	}
+}
+
+// ParseSRT parses SubRip content. Malformed blocks are skipped rather than
+// failing the whole transcript.
+func ParseSRT(content string) []Cue {
+	return parseCueBlocks(content)
+}
+
+// ParseVTT parses WebVTT content. The header, NOTE, STYLE, and REGION blocks
+// carry no timing line and are skipped; cue settings and inline tags such as
+// <c> and <00:00:01.000> are dropped.
+func ParseVTT(content string) []Cue {
+	return parseCueBlocks(content)
+}
+
+// parseCueBlocks parses the blank-line separated blocks shared by SRT and
+// WebVTT: an optional identifier, a timing line, then one or more text lines.
+func parseCueBlocks(content string) []Cue {
+	content = strings.ReplaceAll(strings.TrimPrefix(content, "\ufeff"), "\r\n", "\n")
+
+	var cues []Cue
+	for _, block := range strings.Split(content, "\n\n") {
+		lines := strings.Split(strings.TrimSpace(block), "\n")
+		timing := -1
+		for i, line := range lines {
+			if cueTimingPattern.MatchString(strings.TrimSpace(line)) {
+				timing = i
+				break
+			}
+		}
+		if timing < 0 {
+			continue
+		}
+
+		m := cueTimingPattern.FindStringSubmatch(strings.TrimSpace(lines[timing]))
+		start, err1 := parseCueTime(m[1])
+		end, err2 := parseCueTime(m[2])
+		text := cleanCueText(lines[timing+1:])
+		if err1 != nil || err2 != nil || text == "" {
+			continue
+		}
+
+		index := len(cues) + 1
+		if timing > 0 {
+			if n, err := strconv.Atoi(strings.TrimSpace(lines[timing-1])); err == nil {
+				index = n
+			}
+		}
+		cues = append(cues, Cue{Index: index, Start: start, End: end, Text: text})
+	}
+	return cues
+}
+
+// parseCueTime parses [HH:]MM:SS,mmm or [HH:]MM:SS.mmm.
+func parseCueTime(s string) (time.Duration, error) {
+	s = strings.Replace(s, ",", ".", 1)
+	parts := strings.Split(s, ":")
+	if len(parts) == 2 {
+		parts = append([]string{"0"}, parts...)
+	}
+	if len(parts) != 3 {
+		return 0, fmt.Errorf("invalid cue time %q", s)
+	}
+	hours, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, fmt.Errorf("invalid cue time %q", s)
+	}
+	minutes, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return 0, fmt.Errorf("invalid cue time %q", s)
+	}
+	seconds, err := strconv.ParseFloat(parts[2], 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid cue time %q", s)
+	}
+	return time.Duration(hours)*time.Hour +
+		time.Duration(minutes)*time.Minute +
+		time.Duration(seconds*float64(time.Second)).Round(time.Millisecond), nil
+}
+
+// cleanCueText strips markup and entities from cue lines, keeping one line per
+// caption row so rolling captions can be merged row by row.
+func cleanCueText(lines []string) string {
+	var rows []string
+	for _, line := range lines {
+		line = html.UnescapeString(cueTagPattern.ReplaceAllString(line, ""))
+		if line = strings.Join(strings.Fields(line), " "); line != "" {
+			rows = append(rows, line)
+		}
+	}
+	return strings.Join(rows, "\n")
+}
+
+// whisperJSON covers both whisper JSON layouts: openai-whisper's "segments"
+// with float seconds, and whisper.cpp's "transcription" with millisecond
+// offsets.
+type whisperJSON struct {
+	Segments []struct {
+		Start float64 `json:"start"`
+		End   float64 `json:"end"`
+		Text  string  `json:"text"`
+	} `json:"segments"`
+	Transcription []struct {
+		Offsets struct {
+			From int64 `json:"from"`
+			To   int64 `json:"to"`
+		} `json:"offsets"`
+		Text string `json:"text"`
+	} `json:"transcription"`
+}
+
+// ParseWhisperJSON parses the JSON output of openai-whisper or whisper.cpp.
+func ParseWhisperJSON(content string) ([]Cue, error) {
+	var doc whisperJSON
+	if err := json.Unmarshal([]byte(content), &doc); err != nil {
+		return nil, fmt.Errorf("parsing whisper json: %w", err)
+	}
+
+	var cues []Cue
+	add := func(start, end time.Duration, text string) {
+		if text = cleanCueText([]string{text}); text != "" {
+			cues = append(cues, Cue{Index: len(cues) + 1, Start: start, End: end, Text: text})
+		}
+	}
+	switch {
+	case doc.Segments != nil:
+		for _, s := range doc.Segments {
+			add(secondsToDuration(s.Start), secondsToDuration(s.End), s.Text)
+		}
+	case doc.Transcription != nil:
+		for _, s := range doc.Transcription {
+			add(time.Duration(s.Offsets.From)*time.Millisecond, time.Duration(s.Offsets.To)*time.Millisecond, s.Text)
+		}
+	default:
+		return nil, fmt.Errorf("parsing whisper json: no segments or transcription array")
+	}
+	return cues, nil
+}
+
+func secondsToDuration(s float64) time.Duration {
+	return time.Duration(s * float64(time.Second)).Round(time.Millisecond)
+}
+
+// ParsePlainText treats each non-empty line as an untimed cue.
+func ParsePlainText(content string) []Cue {
+	var cues []Cue
+	for _, line := range strings.Split(content, "\n") {
+		if text := cleanCueText([]string{line}); text != "" {
+			cues = append(cues, Cue{Index: len(cues) + 1, Text: text})
+		}
+	}
+	return cues
+}
+
+// MergedCues returns the cues with rolling caption repetition removed.
+// Auto-generated captions show each row twice, once at the bottom and again
+// at the top of the next cue, and grow a row word by word across cues; only
+// the new words of each cue are kept. A cue that adds nothing extends the
+// previous cue's end time instead. Other transcripts, such as Whisper's, are
+// returned as they are, so a line said twice in a row, like a roll call's
+// "Aye.", is kept both times.
+func (t Transcript) MergedCues() []Cue {
+	if !t.rolling() {
+		return t.Cues
+	}
+	var merged []Cue
+	var prevRows []string
+	for _, cue := range t.Cues {
+		rows := strings.Split(cue.Text, "\n")
+		fresh := newRows(prevRows, rows)
+		prevRows = rows
+
+		if len(fresh) == 0 {
+			if n := len(merged); n > 0 && cue.End > merged[n-1].End {
+				merged[n-1].End = cue.End
+			}
+			continue
+		}
+		cue.Text = strings.Join(fresh, " ")
+		cue.Index = len(merged) + 1
+		merged = append(merged, cue)
+	}
+	return merged
+}
+
+// rolling reports whether the cues are rolling captions: most cues with more
+// than one row begin with the row the cue before ended with.
+func (t Transcript) rolling() bool {
+	multi, carried := 0, 0
+	for i, cue := range t.Cues {
+		rows := strings.Split(cue.Text, "\n")
+		if len(rows) < 2 {
+			continue
+		}
+		multi++
+		if i > 0 {
+			prev := strings.Split(t.Cues[i-1].Text, "\n")
+			if prev[len(prev)-1] == rows[0] {
+				carried++
+			}
+		}
+	}
+	return carried > 0 && carried*2 >= multi
+}
+
+// newRows returns the rows of cur not already shown by prev: rows repeated
+// from the end of prev are dropped, and a row that extends prev's last row
+// keeps only its added words.
+func newRows(prev, cur []string) []string {
+	for k := min(len(prev), len(cur)); k > 0; k-- {
+		if equalRows(prev[len(prev)-k:], cur[:k]) {
+			return cur[k:]
+		}
+	}
+	if len(prev) > 0 && len(cur) > 0 {
+		last := prev[len(prev)-1]
+		if rest, ok := strings.CutPrefix(cur[0], last+" "); ok {
+			return append([]string{rest}, cur[1:]...)
+		}
+	}
+	return cur
+}
+
+func equalRows(a, b []string) bool {
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// Compact renders the merged cues as "[HH:MM:SS] text" lines, the prompt
+// input for analysis. It is far smaller than the SRT it came from.
+func (t Transcript) Compact() string {
+	cues := t.MergedCues()
+	lines := make([]string, len(cues))
+	for i, c := range cues {
+		lines[i] = c.Compact()
+	}
+	return strings.Join(lines, "\n")
 }
 
-// WordCount returns the number of words in the transcript content.
+// WordCount returns the number of spoken words: cue text only, without cue
+// numbers or timings, and with rolling caption repetition merged away.
 func (t Transcript) WordCount() int {
-	if t.Content == "" {
-		return 0
+	count := 0
+	for _, c := range t.MergedCues() {
+		count += len(strings.Fields(c.Text))
 	}
-	return len(strings.Fields(t.Content))
+	return count
 }
 
-// IsEmpty returns true if the transcript has no content.
+// Duration returns the end of the last timed cue, or 0 for an untimed
+// transcript.
+func (t Transcript) Duration() time.Duration {
+	var end time.Duration
+	for _, c := range t.Cues {
+		end = max(end, c.End)
+	}
+	return end
+}
+
+// IsEmpty returns true if the transcript has no cue text.
 func (t Transcript) IsEmpty() bool {
-	return strings.TrimSpace(t.Content) == ""
+	return len(t.Cues) == 0
+}
+
+// SRT renders the transcript's cues as SubRip.
+func (t Transcript) SRT() string {
+	var b strings.Builder
+	for i, c := range t.Cues {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		fmt.Fprintf(&b, "%d\n%s --> %s\n%s\n", c.Index, formatSRTTime(c.Start), formatSRTTime(c.End), c.Text)
+	}
+	return b.String()
+}
+
+// formatSRTTime renders an offset as SubRip's HH:MM:SS,mmm.
+func formatSRTTime(d time.Duration) string {
+	ms := int(d / time.Millisecond)
+	return fmt.Sprintf("%s,%03d", FormatTimestamp(d), ms%1000)
+}
+
+// FormatTimestamp renders an offset as HH:MM:SS.
+func FormatTimestamp(d time.Duration) string {
+	s := int(d / time.Second)
+	return fmt.Sprintf("%02d:%02d:%02d", s/3600, s/60%60, s%60)
 }
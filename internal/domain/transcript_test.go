@@ -1,35 +1,225 @@
 package domain_test
 
 import (
+	"os"
+	"path/filepath"
+	"runtime"
 	"testing"
+	"time"
 
 	"github.com/AvogadroSG1/civic-summary/internal/domain"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
-func TestTranscript_WordCount(t *testing.T) {
+func TestParseSRT(t *testing.T) {
+	cues := domain.ParseSRT("1\n00:00:01,000 --> 00:00:05,500\nThe meeting will\ncome to order.\n\n" +
+		"2\n01:02:03,250 --> 01:02:04,000\n<font color=\"#fff\">Motion carries &amp; passes.</font>\n")
+
+	require.Len(t, cues, 2)
+	assert.Equal(t, domain.Cue{Index: 1, Start: time.Second, End: 5500 * time.Millisecond, Text: "The meeting will\ncome to order."}, cues[0])
+	assert.Equal(t, 2, cues[1].Index)
+	assert.Equal(t, time.Hour+2*time.Minute+3250*time.Millisecond, cues[1].Start)
+	assert.Equal(t, "Motion carries & passes.", cues[1].Text)
+}
+
+func TestParseSRT_CRLFAndMalformedBlocks(t *testing.T) {
+	cues := domain.ParseSRT("\ufeff1\r\n00:00:01,000 --> 00:00:02,000\r\nHello.\r\n\r\n" +
+		"garbage block\r\n\r\n" +
+		"3\r\n00:00:03,000 --> 00:00:04,000\r\n\r\n" +
+		"4\r\n00:00:05,000 --> 00:00:06,000\r\nGoodbye.\r\n")
+
+	require.Len(t, cues, 2, "blocks without timing or text are skipped")
+	assert.Equal(t, "Hello.", cues[0].Text)
+	assert.Equal(t, 4, cues[1].Index)
+}
+
+func TestParseVTT(t *testing.T) {
+	cues := domain.ParseVTT("WEBVTT\nKind: captions\nLanguage: en\n\n" +
+		"NOTE produced by the test\n\n" +
+		"00:01.000 --> 00:04.000 align:start position:0%\nThe<00:00:01.500><c> meeting</c><00:00:02.000><c> will</c>\n\n" +
+		"intro\n00:00:04.000 --> 00:00:06.000\ncome to order.\n")
+
+	require.Len(t, cues, 2)
+	assert.Equal(t, domain.Cue{Index: 1, Start: time.Second, End: 4 * time.Second, Text: "The meeting will"}, cues[0])
+	assert.Equal(t, 2, cues[1].Index, "non-numeric cue identifiers are numbered sequentially")
+	assert.Equal(t, "come to order.", cues[1].Text)
+}
+
+func TestParseWhisperJSON(t *testing.T) {
+	t.Run("openai-whisper segments", func(t *testing.T) {
+		cues, err := domain.ParseWhisperJSON(`{"text":"ignored","segments":[
+			{"id":0,"start":0.0,"end":2.5,"text":" Call to order."},
+			{"id":1,"start":2.5,"end":4.25,"text":" "},
+			{"id":2,"start":4.25,"end":7.0,"text":" Roll call."}]}`)
+		require.NoError(t, err)
+		require.Len(t, cues, 2)
+		assert.Equal(t, domain.Cue{Index: 1, Start: 0, End: 2500 * time.Millisecond, Text: "Call to order."}, cues[0])
+		assert.Equal(t, domain.Cue{Index: 2, Start: 4250 * time.Millisecond, End: 7 * time.Second, Text: "Roll call."}, cues[1])
+	})
+
+	t.Run("whisper.cpp transcription", func(t *testing.T) {
+		cues, err := domain.ParseWhisperJSON(`{"transcription":[
+			{"timestamps":{"from":"00:00:00,000","to":"00:00:03,000"},"offsets":{"from":0,"to":3000},"text":" Call to order."}]}`)
+		require.NoError(t, err)
+		require.Len(t, cues, 1)
+		assert.Equal(t, 3*time.Second, cues[0].End)
+	})
+
+	t.Run("malformed", func(t *testing.T) {
+		_, err := domain.ParseWhisperJSON(`{"segments":`)
+		assert.ErrorContains(t, err, "parsing whisper json")
+	})
+
+	t.Run("no segments", func(t *testing.T) {
+		_, err := domain.ParseWhisperJSON(`{"text":"hello"}`)
+		assert.ErrorContains(t, err, "no segments")
+	})
+}
+
+func TestDetectTranscriptFormat(t *testing.T) {
 	tests := []struct {
 		name    string
+		path    string
 		content string
-		want    int
+		want    domain.TranscriptFormat
 	}{
-		{"empty", "", 0},
-		{"one word", "hello", 1},
-		{"multiple words", "hello world this is a test", 6},
-		{"with newlines", "hello\nworld\ntest", 3},
+		{"srt", "a.srt", "1\n00:00:01,000 --> 00:00:02,000\nHi\n", domain.TranscriptFormatSRT},
+		{"vtt by header", "a.en.srt", "WEBVTT\n\n00:01.000 --> 00:02.000\nHi\n", domain.TranscriptFormatVTT},
+		{"json by extension", "a.json", `{"segments":[]}`, domain.TranscriptFormatWhisperJSON},
+		{"json by content", "a.txt", `{"segments":[]}`, domain.TranscriptFormatWhisperJSON},
+		{"plain text", "a.srt", "just some words", domain.TranscriptFormatText},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, domain.DetectTranscriptFormat(tt.path, tt.content))
+		})
+	}
+}
+
+func TestParseTranscript(t *testing.T) {
+	tr, err := domain.ParseTranscript("WEBVTT\n\n00:01.000 --> 00:02.000\nHi\n", "/tmp/x.vtt", domain.TranscriptSourceCaptions)
+	require.NoError(t, err)
+
+	assert.Equal(t, domain.TranscriptFormatVTT, tr.Format)
+	assert.Equal(t, "/tmp/x.vtt", tr.Path)
+	assert.Equal(t, domain.TranscriptSourceCaptions, tr.Source)
+	require.Len(t, tr.Cues, 1)
+
+	_, err = domain.ParseTranscript("{", "/tmp/x.json", domain.TranscriptSourceWhisper)
+	assert.Error(t, err)
+}
+
+func TestParseTranscript_SampleFixture(t *testing.T) {
+	_, file, _, _ := runtime.Caller(0)
+	path := filepath.Join(filepath.Dir(file), "..", "..", "testdata", "fixtures", "sample.srt")
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	tr, err := domain.ParseTranscript(string(content), path, domain.TranscriptSourceCaptions)
+	require.NoError(t, err)
+
+	require.NotEmpty(t, tr.Cues)
+	assert.Equal(t, "[00:00:00] Good evening everyone, welcome to the regular session of the Hagerstown City Council.",
+		tr.Cues[0].Compact())
+}
+
+// rollingCaptions mimics YouTube auto-captions: each row appears at the
+// bottom of one cue and again at the top of the next, and rows grow word by
+// word across short cues.
+func rollingCaptions() domain.Transcript {
+	return domain.Transcript{Cues: []domain.Cue{
+		{Index: 1, Start: 0, End: 2 * time.Second, Text: "good evening"},
+		{Index: 2, Start: 2 * time.Second, End: 2010 * time.Millisecond, Text: "good evening"},
+		{Index: 3, Start: 2010 * time.Millisecond, End: 4 * time.Second, Text: "good evening\nand welcome to"},
+		{Index: 4, Start: 4 * time.Second, End: 6 * time.Second, Text: "and welcome to\nthe council meeting"},
+		{Index: 5, Start: 6 * time.Second, End: 8 * time.Second, Text: "the council meeting tonight"},
+		{Index: 6, Start: 8 * time.Second, End: 10 * time.Second, Text: "roll call please"},
+	}}
+}
+
+func TestTranscript_MergedCues(t *testing.T) {
+	merged := rollingCaptions().MergedCues()
+
+	require.Len(t, merged, 5)
+	assert.Equal(t, domain.Cue{Index: 1, Start: 0, End: 2010 * time.Millisecond, Text: "good evening"}, merged[0],
+		"a repeated cue extends the previous cue instead of repeating its text")
+	assert.Equal(t, "and welcome to", merged[1].Text)
+	assert.Equal(t, "the council meeting", merged[2].Text)
+	assert.Equal(t, "tonight", merged[3].Text, "a grown row keeps only its new words")
+	assert.Equal(t, "roll call please", merged[4].Text)
+	assert.Equal(t, 5, merged[4].Index)
+}
+
+func TestTranscript_MergedCues_KeepsRepeatsOutsideRollingCaptions(t *testing.T) {
+	rollCall := domain.Transcript{Cues: []domain.Cue{
+		{Index: 1, Start: 0, End: time.Second, Text: "Aye."},
+		{Index: 2, Start: time.Second, End: 2 * time.Second, Text: "Aye."},
+		{Index: 3, Start: 2 * time.Second, End: 3 * time.Second, Text: "Aye."},
+		{Index: 4, Start: 3 * time.Second, End: 4 * time.Second, Text: "Aye. Motion carries."},
+	}}
+
+	merged := rollCall.MergedCues()
+
+	require.Len(t, merged, 4, "single-row cues are not rolling captions")
+	assert.Equal(t, "Aye. Motion carries.", merged[3].Text)
+	assert.Equal(t, 6, rollCall.WordCount())
+}
+
+func TestTranscript_Compact(t *testing.T) {
+	assert.Equal(t,
+		"[00:00:00] good evening\n[00:00:02] and welcome to\n[00:00:04] the council meeting\n[00:00:06] tonight\n[00:00:08] roll call please",
+		rollingCaptions().Compact())
+
+	untimed := domain.Transcript{Cues: domain.ParsePlainText("first line\n\nsecond line\n")}
+	assert.Equal(t, "first line\nsecond line", untimed.Compact())
+}
+
+func TestTranscript_WordCount(t *testing.T) {
+	tests := []struct {
+		name       string
+		transcript domain.Transcript
+		want       int
+	}{
+		{"empty", domain.Transcript{}, 0},
+		{"plain text", domain.Transcript{Cues: domain.ParsePlainText("hello world this is a test")}, 6},
+		{"with newlines", domain.Transcript{Cues: domain.ParsePlainText("hello\nworld\ntest")}, 3},
+		{"srt excludes numbers and timings", domain.Transcript{Cues: domain.ParseSRT("1\n00:00:01,000 --> 00:00:02,000\nhello world\n")}, 2},
+		{"rolling captions counted once", rollingCaptions(), 12},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			tr := domain.Transcript{Content: tt.content}
-			assert.Equal(t, tt.want, tr.WordCount())
+			assert.Equal(t, tt.want, tt.transcript.WordCount())
 		})
 	}
 }
 
+func TestTranscript_Duration(t *testing.T) {
+	assert.Equal(t, 10*time.Second, rollingCaptions().Duration())
+	assert.Zero(t, domain.Transcript{Cues: domain.ParsePlainText("untimed")}.Duration())
+}
+
 func TestTranscript_IsEmpty(t *testing.T) {
-	assert.True(t, domain.Transcript{Content: ""}.IsEmpty())
-	assert.True(t, domain.Transcript{Content: "   "}.IsEmpty())
-	assert.True(t, domain.Transcript{Content: "\n\t"}.IsEmpty())
-	assert.False(t, domain.Transcript{Content: "hello"}.IsEmpty())
+	assert.True(t, domain.Transcript{}.IsEmpty())
+	assert.True(t, domain.Transcript{Cues: domain.ParsePlainText("   \n\t")}.IsEmpty())
+	assert.False(t, domain.Transcript{Cues: domain.ParsePlainText("hello")}.IsEmpty())
+}
+
+func TestFormatTimestamp(t *testing.T) {
+	assert.Equal(t, "00:00:00", domain.FormatTimestamp(0))
+	assert.Equal(t, "01:02:03", domain.FormatTimestamp(time.Hour+2*time.Minute+3500*time.Millisecond))
+}
+
+func TestTranscript_SRT(t *testing.T) {
+	transcript := domain.Transcript{Cues: []domain.Cue{
+		{Index: 1, Start: 1500 * time.Millisecond, End: 4 * time.Second, Text: "Call to order."},
+		{Index: 2, Start: time.Hour + 250*time.Millisecond, End: time.Hour + 3*time.Second, Text: "First row\nsecond row"},
+	}}
+
+	srt := transcript.SRT()
+
+	assert.Equal(t, "1\n00:00:01,500 --> 00:00:04,000\nCall to order.\n\n"+
+		"2\n01:00:00,250 --> 01:00:03,000\nFirst row\nsecond row\n", srt)
+	assert.Equal(t, transcript.Cues, domain.ParseSRT(srt), "round-trips")
 }
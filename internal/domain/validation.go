@@ -1,6 +1,13 @@
 package domain
 
-import "fmt"
+import (
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+)
 
 // ValidationSeverity indicates whether a validation issue is a hard failure or advisory.
 type ValidationSeverity int
@@ -14,6 +21,16 @@ const (
 type ValidationIssue struct {
 	Severity ValidationSeverity
 	Message  string
+	// Check is the built-in check that raised the issue, for issues added
+	// with AddCheck.
+	Check string
+}
+
+// Repairable reports whether a repair round can fix the issue. Faithfulness
+// and roster issues can only be fixed against the transcript or the roster,
+// neither of which the repair prompt carries.
+func (v ValidationIssue) Repairable() bool {
+	return v.Check != CheckFaithfulness && v.Check != CheckRoster
 }
 
 func (v ValidationIssue) String() string {
@@ -81,3 +98,293 @@ func (r *ValidationResult) Warnings() []ValidationIssue {
 func (r *ValidationResult) IsValid() bool {
 	return !r.HasErrors()
 }
+
+// RuleSeverity is the configured severity of a validation rule.
+type RuleSeverity string
+
+const (
+	RuleError   RuleSeverity = "error"
+	RuleWarning RuleSeverity = "warning"
+	// RuleOff disables a rule.
+	RuleOff RuleSeverity = "off"
+)
+
+// Built-in checks whose severity a body can change under validation.checks.
+const (
+	CheckTitle          = "title"           // a "# " title heading
+	CheckFooter         = "footer"          // the attribution footer
+	CheckTimestamps     = "timestamps"      // at least one [HH:MM:SS]
+	CheckTimestampRange = "timestamp_range" // no timestamp past the transcript
+	CheckTagSpaces      = "tag_spaces"      // tags use hyphens, not spaces
+	CheckMetaCommentary = "meta_commentary" // no model preamble before frontmatter
+	CheckFaithfulness   = "faithfulness"    // amounts, votes, and names found in the transcript
+	CheckRoster         = "roster"          // titled officials are on the body's roster
+)
+
+// DefaultRequiredFrontmatter lists the frontmatter keys every summary needs
+// unless a body says otherwise.
+var DefaultRequiredFrontmatter = []string{"date", "author", "tags", "source", "meeting_date"}
+
+// defaultChecks gives each built-in check its severity.
+var defaultChecks = map[string]RuleSeverity{
+	CheckTitle:          RuleError,
+	CheckFooter:         RuleWarning,
+	CheckTimestamps:     RuleWarning,
+	CheckTimestampRange: RuleWarning,
+	CheckTagSpaces:      RuleError,
+	CheckMetaCommentary: RuleError,
+	// Faithfulness is heuristic and opt-in.
+	CheckFaithfulness: RuleOff,
+	// Roster only applies to bodies with a roster.
+	CheckRoster: RuleWarning,
+}
+
+// TextRule matches summary text either literally or by regular expression.
+// Exactly one of Text and Pattern is set. Patterns are compiled in multi-line
+// mode, so ^ and $ match at line boundaries.
+type TextRule struct {
+	Text     string       `yaml:"text" mapstructure:"text"`
+	Pattern  string       `yaml:"pattern" mapstructure:"pattern"`
+	Severity RuleSeverity `yaml:"severity" mapstructure:"severity"`
+}
+
+// Level returns the rule's severity, defaulting to error.
+func (r TextRule) Level() RuleSeverity {
+	if r.Severity == "" {
+		return RuleError
+	}
+	return r.Severity
+}
+
+// Match reports whether the rule matches content.
+func (r TextRule) Match(content string) bool {
+	_, ok := r.Find(content)
+	return ok
+}
+
+// Find returns the first text in content the rule matches. An invalid
+// pattern never matches; config validation rejects those before they get here.
+func (r TextRule) Find(content string) (string, bool) {
+	if r.Pattern == "" {
+		return r.Text, strings.Contains(content, r.Text)
+	}
+	re, err := regexp.Compile("(?m)" + r.Pattern)
+	if err != nil {
+		return "", false
+	}
+	loc := re.FindStringIndex(content)
+	if loc == nil {
+		return "", false
+	}
+	return content[loc[0]:loc[1]], true
+}
+
+// String renders the rule as its text, or its pattern between slashes.
+func (r TextRule) String() string {
+	if r.Pattern != "" {
+		return "/" + r.Pattern + "/"
+	}
+	return r.Text
+}
+
+// validate checks that exactly one of Text and Pattern is set, that Pattern
+// compiles, and that Severity is known.
+func (r TextRule) validate() error {
+	if (r.Text == "") == (r.Pattern == "") {
+		return fmt.Errorf("exactly one of text or pattern is required")
+	}
+	if r.Pattern != "" {
+		if _, err := regexp.Compile("(?m)" + r.Pattern); err != nil {
+			return fmt.Errorf("invalid pattern %q: %w", r.Pattern, err)
+		}
+	}
+	return validateSeverity(r.Severity)
+}
+
+// ValidationRules configure summary validation for a body. Unset fields keep
+// the defaults from DefaultValidationRules, so a body only lists what differs.
+type ValidationRules struct {
+	// RequiredHeadings must each match somewhere in the summary.
+	RequiredHeadings []TextRule `yaml:"required_headings" mapstructure:"required_headings"`
+	// MinWords is the word count below which a summary is rejected; WarnWords
+	// the count below which it passes with a warning. Zero disables either.
+	MinWords  *int `yaml:"min_words" mapstructure:"min_words"`
+	WarnWords *int `yaml:"warn_words" mapstructure:"warn_words"`
+	// FullLengthMinutes is the meeting length the word counts are set for.
+	// A shorter meeting, when its duration is known, has them scaled down in
+	// proportion. Zero always applies them in full.
+	FullLengthMinutes *int `yaml:"full_length_minutes" mapstructure:"full_length_minutes"`
+	// RequiredFrontmatter lists keys the frontmatter must contain.
+	RequiredFrontmatter []string `yaml:"required_frontmatter" mapstructure:"required_frontmatter"`
+	// ForbiddenPhrases must not match the summary body (after frontmatter).
+	ForbiddenPhrases []TextRule `yaml:"forbidden_phrases" mapstructure:"forbidden_phrases"`
+	// Checks overrides the severity of built-in checks by name.
+	Checks map[string]RuleSeverity `yaml:"checks" mapstructure:"checks"`
+}
+
+// DefaultValidationRules returns the rules used when a body sets none, which
+// match the shipped templates: five numbered sections and a conclusion.
+func DefaultValidationRules() ValidationRules {
+	minWords, warnWords, fullLength := 500, 1000, 60
+	return ValidationRules{
+		RequiredHeadings: []TextRule{
+			{Text: "## 1."},
+			{Text: "## 2."},
+			{Text: "## 3."},
+			{Text: "## 4."},
+			{Text: "## 5."},
+			{Text: "## Conclusion", Severity: RuleWarning},
+		},
+		MinWords:            &minWords,
+		WarnWords:           &warnWords,
+		FullLengthMinutes:   &fullLength,
+		RequiredFrontmatter: slices.Clone(DefaultRequiredFrontmatter),
+		ForbiddenPhrases: []TextRule{
+			// Model meta-commentary at the start of the body.
+			{Pattern: `\A(?:Based on|I'll |I will |Let me |Here's |Here is )`},
+		},
+		Checks: maps.Clone(defaultChecks),
+	}
+}
+
+// Effective returns r with every unset field filled from the defaults. A nil
+// receiver yields the defaults.
+func (r *ValidationRules) Effective() ValidationRules {
+	return r.ApplyTo(DefaultValidationRules())
+}
+
+// ApplyTo returns base with every field set in r replacing base's. Checks are
+// merged by name. A nil receiver yields base. base is not modified.
+func (r *ValidationRules) ApplyTo(base ValidationRules) ValidationRules {
+	rules := base
+	rules.Checks = maps.Clone(base.Checks)
+	if rules.Checks == nil {
+		rules.Checks = map[string]RuleSeverity{}
+	}
+	if r == nil {
+		return rules
+	}
+	if r.RequiredHeadings != nil {
+		rules.RequiredHeadings = r.RequiredHeadings
+	}
+	if r.MinWords != nil {
+		rules.MinWords = r.MinWords
+	}
+	if r.WarnWords != nil {
+		rules.WarnWords = r.WarnWords
+	}
+	if r.FullLengthMinutes != nil {
+		rules.FullLengthMinutes = r.FullLengthMinutes
+	}
+	if r.RequiredFrontmatter != nil {
+		rules.RequiredFrontmatter = r.RequiredFrontmatter
+	}
+	if r.ForbiddenPhrases != nil {
+		rules.ForbiddenPhrases = r.ForbiddenPhrases
+	}
+	for name, severity := range r.Checks {
+		rules.Checks[name] = severity
+	}
+	return rules
+}
+
+// Check returns the severity of a built-in check.
+func (r ValidationRules) Check(name string) RuleSeverity {
+	if severity, ok := r.Checks[name]; ok && severity != "" {
+		return severity
+	}
+	return defaultChecks[name]
+}
+
+// Words returns the effective minimum and warning word counts.
+func (r ValidationRules) Words() (minWords, warnWords int) {
+	if r.MinWords != nil {
+		minWords = *r.MinWords
+	}
+	if r.WarnWords != nil {
+		warnWords = *r.WarnWords
+	}
+	return minWords, warnWords
+}
+
+// WordsFor returns the word counts expected of a summary of a meeting that
+// ran for duration. A meeting shorter than FullLengthMinutes has both counts
+// scaled down in proportion; an unknown (zero) duration gets them in full.
+func (r ValidationRules) WordsFor(duration time.Duration) (minWords, warnWords int) {
+	minWords, warnWords = r.Words()
+	if r.FullLengthMinutes == nil || *r.FullLengthMinutes <= 0 || duration <= 0 {
+		return minWords, warnWords
+	}
+	full := time.Duration(*r.FullLengthMinutes) * time.Minute
+	if duration >= full {
+		return minWords, warnWords
+	}
+	scale := func(n int) int { return int(int64(n) * int64(duration) / int64(full)) }
+	return scale(minWords), scale(warnWords)
+}
+
+// Validate checks the rules for config errors.
+func (r ValidationRules) Validate() error {
+	for i, rule := range r.RequiredHeadings {
+		if err := rule.validate(); err != nil {
+			return fmt.Errorf("validation.required_headings[%d]: %w", i, err)
+		}
+	}
+	for i, rule := range r.ForbiddenPhrases {
+		if err := rule.validate(); err != nil {
+			return fmt.Errorf("validation.forbidden_phrases[%d]: %w", i, err)
+		}
+	}
+	minWords, warnWords := r.Words()
+	if minWords < 0 || warnWords < 0 {
+		return fmt.Errorf("validation word counts must not be negative")
+	}
+	if minWords > 0 && warnWords > 0 && warnWords < minWords {
+		return fmt.Errorf("validation.warn_words (%d) must not be below validation.min_words (%d)", warnWords, minWords)
+	}
+	if r.FullLengthMinutes != nil && *r.FullLengthMinutes < 0 {
+		return fmt.Errorf("validation.full_length_minutes must not be negative")
+	}
+	for name, severity := range r.Checks {
+		if _, ok := defaultChecks[name]; !ok {
+			return fmt.Errorf("validation.checks: unknown check %q; known: %v", name, CheckNames())
+		}
+		if err := validateSeverity(severity); err != nil {
+			return fmt.Errorf("validation.checks.%s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// CheckNames returns the built-in check names in sorted order.
+func CheckNames() []string {
+	return slices.Sorted(maps.Keys(defaultChecks))
+}
+
+func validateSeverity(s RuleSeverity) error {
+	switch s {
+	case "", RuleError, RuleWarning, RuleOff:
+		return nil
+	}
+	return fmt.Errorf("severity %q must be error, warning, or off", s)
+}
+
+// Add records an issue at the given rule severity; RuleOff records nothing.
+func (r *ValidationResult) Add(severity RuleSeverity, msg string, args ...interface{}) {
+	switch severity {
+	case RuleOff:
+	case RuleWarning:
+		r.AddWarning(msg, args...)
+	default:
+		r.AddError(msg, args...)
+	}
+}
+
+// AddCheck is Add for an issue raised by the named built-in check.
+func (r *ValidationResult) AddCheck(check string, severity RuleSeverity, msg string, args ...interface{}) {
+	n := len(r.Issues)
+	r.Add(severity, msg, args...)
+	if len(r.Issues) > n {
+		r.Issues[n].Check = check
+	}
+}
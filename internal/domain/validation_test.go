@@ -2,9 +2,11 @@ package domain_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/AvogadroSG1/civic-summary/internal/domain"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestValidationResult_NoIssues(t *testing.T) {
@@ -61,3 +63,162 @@ func TestValidationIssue_String(t *testing.T) {
 	}
 	assert.Equal(t, "[WARNING] test warning", warnIssue.String())
 }
+
+func TestValidationResult_Add(t *testing.T) {
+	r := &domain.ValidationResult{}
+	r.Add(domain.RuleError, "e")
+	r.Add(domain.RuleWarning, "w")
+	r.Add(domain.RuleOff, "ignored")
+	r.Add("", "defaults to error")
+
+	assert.Len(t, r.Errors(), 2)
+	assert.Len(t, r.Warnings(), 1)
+}
+
+func TestValidationResult_AddCheck(t *testing.T) {
+	r := &domain.ValidationResult{}
+	r.AddCheck(domain.CheckFaithfulness, domain.RuleOff, "ignored")
+	r.AddCheck(domain.CheckRoster, domain.RuleError, "roster")
+	r.AddCheck(domain.CheckTagSpaces, domain.RuleError, "tags")
+	r.Add(domain.RuleError, "heading")
+
+	require.Len(t, r.Issues, 3)
+	assert.Equal(t, domain.CheckRoster, r.Issues[0].Check)
+	assert.False(t, r.Issues[0].Repairable(), "repair cannot check names against the roster")
+	assert.True(t, r.Issues[1].Repairable())
+	assert.True(t, r.Issues[2].Repairable())
+}
+
+func TestValidationRules_EffectiveNilIsDefault(t *testing.T) {
+	var rules *domain.ValidationRules
+
+	assert.Equal(t, domain.DefaultValidationRules(), rules.Effective())
+}
+
+func TestValidationRules_EffectiveOverridesSetFieldsOnly(t *testing.T) {
+	minWords := 300
+	rules := (&domain.ValidationRules{
+		RequiredHeadings: []domain.TextRule{{Text: "## Overview"}},
+		MinWords:         &minWords,
+		Checks:           map[string]domain.RuleSeverity{domain.CheckFooter: domain.RuleOff},
+	}).Effective()
+
+	assert.Equal(t, []domain.TextRule{{Text: "## Overview"}}, rules.RequiredHeadings)
+	minW, warnW := rules.Words()
+	assert.Equal(t, 300, minW)
+	assert.Equal(t, 1000, warnW, "warn_words is inherited")
+	assert.Equal(t, domain.DefaultRequiredFrontmatter, rules.RequiredFrontmatter)
+	assert.Equal(t, domain.RuleOff, rules.Check(domain.CheckFooter))
+	assert.Equal(t, domain.RuleError, rules.Check(domain.CheckTitle), "unlisted checks keep their defaults")
+}
+
+func TestValidationRules_EffectiveDoesNotShareDefaults(t *testing.T) {
+	a := (&domain.ValidationRules{Checks: map[string]domain.RuleSeverity{domain.CheckTitle: domain.RuleOff}}).Effective()
+	b := (*domain.ValidationRules)(nil).Effective()
+
+	assert.Equal(t, domain.RuleOff, a.Check(domain.CheckTitle))
+	assert.Equal(t, domain.RuleError, b.Check(domain.CheckTitle))
+}
+
+func TestTextRule_Find(t *testing.T) {
+	content := "# Title\n## Overview\nBased on the record."
+
+	match, ok := domain.TextRule{Text: "## Overview"}.Find(content)
+	assert.True(t, ok)
+	assert.Equal(t, "## Overview", match)
+
+	match, ok = domain.TextRule{Pattern: `^Based on \w+`}.Find(content)
+	assert.True(t, ok, "patterns match at line starts")
+	assert.Equal(t, "Based on the", match)
+
+	assert.False(t, domain.TextRule{Pattern: `\ABased on`}.Match(content))
+	assert.False(t, domain.TextRule{Text: "## overview"}.Match(content), "text rules are case-sensitive")
+}
+
+func TestTextRule_String(t *testing.T) {
+	assert.Equal(t, "## 1.", domain.TextRule{Text: "## 1."}.String())
+	assert.Equal(t, "/^## Adjourn/", domain.TextRule{Pattern: "^## Adjourn"}.String())
+}
+
+func TestValidationRules_WordsFor(t *testing.T) {
+	rules := domain.DefaultValidationRules()
+
+	minW, warnW := rules.WordsFor(0)
+	assert.Equal(t, []int{500, 1000}, []int{minW, warnW}, "an unknown duration gets the full counts")
+	minW, warnW = rules.WordsFor(3 * time.Hour)
+	assert.Equal(t, []int{500, 1000}, []int{minW, warnW}, "a long meeting is not scaled up")
+	minW, warnW = rules.WordsFor(15 * time.Minute)
+	assert.Equal(t, []int{125, 250}, []int{minW, warnW})
+
+	off := 0
+	rules.FullLengthMinutes = &off
+	minW, warnW = rules.WordsFor(15 * time.Minute)
+	assert.Equal(t, []int{500, 1000}, []int{minW, warnW}, "zero disables scaling")
+}
+
+func TestValidationRules_Validate(t *testing.T) {
+	negative := -1
+	five, ten := 5, 10
+	tests := []struct {
+		name    string
+		rules   domain.ValidationRules
+		wantErr string
+	}{
+		{"defaults", domain.DefaultValidationRules(), ""},
+		{
+			"heading with text and pattern",
+			domain.ValidationRules{RequiredHeadings: []domain.TextRule{{Text: "a", Pattern: "b"}}},
+			"validation.required_headings[0]: exactly one of text or pattern",
+		},
+		{
+			"empty phrase",
+			domain.ValidationRules{ForbiddenPhrases: []domain.TextRule{{}}},
+			"validation.forbidden_phrases[0]: exactly one of text or pattern",
+		},
+		{
+			"bad pattern",
+			domain.ValidationRules{ForbiddenPhrases: []domain.TextRule{{Pattern: "("}}},
+			"invalid pattern",
+		},
+		{
+			"bad severity",
+			domain.ValidationRules{RequiredHeadings: []domain.TextRule{{Text: "## 1.", Severity: "fatal"}}},
+			`severity "fatal" must be error, warning, or off`,
+		},
+		{
+			"negative words",
+			domain.ValidationRules{MinWords: &negative},
+			"must not be negative",
+		},
+		{
+			"negative full length",
+			domain.ValidationRules{FullLengthMinutes: &negative},
+			"validation.full_length_minutes must not be negative",
+		},
+		{
+			"warn below min",
+			domain.ValidationRules{MinWords: &ten, WarnWords: &five},
+			"validation.warn_words (5) must not be below validation.min_words (10)",
+		},
+		{
+			"unknown check",
+			domain.ValidationRules{Checks: map[string]domain.RuleSeverity{"spelling": domain.RuleWarning}},
+			`unknown check "spelling"`,
+		},
+		{
+			"bad check severity",
+			domain.ValidationRules{Checks: map[string]domain.RuleSeverity{domain.CheckFooter: "loud"}},
+			"validation.checks.footer",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.rules.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,174 @@
+package domain
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+)
+
+// VocabularyTerm is the canonical spelling of a local proper noun or piece of
+// jargon, with the ways captions are known to get it wrong.
+type VocabularyTerm struct {
+	// Term is the correct spelling, e.g. "Antietam".
+	Term string `yaml:"term" mapstructure:"term"`
+	// Variants are misspellings and phonetic mis-hearings of the term, such
+	// as "anteetam" or "and tee tam". They match without regard to case, as
+	// does the term itself, so "antietam" is capitalized too.
+	Variants []string `yaml:"variants" mapstructure:"variants"`
+}
+
+// Vocabulary is a body's list of canonical terms. It corrects transcripts
+// before analysis and biases Whisper toward the right spellings.
+type Vocabulary []VocabularyTerm
+
+// Validate checks that every entry has a term and no empty variants, and that
+// no spelling is claimed by two different terms.
+func (v Vocabulary) Validate() error {
+	owner := make(map[string]string)
+	for i, t := range v {
+		if strings.TrimSpace(t.Term) == "" {
+			return fmt.Errorf("vocabulary[%d]: term is required", i)
+		}
+		for j, spelling := range append([]string{t.Term}, t.Variants...) {
+			if strings.TrimSpace(spelling) == "" {
+				return fmt.Errorf("vocabulary[%d].variants[%d] is empty", i, j-1)
+			}
+			key := vocabularyKey(spelling)
+			if other, ok := owner[key]; ok && other != t.Term {
+				return fmt.Errorf("vocabulary[%d]: %q is also listed under %q", i, spelling, other)
+			}
+			owner[key] = t.Term
+		}
+	}
+	return nil
+}
+
+// Terms returns the canonical terms, in order, for a transcription prompt.
+func (v Vocabulary) Terms() []string {
+	terms := make([]string, 0, len(v))
+	for _, t := range v {
+		if !slices.Contains(terms, t.Term) {
+			terms = append(terms, t.Term)
+		}
+	}
+	return terms
+}
+
+// Correction is one replacement made in a transcript.
+type Correction struct {
+	// Cue is the 1-based index of the corrected cue.
+	Cue int `json:"cue"`
+	// Time is the cue's start as HH:MM:SS, or empty for an untimed cue.
+	Time string `json:"time,omitempty"`
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// CorrectionReport records the vocabulary corrections applied to a meeting's
+// transcript, for auditing. It is saved beside the transcript.
+type CorrectionReport struct {
+	VideoID     string         `json:"video_id"`
+	Transcript  string         `json:"transcript"`
+	GeneratedAt time.Time      `json:"generated_at"`
+	Counts      map[string]int `json:"counts"` // corrections per term
+	Corrections []Correction   `json:"corrections"`
+}
+
+// Correct returns a copy of the transcript with every variant of a term, and
+// the term itself in the wrong case, replaced with the term, along with the
+// corrections made. Spellings match whole words; longer spellings are
+// preferred, and a spelling does not span the rows of a rolling caption.
+func (v Vocabulary) Correct(t Transcript) (Transcript, []Correction) {
+	if len(v) == 0 {
+		return t, nil
+	}
+
+	terms := make(map[string]string)
+	var spellings []string
+	for _, entry := range v {
+		for _, s := range append([]string{entry.Term}, entry.Variants...) {
+			key := vocabularyKey(s)
+			if _, ok := terms[key]; !ok {
+				terms[key] = entry.Term
+				spellings = append(spellings, s)
+			}
+		}
+	}
+	slices.SortStableFunc(spellings, func(a, b string) int { return len(b) - len(a) })
+	alternatives := make([]string, len(spellings))
+	for i, s := range spellings {
+		alternatives[i] = spellingPattern(s)
+	}
+	pattern := regexp.MustCompile(`(?i)(?:` + strings.Join(alternatives, "|") + `)`)
+
+	var corrections []Correction
+	cues := slices.Clone(t.Cues)
+	for i, c := range cues {
+		cues[i].Text = pattern.ReplaceAllStringFunc(c.Text, func(found string) string {
+			term := terms[vocabularyKey(found)]
+			if found == term {
+				return found
+			}
+			correction := Correction{Cue: c.Index, From: found, To: term}
+			if c.HasTiming() {
+				correction.Time = FormatTimestamp(c.Start)
+			}
+			corrections = append(corrections, correction)
+			return term
+		})
+	}
+	t.Cues = cues
+	return t, corrections
+}
+
+// NewCorrectionReport summarizes the corrections made to a meeting's
+// transcript.
+func NewCorrectionReport(videoID, transcriptPath string, corrections []Correction, now time.Time) CorrectionReport {
+	report := CorrectionReport{
+		VideoID:     videoID,
+		Transcript:  transcriptPath,
+		GeneratedAt: now,
+		Counts:      make(map[string]int),
+		Corrections: corrections,
+	}
+	if report.Corrections == nil {
+		report.Corrections = []Correction{}
+	}
+	for _, c := range corrections {
+		report.Counts[c.To]++
+	}
+	return report
+}
+
+// vocabularyKey normalizes a spelling for comparison: lowercase, with runs of
+// whitespace collapsed.
+func vocabularyKey(s string) string {
+	return strings.ToLower(strings.Join(strings.Fields(s), " "))
+}
+
+// spellingPattern matches a spelling as whole words, with any run of spaces
+// or tabs between its words.
+func spellingPattern(s string) string {
+	words := strings.Fields(s)
+	quoted := make([]string, len(words))
+	for i, w := range words {
+		quoted[i] = regexp.QuoteMeta(w)
+	}
+	pattern := strings.Join(quoted, `[ \t]+`)
+	first, last := words[0], words[len(words)-1]
+	if isASCIIWordByte(first[0]) {
+		pattern = `\b` + pattern
+	}
+	if isASCIIWordByte(last[len(last)-1]) {
+		pattern += `\b`
+	}
+	return pattern
+}
+
+// isASCIIWordByte reports whether b is a character \b treats as part of a
+// word; RE2's \b only knows ASCII.
+func isASCIIWordByte(b byte) bool {
+	return b == '_' || b >= '0' && b <= '9' || b >= 'a' && b <= 'z' || b >= 'A' && b <= 'Z'
+}
@@ -0,0 +1,94 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/AvogadroSG1/civic-summary/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testVocabulary() domain.Vocabulary {
+	return domain.Vocabulary{
+		{Term: "Antietam", Variants: []string{"anteetam", "and tee tam"}},
+		{Term: "Bruchey", Variants: []string{"brewskie", "brew key"}},
+		{Term: "Hagerstown"},
+		{Term: "C&O Canal", Variants: []string{"C and O canal"}},
+	}
+}
+
+func TestVocabulary_Correct(t *testing.T) {
+	transcript := domain.Transcript{Cues: []domain.Cue{
+		{Index: 1, Start: 65 * time.Second, End: 70 * time.Second, Text: "Mayor brewskie opened the meeting in hagerstown."},
+		{Index: 2, Start: 70 * time.Second, End: 75 * time.Second, Text: "The and tee  tam Creek project and the c and o canal."},
+		{Index: 3, Start: 75 * time.Second, End: 80 * time.Second, Text: "Antietam Street and the brewskies next door."},
+		{Index: 4, Text: "Mayor Brew\nkey spoke."},
+	}}
+
+	corrected, corrections := testVocabulary().Correct(transcript)
+
+	assert.Equal(t, "Mayor Bruchey opened the meeting in Hagerstown.", corrected.Cues[0].Text)
+	assert.Equal(t, "The Antietam Creek project and the C&O Canal.", corrected.Cues[1].Text)
+	assert.Equal(t, "Antietam Street and the brewskies next door.", corrected.Cues[2].Text, "whole words only")
+	assert.Equal(t, "Mayor Brew\nkey spoke.", corrected.Cues[3].Text, "not across caption rows")
+	assert.Equal(t, "Mayor brewskie opened the meeting in hagerstown.", transcript.Cues[0].Text, "the original is not modified")
+
+	assert.Equal(t, []domain.Correction{
+		{Cue: 1, Time: "00:01:05", From: "brewskie", To: "Bruchey"},
+		{Cue: 1, Time: "00:01:05", From: "hagerstown", To: "Hagerstown"},
+		{Cue: 2, Time: "00:01:10", From: "and tee  tam", To: "Antietam"},
+		{Cue: 2, Time: "00:01:10", From: "c and o canal", To: "C&O Canal"},
+	}, corrections)
+}
+
+func TestVocabulary_Correct_Empty(t *testing.T) {
+	transcript := domain.Transcript{Cues: []domain.Cue{{Index: 1, Text: "anteetam"}}}
+
+	corrected, corrections := domain.Vocabulary(nil).Correct(transcript)
+
+	assert.Equal(t, transcript, corrected)
+	assert.Empty(t, corrections)
+}
+
+func TestVocabulary_Validate(t *testing.T) {
+	require.NoError(t, testVocabulary().Validate())
+	require.NoError(t, domain.Vocabulary(nil).Validate())
+
+	err := domain.Vocabulary{{Variants: []string{"anteetam"}}}.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "vocabulary[0]: term is required")
+
+	err = domain.Vocabulary{{Term: "Antietam", Variants: []string{" "}}}.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "vocabulary[0].variants[0] is empty")
+
+	err = domain.Vocabulary{{Term: "Keller"}, {Term: "Kellar", Variants: []string{"keller"}}}.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `vocabulary[1]: "keller" is also listed under "Keller"`)
+}
+
+func TestVocabulary_Terms(t *testing.T) {
+	vocabulary := append(testVocabulary(), domain.VocabularyTerm{Term: "Antietam", Variants: []string{"antee tam"}})
+	assert.Equal(t, []string{"Antietam", "Bruchey", "Hagerstown", "C&O Canal"}, vocabulary.Terms())
+}
+
+func TestNewCorrectionReport(t *testing.T) {
+	now := time.Date(2026, 3, 4, 12, 0, 0, 0, time.UTC)
+	corrections := []domain.Correction{
+		{Cue: 1, From: "brewskie", To: "Bruchey"},
+		{Cue: 4, From: "brew key", To: "Bruchey"},
+		{Cue: 7, From: "anteetam", To: "Antietam"},
+	}
+
+	report := domain.NewCorrectionReport("abc123", "/out/abc123.srt", corrections, now)
+
+	assert.Equal(t, "abc123", report.VideoID)
+	assert.Equal(t, "/out/abc123.srt", report.Transcript)
+	assert.Equal(t, now, report.GeneratedAt)
+	assert.Equal(t, map[string]int{"Bruchey": 2, "Antietam": 1}, report.Counts)
+	assert.Equal(t, corrections, report.Corrections)
+
+	empty := domain.NewCorrectionReport("abc123", "/out/abc123.srt", nil, now)
+	assert.NotNil(t, empty.Corrections, "an empty report lists no corrections rather than null")
+}
@@ -0,0 +1,34 @@
+package executor
+
+import (
+	"context"
+	"fmt"
+)
+
+// DiarizerExecutor runs a local speaker diarization tool. The tool is called
+// as "<binary> [args...] <audio> <output.rttm>" and must write RTTM; pyannote
+// and similar libraries need a few lines of wrapper script to do so.
+type DiarizerExecutor struct {
+	commander Commander
+	binary    string
+	args      []string
+}
+
+// NewDiarizerExecutor creates a new DiarizerExecutor. args are passed before
+// the audio and output paths, e.g. to choose a model or device.
+func NewDiarizerExecutor(commander Commander, binary string, args []string) *DiarizerExecutor {
+	return &DiarizerExecutor{
+		commander: commander,
+		binary:    binary,
+		args:      args,
+	}
+}
+
+// Diarize runs the tool on an audio file, writing speaker turns to rttmPath.
+func (d *DiarizerExecutor) Diarize(ctx context.Context, audioPath, rttmPath string) error {
+	args := append(append([]string{}, d.args...), audioPath, rttmPath)
+	if _, err := d.commander.Execute(ctx, d.binary, args...); err != nil {
+		return fmt.Errorf("diarizing audio: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,31 @@
+package executor_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/AvogadroSG1/civic-summary/internal/executor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiarizerExecutor_Diarize(t *testing.T) {
+	mock := executor.NewMockCommander()
+
+	d := executor.NewDiarizerExecutor(mock, "diarize", []string{"--device", "cpu"})
+	require.NoError(t, d.Diarize(context.Background(), "/tmp/abc.mp3", "/tmp/abc.rttm"))
+
+	assert.Equal(t, []string{"diarize --device cpu /tmp/abc.mp3 /tmp/abc.rttm"}, mock.Calls)
+}
+
+func TestDiarizerExecutor_Diarize_Error(t *testing.T) {
+	mock := executor.NewMockCommander()
+	mock.OnCommand("diarize /tmp/abc.mp3 /tmp/abc.rttm", nil, fmt.Errorf("CUDA out of memory"))
+
+	d := executor.NewDiarizerExecutor(mock, "diarize", nil)
+	err := d.Diarize(context.Background(), "/tmp/abc.mp3", "/tmp/abc.rttm")
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "diarizing audio")
+}
@@ -0,0 +1,125 @@
+package executor
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/AvogadroSG1/civic-summary/internal/domain"
+)
+
+var (
+	ffmpegDurationPattern     = regexp.MustCompile(`Duration: (\d+):(\d{2}):(\d{2}(?:\.\d+)?)`)
+	ffmpegSilenceStartPattern = regexp.MustCompile(`silence_start: (-?\d+(?:\.\d+)?)`)
+	ffmpegSilenceEndPattern   = regexp.MustCompile(`silence_end: (\d+(?:\.\d+)?)`)
+)
+
+// CompressedBitrate is the bit rate, in bits per second, of the MP3 audio
+// Extract writes: enough for speech, and small enough that half an hour fits
+// in an 11 MB upload.
+const CompressedBitrate = 48000
+
+// FFmpegExecutor wraps ffmpeg for preparing meeting audio for speech-to-text.
+type FFmpegExecutor struct {
+	commander Commander
+	binary    string
+}
+
+// NewFFmpegExecutor creates a new FFmpegExecutor.
+func NewFFmpegExecutor(commander Commander, binary string) *FFmpegExecutor {
+	return &FFmpegExecutor{
+		commander: commander,
+		binary:    binary,
+	}
+}
+
+// DetectSilence returns a recording's duration and its stretches quieter than
+// thresholdDB lasting at least minSilence, using the silencedetect filter.
+// ffmpeg reports both on stderr. A silence still running when the recording
+// ends is closed at its duration.
+func (f *FFmpegExecutor) DetectSilence(ctx context.Context, path string, thresholdDB int, minSilence time.Duration) (time.Duration, []domain.Silence, error) {
+	filter := fmt.Sprintf("silencedetect=noise=%ddB:d=%s", thresholdDB, ffmpegTime(minSilence))
+	result, err := f.commander.Execute(ctx, f.binary,
+		"-hide_banner", "-nostdin", "-nostats",
+		"-i", path,
+		"-af", filter,
+		"-f", "null", "-",
+	)
+	if err != nil {
+		return 0, nil, fmt.Errorf("detecting silence: %w", err)
+	}
+	return parseSilenceDetect(result.Stderr)
+}
+
+// parseSilenceDetect reads the duration and silences from ffmpeg's stderr.
+func parseSilenceDetect(stderr string) (time.Duration, []domain.Silence, error) {
+	m := ffmpegDurationPattern.FindStringSubmatch(stderr)
+	if m == nil {
+		return 0, nil, fmt.Errorf("detecting silence: ffmpeg reported no duration")
+	}
+	hours, _ := strconv.Atoi(m[1])
+	minutes, _ := strconv.Atoi(m[2])
+	secs, _ := strconv.ParseFloat(m[3], 64)
+	duration := time.Duration(hours)*time.Hour + time.Duration(minutes)*time.Minute + fromSeconds(secs)
+
+	starts := ffmpegSilenceStartPattern.FindAllStringSubmatch(stderr, -1)
+	ends := ffmpegSilenceEndPattern.FindAllStringSubmatch(stderr, -1)
+	silences := make([]domain.Silence, 0, len(starts))
+	for i, s := range starts {
+		start, _ := strconv.ParseFloat(s[1], 64)
+		silence := domain.Silence{Start: max(fromSeconds(start), 0), End: duration}
+		if i < len(ends) {
+			end, _ := strconv.ParseFloat(ends[i][1], 64)
+			silence.End = fromSeconds(end)
+		}
+		silences = append(silences, silence)
+	}
+	return duration, silences, nil
+}
+
+// Extract writes the stretch of a recording from start, lasting length, to
+// outPath as 16 kHz mono audio, the input Whisper models expect. A .mp3
+// outPath is encoded at CompressedBitrate, for uploading; any other is left
+// to ffmpeg, which writes a .wav as uncompressed PCM. With normalize the
+// loudness is evened out with the loudnorm filter.
+func (f *FFmpegExecutor) Extract(ctx context.Context, inPath, outPath string, start, length time.Duration, normalize bool) error {
+	args := []string{
+		"-hide_banner", "-nostdin", "-loglevel", "error", "-y",
+		"-ss", ffmpegTime(start),
+		"-t", ffmpegTime(length),
+		"-i", inPath,
+	}
+	if normalize {
+		args = append(args, "-af", "loudnorm")
+	}
+	if filepath.Ext(outPath) == ".mp3" {
+		args = append(args, "-c:a", "libmp3lame", "-b:a", strconv.Itoa(CompressedBitrate/1000)+"k")
+	}
+	args = append(args, "-ar", "16000", "-ac", "1", outPath)
+
+	if _, err := f.commander.Execute(ctx, f.binary, args...); err != nil {
+		return fmt.Errorf("extracting audio: %w", err)
+	}
+	return nil
+}
+
+// Check runs ffmpeg -version, which fails if it is not installed.
+func (f *FFmpegExecutor) Check(ctx context.Context) error {
+	if _, err := f.commander.Execute(ctx, f.binary, "-version"); err != nil {
+		return fmt.Errorf("%s is not runnable: %w", f.binary, err)
+	}
+	return nil
+}
+
+// ffmpegTime renders a duration as decimal seconds for ffmpeg.
+func ffmpegTime(d time.Duration) string {
+	return strconv.FormatFloat(d.Seconds(), 'f', 3, 64)
+}
+
+// fromSeconds converts ffmpeg's decimal seconds, keeping milliseconds.
+func fromSeconds(s float64) time.Duration {
+	return time.Duration(s * float64(time.Second)).Round(time.Millisecond)
+}
@@ -0,0 +1,71 @@
+package executor_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/AvogadroSG1/civic-summary/internal/domain"
+	"github.com/AvogadroSG1/civic-summary/internal/executor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const silenceDetectStderr = `Input #0, mp3, from '/tmp/abc.mp3':
+  Duration: 02:10:05.50, start: 0.025057, bitrate: 128 kb/s
+  Stream #0:0: Audio: mp3, 44100 Hz, stereo, fltp, 128 kb/s
+[silencedetect @ 0x600001] silence_start: 0
+[silencedetect @ 0x600001] silence_end: 1215.25 | silence_duration: 1215.25
+[silencedetect @ 0x600001] silence_start: 3600.5
+[silencedetect @ 0x600001] silence_end: 3607 | silence_duration: 6.5
+[silencedetect @ 0x600001] silence_start: 7700.125
+size=N/A time=02:10:05.50 bitrate=N/A speed= 512x
+`
+
+func TestFFmpegExecutor_DetectSilence(t *testing.T) {
+	mock := executor.NewMockCommander()
+	key := "ffmpeg -hide_banner -nostdin -nostats -i /tmp/abc.mp3 -af silencedetect=noise=-50dB:d=5.000 -f null -"
+	mock.OnCommand(key, &executor.CommandResult{Stderr: silenceDetectStderr}, nil)
+
+	f := executor.NewFFmpegExecutor(mock, "ffmpeg")
+	duration, silences, err := f.DetectSilence(context.Background(), "/tmp/abc.mp3", -50, 5*time.Second)
+	require.NoError(t, err)
+
+	assert.Equal(t, 2*time.Hour+10*time.Minute+5500*time.Millisecond, duration)
+	assert.Equal(t, []domain.Silence{
+		{Start: 0, End: 1215250 * time.Millisecond},
+		{Start: 3600500 * time.Millisecond, End: 3607 * time.Second},
+		{Start: 7700125 * time.Millisecond, End: duration},
+	}, silences, "a silence running to the end closes at the duration")
+}
+
+func TestFFmpegExecutor_DetectSilence_Errors(t *testing.T) {
+	mock := executor.NewMockCommander()
+	mock.OnCommand("ffmpeg", &executor.CommandResult{Stderr: "/tmp/abc.mp3: Invalid data found when processing input"}, nil)
+
+	_, _, err := executor.NewFFmpegExecutor(mock, "ffmpeg").DetectSilence(context.Background(), "/tmp/abc.mp3", -50, 5*time.Second)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "ffmpeg reported no duration")
+
+	failing := executor.NewMockCommander()
+	failing.OnCommand("ffmpeg", nil, fmt.Errorf("exit status 1"))
+	_, _, err = executor.NewFFmpegExecutor(failing, "ffmpeg").DetectSilence(context.Background(), "/tmp/abc.mp3", -50, 5*time.Second)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "detecting silence")
+}
+
+func TestFFmpegExecutor_Extract(t *testing.T) {
+	mock := executor.NewMockCommander()
+	f := executor.NewFFmpegExecutor(mock, "ffmpeg")
+
+	require.NoError(t, f.Extract(context.Background(), "/tmp/abc.mp3", "/tmp/abc.part02.wav", 1790*time.Second, 30*time.Minute, true))
+	require.NoError(t, f.Extract(context.Background(), "/tmp/abc.mp3", "/tmp/abc.part01.wav", 0, 90*time.Second, false))
+	require.NoError(t, f.Extract(context.Background(), "/tmp/abc.mp3", "/tmp/abc.part01.mp3", 0, 90*time.Second, false))
+
+	assert.Equal(t, []string{
+		"ffmpeg -hide_banner -nostdin -loglevel error -y -ss 1790.000 -t 1800.000 -i /tmp/abc.mp3 -af loudnorm -ar 16000 -ac 1 /tmp/abc.part02.wav",
+		"ffmpeg -hide_banner -nostdin -loglevel error -y -ss 0.000 -t 90.000 -i /tmp/abc.mp3 -ar 16000 -ac 1 /tmp/abc.part01.wav",
+		"ffmpeg -hide_banner -nostdin -loglevel error -y -ss 0.000 -t 90.000 -i /tmp/abc.mp3 -c:a libmp3lame -b:a 48k -ar 16000 -ac 1 /tmp/abc.part01.mp3",
+	}, mock.Calls)
+}
@@ -3,10 +3,14 @@ package executor
 import (
 	"context"
 	"fmt"
+	"sync"
 )
 
-// MockCommander is a test double for Commander that returns pre-configured responses.
+// MockCommander is a test double for Commander that returns pre-configured
+// responses. It is safe for concurrent use once configured.
 type MockCommander struct {
+	mu sync.Mutex
+
 	// Responses maps "command arg1 arg2..." to the result to return.
 	Responses map[string]*CommandResult
 	// Errors maps command keys to errors.
@@ -44,6 +48,8 @@ func (m *MockCommander) ExecuteWithStdin(_ context.Context, _ string, name strin
 	if len(args) > 0 {
 		key = fmt.Sprintf("%s %s", name, joinArgs(args))
 	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	m.Calls = append(m.Calls, key)
 
 	if result, ok := m.Responses[key]; ok {
@@ -0,0 +1,30 @@
+package executor
+
+import (
+	"context"
+	"fmt"
+)
+
+// PdfToTextExecutor wraps poppler's pdftotext for extracting text from PDFs.
+type PdfToTextExecutor struct {
+	commander Commander
+	binary    string
+}
+
+// NewPdfToTextExecutor creates a new PdfToTextExecutor.
+func NewPdfToTextExecutor(commander Commander, binary string) *PdfToTextExecutor {
+	return &PdfToTextExecutor{
+		commander: commander,
+		binary:    binary,
+	}
+}
+
+// Extract returns the text of the PDF at pdfPath. Layout mode keeps agenda
+// item numbering and indentation readable.
+func (p *PdfToTextExecutor) Extract(ctx context.Context, pdfPath string) (string, error) {
+	result, err := p.commander.Execute(ctx, p.binary, "-layout", pdfPath, "-")
+	if err != nil {
+		return "", fmt.Errorf("extracting pdf text: %w", err)
+	}
+	return result.Stdout, nil
+}
@@ -0,0 +1,33 @@
+package executor_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/AvogadroSG1/civic-summary/internal/executor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPdfToTextExecutor_Extract(t *testing.T) {
+	mock := executor.NewMockCommander()
+	mock.OnCommand("pdftotext -layout /tmp/agenda.pdf -", &executor.CommandResult{Stdout: "1. Call to Order\n"}, nil)
+
+	p := executor.NewPdfToTextExecutor(mock, "pdftotext")
+	text, err := p.Extract(context.Background(), "/tmp/agenda.pdf")
+	require.NoError(t, err)
+
+	assert.Equal(t, "1. Call to Order\n", text)
+}
+
+func TestPdfToTextExecutor_Extract_Error(t *testing.T) {
+	mock := executor.NewMockCommander()
+	mock.OnCommand("pdftotext -layout /tmp/agenda.pdf -", nil, fmt.Errorf("Syntax Error: Couldn't find trailer dictionary"))
+
+	p := executor.NewPdfToTextExecutor(mock, "pdftotext")
+	_, err := p.Extract(context.Background(), "/tmp/agenda.pdf")
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "extracting pdf text")
+}
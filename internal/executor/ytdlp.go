@@ -2,14 +2,25 @@ package executor
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"maps"
+	"slices"
 	"strings"
+	"time"
+
+	"github.com/AvogadroSG1/civic-summary/internal/domain"
 )
 
-// PlaylistEntry represents a single video discovered from a YouTube playlist.
+// PlaylistEntry represents a single video discovered from a playlist. Fields
+// other than VideoID and Title are zero when yt-dlp does not report them,
+// which for a flat playlist listing is common.
 type PlaylistEntry struct {
 	VideoID string
 	Title   string
+	// UploadDate is the upload (or release) date, or zero when unknown.
+	UploadDate time.Time
+	Details    domain.VideoDetails
 }
 
 // YtDlpExecutor wraps yt-dlp operations.
@@ -26,48 +37,78 @@ func NewYtDlpExecutor(commander Commander, binary string) *YtDlpExecutor {
 	}
 }
 
-// ListPlaylist returns all videos in a playlist, optionally filtered by year.
-func (y *YtDlpExecutor) ListPlaylist(ctx context.Context, playlistURL string, yearFilter string) ([]PlaylistEntry, error) {
-	args := []string{
+// ListPlaylist returns all videos in a playlist. Filtering by date is left to
+// the caller, which parses the meeting date from each title. Entries are read
+// from yt-dlp's JSON output; the approximate_date extractor argument asks it
+// to estimate upload dates, which a flat YouTube listing otherwise omits.
+func (y *YtDlpExecutor) ListPlaylist(ctx context.Context, playlistURL string) ([]PlaylistEntry, error) {
+	result, err := y.commander.Execute(ctx, y.binary,
 		"--flat-playlist",
-		"--print", "%(id)s|%(title)s",
-	}
-	if yearFilter != "" {
-		args = append(args, "--match-filter", fmt.Sprintf("title ~= '%s'", yearFilter))
+		"--dump-json",
+		"--extractor-args", "youtubetab:approximate_date",
+		playlistURL,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("listing playlist: %w", err)
 	}
-	args = append(args, playlistURL)
 
-	result, err := y.commander.Execute(ctx, y.binary, args...)
+	return parsePlaylistOutput(result.Stdout), nil
+}
+
+// VideoInfo returns the full metadata for one video, including its
+// description, chapters, and caption tracks, which a flat playlist listing
+// leaves out.
+func (y *YtDlpExecutor) VideoInfo(ctx context.Context, videoURL string) (PlaylistEntry, error) {
+	result, err := y.commander.Execute(ctx, y.binary,
+		"--skip-download",
+		"--dump-json",
+		videoURL,
+	)
 	if err != nil {
-		return nil, fmt.Errorf("listing playlist: %w", err)
+		return PlaylistEntry{}, fmt.Errorf("getting video info: %w", err)
 	}
 
-	return parsePlaylistOutput(result.Stdout)
+	var info ytdlpInfo
+	if err := json.Unmarshal([]byte(strings.TrimSpace(result.Stdout)), &info); err != nil {
+		return PlaylistEntry{}, fmt.Errorf("parsing video info: %w", err)
+	}
+	return info.entry(), nil
 }
 
-// DownloadCaptions downloads auto-generated captions in SRT format.
-// Returns the path to the downloaded SRT file, or empty string if unavailable.
-func (y *YtDlpExecutor) DownloadCaptions(ctx context.Context, videoID string, outputDir string) (string, error) {
-	videoURL := "https://www.youtube.com/watch?v=" + videoID
+// ListCaptions returns the caption tracks yt-dlp lists for a video: uploaded
+// subtitles and automatic captions, each with its language and formats.
+func (y *YtDlpExecutor) ListCaptions(ctx context.Context, videoURL string) ([]domain.CaptionTrack, error) {
+	result, err := y.commander.Execute(ctx, y.binary, "--list-subs", videoURL)
+	if err != nil {
+		return nil, fmt.Errorf("checking captions: %w", err)
+	}
+	return parseSubtitleList(result.Stdout + "\n" + result.Stderr), nil
+}
 
-	// Check if auto-captions are available.
-	checkResult, err := y.commander.Execute(ctx, y.binary, "--list-subs", videoURL)
+// DownloadCaptions downloads the caption track the policy selects to
+// outputDir/name.<lang>.<format>. Returns the path to the downloaded file, or
+// empty string if no track matches the policy. videoURL may be any page or
+// media URL yt-dlp supports.
+func (y *YtDlpExecutor) DownloadCaptions(ctx context.Context, videoURL, outputDir, name string, policy domain.CaptionPolicy) (string, error) {
+	tracks, err := y.ListCaptions(ctx, videoURL)
 	if err != nil {
-		return "", fmt.Errorf("checking captions: %w", err)
+		return "", err
 	}
 
-	hasCaptions := strings.Contains(checkResult.Stdout, "Available automatic captions") ||
-		strings.Contains(checkResult.Stderr, "Available automatic captions")
-	if !hasCaptions {
+	track, format, ok := policy.Select(tracks)
+	if !ok {
 		return "", nil
 	}
 
-	// Download captions.
-	outputTemplate := fmt.Sprintf("%s/%s", outputDir, videoID)
+	write := "--write-auto-subs"
+	if track.Kind == domain.CaptionManual {
+		write = "--write-subs"
+	}
+	outputTemplate := fmt.Sprintf("%s/%s", outputDir, name)
 	_, err = y.commander.Execute(ctx, y.binary,
-		"--write-auto-subs",
-		"--sub-lang", "en",
-		"--sub-format", "srt",
+		write,
+		"--sub-lang", track.Language,
+		"--sub-format", format,
 		"--skip-download",
 		"--output", outputTemplate,
 		videoURL,
@@ -76,13 +117,60 @@ func (y *YtDlpExecutor) DownloadCaptions(ctx context.Context, videoID string, ou
 		return "", fmt.Errorf("downloading captions: %w", err)
 	}
 
-	return fmt.Sprintf("%s/%s.en.srt", outputDir, videoID), nil
+	return fmt.Sprintf("%s/%s.%s.%s", outputDir, name, track.Language, format), nil
 }
 
-// DownloadAudio downloads audio in MP3 format for Whisper fallback.
-func (y *YtDlpExecutor) DownloadAudio(ctx context.Context, videoID string, outputPath string) error {
-	videoURL := "https://www.youtube.com/watch?v=" + videoID
+// parseSubtitleList reads the tables yt-dlp --list-subs prints under
+// "Available subtitles" and "Available automatic captions" headings. Each
+// row is a language code, a name that may contain spaces, and a
+// comma-separated list of formats.
+func parseSubtitleList(output string) []domain.CaptionTrack {
+	var tracks []domain.CaptionTrack
+	kind := ""
+	for _, line := range strings.Split(output, "\n") {
+		switch {
+		case strings.Contains(line, "Available automatic captions"):
+			kind = domain.CaptionAuto
+			continue
+		case strings.Contains(line, "Available subtitles"):
+			kind = domain.CaptionManual
+			continue
+		case strings.HasPrefix(line, "["), strings.Contains(line, "has no "):
+			kind = ""
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if kind == "" || len(fields) == 0 || fields[0] == "Language" {
+			continue
+		}
+		tracks = append(tracks, domain.CaptionTrack{
+			Kind:     kind,
+			Language: fields[0],
+			Formats:  subtitleFormats(fields[1:]),
+		})
+	}
+	return tracks
+}
 
+// subtitleFormats takes the trailing "vtt, ttml, srv3" formats from a row's
+// fields, stopping at the language name. Formats are lowercase; a name such
+// as "English" is not mistaken for one.
+func subtitleFormats(fields []string) []string {
+	var formats []string
+	for i := len(fields) - 1; i >= 0; i-- {
+		f := strings.TrimSuffix(fields[i], ",")
+		last := i == len(fields)-1
+		if (!last && !strings.HasSuffix(fields[i], ",")) || f == "" || f != strings.ToLower(f) {
+			break
+		}
+		formats = append([]string{f}, formats...)
+	}
+	return formats
+}
+
+// DownloadAudio downloads audio in MP3 format for Whisper fallback.
+func (y *YtDlpExecutor) DownloadAudio(ctx context.Context, videoURL string, outputPath string) error {
 	_, err := y.commander.Execute(ctx, y.binary,
 		"--extract-audio",
 		"--audio-format", "mp3",
@@ -96,9 +184,7 @@ func (y *YtDlpExecutor) DownloadAudio(ctx context.Context, videoID string, outpu
 }
 
 // GetDescription downloads the video description.
-func (y *YtDlpExecutor) GetDescription(ctx context.Context, videoID string) (string, error) {
-	videoURL := "https://www.youtube.com/watch?v=" + videoID
-
+func (y *YtDlpExecutor) GetDescription(ctx context.Context, videoURL string) (string, error) {
 	result, err := y.commander.Execute(ctx, y.binary,
 		"--skip-download",
 		"--print", "%(description)s",
@@ -110,22 +196,105 @@ func (y *YtDlpExecutor) GetDescription(ctx context.Context, videoID string) (str
 	return strings.TrimSpace(result.Stdout), nil
 }
 
-// parsePlaylistOutput parses the "ID|Title" format output from yt-dlp.
-func parsePlaylistOutput(output string) ([]PlaylistEntry, error) {
+// ytdlpInfo is the subset of yt-dlp's JSON info dict that civic-summary uses.
+type ytdlpInfo struct {
+	ID               string  `json:"id"`
+	Title            string  `json:"title"`
+	UploadDate       string  `json:"upload_date"`
+	ReleaseDate      string  `json:"release_date"`
+	Timestamp        float64 `json:"timestamp"`
+	ReleaseTimestamp float64 `json:"release_timestamp"`
+	Duration         float64 `json:"duration"`
+	LiveStatus       string  `json:"live_status"`
+	Description      string  `json:"description"`
+	Channel          string  `json:"channel"`
+	Uploader         string  `json:"uploader"`
+	Chapters         []struct {
+		Title     string  `json:"title"`
+		StartTime float64 `json:"start_time"`
+		EndTime   float64 `json:"end_time"`
+	} `json:"chapters"`
+	Subtitles         map[string]json.RawMessage `json:"subtitles"`
+	AutomaticCaptions map[string]json.RawMessage `json:"automatic_captions"`
+}
+
+// entry converts the info dict to a PlaylistEntry.
+func (i ytdlpInfo) entry() PlaylistEntry {
+	details := domain.VideoDetails{
+		Duration:     seconds(i.Duration),
+		Released:     i.released(),
+		LiveStatus:   i.LiveStatus,
+		Description:  strings.TrimSpace(i.Description),
+		Channel:      i.Channel,
+		Captions:     slices.Sorted(maps.Keys(i.Subtitles)),
+		AutoCaptions: slices.Sorted(maps.Keys(i.AutomaticCaptions)),
+	}
+	if details.Channel == "" {
+		details.Channel = i.Uploader
+	}
+	for _, c := range i.Chapters {
+		details.Chapters = append(details.Chapters, domain.Chapter{
+			Title: strings.TrimSpace(c.Title),
+			Start: seconds(c.StartTime),
+			End:   seconds(c.EndTime),
+		})
+	}
+
+	return PlaylistEntry{
+		VideoID:    i.ID,
+		Title:      strings.TrimSpace(i.Title),
+		UploadDate: i.uploadDate(),
+		Details:    details,
+	}
+}
+
+// uploadDate prefers the explicit YYYYMMDD dates over Unix timestamps, and
+// the upload over the release, returning the date at UTC midnight.
+func (i ytdlpInfo) uploadDate() time.Time {
+	for _, s := range []string{i.UploadDate, i.ReleaseDate} {
+		if t, err := time.Parse("20060102", s); err == nil {
+			return t
+		}
+	}
+	for _, ts := range []float64{i.Timestamp, i.ReleaseTimestamp} {
+		if ts > 0 {
+			y, m, d := time.Unix(int64(ts), 0).UTC().Date()
+			return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+		}
+	}
+	return time.Time{}
+}
+
+// released returns the exact time the video became available, preferring the
+// release timestamp, which for a livestream is when it began, over the upload
+// timestamp, which for a scheduled stream is when it was announced.
+func (i ytdlpInfo) released() time.Time {
+	for _, ts := range []float64{i.ReleaseTimestamp, i.Timestamp} {
+		if ts > 0 {
+			return time.Unix(int64(ts), 0).UTC()
+		}
+	}
+	return time.Time{}
+}
+
+func seconds(s float64) time.Duration {
+	return time.Duration(s * float64(time.Second)).Round(time.Second)
+}
+
+// parsePlaylistOutput parses yt-dlp's --dump-json output: one JSON object per
+// line. Lines that are not an info dict with an ID are skipped.
+func parsePlaylistOutput(output string) []PlaylistEntry {
 	var entries []PlaylistEntry
 	for _, line := range strings.Split(strings.TrimSpace(output), "\n") {
 		line = strings.TrimSpace(line)
 		if line == "" {
 			continue
 		}
-		parts := strings.SplitN(line, "|", 2)
-		if len(parts) != 2 {
+		var info ytdlpInfo
+		if err := json.Unmarshal([]byte(line), &info); err != nil || info.ID == "" {
 			continue
 		}
-		entries = append(entries, PlaylistEntry{
-			VideoID: strings.TrimSpace(parts[0]),
-			Title:   strings.TrimSpace(parts[1]),
-		})
+		entries = append(entries, info.entry())
 	}
-	return entries, nil
+	return entries
 }
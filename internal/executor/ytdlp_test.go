@@ -3,7 +3,9 @@ package executor_test
 import (
 	"context"
 	"testing"
+	"time"
 
+	"github.com/AvogadroSG1/civic-summary/internal/domain"
 	"github.com/AvogadroSG1/civic-summary/internal/executor"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -12,17 +14,62 @@ import (
 func TestYtDlpExecutor_ListPlaylist(t *testing.T) {
 	mock := executor.NewMockCommander()
 	mock.DefaultResult = &executor.CommandResult{
-		Stdout: "abc123|February 04, 2025 | Mayor & Council Regular Session\ndef456|January 21, 2025 | Mayor & Council Work Session\n",
+		Stdout: `{"id": "abc123", "title": "February 04, 2025 | Mayor & Council Regular Session"}` + "\n" +
+			`{"id": "def456", "title": "January 21, 2025 | Mayor & Council Work Session", "timestamp": 1737500400}` + "\n",
 	}
 
 	ytdlp := executor.NewYtDlpExecutor(mock, "yt-dlp")
-	entries, err := ytdlp.ListPlaylist(context.Background(), "https://youtube.com/playlist?list=TEST", "2025")
+	entries, err := ytdlp.ListPlaylist(context.Background(), "https://youtube.com/playlist?list=TEST")
 	require.NoError(t, err)
 
 	assert.Len(t, entries, 2)
 	assert.Equal(t, "abc123", entries[0].VideoID)
 	assert.Equal(t, "February 04, 2025 | Mayor & Council Regular Session", entries[0].Title)
+	assert.True(t, entries[0].UploadDate.IsZero())
 	assert.Equal(t, "def456", entries[1].VideoID)
+	assert.Equal(t, time.Date(2025, 1, 21, 0, 0, 0, 0, time.UTC), entries[1].UploadDate,
+		"a timestamp stands in for a missing upload_date")
+}
+
+func TestYtDlpExecutor_VideoInfo(t *testing.T) {
+	mock := executor.NewMockCommander()
+	mock.OnCommand("yt-dlp --skip-download --dump-json https://www.youtube.com/watch?v=abc123",
+		&executor.CommandResult{Stdout: `{
+			"id": "abc123",
+			"title": "February 04, 2025 | Regular Session",
+			"upload_date": "20250205",
+			"timestamp": 1738600000,
+			"release_timestamp": 1738710000,
+			"duration": 5412.6,
+			"live_status": "was_live",
+			"description": "Agenda: https://example.gov/agenda.pdf\n",
+			"uploader": "City of Hagerstown",
+			"chapters": [
+				{"title": "Call to Order", "start_time": 0, "end_time": 95},
+				{"title": "Public Comment", "start_time": 95, "end_time": 5412.6}
+			],
+			"subtitles": {},
+			"automatic_captions": {"es": [], "en": []}
+		}`}, nil)
+
+	ytdlp := executor.NewYtDlpExecutor(mock, "yt-dlp")
+	entry, err := ytdlp.VideoInfo(context.Background(), "https://www.youtube.com/watch?v=abc123")
+	require.NoError(t, err)
+
+	assert.Equal(t, time.Date(2025, 2, 5, 0, 0, 0, 0, time.UTC), entry.UploadDate)
+	assert.Equal(t, domain.VideoDetails{
+		Duration:    90*time.Minute + 13*time.Second,
+		Released:    time.Unix(1738710000, 0).UTC(),
+		LiveStatus:  domain.LiveStatusWasLive,
+		Description: "Agenda: https://example.gov/agenda.pdf",
+		Channel:     "City of Hagerstown",
+		Chapters: []domain.Chapter{
+			{Title: "Call to Order", Start: 0, End: 95 * time.Second},
+			{Title: "Public Comment", Start: 95 * time.Second, End: 90*time.Minute + 13*time.Second},
+		},
+		AutoCaptions: []string{"en", "es"},
+	}, entry.Details)
+	assert.True(t, entry.Details.HasCaptions())
 }
 
 func TestYtDlpExecutor_ListPlaylist_EmptyOutput(t *testing.T) {
@@ -30,7 +77,7 @@ func TestYtDlpExecutor_ListPlaylist_EmptyOutput(t *testing.T) {
 	mock.DefaultResult = &executor.CommandResult{Stdout: ""}
 
 	ytdlp := executor.NewYtDlpExecutor(mock, "yt-dlp")
-	entries, err := ytdlp.ListPlaylist(context.Background(), "https://youtube.com/playlist?list=TEST", "")
+	entries, err := ytdlp.ListPlaylist(context.Background(), "https://youtube.com/playlist?list=TEST")
 	require.NoError(t, err)
 	assert.Empty(t, entries)
 }
@@ -42,11 +89,91 @@ func TestYtDlpExecutor_DownloadCaptions_NoCaptions(t *testing.T) {
 	}
 
 	ytdlp := executor.NewYtDlpExecutor(mock, "yt-dlp")
-	path, err := ytdlp.DownloadCaptions(context.Background(), "abc123", "/tmp")
+	path, err := ytdlp.DownloadCaptions(context.Background(), "https://www.youtube.com/watch?v=abc123", "/tmp", "abc123", domain.DefaultCaptionPolicy())
 	require.NoError(t, err)
 	assert.Empty(t, path)
 }
 
+const listSubsOutput = `[youtube] Extracting URL: https://www.youtube.com/watch?v=abc123
+[info] Available automatic captions for abc123:
+Language Name                     Formats
+en-orig  English (Original)       vtt, ttml, srv3, srv2, srv1, json3
+en       English                  vtt, ttml, srv3, srv2, srv1, json3
+es       Spanish                  vtt, ttml, srv3, srv2, srv1, json3
+[info] Available subtitles for abc123:
+Language Name                     Formats
+es-419   Spanish (Latin America)  vtt, srt, ttml
+en       English                  vtt, ttml
+`
+
+func TestYtDlpExecutor_ListCaptions(t *testing.T) {
+	mock := executor.NewMockCommander()
+	mock.DefaultResult = &executor.CommandResult{Stdout: listSubsOutput}
+
+	tracks, err := executor.NewYtDlpExecutor(mock, "yt-dlp").ListCaptions(context.Background(), "https://www.youtube.com/watch?v=abc123")
+	require.NoError(t, err)
+
+	assert.Equal(t, []domain.CaptionTrack{
+		{Kind: domain.CaptionAuto, Language: "en-orig", Formats: []string{"vtt", "ttml", "srv3", "srv2", "srv1", "json3"}},
+		{Kind: domain.CaptionAuto, Language: "en", Formats: []string{"vtt", "ttml", "srv3", "srv2", "srv1", "json3"}},
+		{Kind: domain.CaptionAuto, Language: "es", Formats: []string{"vtt", "ttml", "srv3", "srv2", "srv1", "json3"}},
+		{Kind: domain.CaptionManual, Language: "es-419", Formats: []string{"vtt", "srt", "ttml"}},
+		{Kind: domain.CaptionManual, Language: "en", Formats: []string{"vtt", "ttml"}},
+	}, tracks)
+}
+
+func TestYtDlpExecutor_DownloadCaptions_Policy(t *testing.T) {
+	videoURL := "https://www.youtube.com/watch?v=abc123"
+	tests := []struct {
+		name     string
+		policy   domain.CaptionPolicy
+		wantCmd  string
+		wantPath string
+	}{
+		{
+			"uploaded subtitles first",
+			domain.DefaultCaptionPolicy(),
+			"yt-dlp --write-subs --sub-lang en --sub-format vtt --skip-download --output /out/abc123 " + videoURL,
+			"/out/abc123.en.vtt",
+		},
+		{
+			"regional language",
+			domain.CaptionPolicy{Languages: []string{"es"}}.Effective(),
+			"yt-dlp --write-subs --sub-lang es-419 --sub-format srt --skip-download --output /out/abc123 " + videoURL,
+			"/out/abc123.es-419.srt",
+		},
+		{
+			"automatic only",
+			domain.CaptionPolicy{Kinds: []string{domain.CaptionAuto}, Languages: []string{"es"}}.Effective(),
+			"yt-dlp --write-auto-subs --sub-lang es --sub-format vtt --skip-download --output /out/abc123 " + videoURL,
+			"/out/abc123.es.vtt",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := executor.NewMockCommander()
+			mock.OnCommand("yt-dlp --list-subs "+videoURL, &executor.CommandResult{Stdout: listSubsOutput}, nil)
+			mock.OnCommand(tt.wantCmd, &executor.CommandResult{}, nil)
+
+			path, err := executor.NewYtDlpExecutor(mock, "yt-dlp").DownloadCaptions(context.Background(), videoURL, "/out", "abc123", tt.policy)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantPath, path)
+			assert.Equal(t, tt.wantCmd, mock.Calls[len(mock.Calls)-1])
+		})
+	}
+
+	t.Run("no format the policy accepts", func(t *testing.T) {
+		mock := executor.NewMockCommander()
+		mock.DefaultResult = &executor.CommandResult{Stdout: listSubsOutput}
+		policy := domain.CaptionPolicy{Formats: []string{"srt"}, Languages: []string{"en"}}.Effective()
+
+		path, err := executor.NewYtDlpExecutor(mock, "yt-dlp").DownloadCaptions(context.Background(), videoURL, "/out", "abc123", policy)
+		require.NoError(t, err)
+		assert.Empty(t, path)
+		assert.Len(t, mock.Calls, 1, "nothing is downloaded")
+	})
+}
+
 func TestParsePlaylistOutput(t *testing.T) {
 	// Exported via the ListPlaylist method; test edge cases via mock.
 	mock := executor.NewMockCommander()
@@ -56,18 +183,19 @@ func TestParsePlaylistOutput(t *testing.T) {
 		output string
 		count  int
 	}{
-		{"normal", "id1|title1\nid2|title2\n", 2},
-		{"trailing newlines", "id1|title1\n\n\n", 1},
-		{"no pipe", "malformed\n", 0},
+		{"normal", `{"id": "id1", "title": "title1"}` + "\n" + `{"id": "id2", "title": "title2"}` + "\n", 2},
+		{"trailing newlines", `{"id": "id1", "title": "title1"}` + "\n\n\n", 1},
+		{"not json", "id1|title1\n", 0},
+		{"no id", `{"title": "title1"}` + "\n", 0},
 		{"empty", "", 0},
-		{"pipe in title", "id1|title with | pipe\n", 1},
+		{"warning line", "WARNING: [youtube] unable to extract\n" + `{"id": "id1", "title": "title1"}` + "\n", 1},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			mock.DefaultResult = &executor.CommandResult{Stdout: tt.output}
 			ytdlp := executor.NewYtDlpExecutor(mock, "yt-dlp")
-			entries, err := ytdlp.ListPlaylist(context.Background(), "url", "")
+			entries, err := ytdlp.ListPlaylist(context.Background(), "url")
 			require.NoError(t, err)
 			assert.Len(t, entries, tt.count)
 		})
@@ -122,6 +122,7 @@ func classify(cfg domain.LLMConfig, err error) error {
 
 	var anthropicErr *anthropic.Error
 	var openaiErr *openai.Error
+	var geminiErr *geminiError
 	switch {
 	case errors.As(err, &anthropicErr):
 		status = anthropicErr.StatusCode
@@ -132,6 +133,11 @@ func classify(cfg domain.LLMConfig, err error) error {
 		if body == "" {
 			body = openaiErr.Code
 		}
+	case errors.As(err, &geminiErr):
+		// The canonical status is left out: UNAVAILABLE on an overloaded model
+		// would otherwise read as a missing one.
+		status = geminiErr.StatusCode
+		body = geminiErr.Message
 	default:
 		return &Error{
 			Kind:     KindTransport,
@@ -158,7 +164,7 @@ func classify(cfg domain.LLMConfig, err error) error {
 	case isModelNotFound(body), status == http.StatusNotFound:
 		e.Kind = KindModelNotFound
 		e.Hint = fmt.Sprintf("model %q is not available at this endpoint; check llm.model and llm.base_url", cfg.Model)
-	case status == http.StatusUnauthorized, status == http.StatusForbidden:
+	case status == http.StatusUnauthorized, status == http.StatusForbidden, isInvalidAPIKey(body):
 		e.Kind = KindAuth
 		e.Hint = fmt.Sprintf("check the API key in $%s", cfg.APIKeyEnv)
 	case status == http.StatusTooManyRequests:
@@ -212,6 +218,7 @@ func isContextWindow(body string) bool {
 		"prompt is too long",
 		"too many tokens",
 		"reduce the length",
+		"exceeds the maximum number of tokens",
 	} {
 		if strings.Contains(lower, marker) {
 			return true
@@ -220,6 +227,13 @@ func isContextWindow(body string) bool {
 	return false
 }
 
+// isInvalidAPIKey reports whether an error body describes a rejected key sent
+// with a status other than 401 or 403, as Gemini does with a 400.
+func isInvalidAPIKey(body string) bool {
+	lower := strings.ToLower(body)
+	return strings.Contains(lower, "api_key_invalid") || strings.Contains(lower, "api key not valid")
+}
+
 // isModelNotFound reports whether an error body describes an unknown model.
 // Compatible servers word this many ways ("model 'x' not found, try pulling it
 // first", "The model 'x' does not exist"), so beyond the two machine-readable
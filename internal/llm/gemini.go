@@ -0,0 +1,327 @@
+package llm
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/AvogadroSG1/civic-summary/internal/domain"
+)
+
+// defaultGeminiBaseURL is Google's Generative Language API, used when
+// llm.base_url is empty.
+const defaultGeminiBaseURL = "https://generativelanguage.googleapis.com/v1beta"
+
+// geminiRetryDelay is the wait before the first retry of a transient failure;
+// it doubles with each further attempt, up to geminiMaxRetryDelay.
+const (
+	geminiRetryDelay    = 500 * time.Millisecond
+	geminiMaxRetryDelay = 8 * time.Second
+)
+
+// geminiClient talks to the Gemini generateContent API. There is no Go SDK in
+// the module for it, so requests are built by hand with net/http; retries of
+// transient failures follow llm.max_retries as the SDKs do.
+type geminiClient struct {
+	cfg    domain.LLMConfig
+	apiKey string
+	client *http.Client
+}
+
+// newGeminiClient builds a client for the generateContent API.
+func newGeminiClient(cfg domain.LLMConfig, apiKey string) *geminiClient {
+	return &geminiClient{
+		cfg:    cfg,
+		apiKey: apiKey,
+		client: &http.Client{Timeout: cfg.Timeout()},
+	}
+}
+
+// Describe returns a "provider/model" label.
+func (c *geminiClient) Describe() string {
+	return c.cfg.Describe()
+}
+
+// Complete sends the prompt and returns the first candidate's text.
+func (c *geminiClient) Complete(ctx context.Context, prompt string) (string, error) {
+	return c.complete(ctx, c.params(prompt, c.cfg.MaxTokens))
+}
+
+// Ping issues a one-token completion; see anthropicClient.Ping for why an empty
+// response counts as success.
+func (c *geminiClient) Ping(ctx context.Context) error {
+	_, err := c.complete(ctx, c.params("ping", 1))
+	var llmErr *Error
+	if errors.As(err, &llmErr) && llmErr.Kind == KindEmptyResponse {
+		return nil
+	}
+	return err
+}
+
+// geminiRequest is the body of a generateContent request.
+type geminiRequest struct {
+	Contents          []geminiContent        `json:"contents"`
+	SystemInstruction *geminiContent         `json:"systemInstruction,omitempty"`
+	GenerationConfig  geminiGenerationConfig `json:"generationConfig"`
+}
+
+type geminiContent struct {
+	Role  string       `json:"role,omitempty"`
+	Parts []geminiPart `json:"parts"`
+}
+
+// geminiPart is one piece of a message. Thinking models return their
+// reasoning as parts marked thought, which are left out of the response.
+type geminiPart struct {
+	Text    string `json:"text"`
+	Thought bool   `json:"thought,omitempty"`
+}
+
+type geminiGenerationConfig struct {
+	MaxOutputTokens int      `json:"maxOutputTokens"`
+	Temperature     *float64 `json:"temperature,omitempty"`
+}
+
+// geminiResponse is a generateContent response, or one event of a streamed
+// one. A stream that fails part way reports the failure as an event with an
+// error.
+type geminiResponse struct {
+	Candidates []struct {
+		Content      geminiContent `json:"content"`
+		FinishReason string        `json:"finishReason"`
+	} `json:"candidates"`
+	PromptFeedback *struct {
+		BlockReason string `json:"blockReason"`
+	} `json:"promptFeedback"`
+	Error *geminiError `json:"error"`
+}
+
+// geminiError is the error object of a failed request, in the shape Google
+// APIs share: an HTTP code, a canonical status such as INVALID_ARGUMENT, and a
+// message.
+type geminiError struct {
+	StatusCode int    `json:"code"`
+	Status     string `json:"status"`
+	Message    string `json:"message"`
+}
+
+// Error implements the error interface.
+func (e *geminiError) Error() string {
+	if e.Status == "" {
+		return fmt.Sprintf("%d: %s", e.StatusCode, e.Message)
+	}
+	return fmt.Sprintf("%d %s: %s", e.StatusCode, e.Status, e.Message)
+}
+
+// params builds the request. Temperature is included only when configured, as
+// for the other providers.
+func (c *geminiClient) params(prompt string, maxTokens int) geminiRequest {
+	req := geminiRequest{
+		Contents: []geminiContent{{Role: "user", Parts: []geminiPart{{Text: prompt}}}},
+		GenerationConfig: geminiGenerationConfig{
+			MaxOutputTokens: maxTokens,
+			Temperature:     c.cfg.Temperature,
+		},
+	}
+	if c.cfg.SystemPrompt != "" {
+		req.SystemInstruction = &geminiContent{Parts: []geminiPart{{Text: c.cfg.SystemPrompt}}}
+	}
+	return req
+}
+
+// complete runs the request, retrying rate limits, server errors, and
+// transport failures up to cfg.MaxRetries times with exponential backoff.
+func (c *geminiClient) complete(ctx context.Context, req geminiRequest) (string, error) {
+	payload, err := json.Marshal(req)
+	if err != nil {
+		return "", fmt.Errorf("encoding gemini request: %w", err)
+	}
+
+	delay := geminiRetryDelay
+	for attempt := 0; ; attempt++ {
+		text, err := c.send(ctx, payload)
+		if err == nil || attempt >= c.cfg.MaxRetries || !transient(err) || ctx.Err() != nil {
+			return text, err
+		}
+		select {
+		case <-ctx.Done():
+			return "", err
+		case <-time.After(delay):
+		}
+		delay = min(delay*2, geminiMaxRetryDelay)
+	}
+}
+
+// transient reports whether a failed request is worth repeating as is.
+func transient(err error) bool {
+	var llmErr *Error
+	if !errors.As(err, &llmErr) {
+		return false
+	}
+	switch llmErr.Kind {
+	case KindRateLimit, KindServer, KindTransport:
+		return true
+	default:
+		return false
+	}
+}
+
+// send makes one request, streaming unless disabled.
+func (c *geminiClient) send(ctx context.Context, payload []byte) (string, error) {
+	method := "generateContent"
+	if c.cfg.Stream {
+		method = "streamGenerateContent?alt=sse"
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url(method), bytes.NewReader(payload))
+	if err != nil {
+		return "", fmt.Errorf("building gemini request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("x-goog-api-key", c.apiKey)
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return "", classify(c.cfg, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", classify(c.cfg, readGeminiError(resp))
+	}
+	if !c.cfg.Stream {
+		var out geminiResponse
+		if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+			return "", classify(c.cfg, fmt.Errorf("decoding gemini response: %w", err))
+		}
+		return c.text([]geminiResponse{out})
+	}
+
+	events, err := readGeminiStream(resp.Body)
+	if err != nil {
+		return "", classify(c.cfg, err)
+	}
+	return c.text(events)
+}
+
+// url returns the endpoint for a method of the configured model. A model given
+// with its "models/" resource prefix is accepted as well.
+func (c *geminiClient) url(method string) string {
+	base := c.cfg.BaseURL
+	if base == "" {
+		base = defaultGeminiBaseURL
+	}
+	model := strings.TrimPrefix(c.cfg.Model, "models/")
+	return strings.TrimRight(base, "/") + "/models/" + url.PathEscape(model) + ":" + method
+}
+
+// readGeminiStream collects the events of a server-sent event stream. An error
+// event ends the stream with that error.
+func readGeminiStream(r io.Reader) ([]geminiResponse, error) {
+	var events []geminiResponse
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 10*1024*1024)
+	for scanner.Scan() {
+		data, ok := strings.CutPrefix(scanner.Text(), "data:")
+		if !ok {
+			continue
+		}
+		var event geminiResponse
+		if err := json.Unmarshal([]byte(strings.TrimSpace(data)), &event); err != nil {
+			return nil, fmt.Errorf("decoding gemini stream: %w", err)
+		}
+		if event.Error != nil {
+			return nil, event.Error
+		}
+		events = append(events, event)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading gemini stream: %w", err)
+	}
+	return events, nil
+}
+
+// readGeminiError reads the error of a failed request. Google wraps it in a
+// one-element array on some routes; a body that is not JSON at all, as from a
+// proxy, is kept as the message.
+func readGeminiError(resp *http.Response) *geminiError {
+	raw, _ := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+	var single geminiResponse
+	if json.Unmarshal(raw, &single) == nil && single.Error != nil {
+		single.Error.StatusCode = resp.StatusCode
+		return single.Error
+	}
+	var wrapped []geminiResponse
+	if json.Unmarshal(raw, &wrapped) == nil && len(wrapped) > 0 && wrapped[0].Error != nil {
+		wrapped[0].Error.StatusCode = resp.StatusCode
+		return wrapped[0].Error
+	}
+	return &geminiError{StatusCode: resp.StatusCode, Message: strings.TrimSpace(string(raw))}
+}
+
+// text joins the first candidate's text across the response events. A prompt
+// the safety filters blocked outright is reported as an invalid request, since
+// sending it again would be blocked the same way; a response that stopped
+// early for another reason is reported by finishError.
+func (c *geminiClient) text(events []geminiResponse) (string, error) {
+	var b strings.Builder
+	var finish string
+	for _, event := range events {
+		if event.PromptFeedback != nil && event.PromptFeedback.BlockReason != "" {
+			return "", &Error{
+				Kind:     KindInvalidRequest,
+				Provider: c.cfg.Provider,
+				Model:    c.cfg.Model,
+				Hint:     fmt.Sprintf("the prompt was blocked (%s)", event.PromptFeedback.BlockReason),
+			}
+		}
+		if len(event.Candidates) == 0 {
+			continue
+		}
+		for _, part := range event.Candidates[0].Content.Parts {
+			if !part.Thought {
+				b.WriteString(part.Text)
+			}
+		}
+		if reason := event.Candidates[0].FinishReason; reason != "" {
+			finish = reason
+		}
+	}
+	trimmed := strings.TrimSpace(b.String())
+	if trimmed == "" && (finish == "" || finish == "STOP" || finish == "MAX_TOKENS") {
+		return "", emptyResponseError(c.cfg)
+	}
+	if err := c.finishError(finish); err != nil {
+		return "", err
+	}
+	return trimmed, nil
+}
+
+// finishError reports a response that stopped before its natural end, or
+// returns nil. One the safety or recitation filters stopped would be stopped
+// again, so it is an invalid request; anything else may go differently on a
+// retry. A response cut off by max_tokens is not an error: like the Anthropic
+// and OpenAI clients, this one returns the text it has and leaves validation
+// and repair to find what is missing. An empty reason, as on every streamed
+// event but the last, is not an error either.
+func (c *geminiClient) finishError(reason string) error {
+	err := &Error{Provider: c.cfg.Provider, Model: c.cfg.Model}
+	switch reason {
+	case "", "STOP", "MAX_TOKENS":
+		return nil
+	case "SAFETY", "RECITATION", "BLOCKLIST", "PROHIBITED_CONTENT", "SPII", "IMAGE_SAFETY":
+		err.Kind = KindInvalidRequest
+		err.Hint = fmt.Sprintf("the response was blocked (%s)", reason)
+	default:
+		err.Kind = KindUnknown
+		err.Hint = fmt.Sprintf("the response ended early (%s)", reason)
+	}
+	return err
+}
@@ -0,0 +1,431 @@
+package llm_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/AvogadroSG1/civic-summary/internal/domain"
+	"github.com/AvogadroSG1/civic-summary/internal/llm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// geminiStreamBody renders text as a streamGenerateContent SSE stream, split
+// across two events. An empty text produces a stream whose only candidate has
+// no parts, as happens when the output limit is exhausted before any token is
+// emitted.
+func geminiStreamBody(text string) string {
+	var body strings.Builder
+	write := func(data string) {
+		fmt.Fprintf(&body, "data: %s\r\n\r\n", data)
+	}
+
+	if text != "" {
+		half := len(text) / 2
+		write(fmt.Sprintf(`{"candidates":[{"content":{"role":"model","parts":[{"text":%s}]},"index":0}]}`, mustJSON(text[:half])))
+		write(fmt.Sprintf(`{"candidates":[{"content":{"role":"model","parts":[{"text":%s}]},"finishReason":"STOP","index":0}],"usageMetadata":{"promptTokenCount":10,"candidatesTokenCount":20}}`, mustJSON(text[half:])))
+	} else {
+		write(`{"candidates":[{"content":{"role":"model"},"finishReason":"MAX_TOKENS","index":0}]}`)
+	}
+
+	return body.String()
+}
+
+// geminiContentBody renders text as a non-streaming generateContent response.
+func geminiContentBody(text string) string {
+	return fmt.Sprintf(`{"candidates":[{"content":{"role":"model","parts":[{"text":%s}]},"finishReason":"STOP","index":0}],"usageMetadata":{"promptTokenCount":10,"candidatesTokenCount":20}}`, mustJSON(text))
+}
+
+// geminiServer serves a fixed body and records the last request body and URL.
+func geminiServer(t *testing.T, status int, body string, stream bool) (*httptest.Server, *map[string]any, *string) {
+	t.Helper()
+	captured := map[string]any{}
+	var requestURI string
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "test-key", r.Header.Get("x-goog-api-key"))
+		requestURI = r.URL.RequestURI()
+
+		if err := json.NewDecoder(r.Body).Decode(&captured); err != nil {
+			t.Errorf("decoding request body: %v", err)
+		}
+
+		if stream && status == http.StatusOK {
+			w.Header().Set("content-type", "text/event-stream")
+		} else {
+			w.Header().Set("content-type", "application/json")
+		}
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(srv.Close)
+
+	return srv, &captured, &requestURI
+}
+
+// geminiBaseURL returns the base URL a user would configure for a stand-in of
+// the Generative Language API.
+func geminiBaseURL(srv *httptest.Server) string {
+	return srv.URL + "/v1beta"
+}
+
+func TestGeminiComplete_Streaming(t *testing.T) {
+	srv, captured, uri := geminiServer(t, http.StatusOK, geminiStreamBody("# Summary\n\nBody text."), true)
+	client := newTestClient(t, baseConfig(domain.ProviderGemini, geminiBaseURL(srv)))
+
+	out, err := client.Complete(context.Background(), "the rendered template")
+
+	require.NoError(t, err)
+	assert.Equal(t, "# Summary\n\nBody text.", out)
+	assert.Equal(t, "/v1beta/models/test-model:streamGenerateContent?alt=sse", *uri)
+	assert.Contains(t, *captured, "contents")
+}
+
+func TestGeminiComplete_NonStreaming(t *testing.T) {
+	srv, _, uri := geminiServer(t, http.StatusOK, geminiContentBody("summary text"), false)
+	cfg := baseConfig(domain.ProviderGemini, geminiBaseURL(srv))
+	cfg.Stream = false
+	client := newTestClient(t, cfg)
+
+	out, err := client.Complete(context.Background(), "prompt")
+
+	require.NoError(t, err)
+	assert.Equal(t, "summary text", out)
+	assert.Equal(t, "/v1beta/models/test-model:generateContent", *uri)
+}
+
+func TestGeminiComplete_AcceptsModelResourceName(t *testing.T) {
+	srv, _, uri := geminiServer(t, http.StatusOK, geminiContentBody("ok"), false)
+	cfg := baseConfig(domain.ProviderGemini, geminiBaseURL(srv)+"/")
+	cfg.Model = "models/test-model"
+	cfg.Stream = false
+	client := newTestClient(t, cfg)
+
+	_, err := client.Complete(context.Background(), "prompt")
+
+	require.NoError(t, err)
+	assert.Equal(t, "/v1beta/models/test-model:generateContent", *uri)
+}
+
+func TestGeminiComplete_SkipsThoughtParts(t *testing.T) {
+	body := `{"candidates":[{"content":{"role":"model","parts":[{"text":"Considering the agenda...","thought":true},{"text":"summary text"}]},"finishReason":"STOP"}]}`
+	srv, _, _ := geminiServer(t, http.StatusOK, body, false)
+	cfg := baseConfig(domain.ProviderGemini, geminiBaseURL(srv))
+	cfg.Stream = false
+	client := newTestClient(t, cfg)
+
+	out, err := client.Complete(context.Background(), "prompt")
+
+	require.NoError(t, err)
+	assert.Equal(t, "summary text", out)
+}
+
+func TestGeminiComplete_SendsPromptAsUserContent(t *testing.T) {
+	srv, captured, _ := geminiServer(t, http.StatusOK, geminiStreamBody("ok"), true)
+	client := newTestClient(t, baseConfig(domain.ProviderGemini, geminiBaseURL(srv)))
+
+	_, err := client.Complete(context.Background(), "TRANSCRIPT MARKER")
+	require.NoError(t, err)
+
+	contents, ok := (*captured)["contents"].([]any)
+	require.True(t, ok, "contents should be an array")
+	require.Len(t, contents, 1)
+
+	content := contents[0].(map[string]any)
+	assert.Equal(t, "user", content["role"])
+	assert.Contains(t, mustJSON(content["parts"]), "TRANSCRIPT MARKER")
+	assert.NotContains(t, *captured, "systemInstruction")
+}
+
+func TestGeminiComplete_SendsSystemInstruction(t *testing.T) {
+	srv, captured, _ := geminiServer(t, http.StatusOK, geminiStreamBody("ok"), true)
+	cfg := baseConfig(domain.ProviderGemini, geminiBaseURL(srv))
+	cfg.SystemPrompt = "You are terse."
+	client := newTestClient(t, cfg)
+
+	_, err := client.Complete(context.Background(), "prompt")
+	require.NoError(t, err)
+
+	assert.Contains(t, mustJSON((*captured)["systemInstruction"]), "You are terse.")
+}
+
+func TestGeminiComplete_GenerationConfig(t *testing.T) {
+	srv, captured, _ := geminiServer(t, http.StatusOK, geminiStreamBody("ok"), true)
+	client := newTestClient(t, baseConfig(domain.ProviderGemini, geminiBaseURL(srv)))
+
+	_, err := client.Complete(context.Background(), "prompt")
+	require.NoError(t, err)
+
+	generation := (*captured)["generationConfig"].(map[string]any)
+	assert.Equal(t, float64(1024), generation["maxOutputTokens"])
+	assert.NotContains(t, generation, "temperature")
+}
+
+func TestGeminiComplete_SendsConfiguredTemperature(t *testing.T) {
+	srv, captured, _ := geminiServer(t, http.StatusOK, geminiStreamBody("ok"), true)
+	cfg := baseConfig(domain.ProviderGemini, geminiBaseURL(srv))
+	temperature := 0.7
+	cfg.Temperature = &temperature
+	client := newTestClient(t, cfg)
+
+	_, err := client.Complete(context.Background(), "prompt")
+	require.NoError(t, err)
+
+	generation := (*captured)["generationConfig"].(map[string]any)
+	assert.Equal(t, 0.7, generation["temperature"])
+}
+
+func TestGeminiComplete_EmptyResponse(t *testing.T) {
+	srv, _, _ := geminiServer(t, http.StatusOK, geminiStreamBody(""), true)
+	client := newTestClient(t, baseConfig(domain.ProviderGemini, geminiBaseURL(srv)))
+
+	_, err := client.Complete(context.Background(), "prompt")
+
+	llmErr := requireKind(t, err, llm.KindEmptyResponse)
+	assert.False(t, llmErr.Permanent(), "an empty response is worth retrying")
+	assert.Contains(t, llmErr.Hint, "max_tokens")
+}
+
+func TestGeminiComplete_BlockedPrompt(t *testing.T) {
+	srv, _, _ := geminiServer(t, http.StatusOK, `{"promptFeedback":{"blockReason":"SAFETY"}}`, false)
+	cfg := baseConfig(domain.ProviderGemini, geminiBaseURL(srv))
+	cfg.Stream = false
+	client := newTestClient(t, cfg)
+
+	_, err := client.Complete(context.Background(), "prompt")
+
+	llmErr := requireKind(t, err, llm.KindInvalidRequest)
+	assert.True(t, llmErr.Permanent())
+	assert.Contains(t, llmErr.Hint, "SAFETY")
+}
+
+func TestGeminiComplete_FinishReason(t *testing.T) {
+	tests := []struct {
+		name      string
+		reason    string
+		kind      llm.Kind
+		permanent bool
+		hint      string
+	}{
+		{"blocked by the safety filters", "SAFETY", llm.KindInvalidRequest, true, "blocked (SAFETY)"},
+		{"blocked as recitation", "RECITATION", llm.KindInvalidRequest, true, "blocked (RECITATION)"},
+		{"stopped for another reason", "OTHER", llm.KindUnknown, false, "ended early (OTHER)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body := strings.Replace(geminiStreamBody("# Summary\n\nPartial"), `"finishReason":"STOP"`, `"finishReason":"`+tt.reason+`"`, 1)
+			srv, _, _ := geminiServer(t, http.StatusOK, body, true)
+			cfg := baseConfig(domain.ProviderGemini, geminiBaseURL(srv))
+			cfg.MaxTokens = 1024
+			client := newTestClient(t, cfg)
+
+			_, err := client.Complete(context.Background(), "prompt")
+
+			llmErr := requireKind(t, err, tt.kind)
+			assert.Equal(t, tt.permanent, llmErr.Permanent())
+			assert.Contains(t, llmErr.Hint, tt.hint)
+		})
+	}
+}
+
+func TestGeminiComplete_TruncatedAtMaxTokens(t *testing.T) {
+	body := strings.Replace(geminiStreamBody("# Summary\n\nPartial"), `"finishReason":"STOP"`, `"finishReason":"MAX_TOKENS"`, 1)
+	srv, _, _ := geminiServer(t, http.StatusOK, body, true)
+	client := newTestClient(t, baseConfig(domain.ProviderGemini, geminiBaseURL(srv)))
+
+	out, err := client.Complete(context.Background(), "prompt")
+
+	require.NoError(t, err, "a truncated response is returned, as the other providers return theirs")
+	assert.Equal(t, "# Summary\n\nPartial", out)
+}
+
+func TestGeminiComplete_ErrorInStream(t *testing.T) {
+	body := geminiStreamBody("partial") + "data: " + `{"error":{"code":503,"message":"The model is overloaded.","status":"UNAVAILABLE"}}` + "\r\n\r\n"
+	srv, _, _ := geminiServer(t, http.StatusOK, body, true)
+	client := newTestClient(t, baseConfig(domain.ProviderGemini, geminiBaseURL(srv)))
+
+	_, err := client.Complete(context.Background(), "prompt")
+
+	llmErr := requireKind(t, err, llm.KindServer)
+	assert.Equal(t, http.StatusServiceUnavailable, llmErr.Status)
+}
+
+func TestGeminiComplete_ErrorClassification(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   int
+		body     string
+		wantKind llm.Kind
+		wantPerm bool
+		wantHint string
+	}{
+		{
+			name:     "invalid api key",
+			status:   http.StatusBadRequest,
+			body:     `{"error":{"code":400,"message":"API key not valid. Please pass a valid API key.","status":"INVALID_ARGUMENT","details":[{"@type":"type.googleapis.com/google.rpc.ErrorInfo","reason":"API_KEY_INVALID"}]}}`,
+			wantKind: llm.KindAuth,
+			wantPerm: true,
+			wantHint: "$" + testKeyEnv,
+		},
+		{
+			name:     "permission denied",
+			status:   http.StatusForbidden,
+			body:     `{"error":{"code":403,"message":"Method doesn't allow unregistered callers.","status":"PERMISSION_DENIED"}}`,
+			wantKind: llm.KindAuth,
+			wantPerm: true,
+		},
+		{
+			name:     "model not found",
+			status:   http.StatusNotFound,
+			body:     `{"error":{"code":404,"message":"models/test-model is not found for API version v1beta, or is not supported for generateContent.","status":"NOT_FOUND"}}`,
+			wantKind: llm.KindModelNotFound,
+			wantPerm: true,
+			wantHint: "llm.base_url",
+		},
+		{
+			name:     "context window exceeded",
+			status:   http.StatusBadRequest,
+			body:     `{"error":{"code":400,"message":"The input token count (1200000) exceeds the maximum number of tokens allowed (1048576).","status":"INVALID_ARGUMENT"}}`,
+			wantKind: llm.KindContextWindow,
+			wantPerm: true,
+			wantHint: "larger-context model",
+		},
+		{
+			name:     "temperature rejected",
+			status:   http.StatusBadRequest,
+			body:     `{"error":{"code":400,"message":"Invalid value at 'generation_config.temperature' (TYPE_FLOAT), \"hot\"","status":"INVALID_ARGUMENT"}}`,
+			wantKind: llm.KindInvalidRequest,
+			wantPerm: true,
+			wantHint: "llm.temperature",
+		},
+		{
+			name:     "error wrapped in an array",
+			status:   http.StatusTooManyRequests,
+			body:     `[{"error":{"code":429,"message":"Resource has been exhausted (e.g. check quota).","status":"RESOURCE_EXHAUSTED"}}]`,
+			wantKind: llm.KindRateLimit,
+			wantPerm: false,
+		},
+		{
+			name:     "server error",
+			status:   http.StatusInternalServerError,
+			body:     `{"error":{"code":500,"message":"An internal error has occurred.","status":"INTERNAL"}}`,
+			wantKind: llm.KindServer,
+			wantPerm: false,
+		},
+		{
+			name:     "not json",
+			status:   http.StatusBadGateway,
+			body:     `<html>Bad Gateway</html>`,
+			wantKind: llm.KindServer,
+			wantPerm: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, _, _ := geminiServer(t, tt.status, tt.body, false)
+			client := newTestClient(t, baseConfig(domain.ProviderGemini, geminiBaseURL(srv)))
+
+			_, err := client.Complete(context.Background(), "prompt")
+
+			llmErr := requireKind(t, err, tt.wantKind)
+			assert.Equal(t, tt.wantPerm, llmErr.Permanent())
+			assert.Equal(t, tt.status, llmErr.Status)
+			if tt.wantHint != "" {
+				assert.Contains(t, llmErr.Hint, tt.wantHint)
+			}
+		})
+	}
+}
+
+func TestGeminiComplete_RetriesTransientFailures(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("content-type", "application/json")
+		if calls.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_, _ = w.Write([]byte(`{"error":{"code":503,"message":"The model is overloaded.","status":"UNAVAILABLE"}}`))
+			return
+		}
+		_, _ = w.Write([]byte(geminiContentBody("summary text")))
+	}))
+	t.Cleanup(srv.Close)
+	cfg := baseConfig(domain.ProviderGemini, geminiBaseURL(srv))
+	cfg.Stream = false
+	cfg.MaxRetries = 1
+	client := newTestClient(t, cfg)
+
+	out, err := client.Complete(context.Background(), "prompt")
+
+	require.NoError(t, err)
+	assert.Equal(t, "summary text", out)
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+func TestGeminiComplete_DoesNotRetryPermanentFailures(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.Header().Set("content-type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"error":{"code":404,"message":"models/test-model is not found","status":"NOT_FOUND"}}`))
+	}))
+	t.Cleanup(srv.Close)
+	cfg := baseConfig(domain.ProviderGemini, geminiBaseURL(srv))
+	cfg.MaxRetries = 3
+	client := newTestClient(t, cfg)
+
+	_, err := client.Complete(context.Background(), "prompt")
+
+	requireKind(t, err, llm.KindModelNotFound)
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestGeminiComplete_TransportError(t *testing.T) {
+	client := newTestClient(t, baseConfig(domain.ProviderGemini, "http://127.0.0.1:1/v1beta"))
+
+	_, err := client.Complete(context.Background(), "prompt")
+
+	llmErr := requireKind(t, err, llm.KindTransport)
+	assert.False(t, llmErr.Permanent())
+	assert.Contains(t, llmErr.Hint, "llm.base_url")
+}
+
+func TestGeminiPing_UsesOneToken(t *testing.T) {
+	srv, captured, _ := geminiServer(t, http.StatusOK, geminiStreamBody(""), true)
+	client := newTestClient(t, baseConfig(domain.ProviderGemini, geminiBaseURL(srv)))
+
+	err := client.Ping(context.Background())
+
+	require.NoError(t, err)
+	generation := (*captured)["generationConfig"].(map[string]any)
+	assert.Equal(t, float64(1), generation["maxOutputTokens"])
+}
+
+func TestGeminiPing_AcceptsTruncatedResponse(t *testing.T) {
+	body := `{"candidates":[{"content":{"role":"model","parts":[{"text":"Pong"}]},"finishReason":"MAX_TOKENS"}]}`
+	srv, _, _ := geminiServer(t, http.StatusOK, body, false)
+	cfg := baseConfig(domain.ProviderGemini, geminiBaseURL(srv))
+	cfg.Stream = false
+	client := newTestClient(t, cfg)
+
+	require.NoError(t, client.Ping(context.Background()))
+}
+
+func TestGeminiPing_ReportsAuthFailure(t *testing.T) {
+	srv, _, _ := geminiServer(t, http.StatusBadRequest,
+		`{"error":{"code":400,"message":"API key not valid. Please pass a valid API key.","status":"INVALID_ARGUMENT"}}`, false)
+	client := newTestClient(t, baseConfig(domain.ProviderGemini, geminiBaseURL(srv)))
+
+	err := client.Ping(context.Background())
+
+	llmErr := requireKind(t, err, llm.KindAuth)
+	assert.Contains(t, llmErr.Hint, "$"+testKeyEnv)
+}
@@ -1,8 +1,9 @@
 // Package llm provides a provider-agnostic client for large language model
-// completions. Two wire protocols are supported: Anthropic-compatible
-// (POST /v1/messages) and OpenAI-compatible (POST /v1/chat/completions).
+// completions. Three wire protocols are supported: Anthropic-compatible
+// (POST /v1/messages), OpenAI-compatible (POST /v1/chat/completions), and
+// Google Gemini (POST /v1beta/models/{model}:generateContent).
 //
-// Because both are reachable at an arbitrary base URL, the same two
+// Because each is reachable at an arbitrary base URL, the same three
 // implementations cover first-party APIs, gateways such as OpenRouter or Groq,
 // and self-hosted servers such as Ollama, vLLM, and LM Studio.
 package llm
@@ -58,6 +59,8 @@ func New(cfg domain.LLMConfig) (Client, error) {
 		return newAnthropicClient(cfg, apiKey), nil
 	case domain.ProviderOpenAI:
 		return newOpenAIClient(cfg, apiKey), nil
+	case domain.ProviderGemini:
+		return newGeminiClient(cfg, apiKey), nil
 	default:
 		return nil, fmt.Errorf("llm: unknown provider %q; supported: %v", cfg.Provider, domain.Providers())
 	}
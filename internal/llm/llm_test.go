@@ -38,12 +38,13 @@ func newTestClient(t *testing.T, cfg domain.LLMConfig) llm.Client {
 func TestNew_UnknownProvider(t *testing.T) {
 	t.Setenv(testKeyEnv, "test-key")
 
-	_, err := llm.New(baseConfig("gemini", ""))
+	_, err := llm.New(baseConfig("mistral", ""))
 
 	require.Error(t, err)
-	assert.Contains(t, err.Error(), `unknown provider "gemini"`)
+	assert.Contains(t, err.Error(), `unknown provider "mistral"`)
 	assert.Contains(t, err.Error(), domain.ProviderAnthropic)
 	assert.Contains(t, err.Error(), domain.ProviderOpenAI)
+	assert.Contains(t, err.Error(), domain.ProviderGemini)
 }
 
 func TestNew_MissingAPIKey(t *testing.T) {
@@ -95,6 +96,7 @@ func TestNew_Describe(t *testing.T) {
 	}{
 		{domain.ProviderAnthropic, "anthropic/test-model"},
 		{domain.ProviderOpenAI, "openai/test-model"},
+		{domain.ProviderGemini, "gemini/test-model"},
 	}
 
 	for _, tt := range tests {
@@ -56,18 +56,39 @@ func InjectFrontmatter(fm map[string]interface{}, body string) (string, error) {
 	return sb.String(), nil
 }
 
+// SetFrontmatterField sets a top-level frontmatter key to a scalar value,
+// replacing the key's line if it is already present and otherwise adding it
+// at the end of the frontmatter. The rest of the document is left as written,
+// key order included. Content without frontmatter is returned unchanged.
+func SetFrontmatterField(content, key, value string) string {
+	if !strings.HasPrefix(content, frontmatterDelimiter+"\n") {
+		return content
+	}
+	lines := strings.Split(content, "\n")
+	for i := 1; i < len(lines); i++ {
+		line := lines[i]
+		if strings.TrimRight(line, " \t") == frontmatterDelimiter {
+			lines = append(lines[:i], append([]string{key + ": " + value}, lines[i:]...)...)
+			return strings.Join(lines, "\n")
+		}
+		if strings.HasPrefix(line, key+":") {
+			lines[i] = key + ": " + value
+			return strings.Join(lines, "\n")
+		}
+	}
+	return content
+}
+
 // HasFrontmatter returns true if the content starts with a frontmatter delimiter.
 func HasFrontmatter(content string) bool {
 	return strings.HasPrefix(strings.TrimSpace(content), frontmatterDelimiter)
 }
 
-// RequiredFrontmatterKeys returns the keys that must be present in a valid summary.
-var RequiredFrontmatterKeys = []string{"date", "author", "tags", "source", "meeting_date"}
-
-// ValidateFrontmatter checks that all required keys are present.
-func ValidateFrontmatter(fm map[string]interface{}) []string {
+// ValidateFrontmatter returns the required keys missing from fm. The required
+// keys are configured per body; see domain.ValidationRules.
+func ValidateFrontmatter(fm map[string]interface{}, required []string) []string {
 	var missing []string
-	for _, key := range RequiredFrontmatterKeys {
+	for _, key := range required {
 		if _, ok := fm[key]; !ok {
 			missing = append(missing, key)
 		}
@@ -1,6 +1,7 @@
 package markdown_test
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/AvogadroSG1/civic-summary/internal/markdown"
@@ -62,6 +63,23 @@ func TestInjectFrontmatter(t *testing.T) {
 	assert.Contains(t, result, "# Title")
 }
 
+func TestSetFrontmatterField(t *testing.T) {
+	doc := "---\ndate: 2025-02-05\ntags:\n  - Council\n---\n\n# Title\n\nduration: not frontmatter"
+
+	added := markdown.SetFrontmatterField(doc, "duration", `"01:02:03"`)
+	assert.Equal(t, "---\ndate: 2025-02-05\ntags:\n  - Council\nduration: \"01:02:03\"\n---\n\n# Title\n\nduration: not frontmatter", added)
+
+	replaced := markdown.SetFrontmatterField(added, "duration", `"00:45:00"`)
+	assert.Contains(t, replaced, "  - Council\nduration: \"00:45:00\"\n---")
+	assert.Equal(t, 1, strings.Count(replaced, "duration: \""))
+
+	fm, _, err := markdown.ParseFrontmatter(replaced)
+	require.NoError(t, err)
+	assert.Equal(t, "00:45:00", fm["duration"])
+
+	assert.Equal(t, "# No frontmatter", markdown.SetFrontmatterField("# No frontmatter", "duration", "1"))
+}
+
 func TestHasFrontmatter(t *testing.T) {
 	assert.True(t, markdown.HasFrontmatter("---\ndate: 2025\n---\n"))
 	assert.True(t, markdown.HasFrontmatter("  ---\ndate: 2025\n---\n"))
@@ -69,6 +87,8 @@ func TestHasFrontmatter(t *testing.T) {
 	assert.False(t, markdown.HasFrontmatter(""))
 }
 
+var requiredKeys = []string{"date", "author", "tags", "source", "meeting_date"}
+
 func TestValidateFrontmatter_AllPresent(t *testing.T) {
 	fm := map[string]interface{}{
 		"date":         "2025-02-05",
@@ -77,7 +97,7 @@ func TestValidateFrontmatter_AllPresent(t *testing.T) {
 		"source":       "https://youtube.com/watch?v=abc",
 		"meeting_date": "2025-02-04",
 	}
-	missing := markdown.ValidateFrontmatter(fm)
+	missing := markdown.ValidateFrontmatter(fm, requiredKeys)
 	assert.Empty(t, missing)
 }
 
@@ -85,10 +105,17 @@ func TestValidateFrontmatter_MissingKeys(t *testing.T) {
 	fm := map[string]interface{}{
 		"date": "2025-02-05",
 	}
-	missing := markdown.ValidateFrontmatter(fm)
+	missing := markdown.ValidateFrontmatter(fm, requiredKeys)
 	assert.Len(t, missing, 4) // author, tags, source, meeting_date
 	assert.Contains(t, missing, "author")
 	assert.Contains(t, missing, "tags")
 	assert.Contains(t, missing, "source")
 	assert.Contains(t, missing, "meeting_date")
 }
+
+func TestValidateFrontmatter_CustomKeys(t *testing.T) {
+	fm := map[string]interface{}{"date": "2025-02-05"}
+
+	assert.Empty(t, markdown.ValidateFrontmatter(fm, []string{"date"}))
+	assert.Equal(t, []string{"agenda"}, markdown.ValidateFrontmatter(fm, []string{"date", "agenda"}))
+}
@@ -0,0 +1,78 @@
+package markdown
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// timestampRefPattern matches a bracketed timestamp or range as written in
+// summaries: "[01:23:45]" or "[01:23:45-01:30:00]". En and em dashes are
+// accepted in ranges because models substitute them freely.
+var timestampRefPattern = regexp.MustCompile(`\[(\d{1,2}:\d{2}:\d{2})(?:\s*[-–—]\s*(\d{1,2}:\d{2}:\d{2}))?\]`)
+
+// TimestampRef is a timestamp or range found in a summary.
+type TimestampRef struct {
+	Start time.Duration
+	// End is zero for a single timestamp.
+	End time.Duration
+}
+
+// FindTimestamps returns every timestamp and range in content, linked or not.
+func FindTimestamps(content string) []TimestampRef {
+	var refs []TimestampRef
+	for _, m := range timestampRefPattern.FindAllStringSubmatch(content, -1) {
+		ref := TimestampRef{Start: parseClock(m[1])}
+		if m[2] != "" {
+			ref.End = parseClock(m[2])
+		}
+		refs = append(refs, ref)
+	}
+	return refs
+}
+
+// LinkTimestamps turns each bracketed timestamp or range into a markdown link
+// to videoURL at the start of that moment, e.g. "[00:12:34]" becomes
+// "[00:12:34](https://www.youtube.com/watch?v=abc&t=754s)". Timestamps that
+// are already links are left alone, so the function is idempotent.
+func LinkTimestamps(content, videoURL string) string {
+	matches := timestampRefPattern.FindAllStringSubmatchIndex(content, -1)
+	if len(matches) == 0 {
+		return content
+	}
+
+	separator := "?"
+	if strings.Contains(videoURL, "?") {
+		separator = "&"
+	}
+
+	var b strings.Builder
+	last := 0
+	for _, m := range matches {
+		start, end := m[0], m[1]
+		b.WriteString(content[last:start])
+		last = end
+
+		ref := content[start:end]
+		if strings.HasPrefix(content[end:], "(") {
+			b.WriteString(ref)
+			continue
+		}
+		seconds := int(parseClock(content[m[2]:m[3]]) / time.Second)
+		fmt.Fprintf(&b, "%s(%s%st=%ds)", ref, videoURL, separator, seconds)
+	}
+	b.WriteString(content[last:])
+	return b.String()
+}
+
+// parseClock parses H:MM:SS or HH:MM:SS. The input is pre-validated by
+// timestampRefPattern.
+func parseClock(s string) time.Duration {
+	parts := strings.Split(s, ":")
+	h, _ := strconv.Atoi(parts[0])
+	m, _ := strconv.Atoi(parts[1])
+	sec, _ := strconv.Atoi(parts[2])
+	return time.Duration(h)*time.Hour + time.Duration(m)*time.Minute + time.Duration(sec)*time.Second
+}
@@ -0,0 +1,57 @@
+package markdown_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/AvogadroSG1/civic-summary/internal/markdown"
+	"github.com/stretchr/testify/assert"
+)
+
+const testVideoURL = "https://www.youtube.com/watch?v=abc123"
+
+func TestLinkTimestamps_Single(t *testing.T) {
+	content := "### Budget Vote **[01:02:03]**"
+
+	result := markdown.LinkTimestamps(content, testVideoURL)
+
+	assert.Equal(t, "### Budget Vote **[01:02:03](https://www.youtube.com/watch?v=abc123&t=3723s)**", result)
+}
+
+func TestLinkTimestamps_RangeLinksToStart(t *testing.T) {
+	content := "**[00:12:34-00:15:00]** Zoning and **[0:20:00 – 0:25:00]** parks"
+
+	result := markdown.LinkTimestamps(content, testVideoURL)
+
+	assert.Contains(t, result, "[00:12:34-00:15:00](https://www.youtube.com/watch?v=abc123&t=754s)")
+	assert.Contains(t, result, "[0:20:00 – 0:25:00](https://www.youtube.com/watch?v=abc123&t=1200s)")
+}
+
+func TestLinkTimestamps_Idempotent(t *testing.T) {
+	once := markdown.LinkTimestamps("Vote at [00:00:10].", testVideoURL)
+
+	assert.Equal(t, once, markdown.LinkTimestamps(once, testVideoURL))
+}
+
+func TestLinkTimestamps_URLWithoutQuery(t *testing.T) {
+	result := markdown.LinkTimestamps("[00:01:00]", "https://example.com/video.mp4")
+
+	assert.Equal(t, "[00:01:00](https://example.com/video.mp4?t=60s)", result)
+}
+
+func TestLinkTimestamps_LeavesOtherBracketsAlone(t *testing.T) {
+	content := "See [[Other-Summary|March 4, 2025]] and [note] and [12:30 PM]."
+
+	assert.Equal(t, content, markdown.LinkTimestamps(content, testVideoURL))
+}
+
+func TestFindTimestamps(t *testing.T) {
+	content := "[00:00:05] opening, [01:00:00-01:30:00](https://x?t=3600s) budget"
+
+	refs := markdown.FindTimestamps(content)
+
+	assert.Equal(t, []markdown.TimestampRef{
+		{Start: 5 * time.Second},
+		{Start: time.Hour, End: 90 * time.Minute},
+	}, refs)
+}
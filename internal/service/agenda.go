@@ -0,0 +1,208 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"html"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/AvogadroSG1/civic-summary/internal/domain"
+	"github.com/AvogadroSG1/civic-summary/internal/executor"
+)
+
+const (
+	// maxAgendaBytes caps the download size; agendas with embedded packets can
+	// run to hundreds of pages.
+	maxAgendaBytes = 20 << 20
+	// maxAgendaChars caps the text injected into the prompt so the agenda
+	// cannot crowd out the transcript.
+	maxAgendaChars = 30000
+)
+
+var (
+	urlPattern = regexp.MustCompile(`https?://[^\s<>"'()\[\]]+`)
+
+	htmlDropPattern  = regexp.MustCompile(`(?is)<(script|style|head|noscript)\b.*?</(script|style|head|noscript)>`)
+	htmlBreakPattern = regexp.MustCompile(`(?i)<(br|/p|/div|/li|/tr|/h[1-6]|/table|/section)\b[^>]*>`)
+	htmlTagPattern   = regexp.MustCompile(`(?s)<[^>]*>`)
+	blankRunPattern  = regexp.MustCompile(`\n{3,}`)
+)
+
+// AgendaService finds and extracts the published agenda for a meeting.
+type AgendaService struct {
+	sources    VideoSourceFor
+	pdftotext  *executor.PdfToTextExecutor
+	httpClient *http.Client
+}
+
+// NewAgendaService creates a new AgendaService. pdftotext may be nil, in which
+// case PDF agendas are linked but their text is not extracted.
+func NewAgendaService(sources VideoSourceFor, pdftotext *executor.PdfToTextExecutor, httpClient *http.Client) *AgendaService {
+	return &AgendaService{sources: sources, pdftotext: pdftotext, httpClient: httpClient}
+}
+
+// Find returns the agenda for a meeting. The URL comes from an agenda link in
+// the video description, falling back to the body's agenda_url_pattern.
+// An agenda is enrichment, not a requirement: failures are logged and an
+// empty or URL-only agenda is returned rather than an error.
+func (s *AgendaService) Find(ctx context.Context, meeting domain.Meeting, body domain.Body) domain.Agenda {
+	agendaURL := s.urlFromDescription(ctx, meeting, body)
+	fromPattern := false
+	if agendaURL == "" {
+		url, err := body.AgendaURLFor(meeting.MeetingDate)
+		if err != nil {
+			slog.Warn("agenda url pattern failed", "body", body.Slug, "error", err)
+		}
+		agendaURL, fromPattern = url, true
+	}
+	if agendaURL == "" {
+		return domain.Agenda{}
+	}
+
+	text, err := s.Fetch(ctx, agendaURL)
+	if err != nil {
+		// A pattern-built URL is a guess: no document means no agenda.
+		if fromPattern {
+			slog.Info("no agenda at pattern url", "video_id", meeting.VideoID, "url", agendaURL, "error", err)
+			return domain.Agenda{}
+		}
+		slog.Warn("agenda text unavailable", "video_id", meeting.VideoID, "url", agendaURL, "error", err)
+		return domain.Agenda{URL: agendaURL}
+	}
+
+	slog.Info("agenda found",
+		"video_id", meeting.VideoID,
+		"url", agendaURL,
+		"chars", len(text),
+	)
+	return domain.Agenda{URL: agendaURL, Text: text}
+}
+
+// Fetch downloads an agenda and returns its text. PDFs are extracted with
+// pdftotext; HTML is reduced to plain text; anything else is returned as-is.
+func (s *AgendaService) Fetch(ctx context.Context, agendaURL string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, agendaURL, nil)
+	if err != nil {
+		return "", fmt.Errorf("building agenda request: %w", err)
+	}
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("fetching agenda: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("fetching agenda: HTTP %d", resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxAgendaBytes))
+	if err != nil {
+		return "", fmt.Errorf("reading agenda: %w", err)
+	}
+
+	contentType := strings.ToLower(resp.Header.Get("Content-Type"))
+	var text string
+	switch {
+	case strings.Contains(contentType, "application/pdf") || bytes.HasPrefix(data, []byte("%PDF-")):
+		text, err = s.extractPDF(ctx, data)
+		if err != nil {
+			return "", err
+		}
+	case strings.Contains(contentType, "html") || looksLikeHTML(data):
+		text = htmlToText(string(data))
+	default:
+		text = string(data)
+	}
+
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return "", fmt.Errorf("agenda has no extractable text")
+	}
+	return truncateAgenda(text), nil
+}
+
+// urlFromDescription returns the first agenda link in the video description.
+func (s *AgendaService) urlFromDescription(ctx context.Context, meeting domain.Meeting, body domain.Body) string {
+	videoSource, err := s.sources(body)
+	if err != nil {
+		slog.Warn("video source unavailable", "body", body.Slug, "error", err)
+		return ""
+	}
+	description, err := videoSource.Description(ctx, meeting)
+	if err != nil {
+		slog.Warn("video description unavailable", "video_id", meeting.VideoID, "error", err)
+		return ""
+	}
+	return findAgendaLink(description)
+}
+
+// findAgendaLink returns the first URL that mentions "agenda" in itself or on
+// its line of the description.
+func findAgendaLink(description string) string {
+	for _, line := range strings.Split(description, "\n") {
+		if !strings.Contains(strings.ToLower(line), "agenda") {
+			continue
+		}
+		if url := urlPattern.FindString(line); url != "" {
+			return strings.TrimRight(url, ".,;:")
+		}
+	}
+	return ""
+}
+
+// extractPDF writes the PDF to a temporary file and extracts its text.
+func (s *AgendaService) extractPDF(ctx context.Context, data []byte) (string, error) {
+	if s.pdftotext == nil {
+		return "", fmt.Errorf("agenda is a PDF and tools.pdftotext is not configured")
+	}
+	f, err := os.CreateTemp("", "civic-summary-agenda-*.pdf")
+	if err != nil {
+		return "", fmt.Errorf("creating temp file: %w", err)
+	}
+	defer func() { _ = os.Remove(f.Name()) }()
+
+	if _, err := f.Write(data); err != nil {
+		_ = f.Close()
+		return "", fmt.Errorf("writing temp file: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return "", fmt.Errorf("writing temp file: %w", err)
+	}
+	return s.pdftotext.Extract(ctx, f.Name())
+}
+
+// looksLikeHTML sniffs for markup when the server sends no useful Content-Type.
+func looksLikeHTML(data []byte) bool {
+	head := strings.ToLower(string(data[:min(len(data), 512)]))
+	return strings.Contains(head, "<html") || strings.Contains(head, "<!doctype html")
+}
+
+// htmlToText strips markup from an HTML agenda, keeping block boundaries as
+// line breaks so agenda items stay on separate lines.
+func htmlToText(doc string) string {
+	doc = htmlDropPattern.ReplaceAllString(doc, "")
+	doc = htmlBreakPattern.ReplaceAllString(doc, "\n")
+	doc = htmlTagPattern.ReplaceAllString(doc, "")
+	doc = html.UnescapeString(doc)
+
+	lines := strings.Split(doc, "\n")
+	for i, line := range lines {
+		lines[i] = strings.Join(strings.Fields(line), " ")
+	}
+	return blankRunPattern.ReplaceAllString(strings.Join(lines, "\n"), "\n\n")
+}
+
+// truncateAgenda caps agenda text at maxAgendaChars, marking the cut.
+func truncateAgenda(text string) string {
+	runes := []rune(text)
+	if len(runes) <= maxAgendaChars {
+		return text
+	}
+	return string(runes[:maxAgendaChars]) + "\n[agenda truncated]"
+}
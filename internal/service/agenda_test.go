@@ -0,0 +1,161 @@
+package service_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/AvogadroSG1/civic-summary/internal/domain"
+	"github.com/AvogadroSG1/civic-summary/internal/executor"
+	"github.com/AvogadroSG1/civic-summary/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const agendaHTML = `<!DOCTYPE html>
+<html><head><title>Agenda</title><style>p { color: red; }</style></head>
+<body>
+<h1>Mayor &amp; Council Regular Session</h1>
+<ol><li>Call to Order</li><li>Ordinance 2025-07: Parking Amendments</li></ol>
+<script>track();</script>
+</body></html>`
+
+// agendaServer serves an HTML agenda at /agenda.html, a PDF at /agenda.pdf,
+// and 404s elsewhere.
+func agendaServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	mux := http.NewServeMux()
+	mux.HandleFunc("/agenda.html", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		_, _ = w.Write([]byte(agendaHTML))
+	})
+	mux.HandleFunc("/agendas/2025-02-04.pdf", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/pdf")
+		_, _ = w.Write([]byte("%PDF-1.7 fake"))
+	})
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+// descriptionKey is the MockCommander key for fetching a video description.
+func descriptionKey(videoID string) string {
+	return "yt-dlp --skip-download --print %(description)s https://www.youtube.com/watch?v=" + videoID
+}
+
+func agendaMeeting() domain.Meeting {
+	return domain.Meeting{
+		VideoID:     "abc123",
+		MeetingDate: time.Date(2025, 2, 4, 0, 0, 0, 0, time.UTC),
+		BodySlug:    "hagerstown",
+	}
+}
+
+func TestAgendaService_Find_FromDescription(t *testing.T) {
+	srv := agendaServer(t)
+	mock := executor.NewMockCommander()
+	mock.OnCommand(descriptionKey("abc123"), &executor.CommandResult{
+		Stdout: "Regular session of the Mayor and Council.\nView the agenda: " + srv.URL + "/agenda.html.\nSubscribe for more.",
+	}, nil)
+
+	svc := service.NewAgendaService(youtubeSources(executor.NewYtDlpExecutor(mock, "yt-dlp")), nil, srv.Client())
+	agenda := svc.Find(context.Background(), agendaMeeting(), domain.Body{Slug: "hagerstown"})
+
+	assert.Equal(t, srv.URL+"/agenda.html", agenda.URL, "trailing punctuation is trimmed")
+	assert.Contains(t, agenda.Text, "Mayor & Council Regular Session")
+	assert.Contains(t, agenda.Text, "Ordinance 2025-07: Parking Amendments")
+	assert.NotContains(t, agenda.Text, "track()", "scripts are dropped")
+	assert.NotContains(t, agenda.Text, "color: red", "styles are dropped")
+	assert.NotContains(t, agenda.Text, "<li>")
+}
+
+func TestAgendaService_Find_FromPatternPDF(t *testing.T) {
+	srv := agendaServer(t)
+	mock := executor.NewMockCommander()
+	mock.OnCommand(descriptionKey("abc123"), &executor.CommandResult{Stdout: "No links here."}, nil)
+	mock.OnCommand("pdftotext", &executor.CommandResult{Stdout: "1. Call to Order\n2. Budget Hearing\n"}, nil)
+
+	svc := service.NewAgendaService(
+		youtubeSources(executor.NewYtDlpExecutor(mock, "yt-dlp")),
+		executor.NewPdfToTextExecutor(mock, "pdftotext"),
+		srv.Client(),
+	)
+	body := domain.Body{Slug: "hagerstown", AgendaURLPattern: srv.URL + "/agendas/{{.MeetingDate}}.pdf"}
+
+	agenda := svc.Find(context.Background(), agendaMeeting(), body)
+
+	assert.Equal(t, srv.URL+"/agendas/2025-02-04.pdf", agenda.URL)
+	assert.Equal(t, "1. Call to Order\n2. Budget Hearing", agenda.Text)
+	assert.True(t, strings.HasPrefix(mock.Calls[len(mock.Calls)-1], "pdftotext -layout "))
+}
+
+func TestAgendaService_Find_PatternNotFound(t *testing.T) {
+	srv := agendaServer(t)
+	mock := executor.NewMockCommander()
+	mock.OnCommand(descriptionKey("abc123"), &executor.CommandResult{Stdout: ""}, nil)
+
+	svc := service.NewAgendaService(youtubeSources(executor.NewYtDlpExecutor(mock, "yt-dlp")), nil, srv.Client())
+	body := domain.Body{Slug: "hagerstown", AgendaURLPattern: srv.URL + "/missing/{{.DateCompact}}.pdf"}
+
+	agenda := svc.Find(context.Background(), agendaMeeting(), body)
+
+	assert.True(t, agenda.IsEmpty(), "a guessed URL that 404s is not an agenda")
+}
+
+func TestAgendaService_Find_LinkedPDFWithoutExtractor(t *testing.T) {
+	srv := agendaServer(t)
+	mock := executor.NewMockCommander()
+	mock.OnCommand(descriptionKey("abc123"), &executor.CommandResult{
+		Stdout: "Agenda: " + srv.URL + "/agendas/2025-02-04.pdf",
+	}, nil)
+
+	svc := service.NewAgendaService(youtubeSources(executor.NewYtDlpExecutor(mock, "yt-dlp")), nil, srv.Client())
+	agenda := svc.Find(context.Background(), agendaMeeting(), domain.Body{Slug: "hagerstown"})
+
+	assert.Equal(t, srv.URL+"/agendas/2025-02-04.pdf", agenda.URL, "a linked agenda keeps its URL")
+	assert.Empty(t, agenda.Text)
+}
+
+func TestAgendaService_Find_NoSource(t *testing.T) {
+	mock := executor.NewMockCommander()
+	mock.OnCommand(descriptionKey("abc123"), &executor.CommandResult{
+		Stdout: "Watch live at https://example.gov/live",
+	}, nil)
+
+	svc := service.NewAgendaService(youtubeSources(executor.NewYtDlpExecutor(mock, "yt-dlp")), nil, http.DefaultClient)
+	agenda := svc.Find(context.Background(), agendaMeeting(), domain.Body{Slug: "hagerstown"})
+
+	assert.True(t, agenda.IsEmpty(), "links not mentioning an agenda are ignored")
+}
+
+func TestAgendaService_Fetch_TruncatesLongAgendas(t *testing.T) {
+	long := strings.Repeat("Item. ", 10000)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		_, _ = w.Write([]byte(long))
+	}))
+	defer srv.Close()
+
+	svc := service.NewAgendaService(nil, nil, srv.Client())
+	text, err := svc.Fetch(context.Background(), srv.URL)
+	require.NoError(t, err)
+
+	assert.Less(t, len(text), len(long))
+	assert.True(t, strings.HasSuffix(text, "[agenda truncated]"))
+}
+
+func TestAgendaService_Fetch_HTTPError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	svc := service.NewAgendaService(nil, nil, srv.Client())
+	_, err := svc.Fetch(context.Background(), srv.URL)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "HTTP 500")
+}
@@ -3,10 +3,12 @@ package service
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"os"
 	"path/filepath"
+	"strings"
 	"text/template"
 	"time"
 
@@ -20,15 +22,20 @@ import (
 // rather than once per run.
 type LLMClientFor func(body domain.Body) (llm.Client, error)
 
+// LLMConfigFor returns the resolved llm block for a body, which tells analysis
+// how large a prompt the body's model accepts.
+type LLMConfigFor func(body domain.Body) domain.LLMConfig
+
 // AnalysisService generates meeting summaries with a language model.
 type AnalysisService struct {
 	clientFor   LLMClientFor
+	configFor   LLMConfigFor
 	templateDir string
 }
 
 // NewAnalysisService creates a new AnalysisService.
-func NewAnalysisService(clientFor LLMClientFor, templateDir string) *AnalysisService {
-	return &AnalysisService{clientFor: clientFor, templateDir: templateDir}
+func NewAnalysisService(clientFor LLMClientFor, configFor LLMConfigFor, templateDir string) *AnalysisService {
+	return &AnalysisService{clientFor: clientFor, configFor: configFor, templateDir: templateDir}
 }
 
 // PromptData holds all data injected into a prompt template.
@@ -39,23 +46,47 @@ type PromptData struct {
 	VideoID          string
 	VideoURL         string
 	AgendaURL        string
+	AgendaText       string
 	TodayDate        string
 	Author           string
 	Tags             []string
 	Transcript       string
 	BodyName         string
 	FooterText       string
+	// ChunkCount is the number of segments the transcript was summarized in,
+	// or 0 when it was sent whole. When set, Transcript holds the timestamped
+	// segment notes rather than transcript lines.
+	ChunkCount int
+	// Roster is the body's officials, for spelling their names and titles.
+	// Empty when the body has no roster.
+	Roster []domain.RosterMember
+}
+
+// ChunkPromptData holds the data injected into a body's chunk template, which
+// summarizes one segment of a transcript too long to send whole.
+type ChunkPromptData struct {
+	BodyName         string
+	MeetingDateHuman string
+	MeetingType      string
+	ChunkIndex       int // 1-based
+	ChunkCount       int
+	StartTime        string // HH:MM:SS
+	EndTime          string // HH:MM:SS
+	Transcript       string
+	Roster           []domain.RosterMember
 }
 
 // Analyze sends the meeting transcript to the configured model and returns the
-// generated summary.
+// generated summary. A transcript too long for the model's context window is
+// summarized segment by segment, and the segment notes are then combined
+// through the body's normal prompt template.
 func (s *AnalysisService) Analyze(ctx context.Context, meeting domain.Meeting, transcript domain.Transcript, body domain.Body) (domain.Summary, error) {
 	client, err := s.clientFor(body)
 	if err != nil {
 		return domain.Summary{}, fmt.Errorf("building llm client: %w", err)
 	}
 
-	prompt, err := s.buildPrompt(meeting, transcript, body)
+	prompt, err := s.buildPrompt(meeting, transcript.Compact(), 0, body)
 	if err != nil {
 		return domain.Summary{}, fmt.Errorf("building prompt: %w", err)
 	}
@@ -67,49 +98,253 @@ func (s *AnalysisService) Analyze(ctx context.Context, meeting domain.Meeting, t
 		"transcript_words", transcript.WordCount(),
 	)
 
-	rawOutput, err := client.Complete(ctx, prompt)
+	promptTokens := estimateTokens(prompt)
+	budget := s.configFor(body).InputBudget(promptReserveTokens)
+
+	var rawOutput string
+	if budget > 0 && promptTokens > budget {
+		rawOutput, err = s.analyzeChunked(ctx, client, meeting, transcript, body, budget)
+	} else {
+		rawOutput, err = client.Complete(ctx, prompt)
+		budget = promptTokens
+	}
+	// The estimate was too optimistic, or the context window is not
+	// configured. Halve the budget and summarize in smaller chunks, until a
+	// pass fits or the chunk template leaves no room for transcript.
+	for isContextWindowError(err) {
+		budget /= 2
+		slog.Warn("prompt exceeded context window, retrying in smaller chunks",
+			"video_id", meeting.VideoID,
+			"estimated_tokens", promptTokens,
+			"budget_tokens", budget,
+		)
+		rawOutput, err = s.analyzeChunked(ctx, client, meeting, transcript, body, budget)
+	}
 	if err != nil {
 		return domain.Summary{}, fmt.Errorf("analysis: %w", err)
 	}
 
 	// Models sometimes prefix the document with meta-commentary; strip it.
-	content := markdown.Sanitize(rawOutput)
+	content := withMeetingDuration(markdown.Sanitize(rawOutput), meeting)
 
 	return domain.Summary{
 		Content: content,
 	}, nil
 }
 
-// buildPrompt renders the body-specific prompt template with meeting data.
-func (s *AnalysisService) buildPrompt(meeting domain.Meeting, transcript domain.Transcript, body domain.Body) (string, error) {
-	tmplPath := filepath.Join(s.templateDir, body.PromptTemplate)
-	tmplContent, err := os.ReadFile(tmplPath)
+// analyzeChunked is the map-reduce path. Each chunk of the transcript, split on
+// cue boundaries with overlap, is summarized with the body's chunk template;
+// the notes, each headed by its time span so every segment's timestamps
+// survive, then replace the transcript in the body's prompt template. Notes
+// too long together for budget are condensed first; see condenseNotes.
+func (s *AnalysisService) analyzeChunked(ctx context.Context, client llm.Client, meeting domain.Meeting, transcript domain.Transcript, body domain.Body, budget int) (string, error) {
+	overhead, err := s.buildChunkPrompt(meeting, body, transcriptChunk{}, 0, 0)
 	if err != nil {
-		return "", fmt.Errorf("reading template %s: %w", tmplPath, err)
+		return "", fmt.Errorf("building chunk prompt: %w", err)
+	}
+	chunkBudget := budget - estimateTokens(overhead)
+	if chunkBudget <= 0 {
+		return "", fmt.Errorf("chunk template %s leaves no room for transcript within %d tokens", body.ChunkTemplateName(), budget)
 	}
 
-	tmpl, err := template.New(body.PromptTemplate).Parse(string(tmplContent))
+	chunks := chunkTranscript(transcript.MergedCues(), chunkBudget)
+	slog.Info("transcript exceeds context window, summarizing in chunks",
+		"video_id", meeting.VideoID,
+		"chunks", len(chunks),
+		"chunk_budget_tokens", chunkBudget,
+	)
+
+	notes, err := s.takeNotes(ctx, client, meeting, body, chunks)
 	if err != nil {
-		return "", fmt.Errorf("parsing template: %w", err)
+		return "", err
+	}
+
+	for {
+		prompt, err := s.buildPrompt(meeting, renderNotes(notes), len(notes), body)
+		if err != nil {
+			return "", fmt.Errorf("building prompt: %w", err)
+		}
+		tokens := estimateTokens(prompt)
+		if tokens <= budget {
+			return client.Complete(ctx, prompt)
+		}
+		condensed, err := s.condenseNotes(ctx, client, meeting, body, notes, chunkBudget)
+		if err != nil {
+			return "", err
+		}
+		if len(condensed) == len(notes) {
+			// No two segments' notes fit one request. The estimate errs
+			// high, so the prompt may fit yet; if not, the provider's
+			// context-window error halves the budget.
+			slog.Warn("combined segment notes still exceed the input budget",
+				"video_id", meeting.VideoID,
+				"estimated_tokens", tokens,
+				"budget_tokens", budget,
+			)
+			return client.Complete(ctx, prompt)
+		}
+		slog.Info("combined segment notes exceed the input budget, condensing",
+			"video_id", meeting.VideoID,
+			"from", len(notes),
+			"to", len(condensed),
+		)
+		notes = condensed
 	}
+}
+
+// segmentNotes are the notes taken on a stretch of the recording.
+type segmentNotes struct {
+	Start string // HH:MM:SS, or "" for untimed cues
+	End   string
+	Text  string
+}
 
+// takeNotes summarizes each chunk with the body's chunk template.
+func (s *AnalysisService) takeNotes(ctx context.Context, client llm.Client, meeting domain.Meeting, body domain.Body, chunks []transcriptChunk) ([]segmentNotes, error) {
+	notes := make([]segmentNotes, len(chunks))
+	for i, chunk := range chunks {
+		prompt, err := s.buildChunkPrompt(meeting, body, chunk, i+1, len(chunks))
+		if err != nil {
+			return nil, fmt.Errorf("building chunk prompt: %w", err)
+		}
+		out, err := client.Complete(ctx, prompt)
+		if err != nil {
+			return nil, fmt.Errorf("chunk %d of %d: %w", i+1, len(chunks), err)
+		}
+		notes[i] = segmentNotes{Start: chunk.Start, End: chunk.End, Text: strings.TrimSpace(out)}
+	}
+	return notes, nil
+}
+
+// condenseNotes is one more reduce step for notes too long to combine in one
+// prompt. Consecutive notes are grouped, as many as fit in maxTokens, and each
+// group of two or more is summarized again with the chunk template as one
+// segment spanning the group. A note that fits with no neighbor is kept as it
+// is, so the result is shorter than notes unless no two notes fit together.
+func (s *AnalysisService) condenseNotes(ctx context.Context, client llm.Client, meeting domain.Meeting, body domain.Body, notes []segmentNotes, maxTokens int) ([]segmentNotes, error) {
+	var groups [][]segmentNotes
+	groupTokens := 0
+	for i, n := range notes {
+		tokens := estimateTokens(noteBlock(i, len(notes), n))
+		if len(groups) == 0 || groupTokens+tokens > maxTokens {
+			groups = append(groups, nil)
+			groupTokens = 0
+		}
+		groups[len(groups)-1] = append(groups[len(groups)-1], n)
+		groupTokens += tokens
+	}
+	if len(groups) == len(notes) {
+		return notes, nil
+	}
+
+	// Each group keeps its notes' headings, numbered among all the notes.
+	chunks := make([]transcriptChunk, len(groups))
+	first := 0
+	for i, g := range groups {
+		blocks := make([]string, len(g))
+		for j, n := range g {
+			blocks[j] = noteBlock(first+j, len(notes), n)
+		}
+		first += len(g)
+		chunks[i] = transcriptChunk{Content: strings.Join(blocks, "\n\n"), Start: g[0].Start, End: g[len(g)-1].End}
+	}
+	condensed := make([]segmentNotes, 0, len(groups))
+	for i, g := range groups {
+		if len(g) == 1 {
+			condensed = append(condensed, g[0])
+			continue
+		}
+		prompt, err := s.buildChunkPrompt(meeting, body, chunks[i], i+1, len(chunks))
+		if err != nil {
+			return nil, fmt.Errorf("building chunk prompt: %w", err)
+		}
+		out, err := client.Complete(ctx, prompt)
+		if err != nil {
+			return nil, fmt.Errorf("condensing notes %d of %d: %w", i+1, len(chunks), err)
+		}
+		condensed = append(condensed, segmentNotes{Start: chunks[i].Start, End: chunks[i].End, Text: strings.TrimSpace(out)})
+	}
+	return condensed, nil
+}
+
+// renderNotes joins notes for a prompt, each headed by its place and span.
+func renderNotes(notes []segmentNotes) string {
+	blocks := make([]string, len(notes))
+	for i, n := range notes {
+		blocks[i] = noteBlock(i, len(notes), n)
+	}
+	return strings.Join(blocks, "\n\n")
+}
+
+// noteBlock renders the i-th (0-based) of count notes.
+func noteBlock(i, count int, n segmentNotes) string {
+	return fmt.Sprintf("## Segment %d of %d [%s-%s]\n\n%s", i+1, count, n.Start, n.End, n.Text)
+}
+
+// isContextWindowError reports whether err is a provider rejection of an
+// oversized prompt.
+func isContextWindowError(err error) bool {
+	var llmErr *llm.Error
+	return errors.As(err, &llmErr) && llmErr.Kind == llm.KindContextWindow
+}
+
+// buildPrompt renders the body-specific prompt template with meeting data.
+// chunkCount is non-zero when transcript holds segment notes from the
+// map-reduce path rather than the compact transcript.
+func (s *AnalysisService) buildPrompt(meeting domain.Meeting, transcript string, chunkCount int, body domain.Body) (string, error) {
 	// Determine meeting type tag.
 	tags := make([]string, len(body.Tags))
 	copy(tags, body.Tags)
-	tags = append(tags, meetingTypeTag(meeting.MeetingType))
+	tags = append(tags, body.MeetingTypeTag(meeting.MeetingType))
 
 	data := PromptData{
 		MeetingDateHuman: meeting.HumanDate(),
 		MeetingDateISO:   meeting.ISODate(),
 		MeetingType:      meeting.MeetingType,
 		VideoID:          meeting.VideoID,
-		VideoURL:         body.VideoURL(meeting.VideoID),
+		VideoURL:         body.MeetingVideoURL(meeting),
+		AgendaURL:        meeting.Agenda.URL,
+		AgendaText:       meeting.Agenda.Text,
 		TodayDate:        time.Now().Format("2006-01-02"),
 		Author:           body.Author,
 		Tags:             tags,
-		Transcript:       transcript.Content,
+		Transcript:       transcript,
 		BodyName:         body.Name,
 		FooterText:       body.FooterText,
+		ChunkCount:       chunkCount,
+		Roster:           body.Roster,
+	}
+
+	return s.render(body.PromptTemplate, data)
+}
+
+// buildChunkPrompt renders the body's chunk template for one transcript chunk.
+func (s *AnalysisService) buildChunkPrompt(meeting domain.Meeting, body domain.Body, chunk transcriptChunk, index, count int) (string, error) {
+	data := ChunkPromptData{
+		BodyName:         body.Name,
+		MeetingDateHuman: meeting.HumanDate(),
+		MeetingType:      meeting.MeetingType,
+		ChunkIndex:       index,
+		ChunkCount:       count,
+		StartTime:        chunk.Start,
+		EndTime:          chunk.End,
+		Transcript:       chunk.Content,
+		Roster:           body.Roster,
+	}
+	return s.render(body.ChunkTemplateName(), data)
+}
+
+// render executes the named template from the template directory.
+func (s *AnalysisService) render(name string, data any) (string, error) {
+	tmplPath := filepath.Join(s.templateDir, name)
+	tmplContent, err := os.ReadFile(tmplPath)
+	if err != nil {
+		return "", fmt.Errorf("reading template %s: %w", tmplPath, err)
+	}
+
+	tmpl, err := template.New(name).Parse(string(tmplContent))
+	if err != nil {
+		return "", fmt.Errorf("parsing template: %w", err)
 	}
 
 	var buf bytes.Buffer
@@ -119,17 +354,3 @@ func (s *AnalysisService) buildPrompt(meeting domain.Meeting, transcript domain.
 
 	return buf.String(), nil
 }
-
-// meetingTypeTag converts a meeting type to a tag-friendly format.
-func meetingTypeTag(meetingType string) string {
-	switch meetingType {
-	case "Work Session":
-		return "Work-Session"
-	case "Special Meeting":
-		return "Special-Meeting"
-	case "Evening Meeting":
-		return "Evening-Meeting"
-	default:
-		return "Regular-Session"
-	}
-}
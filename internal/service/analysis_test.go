@@ -5,6 +5,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -16,18 +17,30 @@ import (
 )
 
 // stubClient is an llm.Client that records the prompts it receives, which is
-// what makes the rendered prompt assertable.
+// what makes the rendered prompt assertable. It is safe for concurrent use so
+// pipeline tests can process meetings in parallel.
 type stubClient struct {
+	mu       sync.Mutex
 	response string
 	err      error
 	prompts  []string
+	// responses, when set, are returned in order before falling back to
+	// response.
+	responses []string
 }
 
 func (s *stubClient) Complete(_ context.Context, prompt string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	s.prompts = append(s.prompts, prompt)
 	if s.err != nil {
 		return "", s.err
 	}
+	if len(s.responses) > 0 {
+		r := s.responses[0]
+		s.responses = s.responses[1:]
+		return r, nil
+	}
 	return s.response, nil
 }
 
@@ -47,11 +60,15 @@ func stubClientFor(stub *stubClient) service.LLMClientFor {
 	return func(domain.Body) (llm.Client, error) { return stub, nil }
 }
 
+// unknownContextWindow resolves an llm block with no context window, so
+// prompts are always sent whole.
+func unknownContextWindow(domain.Body) domain.LLMConfig { return domain.LLMConfig{} }
+
 // newAnalysisService wires a service around a stub returning response.
 func newAnalysisService(t *testing.T, response string) (*service.AnalysisService, *stubClient) {
 	t.Helper()
 	stub := &stubClient{response: response}
-	return service.NewAnalysisService(stubClientFor(stub), setupTemplateDir(t)), stub
+	return service.NewAnalysisService(stubClientFor(stub), unknownContextWindow, setupTemplateDir(t)), stub
 }
 
 // testMeeting returns a deterministic meeting for analysis tests.
@@ -68,9 +85,10 @@ func testMeeting() domain.Meeting {
 // testTranscript returns a minimal transcript for analysis tests.
 func testTranscript() domain.Transcript {
 	return domain.Transcript{
-		Content: "1\n00:00:01,000 --> 00:00:05,000\nThe meeting will come to order.\n",
-		Path:    "/tmp/test.srt",
-		Source:  domain.TranscriptSourceCaptions,
+		Cues:   domain.ParseSRT("1\n00:00:01,000 --> 00:00:05,000\nThe meeting will come to order.\n"),
+		Path:   "/tmp/test.srt",
+		Source: domain.TranscriptSourceCaptions,
+		Format: domain.TranscriptFormatSRT,
 	}
 }
 
@@ -82,7 +100,7 @@ func testHagerstownBody() domain.Body {
 		PlaylistID:      "PLJXxCe9GA2fEf4TIVzTH2O-kFJlS8VVgQ",
 		OutputSubdir:    "Hagerstown Town Council - Citizen Summary",
 		FilenamePattern: "Hagerstown-City-Council-{{.MeetingDate}}-Citizen-Summary",
-		TitleDateRegex:  `^([A-Z][a-z]+ \d{1,2},? \d{4})`,
+		TitleDateRegex:  domain.TitlePatterns{{Regex: `^([A-Z][a-z]+ \d{1,2},? \d{4})`}},
 		Tags:            []string{"City-Council", "Hagerstown"},
 		PromptTemplate:  "hagerstown.prompt.tmpl",
 		Author:          "Peter O'Connor",
@@ -98,7 +116,7 @@ func testBOCCBody() domain.Body {
 		PlaylistID:      "PL7X-j0EwreAd_6kV3IjxO-_XNwDNn0esS",
 		OutputSubdir:    "Washington County BOCC - Citizen Summary",
 		FilenamePattern: "BOCC-{{.MeetingDate}}-Citizen-Summary",
-		TitleDateRegex:  `- ([A-Z][a-z]+ \d{1,2}, \d{4})`,
+		TitleDateRegex:  domain.TitlePatterns{{Regex: `- ([A-Z][a-z]+ \d{1,2}, \d{4})`}},
 		Tags:            []string{"BOCC", "Washington-County"},
 		PromptTemplate:  "bocc.prompt.tmpl",
 		Author:          "Peter O'Connor",
@@ -117,7 +135,7 @@ func setupTemplateDir(t *testing.T) string {
 
 	tmpDir := t.TempDir()
 
-	for _, tmpl := range []string{"hagerstown.prompt.tmpl", "bocc.prompt.tmpl"} {
+	for _, tmpl := range []string{"hagerstown.prompt.tmpl", "bocc.prompt.tmpl", "hagerstown.chunk.tmpl", "bocc.chunk.tmpl", "repair.tmpl"} {
 		src := filepath.Join(projectRoot, "templates", tmpl)
 		content, err := os.ReadFile(src)
 		require.NoError(t, err, "reading template %s", tmpl)
@@ -152,7 +170,7 @@ func TestAnalysisService_Analyze_CleanOutput(t *testing.T) {
 
 func TestAnalysisService_Analyze_ModelError(t *testing.T) {
 	stub := &stubClient{err: assert.AnError}
-	svc := service.NewAnalysisService(stubClientFor(stub), setupTemplateDir(t))
+	svc := service.NewAnalysisService(stubClientFor(stub), unknownContextWindow, setupTemplateDir(t))
 
 	_, err := svc.Analyze(context.Background(), testMeeting(), testTranscript(), testHagerstownBody())
 
@@ -164,7 +182,7 @@ func TestAnalysisService_Analyze_ModelError(t *testing.T) {
 // constructed, such as a missing API key.
 func TestAnalysisService_Analyze_ClientError(t *testing.T) {
 	failing := func(domain.Body) (llm.Client, error) { return nil, assert.AnError }
-	svc := service.NewAnalysisService(failing, setupTemplateDir(t))
+	svc := service.NewAnalysisService(failing, unknownContextWindow, setupTemplateDir(t))
 
 	_, err := svc.Analyze(context.Background(), testMeeting(), testTranscript(), testHagerstownBody())
 
@@ -172,6 +190,58 @@ func TestAnalysisService_Analyze_ClientError(t *testing.T) {
 	assert.Contains(t, err.Error(), "building llm client")
 }
 
+func TestAnalysisService_BuildPrompt_Agenda(t *testing.T) {
+	svc, stub := newAnalysisService(t, "---\ndate: 2025-02-05\n---\n# Summary")
+	meeting := testMeeting()
+	meeting.Agenda = domain.Agenda{
+		URL:  "https://example.gov/agendas/2025-02-04.pdf",
+		Text: "1. Call to Order\n2. Ordinance 2025-07: Parking Amendments",
+	}
+
+	_, err := svc.Analyze(context.Background(), meeting, testTranscript(), testHagerstownBody())
+	require.NoError(t, err)
+
+	prompt := stub.lastPrompt(t)
+	assert.Contains(t, prompt, "Agenda available at: https://example.gov/agendas/2025-02-04.pdf")
+	assert.Contains(t, prompt, "Ordinance 2025-07: Parking Amendments")
+	assert.NotContains(t, prompt, "No agenda URL available.")
+}
+
+func TestAnalysisService_BuildPrompt_NoAgenda(t *testing.T) {
+	svc, stub := newAnalysisService(t, "---\ndate: 2025-02-05\n---\n# Summary")
+
+	_, err := svc.Analyze(context.Background(), testMeeting(), testTranscript(), testHagerstownBody())
+	require.NoError(t, err)
+
+	assert.Contains(t, stub.lastPrompt(t), "No agenda URL available.")
+}
+
+func TestAnalysisService_BuildPrompt_Roster(t *testing.T) {
+	svc, stub := newAnalysisService(t, "---\ndate: 2025-02-05\n---\n# Summary")
+	body := testHagerstownBody()
+	body.Roster = domain.Roster{
+		{Name: "Keith Bruchey", Title: "Mayor"},
+		{Name: "Tekesha Martinez", Title: "Councilmember", Seat: "At-large", Aliases: []string{"Tiki", "Ms. Martinez"}},
+	}
+
+	_, err := svc.Analyze(context.Background(), testMeeting(), testTranscript(), body)
+	require.NoError(t, err)
+
+	prompt := stub.lastPrompt(t)
+	assert.Contains(t, prompt, "**OFFICIALS**")
+	assert.Contains(t, prompt, "- Mayor Keith Bruchey\n")
+	assert.Contains(t, prompt, "- Councilmember Tekesha Martinez (At-large); also called Tiki, Ms. Martinez")
+}
+
+func TestAnalysisService_BuildPrompt_NoRoster(t *testing.T) {
+	svc, stub := newAnalysisService(t, "---\ndate: 2025-02-05\n---\n# Summary")
+
+	_, err := svc.Analyze(context.Background(), testMeeting(), testTranscript(), testHagerstownBody())
+	require.NoError(t, err)
+
+	assert.NotContains(t, stub.lastPrompt(t), "**OFFICIALS**")
+}
+
 func TestAnalysisService_BuildPrompt_Hagerstown(t *testing.T) {
 	svc, stub := newAnalysisService(t, "---\ndate: 2025-02-05\n---\n# Summary")
 	meeting := testMeeting()
@@ -187,7 +257,8 @@ func TestAnalysisService_BuildPrompt_Hagerstown(t *testing.T) {
 	assert.Contains(t, prompt, "Regular Session", "meeting type")
 	assert.Contains(t, prompt, "https://www.youtube.com/watch?v=abc123", "video URL")
 	assert.Contains(t, prompt, body.Author)
-	assert.Contains(t, prompt, testTranscript().Content, "the transcript itself")
+	assert.Contains(t, prompt, "[00:00:01] The meeting will come to order.", "the compact transcript")
+	assert.NotContains(t, prompt, "00:00:01,000 -->", "SRT timing lines are not sent")
 	assert.Contains(t, prompt, "- City-Council")
 	assert.Contains(t, prompt, "- Hagerstown")
 	// Body-specific wording confirms the right template was rendered.
@@ -231,7 +302,8 @@ func TestAnalysisService_MeetingTypeTag(t *testing.T) {
 		{"special meeting", "Special Meeting", "- Special-Meeting"},
 		{"evening meeting", "Evening Meeting", "- Evening-Meeting"},
 		{"regular session", "Regular Session", "- Regular-Session"},
-		{"unknown defaults to regular session", "Board Meeting", "- Regular-Session"},
+		{"unknown type hyphenated", "Board Meeting", "- Board-Meeting"},
+		{"empty defaults to regular session", "", "- Regular-Session"},
 	}
 
 	for _, tt := range tests {
@@ -251,7 +323,7 @@ func TestAnalysisService_MeetingTypeTag(t *testing.T) {
 func TestAnalysisService_TemplateMissing(t *testing.T) {
 	// Point to an empty temp dir — no templates.
 	stub := &stubClient{response: "output"}
-	svc := service.NewAnalysisService(stubClientFor(stub), t.TempDir())
+	svc := service.NewAnalysisService(stubClientFor(stub), unknownContextWindow, t.TempDir())
 
 	_, err := svc.Analyze(context.Background(), testMeeting(), testTranscript(), testHagerstownBody())
 
@@ -0,0 +1,40 @@
+package service
+
+import (
+	"log/slog"
+
+	"github.com/AvogadroSG1/civic-summary/internal/domain"
+	"github.com/AvogadroSG1/civic-summary/internal/markdown"
+)
+
+// TrimToMeeting finds the meeting within a transcript by the body's meeting
+// boundaries and returns the transcript cut down to it, with the window
+// found. Cues keep their times, so summary timestamps still point into the
+// recording. When no meeting is found the transcript is returned whole with
+// a zero window.
+func TrimToMeeting(meeting domain.Meeting, body domain.Body, transcript domain.Transcript) (domain.Transcript, domain.MeetingWindow) {
+	window, ok := body.MeetingBoundaries().Detect(transcript)
+	if !ok {
+		return transcript, domain.MeetingWindow{}
+	}
+	trimmed := transcript.Within(window)
+	slog.Info("meeting window detected",
+		"video_id", meeting.VideoID,
+		"start", domain.FormatTimestamp(window.Start),
+		"start_by", window.StartReason,
+		"end", domain.FormatTimestamp(window.End),
+		"end_by", window.EndReason,
+		"cues_dropped", len(transcript.Cues)-len(trimmed.Cues),
+	)
+	return trimmed, window
+}
+
+// withMeetingDuration records the length of the meeting's detected window in
+// the summary frontmatter as duration: "HH:MM:SS". A meeting with no window
+// is left without one.
+func withMeetingDuration(content string, meeting domain.Meeting) string {
+	if !meeting.Window.Found() {
+		return content
+	}
+	return markdown.SetFrontmatterField(content, "duration", `"`+domain.FormatTimestamp(meeting.Window.Duration())+`"`)
+}
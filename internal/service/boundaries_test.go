@@ -0,0 +1,56 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/AvogadroSG1/civic-summary/internal/domain"
+	"github.com/AvogadroSG1/civic-summary/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func preRollTranscript() domain.Transcript {
+	return domain.Transcript{Cues: []domain.Cue{
+		{Index: 1, Start: 0, End: 5 * time.Second, Text: "The meeting will begin shortly."},
+		{Index: 2, Start: 10 * time.Minute, End: 10*time.Minute + 5*time.Second, Text: "I call this meeting to order."},
+		{Index: 3, Start: 30 * time.Minute, End: 30*time.Minute + 5*time.Second, Text: "Public comment."},
+		{Index: 4, Start: 70 * time.Minute, End: 70*time.Minute + 5*time.Second, Text: "We are adjourned."},
+		{Index: 5, Start: 72 * time.Minute, End: 72*time.Minute + 5*time.Second, Text: "Closed session notice."},
+	}}
+}
+
+func TestTrimToMeeting(t *testing.T) {
+	trimmed, window := service.TrimToMeeting(testMeeting(), testHagerstownBody(), preRollTranscript())
+
+	require.True(t, window.Found())
+	assert.Equal(t, 60*time.Minute+5*time.Second, window.Duration())
+	require.Len(t, trimmed.Cues, 3)
+	assert.Equal(t, "I call this meeting to order.", trimmed.Cues[0].Text)
+
+	disabled := false
+	body := testHagerstownBody()
+	body.Boundaries.Enabled = &disabled
+	whole, window := service.TrimToMeeting(testMeeting(), body, preRollTranscript())
+	assert.False(t, window.Found())
+	assert.Equal(t, preRollTranscript(), whole)
+}
+
+func TestAnalysisService_Analyze_MeetingDuration(t *testing.T) {
+	svc, _ := newAnalysisService(t, "---\ndate: 2025-02-05\n---\n# Summary")
+	meeting := testMeeting()
+	_, meeting.Window = service.TrimToMeeting(meeting, testHagerstownBody(), preRollTranscript())
+
+	summary, err := svc.Analyze(context.Background(), meeting, testTranscript(), testHagerstownBody())
+	require.NoError(t, err)
+	assert.Equal(t, "---\ndate: 2025-02-05\nduration: \"01:00:05\"\n---\n# Summary", summary.Content)
+
+	repaired, err := svc.Repair(context.Background(), meeting, testHagerstownBody(), summary.Content, nil, 1)
+	require.NoError(t, err)
+	assert.Contains(t, repaired.Content, "duration: \"01:00:05\"", "repairs keep the duration")
+
+	summary, err = svc.Analyze(context.Background(), testMeeting(), testTranscript(), testHagerstownBody())
+	require.NoError(t, err)
+	assert.NotContains(t, summary.Content, "duration:", "no window, no duration")
+}
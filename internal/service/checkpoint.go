@@ -0,0 +1,88 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/AvogadroSG1/civic-summary/internal/config"
+	"github.com/AvogadroSG1/civic-summary/internal/domain"
+)
+
+// CheckpointService persists per-meeting pipeline progress so that retries
+// resume from the first incomplete stage rather than starting over.
+type CheckpointService struct {
+	cfg *config.Config
+}
+
+// NewCheckpointService creates a new CheckpointService.
+func NewCheckpointService(cfg *config.Config) *CheckpointService {
+	return &CheckpointService{cfg: cfg}
+}
+
+// Load returns the saved progress for a meeting, or a fresh record if the
+// meeting has never been attempted. A corrupt state file is treated as absent:
+// the worst outcome is redoing work, never skipping it.
+func (s *CheckpointService) Load(body domain.Body, meeting domain.Meeting) (*domain.PipelineResult, error) {
+	data, err := os.ReadFile(s.statePath(body, meeting.VideoID))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return domain.NewPipelineResult(meeting), nil
+		}
+		return nil, fmt.Errorf("reading pipeline state: %w", err)
+	}
+
+	var state domain.PipelineResult
+	if err := json.Unmarshal(data, &state); err != nil {
+		return domain.NewPipelineResult(meeting), nil
+	}
+	if state.Checkpoints == nil {
+		state.Checkpoints = make(map[domain.PipelineStage]domain.StageCheckpoint)
+	}
+	return &state, nil
+}
+
+// Save writes a meeting's progress to disk.
+func (s *CheckpointService) Save(body domain.Body, state *domain.PipelineResult) error {
+	dir := s.meetingDir(body, state.VideoID)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("creating state dir: %w", err)
+	}
+	state.UpdatedAt = time.Now()
+	return writeJSON(s.statePath(body, state.VideoID), state)
+}
+
+// SaveArtifact writes a stage's output next to the meeting's state file and
+// returns its path, for stages whose output does not otherwise live on disk.
+func (s *CheckpointService) SaveArtifact(body domain.Body, videoID string, stage domain.PipelineStage, content string) (string, error) {
+	dir := s.meetingDir(body, videoID)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", fmt.Errorf("creating state dir: %w", err)
+	}
+	path := filepath.Join(dir, string(stage)+".md")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		return "", fmt.Errorf("writing %s artifact: %w", stage, err)
+	}
+	return path, nil
+}
+
+// Clear removes a meeting's state and artifacts, typically once its summary
+// has been written.
+func (s *CheckpointService) Clear(body domain.Body, videoID string) error {
+	if err := os.RemoveAll(s.meetingDir(body, videoID)); err != nil {
+		return fmt.Errorf("removing pipeline state: %w", err)
+	}
+	return nil
+}
+
+// meetingDir returns the directory holding one meeting's state and artifacts.
+func (s *CheckpointService) meetingDir(body domain.Body, videoID string) string {
+	return filepath.Join(s.cfg.StateDir(body), videoID)
+}
+
+// statePath returns the path of a meeting's state file.
+func (s *CheckpointService) statePath(body domain.Body, videoID string) string {
+	return filepath.Join(s.meetingDir(body, videoID), "state.json")
+}
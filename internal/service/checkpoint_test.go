@@ -0,0 +1,96 @@
+package service_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/AvogadroSG1/civic-summary/internal/domain"
+	"github.com/AvogadroSG1/civic-summary/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func checkpointMeeting() domain.Meeting {
+	return domain.Meeting{
+		VideoID:     "abc123",
+		BodySlug:    "test",
+		MeetingDate: time.Date(2025, 2, 4, 0, 0, 0, 0, time.UTC),
+	}
+}
+
+func TestCheckpointService_LoadFresh(t *testing.T) {
+	cfg, body := quarantineTestConfig(t)
+	svc := service.NewCheckpointService(cfg)
+
+	state, err := svc.Load(body, checkpointMeeting())
+	require.NoError(t, err)
+
+	assert.Equal(t, "abc123", state.VideoID)
+	assert.Equal(t, "2025-02-04", state.MeetingDate)
+	assert.Equal(t, domain.StageTranscription, state.NextStage())
+}
+
+func TestCheckpointService_RoundTrip(t *testing.T) {
+	cfg, body := quarantineTestConfig(t)
+	svc := service.NewCheckpointService(cfg)
+	meeting := checkpointMeeting()
+
+	state, err := svc.Load(body, meeting)
+	require.NoError(t, err)
+
+	path, err := svc.SaveArtifact(body, meeting.VideoID, domain.StageAnalysis, "# Draft")
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(cfg.StateDir(body), "abc123", "analysis.md"), path)
+
+	state.Complete(domain.StageTranscription, "/tmp/abc123.srt", "captions")
+	state.Complete(domain.StageAnalysis, path, "")
+	require.NoError(t, svc.Save(body, state))
+
+	loaded, err := svc.Load(body, meeting)
+	require.NoError(t, err)
+	assert.Equal(t, domain.StageCrossRef, loaded.NextStage())
+
+	cp, ok := loaded.Checkpoint(domain.StageAnalysis)
+	require.True(t, ok)
+	content, err := os.ReadFile(cp.Artifact)
+	require.NoError(t, err)
+	assert.Equal(t, "# Draft", string(content))
+	assert.False(t, loaded.UpdatedAt.IsZero())
+}
+
+func TestCheckpointService_Clear(t *testing.T) {
+	cfg, body := quarantineTestConfig(t)
+	svc := service.NewCheckpointService(cfg)
+	meeting := checkpointMeeting()
+
+	state, err := svc.Load(body, meeting)
+	require.NoError(t, err)
+	state.Complete(domain.StageTranscription, "/tmp/abc123.srt", "")
+	require.NoError(t, svc.Save(body, state))
+
+	require.NoError(t, svc.Clear(body, meeting.VideoID))
+
+	_, err = os.Stat(filepath.Join(cfg.StateDir(body), "abc123"))
+	assert.True(t, os.IsNotExist(err))
+
+	fresh, err := svc.Load(body, meeting)
+	require.NoError(t, err)
+	assert.Equal(t, domain.StageTranscription, fresh.NextStage())
+}
+
+// TestCheckpointService_CorruptStateStartsOver guards the invariant that a
+// damaged state file can only cause work to be redone, never skipped.
+func TestCheckpointService_CorruptStateStartsOver(t *testing.T) {
+	cfg, body := quarantineTestConfig(t)
+	svc := service.NewCheckpointService(cfg)
+
+	dir := filepath.Join(cfg.StateDir(body), "abc123")
+	require.NoError(t, os.MkdirAll(dir, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "state.json"), []byte("{not json"), 0o644))
+
+	state, err := svc.Load(body, checkpointMeeting())
+	require.NoError(t, err)
+	assert.Equal(t, domain.StageTranscription, state.NextStage())
+}
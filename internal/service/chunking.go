@@ -0,0 +1,119 @@
+package service
+
+import (
+	"strings"
+
+	"github.com/AvogadroSG1/civic-summary/internal/domain"
+)
+
+const (
+	// charsPerToken is a deliberately low estimate of characters per token.
+	// Timestamps and proper names tokenize poorly, and overestimating the prompt only
+	// means chunking a little early, while underestimating means a rejected
+	// request.
+	charsPerToken = 3
+	// promptReserveTokens is held back from the input budget to absorb
+	// estimation error.
+	promptReserveTokens = 2000
+	// chunkOverlapRatio is the share of each chunk's budget repeated at the
+	// start of the next chunk, so an item split across a boundary is seen
+	// whole at least once.
+	chunkOverlapRatio = 0.1
+)
+
+// estimateTokens approximates the token count of s.
+func estimateTokens(s string) int {
+	return len(s)/charsPerToken + 1
+}
+
+// transcriptChunk is a run of consecutive cues sized to fit a model request.
+type transcriptChunk struct {
+	Content string // compact "[HH:MM:SS] text" lines
+	Start   string // HH:MM:SS, or "" for untimed cues
+	End     string
+}
+
+// chunkTranscript splits cues into chunks of at most maxTokens of compact
+// prompt text, each starting with roughly chunkOverlapRatio of the previous
+// chunk's trailing cues. A single cue larger than maxTokens becomes its own
+// chunk rather than being cut mid-cue.
+func chunkTranscript(cues []domain.Cue, maxTokens int) []transcriptChunk {
+	if len(cues) == 0 {
+		return nil
+	}
+	overlapTokens := int(float64(maxTokens) * chunkOverlapRatio)
+
+	var chunks []transcriptChunk
+	var current []domain.Cue
+	currentTokens := 0
+	fresh := 0 // cues in current that are not overlap from the previous chunk
+
+	for _, cue := range cues {
+		cueTokens := cueTokens(cue)
+		if fresh > 0 && currentTokens+cueTokens > maxTokens {
+			chunks = append(chunks, newChunk(current))
+			current = overlapTail(current, overlapTokens, maxTokens-cueTokens)
+			currentTokens = cuesTokens(current)
+			fresh = 0
+		}
+		current = append(current, cue)
+		currentTokens += cueTokens
+		fresh++
+	}
+	chunks = append(chunks, newChunk(current))
+	return chunks
+}
+
+// overlapTail returns the trailing cues of prev that fit within overlapTokens
+// and leave room for the next cue within limit.
+func overlapTail(prev []domain.Cue, overlapTokens, limit int) []domain.Cue {
+	budget := min(overlapTokens, limit)
+	used := 0
+	i := len(prev)
+	for i > 0 {
+		t := cueTokens(prev[i-1])
+		if used+t > budget {
+			break
+		}
+		used += t
+		i--
+	}
+	// Never carry over the whole previous chunk, or chunking would not advance.
+	if i == 0 {
+		i = 1
+	}
+	return append([]domain.Cue(nil), prev[i:]...)
+}
+
+// cueTokens estimates the tokens of a cue's compact prompt line.
+func cueTokens(cue domain.Cue) int {
+	return estimateTokens(cue.Compact())
+}
+
+// cuesTokens sums the estimated tokens of cues.
+func cuesTokens(cues []domain.Cue) int {
+	total := 0
+	for _, c := range cues {
+		total += cueTokens(c)
+	}
+	return total
+}
+
+// newChunk renders cues as compact prompt lines and records the time span
+// they cover.
+func newChunk(cues []domain.Cue) transcriptChunk {
+	lines := make([]string, len(cues))
+	chunk := transcriptChunk{}
+	for i, c := range cues {
+		lines[i] = c.Compact()
+		if !c.HasTiming() {
+			continue
+		}
+		if chunk.Start == "" {
+			chunk.Start = domain.FormatTimestamp(c.Start)
+		}
+		chunk.End = domain.FormatTimestamp(c.End)
+	}
+	chunk.Content = strings.Join(lines, "\n")
+	return chunk
+}
@@ -0,0 +1,228 @@
+package service_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/AvogadroSG1/civic-summary/internal/domain"
+	"github.com/AvogadroSG1/civic-summary/internal/llm"
+	"github.com/AvogadroSG1/civic-summary/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// chunkPromptMarker is the opening of the real chunk templates, which tells
+// segment requests apart from the final summary request.
+const chunkPromptMarker = "You are taking notes on one segment"
+
+// scriptedClient answers chunk prompts with numbered notes and everything else
+// with a fixed summary. Failures returns its errors in order before answering.
+// Padding, when set, is appended to every note.
+type scriptedClient struct {
+	mu       sync.Mutex
+	failures []error
+	prompts  []string
+	padding  string
+}
+
+func (s *scriptedClient) Complete(_ context.Context, prompt string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.prompts = append(s.prompts, prompt)
+	if len(s.failures) > 0 {
+		err := s.failures[0]
+		s.failures = s.failures[1:]
+		return "", err
+	}
+	if strings.HasPrefix(prompt, chunkPromptMarker) {
+		return fmt.Sprintf("notes for request %d%s", len(s.prompts), s.padding), nil
+	}
+	return "---\ndate: 2025-02-04\n---\n# Summary", nil
+}
+
+func (s *scriptedClient) Ping(context.Context) error { return nil }
+
+func (s *scriptedClient) Describe() string { return "scripted/test-model" }
+
+// chunkPrompts returns the segment prompts sent, in order.
+func (s *scriptedClient) chunkPrompts() []string {
+	var out []string
+	for _, p := range s.prompts {
+		if strings.HasPrefix(p, chunkPromptMarker) {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+// smallContextWindow leaves an input budget of about 2000 tokens: enough for
+// the prompt template, not for a long transcript.
+func smallContextWindow(domain.Body) domain.LLMConfig {
+	return domain.LLMConfig{ContextWindow: 5000, MaxTokens: 1000}
+}
+
+// longTranscript returns an SRT transcript of n ten-second cues.
+func longTranscript(n int) domain.Transcript {
+	var b strings.Builder
+	for i := 0; i < n; i++ {
+		start, end := i*10, i*10+9
+		fmt.Fprintf(&b, "%d\n%s --> %s\nSpeaker discusses agenda item number %d in some detail.\n\n",
+			i+1, srtTime(start), srtTime(end), i+1)
+	}
+	return domain.Transcript{Cues: domain.ParseSRT(b.String()), Source: domain.TranscriptSourceCaptions}
+}
+
+func srtTime(seconds int) string {
+	return fmt.Sprintf("%02d:%02d:%02d,000", seconds/3600, seconds/60%60, seconds%60)
+}
+
+func newChunkingService(t *testing.T, client llm.Client, configFor service.LLMConfigFor) *service.AnalysisService {
+	t.Helper()
+	clientFor := func(domain.Body) (llm.Client, error) { return client, nil }
+	return service.NewAnalysisService(clientFor, configFor, setupTemplateDir(t))
+}
+
+func TestAnalysisService_Analyze_FitsWithoutChunking(t *testing.T) {
+	client := &scriptedClient{}
+	svc := newChunkingService(t, client, smallContextWindow)
+
+	_, err := svc.Analyze(context.Background(), testMeeting(), testTranscript(), testHagerstownBody())
+
+	require.NoError(t, err)
+	require.Len(t, client.prompts, 1)
+	assert.Empty(t, client.chunkPrompts())
+}
+
+func TestAnalysisService_Analyze_ChunksLongTranscript(t *testing.T) {
+	client := &scriptedClient{}
+	svc := newChunkingService(t, client, smallContextWindow)
+
+	summary, err := svc.Analyze(context.Background(), testMeeting(), longTranscript(200), testHagerstownBody())
+
+	require.NoError(t, err)
+	assert.Contains(t, summary.Content, "# Summary")
+
+	chunks := client.chunkPrompts()
+	require.Greater(t, len(chunks), 1, "a long transcript should be split")
+	require.Len(t, client.prompts, len(chunks)+1, "one request per chunk plus the final pass")
+
+	// Every cue reaches some chunk, and each chunk names its place and span.
+	for i := 1; i <= 200; i++ {
+		needle := fmt.Sprintf("agenda item number %d in", i)
+		found := false
+		for _, p := range chunks {
+			if strings.Contains(p, needle) {
+				found = true
+				break
+			}
+		}
+		assert.True(t, found, "cue %d missing from every chunk", i)
+	}
+	assert.Contains(t, chunks[0], fmt.Sprintf("**Segment**: 1 of %d, covering 00:00:00 to", len(chunks)))
+	assert.Contains(t, chunks[len(chunks)-1], "to 00:33:19")
+
+	// The final pass uses the normal template over timestamped segment notes.
+	final := client.prompts[len(client.prompts)-1]
+	assert.NotContains(t, final, chunkPromptMarker)
+	assert.Contains(t, final, "Hagerstown City Council")
+	assert.Contains(t, final, fmt.Sprintf("## Segment 1 of %d [00:00:00-", len(chunks)))
+	assert.Contains(t, final, fmt.Sprintf("## Segment %d of %d [", len(chunks), len(chunks)))
+	assert.Contains(t, final, "notes for request 1")
+	assert.NotContains(t, final, "agenda item number 1 in", "raw transcript should not reach the final pass")
+}
+
+func TestAnalysisService_Analyze_ChunksOverlap(t *testing.T) {
+	client := &scriptedClient{}
+	svc := newChunkingService(t, client, smallContextWindow)
+
+	_, err := svc.Analyze(context.Background(), testMeeting(), longTranscript(200), testHagerstownBody())
+	require.NoError(t, err)
+
+	chunks := client.chunkPrompts()
+	require.Greater(t, len(chunks), 1)
+
+	// The last cue of each chunk is repeated at the start of the next.
+	for i := 0; i < len(chunks)-1; i++ {
+		last := lastCueNumber(t, chunks[i])
+		assert.Contains(t, chunks[i+1], fmt.Sprintf("agenda item number %d in", last),
+			"chunk %d should overlap chunk %d", i+2, i+1)
+	}
+}
+
+func TestAnalysisService_Analyze_ContextWindowErrorFallsBackToChunks(t *testing.T) {
+	client := &scriptedClient{
+		failures: []error{&llm.Error{Kind: llm.KindContextWindow, Provider: "stub", Model: "test-model"}},
+	}
+	svc := newChunkingService(t, client, unknownContextWindow)
+
+	summary, err := svc.Analyze(context.Background(), testMeeting(), longTranscript(200), testHagerstownBody())
+
+	require.NoError(t, err)
+	assert.Contains(t, summary.Content, "# Summary")
+	assert.NotContains(t, client.prompts[0], chunkPromptMarker, "the first attempt sends the whole transcript")
+	assert.Greater(t, len(client.chunkPrompts()), 1)
+}
+
+func TestAnalysisService_Analyze_CondensesLongNotes(t *testing.T) {
+	client := &scriptedClient{padding: strings.Repeat(" and more detail", 40)}
+	svc := newChunkingService(t, client, smallContextWindow)
+
+	summary, err := svc.Analyze(context.Background(), testMeeting(), longTranscript(200), testHagerstownBody())
+
+	require.NoError(t, err)
+	assert.Contains(t, summary.Content, "# Summary")
+
+	// The notes of neighboring segments were summarized together again.
+	var condensing []string
+	for _, p := range client.chunkPrompts() {
+		if strings.Contains(p, "## Segment 1 of ") {
+			condensing = append(condensing, p)
+		}
+	}
+	require.NotEmpty(t, condensing, "notes too long to combine are condensed")
+	assert.Contains(t, condensing[0], "notes for request 1 and more detail")
+
+	final := client.prompts[len(client.prompts)-1]
+	assert.NotContains(t, final, chunkPromptMarker)
+	assert.NotContains(t, final, "notes for request 1 and", "the final pass sees the condensed notes")
+	assert.Less(t, len(final)/3, 2000, "the final prompt fits the input budget")
+}
+
+func TestAnalysisService_Analyze_ContextWindowErrorHalvesAgain(t *testing.T) {
+	overflow := &llm.Error{Kind: llm.KindContextWindow, Provider: "stub", Model: "test-model"}
+	client := &scriptedClient{failures: []error{overflow, overflow}}
+	svc := newChunkingService(t, client, unknownContextWindow)
+
+	summary, err := svc.Analyze(context.Background(), testMeeting(), longTranscript(200), testHagerstownBody())
+
+	require.NoError(t, err, "a chunked pass that still overflows is retried in smaller chunks")
+	assert.Contains(t, summary.Content, "# Summary")
+	assert.Less(t, len(client.prompts[2]), len(client.prompts[1]), "the second chunked pass uses smaller chunks")
+}
+
+func TestAnalysisService_Analyze_ChunkError(t *testing.T) {
+	client := &scriptedClient{
+		failures: []error{&llm.Error{Kind: llm.KindContextWindow}, &llm.Error{Kind: llm.KindRateLimit}},
+	}
+	svc := newChunkingService(t, client, unknownContextWindow)
+
+	_, err := svc.Analyze(context.Background(), testMeeting(), longTranscript(200), testHagerstownBody())
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "chunk 1 of")
+}
+
+// lastCueNumber returns the item number of the last cue in a chunk prompt.
+func lastCueNumber(t *testing.T, prompt string) int {
+	t.Helper()
+	const marker = "agenda item number "
+	i := strings.LastIndex(prompt, marker)
+	require.NotEqual(t, -1, i)
+	var n int
+	_, err := fmt.Sscanf(prompt[i+len(marker):], "%d", &n)
+	require.NoError(t, err)
+	return n
+}
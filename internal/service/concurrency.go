@@ -0,0 +1,106 @@
+package service
+
+import (
+	"context"
+	"sync"
+
+	"github.com/AvogadroSG1/civic-summary/internal/config"
+	"github.com/AvogadroSG1/civic-summary/internal/domain"
+	"github.com/AvogadroSG1/civic-summary/internal/llm"
+)
+
+// semaphore bounds how many callers hold a slot at once.
+type semaphore chan struct{}
+
+// newSemaphore creates a semaphore with n slots. Non-positive n means one slot.
+func newSemaphore(n int) semaphore {
+	if n < 1 {
+		n = 1
+	}
+	return make(semaphore, n)
+}
+
+// acquire blocks until a slot is free or ctx is cancelled.
+func (s semaphore) acquire(ctx context.Context) error {
+	select {
+	case s <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// release frees a slot taken by acquire.
+func (s semaphore) release() {
+	<-s
+}
+
+// forEach calls fn for every item with at most limit calls running at once.
+// Once ctx is cancelled no further items are started; forEach still waits for
+// the calls already running, which observe the same ctx.
+func forEach[T any](ctx context.Context, limit int, items []T, fn func(T)) {
+	sem := newSemaphore(limit)
+	var wg sync.WaitGroup
+	for _, item := range items {
+		if err := sem.acquire(ctx); err != nil {
+			break
+		}
+		wg.Add(1)
+		go func(item T) {
+			defer wg.Done()
+			defer sem.release()
+			fn(item)
+		}(item)
+	}
+	wg.Wait()
+}
+
+// LimitLLMRequests wraps clientFor so that at most perProvider requests are in
+// flight to each provider at once, across every body sharing that provider.
+func LimitLLMRequests(clientFor LLMClientFor, cfg *config.Config, perProvider int) LLMClientFor {
+	var mu sync.Mutex
+	limits := make(map[string]semaphore)
+
+	limitFor := func(provider string) semaphore {
+		mu.Lock()
+		defer mu.Unlock()
+		sem, ok := limits[provider]
+		if !ok {
+			sem = newSemaphore(perProvider)
+			limits[provider] = sem
+		}
+		return sem
+	}
+
+	return func(body domain.Body) (llm.Client, error) {
+		client, err := clientFor(body)
+		if err != nil {
+			return nil, err
+		}
+		return &limitedClient{Client: client, sem: limitFor(cfg.ResolveLLM(body).Provider)}, nil
+	}
+}
+
+// limitedClient holds a provider slot for the duration of each request.
+type limitedClient struct {
+	llm.Client
+	sem semaphore
+}
+
+// Complete waits for a provider slot, then sends the prompt.
+func (c *limitedClient) Complete(ctx context.Context, prompt string) (string, error) {
+	if err := c.sem.acquire(ctx); err != nil {
+		return "", err
+	}
+	defer c.sem.release()
+	return c.Client.Complete(ctx, prompt)
+}
+
+// Ping waits for a provider slot, then probes the model.
+func (c *limitedClient) Ping(ctx context.Context) error {
+	if err := c.sem.acquire(ctx); err != nil {
+		return err
+	}
+	defer c.sem.release()
+	return c.Client.Ping(ctx)
+}
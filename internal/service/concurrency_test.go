@@ -0,0 +1,135 @@
+package service_test
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/AvogadroSG1/civic-summary/internal/config"
+	"github.com/AvogadroSG1/civic-summary/internal/domain"
+	"github.com/AvogadroSG1/civic-summary/internal/llm"
+	"github.com/AvogadroSG1/civic-summary/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// countingClient records the peak number of concurrent Complete calls.
+type countingClient struct {
+	inFlight atomic.Int32
+	peak     atomic.Int32
+}
+
+func (c *countingClient) Complete(context.Context, string) (string, error) {
+	n := c.inFlight.Add(1)
+	defer c.inFlight.Add(-1)
+	for {
+		peak := c.peak.Load()
+		if n <= peak || c.peak.CompareAndSwap(peak, n) {
+			break
+		}
+	}
+	time.Sleep(20 * time.Millisecond)
+	return "ok", nil
+}
+
+func (c *countingClient) Ping(context.Context) error { return nil }
+
+func (c *countingClient) Describe() string { return "counting/test-model" }
+
+func TestLimitLLMRequests_CapsInFlightPerProvider(t *testing.T) {
+	cfg := &config.Config{LLM: validLLMConfig()}
+	client := &countingClient{}
+	clientFor := service.LimitLLMRequests(
+		func(domain.Body) (llm.Client, error) { return client, nil },
+		cfg, 2,
+	)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			c, err := clientFor(domain.Body{Slug: "hagerstown"})
+			require.NoError(t, err)
+			_, err = c.Complete(context.Background(), "prompt")
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(2), client.peak.Load())
+}
+
+func TestLimitLLMRequests_SeparateLimitPerProvider(t *testing.T) {
+	cfg := &config.Config{LLM: validLLMConfig()}
+	client := &countingClient{}
+	clientFor := service.LimitLLMRequests(
+		func(domain.Body) (llm.Client, error) { return client, nil },
+		cfg, 1,
+	)
+
+	openai := domain.ProviderOpenAI
+	bodies := []domain.Body{
+		{Slug: "hagerstown"},
+		{Slug: "bocc", LLM: &domain.LLMOverride{Provider: &openai}},
+	}
+
+	var wg sync.WaitGroup
+	for _, body := range bodies {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			c, err := clientFor(body)
+			require.NoError(t, err)
+			_, err = c.Complete(context.Background(), "prompt")
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(2), client.peak.Load(), "each provider gets its own slot")
+}
+
+func TestLimitLLMRequests_CancelledWhileWaiting(t *testing.T) {
+	cfg := &config.Config{LLM: validLLMConfig()}
+	block := make(chan struct{})
+	clientFor := service.LimitLLMRequests(
+		func(domain.Body) (llm.Client, error) { return blockingClient(block), nil },
+		cfg, 1,
+	)
+
+	holder, err := clientFor(domain.Body{})
+	require.NoError(t, err)
+	go func() { _, _ = holder.Complete(context.Background(), "prompt") }()
+	defer close(block)
+
+	// Give the first request time to take the only slot.
+	time.Sleep(10 * time.Millisecond)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	waiter, err := clientFor(domain.Body{})
+	require.NoError(t, err)
+	_, err = waiter.Complete(ctx, "prompt")
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+// blockingClient is an llm.Client whose requests wait until release is closed.
+type blockingClient chan struct{}
+
+func (c blockingClient) Complete(context.Context, string) (string, error) {
+	<-c
+	return "ok", nil
+}
+
+func (c blockingClient) Ping(context.Context) error { return nil }
+
+func (c blockingClient) Describe() string { return "blocking/test-model" }
+
+// validLLMConfig returns a resolved llm block for tests that only need a
+// provider.
+func validLLMConfig() domain.LLMConfig {
+	return domain.LLMConfig{Provider: domain.ProviderAnthropic, Model: "claude-opus-5"}
+}
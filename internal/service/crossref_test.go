@@ -24,7 +24,7 @@ func crossrefConfig(t *testing.T) *config.Config {
 				PlaylistID:      "TEST",
 				OutputSubdir:    "Hagerstown Town Council - Citizen Summary",
 				FilenamePattern: "Hagerstown-City-Council-{{.MeetingDate}}-Citizen-Summary",
-				TitleDateRegex:  `^([A-Z][a-z]+ \d{1,2},? \d{4})`,
+				TitleDateRegex:  domain.TitlePatterns{{Regex: `^([A-Z][a-z]+ \d{1,2},? \d{4})`}},
 				Tags:            []string{"City-Council"},
 				PromptTemplate:  "test.tmpl",
 			},
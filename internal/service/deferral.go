@@ -0,0 +1,151 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/AvogadroSG1/civic-summary/internal/config"
+	"github.com/AvogadroSG1/civic-summary/internal/domain"
+)
+
+// DeferralService records meetings whose transcription is waiting for
+// captions. It is safe for concurrent use: meetings processed in parallel
+// share one file per body.
+type DeferralService struct {
+	cfg *config.Config
+	mu  sync.Mutex
+}
+
+// NewDeferralService creates a new DeferralService.
+func NewDeferralService(cfg *config.Config) *DeferralService {
+	return &DeferralService{cfg: cfg}
+}
+
+// List returns a body's deferred meetings, soonest recheck first.
+func (s *DeferralService) List(body domain.Body) ([]domain.DeferredMeeting, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	deferrals, err := s.load(body)
+	if err != nil {
+		return nil, err
+	}
+	entries := deferrals.Entries
+	slices.SortStableFunc(entries, func(a, b domain.DeferredMeeting) int {
+		if c := a.RecheckAt.Compare(b.RecheckAt); c != 0 {
+			return c
+		}
+		return strings.Compare(a.VideoID, b.VideoID)
+	})
+	return entries, nil
+}
+
+// Get returns a meeting's deferral, if it has one.
+func (s *DeferralService) Get(body domain.Body, videoID string) (domain.DeferredMeeting, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	deferrals, err := s.load(body)
+	if err != nil {
+		return domain.DeferredMeeting{}, false, err
+	}
+	if i := deferralIndex(deferrals, videoID); i >= 0 {
+		return deferrals.Entries[i], true, nil
+	}
+	return domain.DeferredMeeting{}, false, nil
+}
+
+// Defer records that a meeting's captions were not available at now, to be
+// looked for again after captions.recheck_minutes. A meeting already
+// deferred keeps its original DeferredAt.
+func (s *DeferralService) Defer(body domain.Body, meeting domain.Meeting, reason string, now time.Time) (domain.DeferredMeeting, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	deferrals, err := s.load(body)
+	if err != nil {
+		return domain.DeferredMeeting{}, err
+	}
+
+	entry := domain.DeferredMeeting{VideoID: meeting.VideoID, DeferredAt: now}
+	i := deferralIndex(deferrals, meeting.VideoID)
+	if i >= 0 {
+		entry = deferrals.Entries[i]
+	}
+	entry.Title = meeting.Title
+	entry.MeetingDate = meeting.ISODate()
+	entry.Reason = reason
+	entry.RecheckAt = now.Add(s.cfg.Captions.Recheck())
+	entry.Checks++
+
+	if i >= 0 {
+		deferrals.Entries[i] = entry
+	} else {
+		deferrals.Entries = append(deferrals.Entries, entry)
+	}
+	return entry, s.save(body, deferrals)
+}
+
+// Clear removes a meeting's deferral, typically once it has been
+// transcribed. Clearing a meeting that is not deferred is not an error.
+func (s *DeferralService) Clear(body domain.Body, videoID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	deferrals, err := s.load(body)
+	if err != nil {
+		return err
+	}
+	i := deferralIndex(deferrals, videoID)
+	if i < 0 {
+		return nil
+	}
+	deferrals.Entries = slices.Delete(deferrals.Entries, i, i+1)
+	return s.save(body, deferrals)
+}
+
+// deferralIndex returns the position of a video's entry, or -1.
+func deferralIndex(deferrals *domain.Deferrals, videoID string) int {
+	return slices.IndexFunc(deferrals.Entries, func(e domain.DeferredMeeting) bool {
+		return e.VideoID == videoID
+	})
+}
+
+// load reads the body's deferrals; a missing file is an empty list. Callers
+// must hold s.mu.
+func (s *DeferralService) load(body domain.Body) (*domain.Deferrals, error) {
+	data, err := os.ReadFile(s.cfg.DeferredPath(body))
+	if errors.Is(err, os.ErrNotExist) {
+		return &domain.Deferrals{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading deferred meetings: %w", err)
+	}
+	var deferrals domain.Deferrals
+	if err := json.Unmarshal(data, &deferrals); err != nil {
+		return nil, fmt.Errorf("parsing deferred meetings: %w", err)
+	}
+	return &deferrals, nil
+}
+
+// save writes the body's deferrals. Callers must hold s.mu.
+func (s *DeferralService) save(body domain.Body, deferrals *domain.Deferrals) error {
+	if deferrals.Entries == nil {
+		deferrals.Entries = []domain.DeferredMeeting{}
+	}
+	path := s.cfg.DeferredPath(body)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("creating automation dir: %w", err)
+	}
+	if err := writeJSON(path, deferrals); err != nil {
+		return fmt.Errorf("writing deferred meetings: %w", err)
+	}
+	return nil
+}
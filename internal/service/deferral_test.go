@@ -0,0 +1,65 @@
+package service_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/AvogadroSG1/civic-summary/internal/config"
+	"github.com/AvogadroSG1/civic-summary/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeferralService_Defer(t *testing.T) {
+	cfg := boccConfig(t)
+	cfg.Captions = config.CaptionsConfig{GraceHours: 24, RecheckMinutes: 30}
+	body, _ := cfg.GetBody("bocc")
+	deferrals := service.NewDeferralService(cfg)
+
+	first := time.Date(2025, 2, 24, 21, 0, 0, 0, time.UTC)
+	entry, err := deferrals.Defer(body, boccMeeting("vid_a", 24), "no captions", first)
+	require.NoError(t, err)
+	assert.Equal(t, first, entry.DeferredAt)
+	assert.Equal(t, first.Add(30*time.Minute), entry.RecheckAt)
+	assert.Equal(t, 1, entry.Checks)
+	assert.False(t, entry.Due(first.Add(29*time.Minute)))
+	assert.True(t, entry.Due(first.Add(30*time.Minute)))
+
+	later := first.Add(time.Hour)
+	entry, err = deferrals.Defer(body, boccMeeting("vid_a", 24), "still no captions", later)
+	require.NoError(t, err)
+	assert.Equal(t, first, entry.DeferredAt, "the grace period runs from the first deferral")
+	assert.Equal(t, later.Add(30*time.Minute), entry.RecheckAt)
+	assert.Equal(t, 2, entry.Checks)
+
+	got, ok, err := deferrals.Get(body, "vid_a")
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, "still no captions", got.Reason)
+	assert.Equal(t, "2025-02-24", got.MeetingDate)
+}
+
+func TestDeferralService_ListAndClear(t *testing.T) {
+	cfg := boccConfig(t)
+	cfg.Captions = config.CaptionsConfig{GraceHours: 24, RecheckMinutes: 60}
+	body, _ := cfg.GetBody("bocc")
+	deferrals := service.NewDeferralService(cfg)
+
+	now := time.Date(2025, 2, 25, 9, 0, 0, 0, time.UTC)
+	_, err := deferrals.Defer(body, boccMeeting("vid_b", 25), "no captions", now)
+	require.NoError(t, err)
+	_, err = deferrals.Defer(body, boccMeeting("vid_a", 24), "no captions", now.Add(-time.Hour))
+	require.NoError(t, err)
+
+	entries, err := deferrals.List(body)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, "vid_a", entries[0].VideoID, "soonest recheck first")
+
+	require.NoError(t, deferrals.Clear(body, "vid_a"))
+	require.NoError(t, deferrals.Clear(body, "unknown"))
+	entries, err = deferrals.List(body)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "vid_b", entries[0].VideoID)
+}
@@ -0,0 +1,198 @@
+package service
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/AvogadroSG1/civic-summary/internal/domain"
+	"github.com/AvogadroSG1/civic-summary/internal/executor"
+)
+
+// handoffCues is how many cues at the end of a speaker's turn are searched
+// for the member being handed the floor.
+const handoffCues = 2
+
+// DiarizationService labels who is speaking in each line of a transcript and
+// names the speakers from the body's roster.
+type DiarizationService struct {
+	sources  VideoSourceFor
+	diarizer *executor.DiarizerExecutor
+}
+
+// NewDiarizationService creates a new DiarizationService. A nil diarizer
+// disables diarization.
+func NewDiarizationService(sources VideoSourceFor, diarizer *executor.DiarizerExecutor) *DiarizationService {
+	return &DiarizationService{sources: sources, diarizer: diarizer}
+}
+
+// Diarize returns the transcript with each line labeled with its speaker,
+// for bodies that set diarize. Speaker turns are read from <video id>.rttm in
+// outputDir, running the diarizer on the meeting's audio first when the file
+// does not exist yet. Speaker labels only improve the summary, so when
+// diarization fails the transcript is returned unlabeled.
+func (s *DiarizationService) Diarize(ctx context.Context, meeting domain.Meeting, body domain.Body, transcript domain.Transcript, outputDir string) domain.Transcript {
+	if s.diarizer == nil || !body.Diarize || transcript.IsEmpty() {
+		return transcript
+	}
+
+	turns, err := s.turns(ctx, meeting, body, outputDir)
+	if err != nil {
+		slog.Warn("diarization failed, continuing without speaker labels",
+			"video_id", meeting.VideoID,
+			"error", err,
+		)
+		return transcript
+	}
+
+	labeled := transcript.WithSpeakers(turns)
+	names := NameSpeakers(labeled, body.Roster)
+	slog.Info("diarized transcript",
+		"video_id", meeting.VideoID,
+		"speakers", len(names),
+		"named", countNamed(names),
+	)
+	return labeled.RelabelSpeakers(names)
+}
+
+// turns returns the meeting's speaker turns, diarizing its audio unless an
+// earlier run already did.
+func (s *DiarizationService) turns(ctx context.Context, meeting domain.Meeting, body domain.Body, outputDir string) ([]domain.SpeakerTurn, error) {
+	rttmPath := filepath.Join(outputDir, meeting.VideoID+".rttm")
+	if _, err := os.Stat(rttmPath); err != nil {
+		videoSource, err := s.sources(body)
+		if err != nil {
+			return nil, fmt.Errorf("video source for %s: %w", body.Slug, err)
+		}
+		audioPath, err := videoSource.Audio(ctx, meeting, outputDir)
+		if err != nil {
+			return nil, fmt.Errorf("downloading audio: %w", err)
+		}
+		if err := s.diarizer.Diarize(ctx, audioPath, rttmPath); err != nil {
+			return nil, err
+		}
+	}
+
+	content, err := os.ReadFile(rttmPath)
+	if err != nil {
+		return nil, fmt.Errorf("reading speaker turns: %w", err)
+	}
+	turns, err := domain.ParseRTTM(string(content))
+	if err != nil {
+		return nil, err
+	}
+	if len(turns) == 0 {
+		return nil, fmt.Errorf("%s lists no speaker turns", rttmPath)
+	}
+	return turns, nil
+}
+
+// NameSpeakers maps a diarized transcript's speaker labels to display names.
+// Whenever a roster member is named at the end of one speaker's turn ("Thank
+// you. Councilmember Martinez?"), the next speaker gets a vote for that
+// member. Labels are then matched to members greedily, most votes first, when
+// a member has a majority of a label's votes; each member names at most one
+// label. Labels left over are numbered "Speaker 1", "Speaker 2", and so on in
+// order of appearance.
+func NameSpeakers(transcript domain.Transcript, roster domain.Roster) map[string]string {
+	labels := transcript.Speakers()
+	votes := make(map[string]map[string]int)
+	if len(roster) > 0 {
+		for _, h := range handoffs(transcript.MergedCues()) {
+			member, ok := roster.LastReferenced(h.text)
+			if !ok {
+				continue
+			}
+			if votes[h.next] == nil {
+				votes[h.next] = make(map[string]int)
+			}
+			votes[h.next][member.Label()]++
+		}
+	}
+
+	type candidate struct {
+		label, name string
+		votes       int
+	}
+	var candidates []candidate
+	for _, label := range labels {
+		total := 0
+		for _, n := range votes[label] {
+			total += n
+		}
+		for name, n := range votes[label] {
+			if 2*n > total {
+				candidates = append(candidates, candidate{label, name, n})
+			}
+		}
+	}
+	slices.SortStableFunc(candidates, func(a, b candidate) int {
+		if c := cmp.Compare(b.votes, a.votes); c != 0 {
+			return c
+		}
+		return cmp.Compare(slices.Index(labels, a.label), slices.Index(labels, b.label))
+	})
+
+	names := make(map[string]string, len(labels))
+	taken := make(map[string]bool)
+	for _, c := range candidates {
+		if _, ok := names[c.label]; ok || taken[c.name] {
+			continue
+		}
+		names[c.label] = c.name
+		taken[c.name] = true
+	}
+
+	n := 0
+	for _, label := range labels {
+		if _, ok := names[label]; !ok {
+			n++
+			names[label] = fmt.Sprintf("Speaker %d", n)
+		}
+	}
+	return names
+}
+
+// handoff is the end of one speaker's turn and the speaker who follows.
+type handoff struct {
+	text string
+	next string
+}
+
+// handoffs returns every change of speaker in cues, with the last few cues of
+// the outgoing turn.
+func handoffs(cues []domain.Cue) []handoff {
+	var out []handoff
+	turnStart := 0
+	for i := 1; i < len(cues); i++ {
+		if cues[i].Speaker == cues[i-1].Speaker {
+			continue
+		}
+		if cues[i-1].Speaker != "" && cues[i].Speaker != "" {
+			var tail []string
+			for _, c := range cues[max(turnStart, i-handoffCues):i] {
+				tail = append(tail, c.Text)
+			}
+			out = append(out, handoff{text: strings.Join(tail, " "), next: cues[i].Speaker})
+		}
+		turnStart = i
+	}
+	return out
+}
+
+// countNamed returns how many labels were named from the roster rather than
+// numbered.
+func countNamed(names map[string]string) int {
+	n := 0
+	for _, name := range names {
+		if !strings.HasPrefix(name, "Speaker ") {
+			n++
+		}
+	}
+	return n
+}
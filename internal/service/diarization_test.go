@@ -0,0 +1,123 @@
+package service_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/AvogadroSG1/civic-summary/internal/domain"
+	"github.com/AvogadroSG1/civic-summary/internal/executor"
+	"github.com/AvogadroSG1/civic-summary/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func diarizedBody() domain.Body {
+	body := testHagerstownBody()
+	body.Diarize = true
+	body.Roster = domain.Roster{
+		{Name: "Keith Bruchey", Title: "Mayor"},
+		{Name: "Tekesha Martinez", Title: "Councilmember"},
+		{Name: "Kristin Aleshire", Title: "Councilmember"},
+	}
+	return body
+}
+
+// speakerCues builds a timed transcript, one cue per line of speaker and text.
+func speakerCues(lines ...[2]string) domain.Transcript {
+	var cues []domain.Cue
+	for i, l := range lines {
+		start := time.Duration(i) * 10 * time.Second
+		cues = append(cues, domain.Cue{Index: i + 1, Start: start, End: start + 10*time.Second, Speaker: l[0], Text: l[1]})
+	}
+	return domain.Transcript{Cues: cues}
+}
+
+func TestNameSpeakers(t *testing.T) {
+	transcript := speakerCues(
+		[2]string{"SPEAKER_00", "Good evening. Councilmember Martinez, you had a question?"},
+		[2]string{"SPEAKER_01", "Thank you, Mr. Mayor. I do."},
+		[2]string{"SPEAKER_00", "Councilmember Aleshire?"},
+		[2]string{"SPEAKER_02", "Nothing from me, Mayor Bruchey."},
+		[2]string{"SPEAKER_00", "Next, public comment."},
+		[2]string{"SPEAKER_03", "My name is Jane Doe and I live on Potomac Street."},
+		[2]string{"SPEAKER_00", "Thank you. Councilmember Martinez?"},
+		[2]string{"SPEAKER_01", "One more point."},
+	)
+
+	names := service.NameSpeakers(transcript, diarizedBody().Roster)
+
+	assert.Equal(t, map[string]string{
+		"SPEAKER_00": "Mayor Keith Bruchey",
+		"SPEAKER_01": "Councilmember Tekesha Martinez",
+		"SPEAKER_02": "Councilmember Kristin Aleshire",
+		"SPEAKER_03": "Speaker 1",
+	}, names)
+}
+
+func TestNameSpeakers_NoRoster(t *testing.T) {
+	transcript := speakerCues(
+		[2]string{"SPEAKER_01", "Call to order."},
+		[2]string{"SPEAKER_00", "Councilmember Martinez?"},
+		[2]string{"SPEAKER_01", "Thank you."},
+	)
+
+	names := service.NameSpeakers(transcript, nil)
+
+	assert.Equal(t, map[string]string{"SPEAKER_01": "Speaker 1", "SPEAKER_00": "Speaker 2"}, names)
+}
+
+func TestDiarizationService_Diarize_ReusesSpeakerTurns(t *testing.T) {
+	tmpDir := t.TempDir()
+	meeting := transcribeMeeting()
+	rttm := "SPEAKER test123 1 0.0 10.0 <NA> <NA> SPEAKER_00 <NA> <NA>\n" +
+		"SPEAKER test123 1 10.0 10.0 <NA> <NA> SPEAKER_01 <NA> <NA>\n"
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "test123.rttm"), []byte(rttm), 0o644))
+
+	mock := executor.NewMockCommander()
+	svc := service.NewDiarizationService(youtubeSources(executor.NewYtDlpExecutor(mock, "yt-dlp")),
+		executor.NewDiarizerExecutor(mock, "diarize", nil))
+	transcript := speakerCues(
+		[2]string{"", "Councilmember Martinez, please go ahead."},
+		[2]string{"", "Thank you."},
+	)
+
+	labeled := svc.Diarize(context.Background(), meeting, diarizedBody(), transcript, tmpDir)
+
+	assert.Empty(t, mock.Calls, "existing speaker turns are reused")
+	assert.Equal(t, "Speaker 1", labeled.Cues[0].Speaker)
+	assert.Equal(t, "Councilmember Tekesha Martinez", labeled.Cues[1].Speaker)
+}
+
+func TestDiarizationService_Diarize_FailureKeepsTranscript(t *testing.T) {
+	tmpDir := t.TempDir()
+	recording := filepath.Join(t.TempDir(), "meeting.mp4")
+	require.NoError(t, os.WriteFile(recording, []byte("video"), 0o644))
+	meeting := domain.Meeting{VideoID: "meeting", VideoURL: recording}
+
+	// The diarizer "succeeds" without writing any speaker turns.
+	mock := executor.NewMockCommander()
+	svc := service.NewDiarizationService(youtubeSources(executor.NewYtDlpExecutor(mock, "yt-dlp")),
+		executor.NewDiarizerExecutor(mock, "diarize", nil))
+	transcript := speakerCues([2]string{"", "Call to order."})
+
+	labeled := svc.Diarize(context.Background(), meeting, diarizedBody(), transcript, tmpDir)
+
+	assert.Equal(t, []string{"diarize " + recording + " " + filepath.Join(tmpDir, "meeting.rttm")}, mock.Calls)
+	assert.Equal(t, transcript, labeled)
+}
+
+func TestDiarizationService_Diarize_Disabled(t *testing.T) {
+	mock := executor.NewMockCommander()
+	transcript := speakerCues([2]string{"", "Call to order."})
+
+	notEnabled := service.NewDiarizationService(nil, executor.NewDiarizerExecutor(mock, "diarize", nil))
+	assert.Equal(t, transcript, notEnabled.Diarize(context.Background(), transcribeMeeting(), testHagerstownBody(), transcript, t.TempDir()))
+
+	noTool := service.NewDiarizationService(nil, nil)
+	assert.Equal(t, transcript, noTool.Diarize(context.Background(), transcribeMeeting(), diarizedBody(), transcript, t.TempDir()))
+
+	assert.Empty(t, mock.Calls)
+}
@@ -6,76 +6,109 @@ import (
 	"fmt"
 	"log/slog"
 	"os"
-	"regexp"
+	"slices"
 	"sort"
 	"strings"
 	"time"
 
 	"github.com/AvogadroSG1/civic-summary/internal/config"
 	"github.com/AvogadroSG1/civic-summary/internal/domain"
-	"github.com/AvogadroSG1/civic-summary/internal/executor"
+	"github.com/AvogadroSG1/civic-summary/internal/source"
 )
 
-// DiscoveryService finds unprocessed meetings from YouTube video sources.
+// VideoSourceFor returns the video source that publishes a body's meetings.
+type VideoSourceFor func(body domain.Body) (source.VideoSource, error)
+
+// DiscoveryService finds unprocessed meetings from each body's video source.
 type DiscoveryService struct {
-	ytdlp *executor.YtDlpExecutor
-	cfg   *config.Config
+	sources   VideoSourceFor
+	ledger    *LedgerService
+	sequences *SequenceService
+	cfg       *config.Config
 }
 
 // NewDiscoveryService creates a new DiscoveryService.
-func NewDiscoveryService(ytdlp *executor.YtDlpExecutor, cfg *config.Config) *DiscoveryService {
-	return &DiscoveryService{ytdlp: ytdlp, cfg: cfg}
+func NewDiscoveryService(sources VideoSourceFor, cfg *config.Config) *DiscoveryService {
+	ledger := NewLedgerService(cfg)
+	return &DiscoveryService{
+		sources:   sources,
+		ledger:    ledger,
+		sequences: NewSequenceService(cfg, ledger),
+		cfg:       cfg,
+	}
 }
 
-// DiscoverNewMeetings finds all unprocessed meetings for a body.
-// It lists the playlist, parses dates from titles, assigns sequence numbers
-// for same-date disambiguation, and filters out already-processed meetings.
-func (s *DiscoveryService) DiscoverNewMeetings(ctx context.Context, body domain.Body) ([]domain.Meeting, error) {
-	yearFilter := fmt.Sprintf("%d", time.Now().Year())
-
-	slog.Info("discovering new videos",
-		"body", body.Slug,
-		"source", body.DiscoveryURL(),
-		"year_filter", yearFilter,
-	)
-
-	entries, err := s.ytdlp.ListPlaylist(ctx, body.DiscoveryURL(), yearFilter)
+// DiscoverNewMeetings finds all unprocessed meetings for a body whose date
+// falls within window. It lists the body's source, parses dates from titles,
+// gives each meeting the same-date sequence it would be assigned, and filters
+// out meetings outside the window or already in the ledger. Meetings are
+// returned oldest-first so that cross-references resolve as an archive is
+// backfilled.
+//
+// It changes nothing: no sequence is persisted and no summary renamed. A run
+// that will process the meetings uses PrepareNewMeetings instead.
+func (s *DiscoveryService) DiscoverNewMeetings(ctx context.Context, body domain.Body, window domain.DateRange) ([]domain.Meeting, error) {
+	allParsed, err := s.listMeetings(ctx, body)
 	if err != nil {
-		return nil, fmt.Errorf("listing videos for %s: %w", body.Slug, err)
+		return nil, err
 	}
 
-	slog.Info("found videos in playlist",
-		"body", body.Slug,
-		"count", len(entries),
-	)
+	// Sequences are worked out across the whole listing so a meeting's
+	// sequence does not depend on the discovery window.
+	if err := s.sequences.Preview(body, allParsed); err != nil {
+		return nil, fmt.Errorf("assigning sequences for %s: %w", body.Slug, err)
+	}
+	return s.newMeetings(body, allParsed, window), nil
+}
 
-	dateRegex, err := regexp.Compile(body.TitleDateRegex)
+// PrepareNewMeetings is DiscoverNewMeetings for a run that will process the
+// meetings. The first run for a body imports its existing summaries into the
+// ledger, so an archive processed before the ledger existed is not run again.
+// The listing may leave out whether a video is live or how long it is, so the
+// meetings to be processed are checked again with their full details before
+// they can take a sequence number; those due reports false for, such as
+// meetings waiting to recheck for captions, are not looked up. Sequence numbers are then persisted, and
+// when a new upload changes a processed meeting's number, its summary is
+// renamed to match. See SequenceService.
+func (s *DiscoveryService) PrepareNewMeetings(ctx context.Context, body domain.Body, window domain.DateRange, due func(domain.Meeting) bool) ([]domain.Meeting, error) {
+	allParsed, err := s.listMeetings(ctx, body)
 	if err != nil {
-		return nil, fmt.Errorf("compiling date regex for %s: %w", body.Slug, err)
+		return nil, err
 	}
 
-	// Phase 1: Parse all entries into meetings.
-	var allParsed []domain.Meeting
-	for _, entry := range entries {
-		meeting, err := s.parseMeeting(entry, body, dateRegex)
-		if err != nil {
-			slog.Warn("skipping video: unable to parse",
-				"video_id", entry.VideoID,
-				"title", entry.Title,
-				"error", err,
-			)
-			continue
+	if !s.ledger.Imported(body) {
+		if _, err := s.importLedger(body, legacySequences(allParsed)); err != nil {
+			return nil, err
 		}
-		allParsed = append(allParsed, meeting)
 	}
 
-	// Phase 2: Assign sequence numbers for same-date disambiguation.
-	assignSequences(allParsed)
+	allParsed = s.addDetails(ctx, body, allParsed, window, due)
 
-	// Phase 3: Filter out already-processed meetings.
+	// Sequences are assigned across the whole listing so a meeting's sequence
+	// does not depend on the discovery window.
+	if err := s.sequences.Assign(body, allParsed); err != nil {
+		return nil, fmt.Errorf("assigning sequences for %s: %w", body.Slug, err)
+	}
+	s.RepairSequences(body)
+
+	return s.newMeetings(body, allParsed, window), nil
+}
+
+// newMeetings filters out the meetings outside the window and the processed
+// ones, and orders the rest oldest-first.
+func (s *DiscoveryService) newMeetings(body domain.Body, allParsed []domain.Meeting, window domain.DateRange) []domain.Meeting {
+	isProcessed := s.processedCheck(body)
 	var meetings []domain.Meeting
 	for _, meeting := range allParsed {
-		if s.isProcessed(meeting, body) {
+		if !window.Contains(meeting.MeetingDate) {
+			slog.Debug("outside discovery window",
+				"body", body.Slug,
+				"date", meeting.ISODate(),
+				"window", window.String(),
+			)
+			continue
+		}
+		if isProcessed(meeting) {
 			slog.Info("already processed",
 				"body", body.Slug,
 				"date", meeting.ISODate(),
@@ -86,50 +119,421 @@ func (s *DiscoveryService) DiscoverNewMeetings(ctx context.Context, body domain.
 		meetings = append(meetings, meeting)
 	}
 
+	// Order oldest-first.
+	sort.SliceStable(meetings, func(i, j int) bool {
+		if !meetings[i].MeetingDate.Equal(meetings[j].MeetingDate) {
+			return meetings[i].MeetingDate.Before(meetings[j].MeetingDate)
+		}
+		return meetings[i].Sequence < meetings[j].Sequence
+	})
+
 	slog.Info("new meetings discovered",
 		"body", body.Slug,
 		"count", len(meetings),
 	)
 
+	return meetings
+}
+
+// addDetails merges the full metadata of each unprocessed meeting in window
+// that is due into its details when the body's source has more than its
+// listing reports, and drops the meetings those details show to be unfinished
+// livestreams or too short, as decide would have had the listing said so. Only
+// meetings about to be processed are looked up, since each lookup is a request
+// of its own. A failed lookup is logged and the listing's details are kept.
+func (s *DiscoveryService) addDetails(ctx context.Context, body domain.Body, meetings []domain.Meeting, window domain.DateRange, due func(domain.Meeting) bool) []domain.Meeting {
+	videoSource, err := s.sources(body)
+	if err != nil {
+		return meetings
+	}
+	detailer, ok := videoSource.(source.Detailer)
+	if !ok {
+		return meetings
+	}
+	isProcessed := s.processedCheck(body)
+	kept := meetings[:0]
+	for _, meeting := range meetings {
+		if !window.Contains(meeting.MeetingDate) || isProcessed(meeting) || !due(meeting) {
+			kept = append(kept, meeting)
+			continue
+		}
+		details, err := detailer.Details(ctx, meeting)
+		if err != nil {
+			slog.Warn("could not fetch video details",
+				"body", body.Slug,
+				"video_id", meeting.VideoID,
+				"error", err,
+			)
+			kept = append(kept, meeting)
+			continue
+		}
+		meeting.Details = meeting.Details.Merge(details)
+		if reason := skipReason(body, meeting.Details); reason != "" {
+			slog.Info("skipping video",
+				"video_id", meeting.VideoID,
+				"title", meeting.Title,
+				"reason", reason,
+			)
+			continue
+		}
+		kept = append(kept, meeting)
+	}
+	return kept
+}
+
+// ImportLedger records the body's existing finalized summaries in its
+// ledger, matching them against the source's current listing. Entries already
+// in the ledger are kept as they are.
+func (s *DiscoveryService) ImportLedger(ctx context.Context, body domain.Body) (LedgerImport, error) {
+	meetings, err := s.listMeetings(ctx, body)
+	if err != nil {
+		return LedgerImport{}, err
+	}
+	// Summaries may be named under either numbering, so both are matched.
+	candidates := legacySequences(meetings)
+	if err := s.sequences.Assign(body, meetings); err != nil {
+		return LedgerImport{}, fmt.Errorf("assigning sequences for %s: %w", body.Slug, err)
+	}
+	return s.importLedger(body, append(candidates, meetings...))
+}
+
+// RepairSequences renames the summaries of processed meetings whose sequence
+// has changed, such as a solo meeting that becomes "-1" when a second meeting
+// for its date appears, and logs what it changed. PrepareNewMeetings runs it
+// after assigning sequences.
+func (s *DiscoveryService) RepairSequences(body domain.Body) []SequenceChange {
+	changes, err := s.sequences.Repair(body)
+	for _, c := range changes {
+		slog.Info("summary renumbered",
+			"body", body.Slug,
+			"video_id", c.VideoID,
+			"date", c.MeetingDate,
+			"from", c.From,
+			"to", c.To,
+			"path", c.NewPath,
+			"missing", c.Missing,
+		)
+	}
+	if err != nil {
+		slog.Warn("failed to renumber summaries", "body", body.Slug, "error", err)
+	}
+	return changes
+}
+
+// CurrentSequence returns the persisted sequence for a meeting, or its own
+// Sequence when none has been assigned, as for a meeting that was
+// quarantined before a second meeting appeared on its date.
+func (s *DiscoveryService) CurrentSequence(meeting domain.Meeting, body domain.Body) int {
+	seq, ok, err := s.sequences.Sequence(body, meeting.ISODate(), meeting.VideoID)
+	if err != nil || !ok {
+		return meeting.Sequence
+	}
+	return seq
+}
+
+func (s *DiscoveryService) importLedger(body domain.Body, meetings []domain.Meeting) (LedgerImport, error) {
+	result, err := s.ledger.Import(body, meetings)
+	if err != nil {
+		return result, fmt.Errorf("importing ledger for %s: %w", body.Slug, err)
+	}
+	slog.Info("imported existing summaries into ledger",
+		"body", body.Slug,
+		"added", result.Added,
+		"unmatched", len(result.Unmatched),
+	)
+	for _, path := range result.Unmatched {
+		slog.Warn("summary not matched to a video", "body", body.Slug, "path", path)
+	}
+	return result, nil
+}
+
+// DiscoveryDecision records what discovery made of one listed video, for
+// "discover --explain".
+type DiscoveryDecision struct {
+	Video domain.Video
+	// Meeting is the parsed meeting, zero when the video was skipped before
+	// its title was parsed.
+	Meeting domain.Meeting
+	// DatedBy names what dated the meeting: a title_date_regex pattern, or
+	// the source's publish date.
+	DatedBy string
+	// Skipped says why the video is not processed; empty for a new meeting.
+	Skipped string
+}
+
+// Explain lists the body's source and reports, for every entry, which rule
+// dated it or why it was skipped, without changing the ledger or sequences.
+func (s *DiscoveryService) Explain(ctx context.Context, body domain.Body, window domain.DateRange) ([]DiscoveryDecision, error) {
+	decisions, err := s.decide(ctx, body)
+	if err != nil {
+		return nil, err
+	}
+	isProcessed := s.processedCheck(body)
+	for i, d := range decisions {
+		switch {
+		case d.Skipped != "":
+		case !window.Contains(d.Meeting.MeetingDate):
+			decisions[i].Skipped = fmt.Sprintf("outside the discovery window (%s)", window)
+		case isProcessed(d.Meeting):
+			decisions[i].Skipped = "already in the ledger"
+		}
+	}
+	return decisions, nil
+}
+
+// listMeetings lists the body's source and parses every entry into a
+// meeting, without sequences. Entries dropped by the title filters, whose
+// titles cannot be dated, or that are shorter than the body's minimum
+// duration are logged and skipped, so they never take a same-date sequence
+// number.
+func (s *DiscoveryService) listMeetings(ctx context.Context, body domain.Body) ([]domain.Meeting, error) {
+	decisions, err := s.decide(ctx, body)
+	if err != nil {
+		return nil, err
+	}
+
+	var meetings []domain.Meeting
+	for _, d := range decisions {
+		if d.Skipped != "" {
+			slog.Info("skipping video",
+				"video_id", d.Video.ID,
+				"title", d.Video.Title,
+				"reason", d.Skipped,
+			)
+			continue
+		}
+		meetings = append(meetings, d.Meeting)
+	}
 	return meetings, nil
 }
 
-// parseMeeting extracts meeting metadata from a playlist entry.
-func (s *DiscoveryService) parseMeeting(entry executor.PlaylistEntry, body domain.Body, dateRegex *regexp.Regexp) (domain.Meeting, error) {
-	matches := dateRegex.FindStringSubmatch(entry.Title)
-	if len(matches) < 2 {
-		return domain.Meeting{}, fmt.Errorf("no date found in title %q", entry.Title)
+// decide lists the body's source and parses or skips each entry.
+func (s *DiscoveryService) decide(ctx context.Context, body domain.Body) ([]DiscoveryDecision, error) {
+	slog.Info("discovering new videos",
+		"body", body.Slug,
+		"source", body.SourceType(),
+		"url", body.DiscoveryURL(),
+	)
+
+	videoSource, err := s.sources(body)
+	if err != nil {
+		return nil, fmt.Errorf("video source for %s: %w", body.Slug, err)
+	}
+	entries, err := videoSource.Videos(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing videos for %s: %w", body.Slug, err)
+	}
+
+	slog.Info("found videos in source",
+		"body", body.Slug,
+		"count", len(entries),
+	)
+
+	rules, err := compileTitleRules(body)
+	if err != nil {
+		return nil, fmt.Errorf("title rules for %s: %w", body.Slug, err)
+	}
+
+	decisions := make([]DiscoveryDecision, 0, len(entries))
+	for _, entry := range entries {
+		d := DiscoveryDecision{Video: entry}
+		if d.Skipped = rules.filter(entry.Title); d.Skipped == "" {
+			d.Skipped = liveReason(entry.Details.LiveStatus)
+		}
+		if d.Skipped != "" {
+			decisions = append(decisions, d)
+			continue
+		}
+		d.Meeting, d.DatedBy, err = s.parseMeeting(entry, body, rules)
+		if err != nil {
+			d.Skipped = err.Error()
+		} else {
+			d.Skipped = skipReason(body, d.Meeting.Details)
+		}
+		decisions = append(decisions, d)
+	}
+	return decisions, nil
+}
+
+// skipReason returns why a meeting with these details cannot be summarized:
+// it is an unfinished livestream or shorter than the body's minimum duration.
+// It returns "" for a meeting that can be.
+func skipReason(body domain.Body, details domain.VideoDetails) string {
+	if reason := liveReason(details.LiveStatus); reason != "" {
+		return reason
+	}
+	if body.TooShort(details.Duration) {
+		return fmt.Sprintf("shorter than min_duration_minutes (%s)", details.Duration)
+	}
+	return ""
+}
+
+// liveReason returns why a livestream cannot be summarized yet, or "" if it
+// is not one or has ended. A skipped stream is not recorded anywhere, so the
+// next discovery finds it again.
+func liveReason(status string) string {
+	switch status {
+	case domain.LiveStatusUpcoming:
+		return "livestream has not started"
+	case domain.LiveStatusLive:
+		return "livestream is still live"
+	}
+	return ""
+}
+
+// MeetingFor builds the meeting for a video that was not found by discovery,
+// such as a local file passed to ingest. The date and meeting type are parsed
+// from the title as discovery would, but the title filters are not applied;
+// the sequence is left at zero until AssignSequence.
+func (s *DiscoveryService) MeetingFor(video domain.Video, body domain.Body) (domain.Meeting, error) {
+	rules, err := compileTitleRules(body)
+	if err != nil {
+		return domain.Meeting{}, fmt.Errorf("title rules for %s: %w", body.Slug, err)
+	}
+	meeting, _, err := s.parseMeeting(video, body, rules)
+	return meeting, err
+}
+
+// AssignSequence gives a meeting built by MeetingFor its same-date sequence,
+// as PrepareNewMeetings does for listed ones, so a second recording for a
+// date does not take the first one's summary path. Call it once the date is
+// final and the meeting is known to be new. A processed meeting renumbered by
+// the assignment has its summary renamed. The flag reports whether the
+// meeting was numbered just now rather than before, and so whether
+// ReleaseSequence may undo the assignment.
+func (s *DiscoveryService) AssignSequence(meeting domain.Meeting, body domain.Body) (domain.Meeting, bool, error) {
+	_, known, err := s.sequences.Sequence(body, meeting.ISODate(), meeting.VideoID)
+	if err != nil {
+		return domain.Meeting{}, false, fmt.Errorf("assigning sequences for %s: %w", body.Slug, err)
+	}
+	meetings := []domain.Meeting{meeting}
+	if err := s.sequences.Assign(body, meetings); err != nil {
+		return domain.Meeting{}, false, fmt.Errorf("assigning sequences for %s: %w", body.Slug, err)
+	}
+	s.RepairSequences(body)
+	return meetings[0], !known, nil
+}
+
+// ReleaseSequence undoes AssignSequence for a meeting that was not
+// summarized, such as an ingest that failed, so it does not hold a place on
+// its date: the meetings numbered after it move up, and a summary left alone
+// on the date loses its suffix again.
+func (s *DiscoveryService) ReleaseSequence(meeting domain.Meeting, body domain.Body) error {
+	if err := s.sequences.Release(body, meeting.ISODate(), meeting.VideoID); err != nil {
+		return fmt.Errorf("releasing sequence for %s: %w", meeting.VideoID, err)
 	}
+	s.RepairSequences(body)
+	return nil
+}
 
-	dateStr := matches[1]
-	meetingDate, err := parseFlexibleDate(dateStr)
+// RetrySequence returns the sequence a quarantined meeting is retried under.
+// An ingest that failed gave its number back (see ReleaseSequence), so a local
+// recording with no persisted number is numbered again, renaming any summary
+// that must gain a suffix; any other meeting, or one whose date is unknown,
+// keeps its persisted sequence, or failing that its own.
+func (s *DiscoveryService) RetrySequence(meeting domain.Meeting, body domain.Body) int {
+	if meeting.MeetingDate.IsZero() || !domain.IsLocalFile(meeting.VideoURL) {
+		return s.CurrentSequence(meeting, body)
+	}
+	_, known, err := s.sequences.Sequence(body, meeting.ISODate(), meeting.VideoID)
+	if err != nil || known {
+		return s.CurrentSequence(meeting, body)
+	}
+	numbered, _, err := s.AssignSequence(meeting, body)
 	if err != nil {
-		return domain.Meeting{}, fmt.Errorf("parsing date %q from title: %w", dateStr, err)
+		slog.Warn("failed to number quarantined ingest", "video_id", meeting.VideoID, "error", err)
+		return meeting.Sequence
+	}
+	return numbered.Sequence
+}
+
+// parseMeeting extracts meeting metadata from a listed video, and names the
+// rule that dated it. The date comes from the first title_date_regex pattern
+// that finds one; when none does, the source's publish date (for YouTube, the
+// upload date) is used if it reported one.
+func (s *DiscoveryService) parseMeeting(entry domain.Video, body domain.Body, rules *titleRules) (domain.Meeting, string, error) {
+	meetingDate, datedBy, err := rules.date(entry.Title)
+	if err != nil {
+		if entry.Published.IsZero() {
+			return domain.Meeting{}, "", err
+		}
+		y, m, d := entry.Published.Date()
+		meetingDate = time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+		datedBy = "publish date"
 	}
 
-	meetingType := detectMeetingType(entry.Title, body.MeetingTypes)
+	meetingType := body.DetectMeetingType(entry.Title)
 
 	return domain.Meeting{
-		VideoID:     entry.VideoID,
+		VideoID:     entry.ID,
 		Title:       entry.Title,
+		VideoURL:    entry.URL,
+		Published:   entry.Published,
+		Details:     entry.Details,
 		MeetingDate: meetingDate,
 		MeetingType: meetingType,
 		BodySlug:    body.Slug,
-	}, nil
+	}, datedBy, nil
+}
+
+// IsProcessed reports whether the ledger has an entry for the meeting's
+// video, whatever its status.
+func (s *DiscoveryService) IsProcessed(meeting domain.Meeting, body domain.Body) bool {
+	return s.processedCheck(body)(meeting)
+}
+
+// processedCheck reads the ledger once and returns a check for meetings
+// against it. If the ledger cannot be read, the check falls back to looking
+// for each summary file, so a damaged ledger does not re-run an archive. Until
+// the body's existing summaries have been imported, as before its first
+// processing run, a meeting whose summary file exists counts as processed too.
+func (s *DiscoveryService) processedCheck(body domain.Body) func(domain.Meeting) bool {
+	hasSummary := func(meeting domain.Meeting) bool {
+		_, err := os.Stat(s.SummaryPath(meeting, body))
+		return err == nil
+	}
+	entries, err := s.ledger.List(body)
+	if err != nil {
+		slog.Warn("ledger unreadable; checking for summary files instead",
+			"body", body.Slug,
+			"error", err,
+		)
+		return hasSummary
+	}
+
+	seen := make(map[string]bool, len(entries))
+	for _, e := range entries {
+		seen[e.VideoID] = true
+	}
+	imported := s.ledger.Imported(body)
+	return func(meeting domain.Meeting) bool {
+		return seen[meeting.VideoID] || (!imported && hasSummary(meeting))
+	}
 }
 
-// isProcessed checks if a summary file already exists for this meeting.
-func (s *DiscoveryService) isProcessed(meeting domain.Meeting, body domain.Body) bool {
-	summaryPath := s.SummaryPath(meeting, body)
-	_, err := os.Stat(summaryPath)
-	return err == nil
+// MarkProcessed records in the ledger that a meeting's summary was written to
+// summaryPath.
+func (s *DiscoveryService) MarkProcessed(meeting domain.Meeting, body domain.Body, summaryPath string) error {
+	return s.ledger.Record(body, domain.NewLedgerEntry(meeting, domain.LedgerDone, summaryPath))
+}
+
+// MarkQuarantined records in the ledger that a meeting failed and is waiting
+// in quarantine, so discovery leaves it to the quarantine retry.
+func (s *DiscoveryService) MarkQuarantined(meeting domain.Meeting, body domain.Body) error {
+	return s.ledger.Record(body, domain.NewLedgerEntry(meeting, domain.LedgerQuarantined, ""))
 }
 
 // SummaryPath returns the expected file path for a meeting's summary.
 func (s *DiscoveryService) SummaryPath(meeting domain.Meeting, body domain.Body) string {
+	return summaryPath(s.cfg, meeting, body)
+}
+
+// summaryPath returns the file path a meeting's summary is written to under
+// the body's current filename pattern.
+func summaryPath(cfg *config.Config, meeting domain.Meeting, body domain.Body) string {
 	filename := buildFilename(meeting, body)
 	return fmt.Sprintf("%s/%s/%s.md",
-		s.cfg.FinalizedDir(body),
+		cfg.FinalizedDir(body),
 		meeting.DateFolder(),
 		filename,
 	)
@@ -143,9 +547,17 @@ func buildFilename(meeting domain.Meeting, body domain.Body) string {
 	return name + meeting.SequenceSuffix()
 }
 
-// assignSequences sets Sequence on each meeting for same-date disambiguation.
-// Solo meetings get Sequence=0. Multiple meetings on the same date are sorted
-// by VideoID for determinism and assigned 1, 2, 3, etc.
+// legacySequences returns a copy of meetings numbered as discovery numbered
+// them before sequences were persisted, to match summaries written then.
+func legacySequences(meetings []domain.Meeting) []domain.Meeting {
+	legacy := slices.Clone(meetings)
+	assignSequences(legacy)
+	return legacy
+}
+
+// assignSequences sets Sequence on each meeting for same-date disambiguation
+// by the original scheme: solo meetings get Sequence=0, and multiple meetings
+// on the same date are sorted by VideoID and assigned 1, 2, 3, etc.
 func assignSequences(meetings []domain.Meeting) {
 	// Group by ISO date.
 	groups := make(map[string][]int) // date -> indices
@@ -170,52 +582,3 @@ func assignSequences(meetings []domain.Meeting) {
 		}
 	}
 }
-
-// parseFlexibleDate tries multiple date formats.
-func parseFlexibleDate(s string) (time.Time, error) {
-	// Clean ordinal suffixes.
-	cleaned := s
-	for _, suffix := range []string{"st,", "nd,", "rd,", "th,"} {
-		cleaned = strings.Replace(cleaned, suffix, ",", 1)
-	}
-	for _, suffix := range []string{"st ", "nd ", "rd ", "th "} {
-		cleaned = strings.Replace(cleaned, suffix, " ", 1)
-	}
-	cleaned = strings.TrimSpace(cleaned)
-
-	formats := []string{
-		"2006-01-02",
-		"January 2, 2006",
-		"January 2 2006",
-		"January 02, 2006",
-		"January 02 2006",
-	}
-	for _, format := range formats {
-		if t, err := time.Parse(format, cleaned); err == nil {
-			return t, nil
-		}
-	}
-	return time.Time{}, fmt.Errorf("unable to parse date: %q", s)
-}
-
-// detectMeetingType determines the meeting type from the video title.
-func detectMeetingType(title string, configuredTypes []string) string {
-	titleLower := strings.ToLower(title)
-	for _, mt := range configuredTypes {
-		if strings.Contains(titleLower, strings.ToLower(mt)) {
-			return mt
-		}
-	}
-
-	// Common fallback patterns.
-	switch {
-	case strings.Contains(titleLower, "work session"):
-		return "Work Session"
-	case strings.Contains(titleLower, "special"):
-		return "Special Meeting"
-	case strings.Contains(titleLower, "evening"):
-		return "Evening Meeting"
-	default:
-		return "Regular Session"
-	}
-}
@@ -2,14 +2,19 @@ package service_test
 
 import (
 	"context"
+	"encoding/json"
+	"net/http"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/AvogadroSG1/civic-summary/internal/config"
 	"github.com/AvogadroSG1/civic-summary/internal/domain"
 	"github.com/AvogadroSG1/civic-summary/internal/executor"
 	"github.com/AvogadroSG1/civic-summary/internal/service"
+	"github.com/AvogadroSG1/civic-summary/internal/source"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -28,7 +33,7 @@ func testConfig(t *testing.T) *config.Config {
 				PlaylistID:      "PLJXxCe9GA2fEf4TIVzTH2O-kFJlS8VVgQ",
 				OutputSubdir:    "Hagerstown Town Council - Citizen Summary",
 				FilenamePattern: "Hagerstown-City-Council-{{.MeetingDate}}-Citizen-Summary",
-				TitleDateRegex:  `^([A-Z][a-z]+ \d{1,2},? \d{4})`,
+				TitleDateRegex:  domain.TitlePatterns{{Regex: `^([A-Z][a-z]+ \d{1,2},? \d{4})`}},
 				Tags:            []string{"City-Council", "Hagerstown"},
 				PromptTemplate:  "hagerstown.prompt.tmpl",
 				Author:          "Peter O'Connor",
@@ -37,30 +42,56 @@ func testConfig(t *testing.T) *config.Config {
 	}
 }
 
+// allDue is the due check for a run with no deferred meetings.
+func allDue(domain.Meeting) bool { return true }
+
+// playlist renders "id|title" lines as the JSON lines yt-dlp prints for a
+// flat playlist listing.
+func playlist(listing string) string {
+	var b strings.Builder
+	for _, line := range strings.Split(strings.TrimSpace(listing), "\n") {
+		id, title, _ := strings.Cut(line, "|")
+		entry, _ := json.Marshal(map[string]string{"id": id, "title": title})
+		b.Write(entry)
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// youtubeSources resolves every body to a YouTube source backed by ytdlp.
+func youtubeSources(ytdlp *executor.YtDlpExecutor) service.VideoSourceFor {
+	return func(body domain.Body) (source.VideoSource, error) {
+		return source.NewYouTube(ytdlp, body.DiscoveryURL()), nil
+	}
+}
+
 func TestDiscoveryService_DiscoverNewMeetings(t *testing.T) {
 	mock := executor.NewMockCommander()
 	mock.DefaultResult = &executor.CommandResult{
-		Stdout: "abc123|February 04, 2025 | Mayor & Council Regular Session\ndef456|January 21, 2025 | Mayor & Council Work Session\n",
+		Stdout: playlist("abc123|February 04, 2025 | Mayor & Council Regular Session\ndef456|January 21, 2025 | Mayor & Council Work Session\n"),
 	}
 
 	cfg := testConfig(t)
 	ytdlp := executor.NewYtDlpExecutor(mock, "yt-dlp")
-	discovery := service.NewDiscoveryService(ytdlp, cfg)
+	discovery := service.NewDiscoveryService(youtubeSources(ytdlp), cfg)
 
 	body, _ := cfg.GetBody("hagerstown")
-	meetings, err := discovery.DiscoverNewMeetings(context.Background(), body)
+	meetings, err := discovery.DiscoverNewMeetings(context.Background(), body, domain.DateRange{})
 	require.NoError(t, err)
 
-	assert.Len(t, meetings, 2)
-	assert.Equal(t, "abc123", meetings[0].VideoID)
-	assert.Equal(t, "2025-02-04", meetings[0].ISODate())
-	assert.Equal(t, "hagerstown", meetings[0].BodySlug)
+	require.Len(t, meetings, 2)
+	// Oldest-first: the January meeting precedes the February one.
+	assert.Equal(t, "def456", meetings[0].VideoID)
+	assert.Equal(t, "2025-01-21", meetings[0].ISODate())
+	assert.Equal(t, "abc123", meetings[1].VideoID)
+	assert.Equal(t, "2025-02-04", meetings[1].ISODate())
+	assert.Equal(t, "hagerstown", meetings[1].BodySlug)
 }
 
 func TestDiscoveryService_SkipsProcessed(t *testing.T) {
 	mock := executor.NewMockCommander()
 	mock.DefaultResult = &executor.CommandResult{
-		Stdout: "abc123|February 04, 2025 | Mayor & Council Regular Session\n",
+		Stdout: playlist("abc123|February 04, 2025 | Mayor & Council Regular Session\n"),
 	}
 
 	cfg := testConfig(t)
@@ -75,34 +106,241 @@ func TestDiscoveryService_SkipsProcessed(t *testing.T) {
 	))
 
 	ytdlp := executor.NewYtDlpExecutor(mock, "yt-dlp")
-	discovery := service.NewDiscoveryService(ytdlp, cfg)
-	meetings, err := discovery.DiscoverNewMeetings(context.Background(), body)
+	discovery := service.NewDiscoveryService(youtubeSources(ytdlp), cfg)
+	meetings, err := discovery.DiscoverNewMeetings(context.Background(), body, domain.DateRange{})
 	require.NoError(t, err)
 
 	assert.Empty(t, meetings)
 }
 
+func TestDiscoveryService_FiltersAgainstLedger(t *testing.T) {
+	mock := executor.NewMockCommander()
+	mock.DefaultResult = &executor.CommandResult{
+		Stdout: playlist("abc123|February 04, 2025 | Mayor & Council Regular Session\n" +
+			"def456|January 21, 2025 | Mayor & Council Work Session\n" +
+			"ghi789|January 07, 2025 | Test Stream\n"),
+	}
+
+	cfg := testConfig(t)
+	body, _ := cfg.GetBody("hagerstown")
+
+	summaryDir := filepath.Join(cfg.FinalizedDir(body), "20250204")
+	summaryPath := filepath.Join(summaryDir, "Hagerstown-City-Council-2025-02-04-Citizen-Summary.md")
+	require.NoError(t, os.MkdirAll(summaryDir, 0o755))
+	require.NoError(t, os.WriteFile(summaryPath, []byte("# Existing"), 0o644))
+
+	ledger := service.NewLedgerService(cfg)
+	require.NoError(t, ledger.SetStatus(body, "ghi789", domain.LedgerIgnored))
+
+	discovery := service.NewDiscoveryService(youtubeSources(executor.NewYtDlpExecutor(mock, "yt-dlp")), cfg)
+	meetings, err := discovery.PrepareNewMeetings(context.Background(), body, domain.DateRange{}, allDue)
+	require.NoError(t, err)
+	require.Len(t, meetings, 1)
+	assert.Equal(t, "def456", meetings[0].VideoID, "the imported and ignored videos are skipped")
+
+	entry, ok, err := ledger.Get(body, "abc123")
+	require.NoError(t, err)
+	require.True(t, ok, "the first discovery imports existing summaries")
+	assert.Equal(t, summaryPath, entry.OutputPath)
+
+	// Deleting the summary does not bring the meeting back; forgetting it does.
+	require.NoError(t, os.Remove(summaryPath))
+	meetings, err = discovery.PrepareNewMeetings(context.Background(), body, domain.DateRange{}, allDue)
+	require.NoError(t, err)
+	require.Len(t, meetings, 1)
+
+	require.NoError(t, ledger.Forget(body, "abc123"))
+	meetings, err = discovery.PrepareNewMeetings(context.Background(), body, domain.DateRange{}, allDue)
+	require.NoError(t, err)
+	require.Len(t, meetings, 2)
+	assert.Equal(t, "abc123", meetings[1].VideoID)
+}
+
 func TestDiscoveryService_SkipsUnparsableTitles(t *testing.T) {
 	mock := executor.NewMockCommander()
 	mock.DefaultResult = &executor.CommandResult{
-		Stdout: "abc123|Public Hearing for Comprehensive Plan 2040\n",
+		Stdout: playlist("abc123|Public Hearing for Comprehensive Plan 2040\n"),
 	}
 
 	cfg := testConfig(t)
 	ytdlp := executor.NewYtDlpExecutor(mock, "yt-dlp")
-	discovery := service.NewDiscoveryService(ytdlp, cfg)
+	discovery := service.NewDiscoveryService(youtubeSources(ytdlp), cfg)
 
 	body, _ := cfg.GetBody("hagerstown")
-	meetings, err := discovery.DiscoverNewMeetings(context.Background(), body)
+	meetings, err := discovery.DiscoverNewMeetings(context.Background(), body, domain.DateRange{})
 	require.NoError(t, err)
 
 	assert.Empty(t, meetings) // Should be skipped due to unparsable date.
 }
 
+func TestDiscoveryService_UploadDateFallback(t *testing.T) {
+	mock := executor.NewMockCommander()
+	mock.DefaultResult = &executor.CommandResult{
+		Stdout: `{"id": "abc123", "title": "Special Meeting", "upload_date": "20250211", "duration": 3600}` + "\n",
+	}
+
+	cfg := testConfig(t)
+	discovery := service.NewDiscoveryService(youtubeSources(executor.NewYtDlpExecutor(mock, "yt-dlp")), cfg)
+
+	body, _ := cfg.GetBody("hagerstown")
+	meetings, err := discovery.DiscoverNewMeetings(context.Background(), body, domain.DateRange{})
+	require.NoError(t, err)
+
+	require.Len(t, meetings, 1, "a title without a date falls back to the upload date")
+	assert.Equal(t, "2025-02-11", meetings[0].ISODate())
+	assert.Equal(t, time.Hour, meetings[0].Details.Duration, "the listing's metadata reaches the meeting")
+}
+
+func TestDiscoveryService_SkipsShortClips(t *testing.T) {
+	mock := executor.NewMockCommander()
+	mock.DefaultResult = &executor.CommandResult{
+		Stdout: `{"id": "clip", "title": "February 04, 2025 | Proclamation", "duration": 240}` + "\n" +
+			`{"id": "abc123", "title": "February 04, 2025 | Regular Session", "duration": 5400}` + "\n" +
+			`{"id": "def456", "title": "January 21, 2025 | Work Session"}` + "\n",
+	}
+
+	cfg := testConfig(t)
+	body := cfg.Bodies["hagerstown"]
+	body.MinDurationMinutes = 10
+	cfg.Bodies["hagerstown"] = body
+	discovery := service.NewDiscoveryService(youtubeSources(executor.NewYtDlpExecutor(mock, "yt-dlp")), cfg)
+
+	meetings, err := discovery.DiscoverNewMeetings(context.Background(), body, domain.DateRange{})
+	require.NoError(t, err)
+
+	require.Len(t, meetings, 2, "a video of unknown length is kept")
+	assert.Equal(t, "def456", meetings[0].VideoID)
+	assert.Equal(t, "abc123", meetings[1].VideoID)
+	assert.Equal(t, 0, meetings[1].Sequence, "a skipped clip does not take a sequence number")
+}
+
+func TestDiscoveryService_SkipsUnfinishedLivestreams(t *testing.T) {
+	mock := executor.NewMockCommander()
+	mock.DefaultResult = &executor.CommandResult{
+		Stdout: `{"id": "soon", "title": "February 18, 2025 | Regular Session", "live_status": "is_upcoming"}` + "\n" +
+			`{"id": "now", "title": "February 11, 2025 | Work Session", "live_status": "is_live"}` + "\n" +
+			`{"id": "ended", "title": "February 04, 2025 | Regular Session", "live_status": "post_live"}` + "\n",
+	}
+
+	cfg := testConfig(t)
+	discovery := service.NewDiscoveryService(youtubeSources(executor.NewYtDlpExecutor(mock, "yt-dlp")), cfg)
+	body, _ := cfg.GetBody("hagerstown")
+
+	decisions, err := discovery.Explain(context.Background(), body, domain.DateRange{})
+	require.NoError(t, err)
+	require.Len(t, decisions, 3)
+	assert.Equal(t, "livestream has not started", decisions[0].Skipped)
+	assert.Equal(t, "livestream is still live", decisions[1].Skipped)
+	assert.Empty(t, decisions[2].Skipped, "an ended stream is processed")
+}
+
+func TestDiscoveryService_TitlePatterns(t *testing.T) {
+	mock := executor.NewMockCommander()
+	mock.DefaultResult = &executor.CommandResult{
+		Stdout: playlist("a|January 7, 2025 | Regular Session\n" +
+			"b|Council Meeting 01/14/2025\n" +
+			"c|Council Meeting 21 Jan 2025\n" +
+			"d|Council Meeting Jan. 28th, 2025\n" +
+			"e|Council Meeting 3rd Sept 25\n" +
+			"f|Council Meeting 13/45/2025\n"),
+	}
+
+	cfg := testConfig(t)
+	body := cfg.Bodies["hagerstown"]
+	body.TitleDateRegex = domain.TitlePatterns{
+		{Regex: `^([A-Z][a-z]+ \d{1,2},? \d{4})`},
+		{Regex: `(\d{2}/\d{2}/\d{4})`, Layouts: []string{"01/02/2006"}},
+		{Regex: `(?P<day>\d{1,2})(?:st|nd|rd|th)? (?P<month>[A-Z][a-z]+)\.? (?P<year>\d{2,4})`},
+		{Regex: `([A-Z][a-z]+\.? \d{1,2}(?:st|nd|rd|th)?, \d{4})`},
+	}
+	cfg.Bodies["hagerstown"] = body
+	discovery := service.NewDiscoveryService(youtubeSources(executor.NewYtDlpExecutor(mock, "yt-dlp")), cfg)
+
+	decisions, err := discovery.Explain(context.Background(), body, domain.DateRange{})
+	require.NoError(t, err)
+	require.Len(t, decisions, 6)
+
+	dates := make(map[string]string)
+	for _, d := range decisions {
+		if d.Skipped == "" {
+			dates[d.Video.ID] = d.Meeting.ISODate()
+		}
+	}
+	assert.Equal(t, map[string]string{
+		"a": "2025-01-07",
+		"b": "2025-01-14",
+		"c": "2025-01-21",
+		"d": "2025-01-28",
+		"e": "2025-09-03",
+	}, dates)
+	assert.Equal(t, `title_date_regex[1] /(\d{2}/\d{2}/\d{4})/`, decisions[1].DatedBy)
+	assert.Contains(t, decisions[5].Skipped, `parsing date "13/45/2025"`, "a failed parse is reported")
+}
+
+func TestDiscoveryService_TitleFilters(t *testing.T) {
+	mock := executor.NewMockCommander()
+	mock.DefaultResult = &executor.CommandResult{
+		Stdout: playlist("abc123|February 04, 2025 | Regular Session\n" +
+			"promo|February 04, 2025 | Budget Promo\n" +
+			"ribbon|February 05, 2025 | Ribbon Cutting at City Park\n" +
+			"def456|January 21, 2025 | Work Session\n"),
+	}
+
+	cfg := testConfig(t)
+	body := cfg.Bodies["hagerstown"]
+	body.TitleInclude = []string{"Session"}
+	body.TitleExclude = []string{"(?i)promo"}
+	cfg.Bodies["hagerstown"] = body
+	discovery := service.NewDiscoveryService(youtubeSources(executor.NewYtDlpExecutor(mock, "yt-dlp")), cfg)
+
+	meetings, err := discovery.DiscoverNewMeetings(context.Background(), body, domain.DateRange{})
+	require.NoError(t, err)
+	require.Len(t, meetings, 2)
+	assert.Equal(t, "def456", meetings[0].VideoID)
+	assert.Equal(t, "abc123", meetings[1].VideoID)
+	assert.Equal(t, 0, meetings[1].Sequence, "a filtered video does not take a sequence number")
+}
+
+func TestDiscoveryService_Explain(t *testing.T) {
+	mock := executor.NewMockCommander()
+	mock.DefaultResult = &executor.CommandResult{
+		Stdout: playlist("abc123|February 04, 2025 | Regular Session\n" +
+			"promo|February 04, 2025 | Budget Promo\n" +
+			"def456|January 21, 2025 | Work Session\n" +
+			"old|December 17, 2024 | Regular Session\n" +
+			"nodate|Public Hearing for Comprehensive Plan 2040\n"),
+	}
+
+	cfg := testConfig(t)
+	body := cfg.Bodies["hagerstown"]
+	body.TitleExclude = []string{"Promo"}
+	cfg.Bodies["hagerstown"] = body
+	discovery := service.NewDiscoveryService(youtubeSources(executor.NewYtDlpExecutor(mock, "yt-dlp")), cfg)
+
+	processed := testMeeting()
+	processed.VideoID = "def456"
+	require.NoError(t, discovery.MarkProcessed(processed, body, "/out/def456.md"))
+
+	window := domain.DateRange{Since: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)}
+	decisions, err := discovery.Explain(context.Background(), body, window)
+	require.NoError(t, err)
+	require.Len(t, decisions, 5)
+
+	assert.Empty(t, decisions[0].Skipped)
+	assert.Equal(t, "title_date_regex[0] /^([A-Z][a-z]+ \\d{1,2},? \\d{4})/", decisions[0].DatedBy)
+	assert.Equal(t, "title matches title_exclude /Promo/", decisions[1].Skipped)
+	assert.Equal(t, "already in the ledger", decisions[2].Skipped)
+	assert.Contains(t, decisions[3].Skipped, "outside the discovery window")
+	assert.Contains(t, decisions[4].Skipped, "no date found")
+
+	_, err = os.Stat(cfg.SequencesPath(body))
+	assert.True(t, os.IsNotExist(err), "explaining assigns no sequences")
+}
+
 func TestDiscoveryService_MeetingType_Detection(t *testing.T) {
 	mock := executor.NewMockCommander()
 	mock.DefaultResult = &executor.CommandResult{
-		Stdout: "id1|February 04, 2025 | Mayor & Council Work Session\n",
+		Stdout: playlist("id1|February 04, 2025 | Mayor & Council Work Session\n"),
 	}
 
 	cfg := testConfig(t)
@@ -112,16 +350,16 @@ func TestDiscoveryService_MeetingType_Detection(t *testing.T) {
 		PlaylistID:      "TEST",
 		OutputSubdir:    "Test",
 		FilenamePattern: "Test-{{.MeetingDate}}",
-		TitleDateRegex:  `^([A-Z][a-z]+ \d{1,2},? \d{4})`,
+		TitleDateRegex:  domain.TitlePatterns{{Regex: `^([A-Z][a-z]+ \d{1,2},? \d{4})`}},
 		Tags:            []string{"test"},
 		PromptTemplate:  "test.tmpl",
-		MeetingTypes:    []string{"Regular Session", "Work Session"},
+		MeetingTypes:    []domain.MeetingTypeRule{{Name: "Regular Session"}, {Name: "Work Session"}},
 	}
 
 	ytdlp := executor.NewYtDlpExecutor(mock, "yt-dlp")
-	discovery := service.NewDiscoveryService(ytdlp, cfg)
+	discovery := service.NewDiscoveryService(youtubeSources(ytdlp), cfg)
 	body, _ := cfg.GetBody("hagerstown")
-	meetings, err := discovery.DiscoverNewMeetings(context.Background(), body)
+	meetings, err := discovery.DiscoverNewMeetings(context.Background(), body, domain.DateRange{})
 	require.NoError(t, err)
 
 	assert.Len(t, meetings, 1)
@@ -143,10 +381,10 @@ func boccConfig(t *testing.T) *config.Config {
 				PlaylistID:      "PL7X-j0EwreAd_6kV3IjxO-_XNwDNn0esS",
 				OutputSubdir:    "Washington County BOCC - Citizen Summary",
 				FilenamePattern: "BOCC-{{.MeetingDate}}-Citizen-Summary",
-				TitleDateRegex:  `- ([A-Z][a-z]+ \d{1,2}, \d{4})`,
+				TitleDateRegex:  domain.TitlePatterns{{Regex: `- ([A-Z][a-z]+ \d{1,2}, \d{4})`}},
 				Tags:            []string{"BOCC", "Washington-County"},
 				PromptTemplate:  "bocc.prompt.tmpl",
-				MeetingTypes:    []string{"Regular Meeting", "Work Session", "Public Hearing"},
+				MeetingTypes:    []domain.MeetingTypeRule{{Name: "Regular Meeting"}, {Name: "Work Session"}, {Name: "Public Hearing"}},
 				Author:          "Peter O'Connor",
 			},
 		},
@@ -156,15 +394,15 @@ func boccConfig(t *testing.T) *config.Config {
 func TestDiscoveryService_BOCC_DateAtEnd(t *testing.T) {
 	mock := executor.NewMockCommander()
 	mock.DefaultResult = &executor.CommandResult{
-		Stdout: "vid1|Board of County Commissioners Regular Meeting - January 7, 2025\n",
+		Stdout: playlist("vid1|Board of County Commissioners Regular Meeting - January 7, 2025\n"),
 	}
 
 	cfg := boccConfig(t)
 	ytdlp := executor.NewYtDlpExecutor(mock, "yt-dlp")
-	discovery := service.NewDiscoveryService(ytdlp, cfg)
+	discovery := service.NewDiscoveryService(youtubeSources(ytdlp), cfg)
 
 	body, _ := cfg.GetBody("bocc")
-	meetings, err := discovery.DiscoverNewMeetings(context.Background(), body)
+	meetings, err := discovery.DiscoverNewMeetings(context.Background(), body, domain.DateRange{})
 	require.NoError(t, err)
 
 	require.Len(t, meetings, 1)
@@ -178,15 +416,15 @@ func TestDiscoveryService_BOCC_SkipsNonDateTitles(t *testing.T) {
 	mock := executor.NewMockCommander()
 	mock.DefaultResult = &executor.CommandResult{
 		// These titles don't match the BOCC regex (no "- Month Day, Year" suffix).
-		Stdout: "vid1|Public Hearing for Comprehensive Plan 2040\nvid2|Board Work Session Overview\n",
+		Stdout: playlist("vid1|Public Hearing for Comprehensive Plan 2040\nvid2|Board Work Session Overview\n"),
 	}
 
 	cfg := boccConfig(t)
 	ytdlp := executor.NewYtDlpExecutor(mock, "yt-dlp")
-	discovery := service.NewDiscoveryService(ytdlp, cfg)
+	discovery := service.NewDiscoveryService(youtubeSources(ytdlp), cfg)
 
 	body, _ := cfg.GetBody("bocc")
-	meetings, err := discovery.DiscoverNewMeetings(context.Background(), body)
+	meetings, err := discovery.DiscoverNewMeetings(context.Background(), body, domain.DateRange{})
 	require.NoError(t, err)
 
 	assert.Empty(t, meetings)
@@ -195,15 +433,15 @@ func TestDiscoveryService_BOCC_SkipsNonDateTitles(t *testing.T) {
 func TestDiscoveryService_SameDateDisambiguation_TwoMeetings(t *testing.T) {
 	mock := executor.NewMockCommander()
 	mock.DefaultResult = &executor.CommandResult{
-		Stdout: "vid_b|Board of County Commissioners Regular Meeting - February 24, 2025\nvid_a|Board of County Commissioners Work Session - February 24, 2025\n",
+		Stdout: playlist("vid_b|Board of County Commissioners Regular Meeting - February 24, 2025\nvid_a|Board of County Commissioners Work Session - February 24, 2025\n"),
 	}
 
 	cfg := boccConfig(t)
 	ytdlp := executor.NewYtDlpExecutor(mock, "yt-dlp")
-	discovery := service.NewDiscoveryService(ytdlp, cfg)
+	discovery := service.NewDiscoveryService(youtubeSources(ytdlp), cfg)
 
 	body, _ := cfg.GetBody("bocc")
-	meetings, err := discovery.DiscoverNewMeetings(context.Background(), body)
+	meetings, err := discovery.DiscoverNewMeetings(context.Background(), body, domain.DateRange{})
 	require.NoError(t, err)
 
 	require.Len(t, meetings, 2)
@@ -222,15 +460,15 @@ func TestDiscoveryService_SameDateDisambiguation_TwoMeetings(t *testing.T) {
 func TestDiscoveryService_SameDateDisambiguation_ThreeMeetings(t *testing.T) {
 	mock := executor.NewMockCommander()
 	mock.DefaultResult = &executor.CommandResult{
-		Stdout: "vid_c|BOCC Meeting - February 24, 2025\nvid_a|BOCC Session - February 24, 2025\nvid_b|BOCC Hearing - February 24, 2025\n",
+		Stdout: playlist("vid_c|BOCC Meeting - February 24, 2025\nvid_a|BOCC Session - February 24, 2025\nvid_b|BOCC Hearing - February 24, 2025\n"),
 	}
 
 	cfg := boccConfig(t)
 	ytdlp := executor.NewYtDlpExecutor(mock, "yt-dlp")
-	discovery := service.NewDiscoveryService(ytdlp, cfg)
+	discovery := service.NewDiscoveryService(youtubeSources(ytdlp), cfg)
 
 	body, _ := cfg.GetBody("bocc")
-	meetings, err := discovery.DiscoverNewMeetings(context.Background(), body)
+	meetings, err := discovery.DiscoverNewMeetings(context.Background(), body, domain.DateRange{})
 	require.NoError(t, err)
 
 	require.Len(t, meetings, 3)
@@ -248,15 +486,15 @@ func TestDiscoveryService_SameDateDisambiguation_ThreeMeetings(t *testing.T) {
 func TestDiscoveryService_SameDateDisambiguation_SoloMeeting(t *testing.T) {
 	mock := executor.NewMockCommander()
 	mock.DefaultResult = &executor.CommandResult{
-		Stdout: "vid1|Board of County Commissioners Regular Meeting - January 7, 2025\nvid_a|BOCC Session - February 24, 2025\nvid_b|BOCC Hearing - February 24, 2025\n",
+		Stdout: playlist("vid1|Board of County Commissioners Regular Meeting - January 7, 2025\nvid_a|BOCC Session - February 24, 2025\nvid_b|BOCC Hearing - February 24, 2025\n"),
 	}
 
 	cfg := boccConfig(t)
 	ytdlp := executor.NewYtDlpExecutor(mock, "yt-dlp")
-	discovery := service.NewDiscoveryService(ytdlp, cfg)
+	discovery := service.NewDiscoveryService(youtubeSources(ytdlp), cfg)
 
 	body, _ := cfg.GetBody("bocc")
-	meetings, err := discovery.DiscoverNewMeetings(context.Background(), body)
+	meetings, err := discovery.DiscoverNewMeetings(context.Background(), body, domain.DateRange{})
 	require.NoError(t, err)
 
 	require.Len(t, meetings, 3)
@@ -276,7 +514,7 @@ func TestDiscoveryService_SameDateDisambiguation_SoloMeeting(t *testing.T) {
 func TestDiscoveryService_SameDateDisambiguation_OneProcessed(t *testing.T) {
 	mock := executor.NewMockCommander()
 	mock.DefaultResult = &executor.CommandResult{
-		Stdout: "vid_a|BOCC Session - February 24, 2025\nvid_b|BOCC Hearing - February 24, 2025\n",
+		Stdout: playlist("vid_a|BOCC Session - February 24, 2025\nvid_b|BOCC Hearing - February 24, 2025\n"),
 	}
 
 	cfg := boccConfig(t)
@@ -291,8 +529,8 @@ func TestDiscoveryService_SameDateDisambiguation_OneProcessed(t *testing.T) {
 	))
 
 	ytdlp := executor.NewYtDlpExecutor(mock, "yt-dlp")
-	discovery := service.NewDiscoveryService(ytdlp, cfg)
-	meetings, err := discovery.DiscoverNewMeetings(context.Background(), body)
+	discovery := service.NewDiscoveryService(youtubeSources(ytdlp), cfg)
+	meetings, err := discovery.DiscoverNewMeetings(context.Background(), body, domain.DateRange{})
 	require.NoError(t, err)
 
 	// Only vid_b (sequence 2) should remain.
@@ -301,30 +539,270 @@ func TestDiscoveryService_SameDateDisambiguation_OneProcessed(t *testing.T) {
 	assert.Equal(t, 2, meetings[0].Sequence)
 }
 
+func TestDiscoveryService_LaterUploadKeepsProcessedSequence(t *testing.T) {
+	mock := executor.NewMockCommander()
+	mock.DefaultResult = &executor.CommandResult{
+		Stdout: playlist("vid_m|BOCC Session - February 24, 2025\n"),
+	}
+
+	cfg := boccConfig(t)
+	body, _ := cfg.GetBody("bocc")
+	discovery := service.NewDiscoveryService(youtubeSources(executor.NewYtDlpExecutor(mock, "yt-dlp")), cfg)
+
+	meetings, err := discovery.PrepareNewMeetings(context.Background(), body, domain.DateRange{}, allDue)
+	require.NoError(t, err)
+	require.Len(t, meetings, 1)
+	assert.Equal(t, 0, meetings[0].Sequence)
+	soloPath := discovery.SummaryPath(meetings[0], body)
+	require.NoError(t, os.MkdirAll(filepath.Dir(soloPath), 0o755))
+	require.NoError(t, os.WriteFile(soloPath, []byte("# Solo"), 0o644))
+	require.NoError(t, discovery.MarkProcessed(meetings[0], body, soloPath))
+
+	// A second video for the date appears with a lexically smaller ID.
+	mock.DefaultResult = &executor.CommandResult{
+		Stdout: playlist("vid_a|BOCC Hearing - February 24, 2025\nvid_m|BOCC Session - February 24, 2025\n"),
+	}
+	meetings, err = discovery.PrepareNewMeetings(context.Background(), body, domain.DateRange{}, allDue)
+	require.NoError(t, err)
+
+	require.Len(t, meetings, 1, "the processed meeting is not run again")
+	assert.Equal(t, "vid_a", meetings[0].VideoID)
+	assert.Equal(t, 2, meetings[0].Sequence)
+
+	renamed := filepath.Join(filepath.Dir(soloPath), "BOCC-2025-02-24-Citizen-Summary-1.md")
+	assert.NoFileExists(t, soloPath)
+	assert.FileExists(t, renamed, "the solo summary gains its -1 suffix")
+	assert.Equal(t, 1, discovery.CurrentSequence(domain.Meeting{VideoID: "vid_m", MeetingDate: meetings[0].MeetingDate}, body))
+}
+
+func TestDiscoveryService_DiscoverChangesNothing(t *testing.T) {
+	mock := executor.NewMockCommander()
+	mock.DefaultResult = &executor.CommandResult{
+		Stdout: playlist("vid_m|BOCC Session - February 24, 2025\nvid_x|BOCC Session - February 25, 2025\n"),
+	}
+
+	cfg := boccConfig(t)
+	body, _ := cfg.GetBody("bocc")
+	discovery := service.NewDiscoveryService(youtubeSources(executor.NewYtDlpExecutor(mock, "yt-dlp")), cfg)
+
+	// vid_x was summarized before the ledger existed.
+	archived := filepath.Join(cfg.FinalizedDir(body), "20250225", "BOCC-2025-02-25-Citizen-Summary.md")
+	require.NoError(t, os.MkdirAll(filepath.Dir(archived), 0o755))
+	require.NoError(t, os.WriteFile(archived, []byte("# Archived"), 0o644))
+
+	meetings, err := discovery.DiscoverNewMeetings(context.Background(), body, domain.DateRange{})
+	require.NoError(t, err)
+	require.Len(t, meetings, 1, "a summary not yet imported still counts as processed")
+	assert.Equal(t, "vid_m", meetings[0].VideoID)
+	_, ok, err := service.NewLedgerService(cfg).Get(body, "vid_x")
+	require.NoError(t, err)
+	assert.False(t, ok, "the ledger is not imported")
+	assert.NoFileExists(t, cfg.SequencesPath(body))
+
+	meetings, err = discovery.PrepareNewMeetings(context.Background(), body, domain.DateRange{}, allDue)
+	require.NoError(t, err)
+	require.Len(t, meetings, 1)
+	soloPath := discovery.SummaryPath(meetings[0], body)
+	require.NoError(t, os.MkdirAll(filepath.Dir(soloPath), 0o755))
+	require.NoError(t, os.WriteFile(soloPath, []byte("# Solo"), 0o644))
+	require.NoError(t, discovery.MarkProcessed(meetings[0], body, soloPath))
+
+	// A second video for vid_m's date is listed but not renumbered into place.
+	mock.DefaultResult = &executor.CommandResult{
+		Stdout: playlist("vid_a|BOCC Hearing - February 24, 2025\nvid_m|BOCC Session - February 24, 2025\n"),
+	}
+	meetings, err = discovery.DiscoverNewMeetings(context.Background(), body, domain.DateRange{})
+	require.NoError(t, err)
+	require.Len(t, meetings, 1)
+	assert.Equal(t, "vid_a", meetings[0].VideoID)
+	assert.Equal(t, 2, meetings[0].Sequence, "the sequence it would be given")
+	assert.FileExists(t, soloPath, "the processed summary is not renamed")
+	assert.Equal(t, 0, discovery.CurrentSequence(domain.Meeting{VideoID: "vid_m", MeetingDate: meetings[0].MeetingDate}, body))
+}
+
 func TestDiscoveryService_BOCC_MeetingTypeVariants(t *testing.T) {
 	mock := executor.NewMockCommander()
 	mock.DefaultResult = &executor.CommandResult{
 		// Test that "Commissioner" (singular) and "Commissioners" (plural) both parse.
-		Stdout: "vid1|Board of County Commissioners Regular Meeting - February 4, 2025\nvid2|Board of County Commissioner Work Session - January 21, 2025\nvid3|BOCC Public Hearing - January 14, 2025\n",
+		Stdout: playlist("vid1|Board of County Commissioners Regular Meeting - February 4, 2025\nvid2|Board of County Commissioner Work Session - January 21, 2025\nvid3|BOCC Public Hearing - January 14, 2025\n"),
 	}
 
 	cfg := boccConfig(t)
 	ytdlp := executor.NewYtDlpExecutor(mock, "yt-dlp")
-	discovery := service.NewDiscoveryService(ytdlp, cfg)
+	discovery := service.NewDiscoveryService(youtubeSources(ytdlp), cfg)
 
 	body, _ := cfg.GetBody("bocc")
-	meetings, err := discovery.DiscoverNewMeetings(context.Background(), body)
+	meetings, err := discovery.DiscoverNewMeetings(context.Background(), body, domain.DateRange{})
 	require.NoError(t, err)
 
 	require.Len(t, meetings, 3)
 
-	// All should parse dates correctly.
-	assert.Equal(t, "2025-02-04", meetings[0].ISODate())
+	// All should parse dates correctly, returned oldest-first.
+	assert.Equal(t, "2025-01-14", meetings[0].ISODate())
 	assert.Equal(t, "2025-01-21", meetings[1].ISODate())
-	assert.Equal(t, "2025-01-14", meetings[2].ISODate())
+	assert.Equal(t, "2025-02-04", meetings[2].ISODate())
 
 	// Meeting type detection.
-	assert.Equal(t, "Regular Meeting", meetings[0].MeetingType)
+	assert.Equal(t, "Public Hearing", meetings[0].MeetingType)
 	assert.Equal(t, "Work Session", meetings[1].MeetingType)
-	assert.Equal(t, "Public Hearing", meetings[2].MeetingType)
+	assert.Equal(t, "Regular Meeting", meetings[2].MeetingType)
+}
+
+func TestDiscoveryService_FiltersByWindow(t *testing.T) {
+	mock := executor.NewMockCommander()
+	mock.DefaultResult = &executor.CommandResult{
+		Stdout: playlist("new1|February 04, 2025 | Regular Session\n" +
+			"old1|December 17, 2024 | Regular Session\n" +
+			"old2|March 05, 2024 | Regular Session\n" +
+			"older|June 13, 2023 | Regular Session\n"),
+	}
+
+	cfg := testConfig(t)
+	discovery := service.NewDiscoveryService(youtubeSources(executor.NewYtDlpExecutor(mock, "yt-dlp")), cfg)
+	body, _ := cfg.GetBody("hagerstown")
+
+	meetings, err := discovery.DiscoverNewMeetings(context.Background(), body, domain.YearRange(2024))
+	require.NoError(t, err)
+
+	require.Len(t, meetings, 2)
+	assert.Equal(t, "old2", meetings[0].VideoID)
+	assert.Equal(t, "old1", meetings[1].VideoID)
+}
+
+func TestDiscoveryService_WindowDoesNotChangeSequences(t *testing.T) {
+	mock := executor.NewMockCommander()
+	mock.DefaultResult = &executor.CommandResult{
+		Stdout: playlist("vid_b|February 04, 2025 | Regular Session\n" +
+			"vid_a|February 04, 2025 | Work Session\n" +
+			"vid_c|January 21, 2025 | Regular Session\n"),
+	}
+
+	cfg := testConfig(t)
+	discovery := service.NewDiscoveryService(youtubeSources(executor.NewYtDlpExecutor(mock, "yt-dlp")), cfg)
+	body, _ := cfg.GetBody("hagerstown")
+
+	since, _ := time.Parse("2006-01-02", "2025-02-01")
+	meetings, err := discovery.DiscoverNewMeetings(context.Background(), body, domain.DateRange{Since: since})
+	require.NoError(t, err)
+
+	require.Len(t, meetings, 2)
+	assert.Equal(t, "vid_a", meetings[0].VideoID)
+	assert.Equal(t, 1, meetings[0].Sequence)
+	assert.Equal(t, "vid_b", meetings[1].VideoID)
+	assert.Equal(t, 2, meetings[1].Sequence)
+}
+
+func TestDiscoveryService_FetchesDetailsOfNewMeetings(t *testing.T) {
+	mock := executor.NewMockCommander()
+	mock.OnCommand("yt-dlp --flat-playlist --dump-json --extractor-args youtubetab:approximate_date https://www.youtube.com/playlist?list=PLJXxCe9GA2fEf4TIVzTH2O-kFJlS8VVgQ",
+		&executor.CommandResult{Stdout: `{"id": "new1", "title": "February 04, 2025 | Regular Session", "duration": 5400}
+{"id": "old1", "title": "December 17, 2024 | Regular Session"}
+`}, nil)
+	mock.OnCommand("yt-dlp --skip-download --dump-json https://www.youtube.com/watch?v=new1",
+		&executor.CommandResult{Stdout: `{
+			"id": "new1",
+			"description": "Agenda: https://example.gov/agenda.pdf",
+			"chapters": [{"title": "Call to Order", "start_time": 0, "end_time": 95}],
+			"automatic_captions": {"en": []}
+		}`}, nil)
+
+	cfg := testConfig(t)
+	discovery := service.NewDiscoveryService(youtubeSources(executor.NewYtDlpExecutor(mock, "yt-dlp")), cfg)
+	body, _ := cfg.GetBody("hagerstown")
+
+	meetings, err := discovery.PrepareNewMeetings(context.Background(), body, domain.YearRange(2025), allDue)
+	require.NoError(t, err)
+
+	require.Len(t, meetings, 1)
+	details := meetings[0].Details
+	assert.Equal(t, 90*time.Minute, details.Duration, "listing details are kept")
+	assert.Equal(t, "Agenda: https://example.gov/agenda.pdf", details.Description)
+	require.Len(t, details.Chapters, 1)
+	assert.Equal(t, "Call to Order", details.Chapters[0].Title)
+	assert.True(t, details.HasCaptions())
+	for _, call := range mock.Calls {
+		assert.NotContains(t, call, "watch?v=old1", "meetings outside the window are not looked up")
+	}
+}
+
+func TestDiscoveryService_SkipsDetailsOfMeetingsNotDue(t *testing.T) {
+	mock := executor.NewMockCommander()
+	mock.OnCommand("yt-dlp --flat-playlist --dump-json --extractor-args youtubetab:approximate_date https://www.youtube.com/playlist?list=PLJXxCe9GA2fEf4TIVzTH2O-kFJlS8VVgQ",
+		&executor.CommandResult{Stdout: `{"id": "new1", "title": "February 04, 2025 | Regular Session"}
+{"id": "wait1", "title": "February 11, 2025 | Regular Session"}
+`}, nil)
+	mock.OnCommand("yt-dlp --skip-download --dump-json https://www.youtube.com/watch?v=new1",
+		&executor.CommandResult{Stdout: `{"id": "new1", "duration": 5400}`}, nil)
+
+	cfg := testConfig(t)
+	discovery := service.NewDiscoveryService(youtubeSources(executor.NewYtDlpExecutor(mock, "yt-dlp")), cfg)
+	body, _ := cfg.GetBody("hagerstown")
+
+	meetings, err := discovery.PrepareNewMeetings(context.Background(), body, domain.YearRange(2025),
+		func(m domain.Meeting) bool { return m.VideoID != "wait1" })
+	require.NoError(t, err)
+
+	require.Len(t, meetings, 2, "a meeting not due is still listed")
+	for _, call := range mock.Calls {
+		assert.NotContains(t, call, "watch?v=wait1", "a meeting not due is not looked up")
+	}
+}
+
+func TestDiscoveryService_SkipsByFetchedDetails(t *testing.T) {
+	mock := executor.NewMockCommander()
+	mock.OnCommand("yt-dlp --flat-playlist --dump-json --extractor-args youtubetab:approximate_date https://www.youtube.com/playlist?list=PLJXxCe9GA2fEf4TIVzTH2O-kFJlS8VVgQ",
+		&executor.CommandResult{Stdout: `{"id": "live1", "title": "February 04, 2025 | Regular Session"}
+{"id": "clip1", "title": "February 04, 2025 | Proclamation"}
+{"id": "full1", "title": "February 04, 2025 | Work Session"}
+`}, nil)
+	mock.OnCommand("yt-dlp --skip-download --dump-json https://www.youtube.com/watch?v=live1",
+		&executor.CommandResult{Stdout: `{"id": "live1", "live_status": "is_live"}`}, nil)
+	mock.OnCommand("yt-dlp --skip-download --dump-json https://www.youtube.com/watch?v=clip1",
+		&executor.CommandResult{Stdout: `{"id": "clip1", "live_status": "not_live", "duration": 240}`}, nil)
+	mock.OnCommand("yt-dlp --skip-download --dump-json https://www.youtube.com/watch?v=full1",
+		&executor.CommandResult{Stdout: `{"id": "full1", "live_status": "not_live", "duration": 5400}`}, nil)
+
+	cfg := testConfig(t)
+	body := cfg.Bodies["hagerstown"]
+	body.MinDurationMinutes = 10
+	cfg.Bodies["hagerstown"] = body
+	discovery := service.NewDiscoveryService(youtubeSources(executor.NewYtDlpExecutor(mock, "yt-dlp")), cfg)
+
+	meetings, err := discovery.PrepareNewMeetings(context.Background(), body, domain.YearRange(2025), allDue)
+	require.NoError(t, err)
+
+	require.Len(t, meetings, 1, "a stream the listing did not mark live, and a short clip, are skipped")
+	assert.Equal(t, "full1", meetings[0].VideoID)
+	assert.Equal(t, 0, meetings[0].Sequence, "skipped videos do not take a sequence number")
+}
+
+func TestDiscoveryService_FeedSource(t *testing.T) {
+	rss := `<rss version="2.0"><channel>
+<item><title>City Council - March 4, 2025</title><guid>cc-0304</guid>
+<enclosure url="https://media.example.gov/cc-0304.mp4" type="video/mp4"/></item>
+<item><title>Budget Hearing</title><guid>cc-budget</guid><pubDate>Tue, 11 Mar 2025 23:30:00 +0000</pubDate>
+<enclosure url="https://media.example.gov/cc-budget.mp4" type="video/mp4"/></item>
+<item><title>Untitled recording</title><guid>cc-unknown</guid>
+<enclosure url="https://media.example.gov/cc-unknown.mp4" type="video/mp4"/></item>
+</channel></rss>`
+	feedPath := filepath.Join(t.TempDir(), "feed.xml")
+	require.NoError(t, os.WriteFile(feedPath, []byte(rss), 0o644))
+
+	cfg := testConfig(t)
+	body, _ := cfg.GetBody("hagerstown")
+	body.Source = domain.SourceConfig{Type: domain.SourceFeed, URL: feedPath}
+	body.TitleDateRegex = domain.TitlePatterns{{Regex: `([A-Z][a-z]+ \d{1,2}, \d{4})`}}
+	sources := func(body domain.Body) (source.VideoSource, error) {
+		return source.New(body, nil, http.DefaultClient)
+	}
+	discovery := service.NewDiscoveryService(sources, cfg)
+
+	meetings, err := discovery.DiscoverNewMeetings(context.Background(), body, domain.YearRange(2025))
+	require.NoError(t, err)
+
+	require.Len(t, meetings, 2, "items with no date in the title or feed are skipped")
+	assert.Equal(t, "cc-0304", meetings[0].VideoID)
+	assert.Equal(t, "https://media.example.gov/cc-0304.mp4", meetings[0].VideoURL)
+	assert.Equal(t, "cc-budget", meetings[1].VideoID)
+	assert.Equal(t, "2025-03-11", meetings[1].ISODate(), "the publish date is used when the title has none")
 }
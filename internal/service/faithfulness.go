@@ -0,0 +1,508 @@
+package service
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/AvogadroSG1/civic-summary/internal/domain"
+	"github.com/AvogadroSG1/civic-summary/internal/markdown"
+)
+
+const (
+	// faithfulnessSlack widens the transcript window around a cited time:
+	// summary timestamps mark where an item starts, not each sentence in it.
+	faithfulnessSlack = 2 * time.Minute
+	// singleTimestampSpan is how far past a single cited timestamp, rather than
+	// a range, a claim is looked for.
+	singleTimestampSpan = 10 * time.Minute
+)
+
+var (
+	// numberTokenPattern splits text into the tokens number phrases are built
+	// from: "$", digit groups such as 2,300,000 or 2.3, and words.
+	numberTokenPattern = regexp.MustCompile(`\$|\d+(?:,\d{3})*(?:\.\d+)?|[A-Za-z]+`)
+
+	tallyNumber    = `[1-9]\d|\d|zero|none|nothing|one|two|three|four|five|six|seven|eight|nine|ten|eleven|twelve|thirteen|fourteen|fifteen`
+	tallyPattern   = regexp.MustCompile(`(?i)\b(` + tallyNumber + `)\s*(?:-|–|—|to)\s*(` + tallyNumber + `)\b`)
+	votePattern    = regexp.MustCompile(`(?i)\bvot|\b(?:passed|approved|carried|failed|defeated|adopted|denied)\b`)
+	unanimityWords = regexp.MustCompile(`(?i)\bunanimous|\ball (?:those )?in favou?r\b|\ball ayes\b`)
+	ayePattern     = regexp.MustCompile(`(?i)\bayes?\b`)
+	nayPattern     = regexp.MustCompile(`(?i)\bnays?\b`)
+	// documentNumber precedes numbers such as "Ordinance 25-01" that look
+	// like tallies but are not.
+	documentNumber = regexp.MustCompile(`(?i)(?:ordinance|resolution|bill|no\.?|number|#)\s*$`)
+
+	capitalizedWord = `(?:[A-Z]['’])?[A-Z][a-z]+(?:[A-Z][a-z]+)?`
+	nameRunPattern  = regexp.MustCompile(capitalizedWord + `(?:\s+` + capitalizedWord + `)+`)
+	honorificName   = regexp.MustCompile(`\b(?:Mr|Mrs|Ms|Dr)\.\s+(` + capitalizedWord + `)`)
+
+	// claimNoisePattern matches text that looks like a claim but is not one:
+	// link targets, URLs, and clock times.
+	claimNoisePattern = regexp.MustCompile(`\]\([^)]*\)|https?://\S+|\d{1,2}:\d{2}(?::\d{2})?`)
+)
+
+// leadingNameWords are titles and sentence openers dropped from the front of a
+// run of capitalized words, so "City Manager Robert Wilson" is checked as
+// "Robert Wilson".
+var leadingNameWords = map[string]bool{
+	"The": true, "A": true, "An": true, "This": true, "That": true, "These": true, "Both": true,
+	"Mayor": true, "Vice": true, "President": true, "Chair": true, "Chairman": true, "Chairwoman": true,
+	"Council": true, "Councilmember": true, "Councilman": true, "Councilwoman": true,
+	"Commissioner": true, "Commissioners": true, "Member": true, "Members": true, "Board": true,
+	"City": true, "County": true, "Town": true, "Manager": true, "Director": true, "Engineer": true,
+	"Clerk": true, "Attorney": true, "Deputy": true, "Assistant": true, "Chief": true, "Staff": true,
+	"Finance": true, "Planning": true, "Public": true, "Works": true, "Police": true, "Fire": true,
+}
+
+var (
+	unitWords = map[string]float64{
+		"zero": 0, "one": 1, "two": 2, "three": 3, "four": 4,
+		"five": 5, "six": 6, "seven": 7, "eight": 8, "nine": 9,
+	}
+	teenWords = map[string]float64{
+		"ten": 10, "eleven": 11, "twelve": 12, "thirteen": 13, "fourteen": 14,
+		"fifteen": 15, "sixteen": 16, "seventeen": 17, "eighteen": 18, "nineteen": 19,
+	}
+	tensWords = map[string]float64{
+		"twenty": 20, "thirty": 30, "forty": 40, "fifty": 50,
+		"sixty": 60, "seventy": 70, "eighty": 80, "ninety": 90,
+	}
+	scaleWords = map[string]float64{
+		"thousand": 1e3, "million": 1e6, "billion": 1e9,
+	}
+)
+
+// ValidateFaithfulness checks the facts a model can get wrong without it being
+// obvious: currency amounts, other numbers, vote tallies, and proper names.
+// Each is looked for in the transcript near the timestamp its section cites,
+// or anywhere in the transcript when the section cites none, and every one not
+// found is reported at the given severity. The checks are heuristics, so a
+// reported claim is one to verify, not necessarily a wrong one.
+func (s *ValidationService) ValidateFaithfulness(content string, transcript domain.Transcript, severity domain.RuleSeverity, result *domain.ValidationResult) {
+	if severity == domain.RuleOff || transcript.IsEmpty() {
+		return
+	}
+
+	cues := transcript.MergedCues()
+	windows := make(map[citation]*transcriptWindow)
+	for _, c := range extractClaims(content) {
+		window, ok := windows[c.cited]
+		if !ok {
+			window = newTranscriptWindow(cues, c.cited)
+			windows[c.cited] = window
+		}
+		if !window.supports(c) {
+			result.AddCheck(domain.CheckFaithfulness, severity, "%s %q not found in the transcript%s", c.kind, c.text, c.cited)
+		}
+	}
+}
+
+// citation is the part of the transcript a summary line cites. The zero value
+// cites the whole transcript.
+type citation struct {
+	start, end time.Duration
+	timed      bool
+}
+
+func citationFor(ref markdown.TimestampRef) citation {
+	end := ref.End
+	if end <= ref.Start {
+		end = ref.Start + singleTimestampSpan
+	}
+	return citation{start: ref.Start, end: end, timed: true}
+}
+
+// String renders the citation for messages, e.g. " near [00:35:00]".
+func (c citation) String() string {
+	if !c.timed {
+		return ""
+	}
+	return fmt.Sprintf(" near [%s]", domain.FormatTimestamp(c.start))
+}
+
+type claimKind string
+
+const (
+	claimAmount claimKind = "amount"
+	claimNumber claimKind = "number"
+	claimTally  claimKind = "vote tally"
+	claimName   claimKind = "name"
+)
+
+// claim is one checkable fact from a summary.
+type claim struct {
+	kind  claimKind
+	text  string
+	cited citation
+
+	value   float64 // amount and number
+	yes, no int     // vote tally
+	surname string  // name, lowercased
+}
+
+// extractClaims returns the checkable facts in a summary's sections. The
+// header block before the first "## " heading is skipped: it only restates
+// the meeting's metadata. A timestamp applies to the rest of its section.
+func extractClaims(content string) []claim {
+	if _, rest, err := markdown.ParseFrontmatter(content); err == nil {
+		content = rest
+	}
+
+	var claims []claim
+	var cited citation
+	inSections := false
+	for _, line := range strings.Split(content, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "#") {
+			cited = citation{}
+			if strings.HasPrefix(trimmed, "## ") {
+				inSections = true
+				continue
+			}
+		}
+		if !inSections {
+			continue
+		}
+		if refs := markdown.FindTimestamps(line); len(refs) > 0 {
+			cited = citationFor(refs[0])
+		}
+		claims = append(claims, lineClaims(line, cited, strings.HasPrefix(trimmed, "#"))...)
+	}
+	return claims
+}
+
+// lineClaims extracts the claims in one summary line. Names are not taken
+// from headings, whose title case makes every topic look like one.
+func lineClaims(line string, cited citation, heading bool) []claim {
+	text := claimNoisePattern.ReplaceAllStringFunc(line, blank)
+
+	var claims []claim
+	if votePattern.MatchString(text) {
+		for _, m := range tallyPattern.FindAllStringSubmatchIndex(text, -1) {
+			if documentNumber.MatchString(text[:m[0]]) {
+				continue
+			}
+			yes, _ := tallyValue(text[m[2]:m[3]])
+			no, _ := tallyValue(text[m[4]:m[5]])
+			claims = append(claims, claim{kind: claimTally, text: text[m[0]:m[1]], cited: cited, yes: yes, no: no})
+			text = text[:m[0]] + blank(text[m[0]:m[1]]) + text[m[1]:]
+		}
+	}
+
+	for _, n := range parseNumbers(text) {
+		switch {
+		case n.currency:
+			claims = append(claims, claim{kind: claimAmount, text: n.text, cited: cited, value: n.value})
+		case n.value >= 10 && !n.year:
+			claims = append(claims, claim{kind: claimNumber, text: n.text, cited: cited, value: n.value})
+		}
+	}
+
+	if heading {
+		return claims
+	}
+	for _, name := range names(text) {
+		words := strings.Fields(name)
+		surname := strings.ToLower(words[len(words)-1])
+		claims = append(claims, claim{kind: claimName, text: name, cited: cited, surname: surname})
+	}
+	return claims
+}
+
+// blank replaces s with spaces, keeping the offsets of the rest of the line.
+func blank(s string) string {
+	return strings.Repeat(" ", len(s))
+}
+
+// names returns the proper names in text: an honorific followed by a name, or
+// a run of two or more capitalized words once leading titles are dropped.
+func names(text string) []string {
+	var out []string
+	for _, m := range honorificName.FindAllStringSubmatch(text, -1) {
+		out = append(out, m[1])
+	}
+	for _, run := range nameRunPattern.FindAllString(text, -1) {
+		words := strings.Fields(run)
+		for len(words) > 0 && leadingNameWords[words[0]] {
+			words = words[1:]
+		}
+		if len(words) >= 2 {
+			out = append(out, strings.Join(words, " "))
+		}
+	}
+	return out
+}
+
+// tallyValue parses one side of a vote tally.
+func tallyValue(s string) (int, bool) {
+	s = strings.ToLower(s)
+	if n, err := strconv.Atoi(s); err == nil {
+		return n, true
+	}
+	if s == "none" || s == "nothing" {
+		return 0, true
+	}
+	if v, ok := unitWords[s]; ok {
+		return int(v), true
+	}
+	if v, ok := teenWords[s]; ok {
+		return int(v), true
+	}
+	return 0, false
+}
+
+// transcriptWindow is the transcript text a citation covers, prepared for
+// claim lookups.
+type transcriptWindow struct {
+	text    string
+	words   map[string]bool
+	numbers []float64
+	tallies [][2]int
+}
+
+func newTranscriptWindow(cues []domain.Cue, cited citation) *transcriptWindow {
+	var lines []string
+	for _, c := range cues {
+		if cited.timed && c.HasTiming() &&
+			(c.End < cited.start-faithfulnessSlack || c.Start > cited.end+faithfulnessSlack) {
+			continue
+		}
+		lines = append(lines, c.Text)
+	}
+	text := strings.Join(lines, "\n")
+
+	w := &transcriptWindow{text: text, words: make(map[string]bool)}
+	for _, word := range strings.FieldsFunc(strings.ToLower(text), notWordRune) {
+		word = strings.ReplaceAll(word, "’", "'")
+		w.words[word] = true
+		w.words[strings.TrimSuffix(word, "'s")] = true
+	}
+	for _, n := range parseNumbers(text) {
+		w.numbers = append(w.numbers, n.value)
+	}
+	for _, m := range tallyPattern.FindAllStringSubmatch(text, -1) {
+		yes, ok1 := tallyValue(m[1])
+		no, ok2 := tallyValue(m[2])
+		if ok1 && ok2 {
+			w.tallies = append(w.tallies, [2]int{yes, no})
+		}
+	}
+	return w
+}
+
+func notWordRune(r rune) bool {
+	return !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r == '\'' || r == '’')
+}
+
+// supports reports whether the window backs up c.
+func (w *transcriptWindow) supports(c claim) bool {
+	switch c.kind {
+	case claimAmount, claimNumber:
+		for _, n := range w.numbers {
+			if math.Abs(n-c.value) <= 1e-9*math.Max(1, math.Abs(c.value)) {
+				return true
+			}
+		}
+		return false
+	case claimTally:
+		for _, t := range w.tallies {
+			if t == [2]int{c.yes, c.no} {
+				return true
+			}
+		}
+		if c.no == 0 && unanimityWords.MatchString(w.text) {
+			return true
+		}
+		// A roll call: enough ayes, and enough nays if any are claimed.
+		ayes := len(ayePattern.FindAllString(w.text, -1))
+		nays := len(nayPattern.FindAllString(w.text, -1))
+		return c.yes > 0 && ayes >= c.yes && nays >= c.no
+	case claimName:
+		return w.words[strings.ReplaceAll(c.surname, "’", "'")]
+	}
+	return true
+}
+
+// numberMention is a number found in text, written in digits, words, or both:
+// "$2.3 million", "2,300,000", and "two point three million dollars" all have
+// the value 2300000.
+type numberMention struct {
+	value    float64
+	text     string
+	currency bool
+	// year is set for a bare four-digit number that reads as a year, which
+	// captions rarely render the same way and so is not checked.
+	year bool
+}
+
+// numberWordKind is the role a token plays within a number phrase.
+type numberWordKind int
+
+const (
+	kindNone numberWordKind = iota
+	kindDigits
+	kindUnit
+	kindTeen
+	kindTens
+	kindHundred
+	kindScale
+	kindDecimal
+)
+
+// numberPhrase accumulates the tokens of one number as parseNumbers reads
+// them.
+type numberPhrase struct {
+	active         bool
+	start, end     int
+	total, current float64
+	last           numberWordKind
+	currency       bool
+	digits         string
+}
+
+func (p *numberPhrase) begin(offset int) {
+	if !p.active {
+		p.active = true
+		if !p.currency {
+			p.start = offset
+		}
+	}
+}
+
+func (p *numberPhrase) mention(text string) numberMention {
+	m := numberMention{
+		value:    p.total + p.current,
+		text:     strings.TrimSpace(text[p.start:p.end]),
+		currency: p.currency,
+	}
+	if p.last == kindDigits && p.total == 0 && !p.currency && len(p.digits) == 4 {
+		m.year = m.value >= 1900 && m.value < 2100
+	}
+	return m
+}
+
+// parseNumbers returns every number mentioned in text.
+func parseNumbers(text string) []numberMention {
+	tokens := numberTokenPattern.FindAllStringIndex(text, -1)
+
+	var out []numberMention
+	var p numberPhrase
+	flush := func() {
+		if p.active {
+			out = append(out, p.mention(text))
+		}
+		p = numberPhrase{}
+	}
+	// extend adds the token at i to the current phrase.
+	extend := func(i int, kind numberWordKind) {
+		p.begin(tokens[i][0])
+		p.end = tokens[i][1]
+		p.last = kind
+	}
+
+	for i := 0; i < len(tokens); i++ {
+		tok := text[tokens[i][0]:tokens[i][1]]
+		word := strings.ToLower(tok)
+
+		switch {
+		case tok == "$":
+			flush()
+			p.currency = true
+			p.start = tokens[i][0]
+
+		case tok[0] >= '0' && tok[0] <= '9':
+			if p.active {
+				flush()
+			}
+			v, err := strconv.ParseFloat(strings.ReplaceAll(tok, ",", ""), 64)
+			if err != nil {
+				p = numberPhrase{}
+				continue
+			}
+			extend(i, kindDigits)
+			p.current = v
+			p.digits = tok
+
+		case isKey(unitWords, word):
+			if p.active && p.last != kindTens && p.last != kindHundred && p.last != kindScale {
+				flush()
+			}
+			extend(i, kindUnit)
+			p.current += unitWords[word]
+
+		case isKey(teenWords, word) || isKey(tensWords, word):
+			if p.active && p.last != kindHundred && p.last != kindScale {
+				flush()
+			}
+			kind := kindTeen
+			v, ok := teenWords[word]
+			if !ok {
+				kind, v = kindTens, tensWords[word]
+			}
+			extend(i, kind)
+			p.current += v
+
+		case word == "hundred":
+			switch {
+			case !p.active:
+				extend(i, kindHundred)
+				p.current = 100
+			case p.last == kindUnit || p.last == kindTeen || p.last == kindTens || p.last == kindDigits:
+				extend(i, kindHundred)
+				p.current *= 100
+			default:
+				flush()
+			}
+
+		case isKey(scaleWords, word):
+			if !p.active {
+				p = numberPhrase{}
+				continue
+			}
+			extend(i, kindScale)
+			p.total += p.current * scaleWords[word]
+			p.current = 0
+
+		case word == "point" && p.active && p.last != kindDecimal && p.last != kindScale:
+			var digits strings.Builder
+			j := i + 1
+			for ; j < len(tokens); j++ {
+				v, ok := unitWords[strings.ToLower(text[tokens[j][0]:tokens[j][1]])]
+				if !ok {
+					break
+				}
+				digits.WriteString(strconv.Itoa(int(v)))
+			}
+			if digits.Len() == 0 {
+				flush()
+				continue
+			}
+			frac, _ := strconv.ParseFloat("0."+digits.String(), 64)
+			p.current += frac
+			i = j - 1
+			extend(i, kindDecimal)
+
+		case word == "and" && p.active && (p.last == kindHundred || p.last == kindScale):
+			// "one hundred and five" continues the phrase.
+
+		case (word == "dollar" || word == "dollars") && p.active:
+			p.currency = true
+			p.end = tokens[i][1]
+			flush()
+
+		default:
+			flush()
+		}
+	}
+	flush()
+	return out
+}
+
+func isKey(m map[string]float64, key string) bool {
+	_, ok := m[key]
+	return ok
+}
@@ -0,0 +1,128 @@
+package service_test
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/AvogadroSG1/civic-summary/internal/domain"
+	"github.com/AvogadroSG1/civic-summary/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// timedTranscript builds a transcript from cues keyed by their start in
+// seconds; each lasts ten seconds.
+func timedTranscript(cues map[int]string) domain.Transcript {
+	var b strings.Builder
+	i := 0
+	for _, start := range slices.Sorted(maps.Keys(cues)) {
+		i++
+		fmt.Fprintf(&b, "%d\n%s --> %s\n%s\n\n", i, srtTime(start), srtTime(start+10), cues[start])
+	}
+	return domain.Transcript{Cues: domain.ParseSRT(b.String())}
+}
+
+func faithfulnessTranscript() domain.Transcript {
+	return timedTranscript(map[int]string{
+		0:    "Call to order. Roll call please.",
+		2100: "Councilmember Jones moved to approve the grant of fifty thousand dollars for the Jonathan Street corridor.",
+		2110: "All in favor? Aye. The motion carries.",
+		3300: "Finance director sarah chen said the capital gap is about $4 million.",
+	})
+}
+
+const faithfulnessSummary = `---
+date: 2025-02-05
+---
+# Council Summary
+**Budget:** $999 header amounts are not checked.
+
+## 3. Actions Taken
+
+### Grant Approval **[00:35:00-00:40:00]**
+Council approved a $50,000 grant for the Jonathan Street corridor. Vote count was 5-0.
+
+### Budget **[00:55:00]**
+Finance Director Sarah Chen described a four million dollar gap and a $7.5 million request from Robert Wilson.
+
+## Conclusion
+
+The fifty thousand dollar grant passed in 2025.
+`
+
+func TestValidationService_ValidateFaithfulness(t *testing.T) {
+	svc := service.NewValidationService()
+	result := &domain.ValidationResult{}
+
+	svc.ValidateFaithfulness(faithfulnessSummary, faithfulnessTranscript(), domain.RuleWarning, result)
+
+	assert.False(t, result.HasErrors())
+	assert.Equal(t, []string{
+		`amount "$7.5 million" not found in the transcript near [00:55:00]`,
+		`name "Robert Wilson" not found in the transcript near [00:55:00]`,
+	}, issueMessages(result.Warnings()))
+}
+
+func TestValidationService_ValidateFaithfulness_Severity(t *testing.T) {
+	svc := service.NewValidationService()
+
+	errs := &domain.ValidationResult{}
+	svc.ValidateFaithfulness(faithfulnessSummary, faithfulnessTranscript(), domain.RuleError, errs)
+	assert.Len(t, errs.Errors(), 2)
+
+	off := &domain.ValidationResult{}
+	svc.ValidateFaithfulness(faithfulnessSummary, faithfulnessTranscript(), domain.RuleOff, off)
+	assert.Empty(t, off.Issues)
+}
+
+func TestValidationService_ValidateFaithfulness_LooksNearCitedTime(t *testing.T) {
+	svc := service.NewValidationService()
+	result := &domain.ValidationResult{}
+
+	// The grant is discussed at 00:35:00, not 00:55:00.
+	summary := "## 1. Updates\n\n### Budget **[00:55:00]**\nA $50,000 grant.\n"
+	svc.ValidateFaithfulness(summary, faithfulnessTranscript(), domain.RuleWarning, result)
+
+	assert.Equal(t, []string{`amount "$50,000" not found in the transcript near [00:55:00]`}, issueMessages(result.Issues))
+}
+
+func TestValidationService_ValidateFaithfulness_Numbers(t *testing.T) {
+	tests := []struct {
+		name       string
+		summary    string
+		transcript string
+		supported  bool
+	}{
+		{"digits match words", "a $2.3 million contract", "two point three million dollars for the contract", true},
+		{"words match digits", "three hundred eighty-four thousand dollars a year", "it is $384,000 annually", true},
+		{"hyphenated count", "twenty-three miles of road", "we have 23 miles rated poor", true},
+		{"hundred and", "one hundred and five homes", "105 homes", true},
+		{"wrong amount", "a $2.5 million contract", "two point three million dollars", false},
+		{"small numbers ignored", "3 items", "nothing relevant", true},
+		{"years ignored", "since 2019", "nothing relevant", true},
+		{"tally by roll call", "the motion passed 3-1", "aye aye aye nay", true},
+		{"tally not supported", "the motion passed 4-1", "aye aye nay", false},
+		{"ordinance number is not a tally", "Ordinance 25-01 was approved", "ordinance 25-01", true},
+		{"tally in words", "approved five to two", "the vote is five to two", true},
+		{"honorific name", "Mr. Smith spoke", "mister smith's comments", true},
+		{"unknown name", "Janet Doe spoke", "a resident spoke", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := &domain.ValidationResult{}
+			transcript := domain.Transcript{Cues: domain.ParsePlainText(tt.transcript)}
+
+			service.NewValidationService().ValidateFaithfulness("## 1. Items\n"+tt.summary+"\n", transcript, domain.RuleWarning, result)
+
+			if tt.supported {
+				assert.Empty(t, result.Issues)
+			} else {
+				require.Len(t, result.Issues, 1)
+				assert.Contains(t, result.Issues[0].Message, "not found in the transcript")
+			}
+		})
+	}
+}
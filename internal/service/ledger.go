@@ -0,0 +1,352 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/AvogadroSG1/civic-summary/internal/config"
+	"github.com/AvogadroSG1/civic-summary/internal/domain"
+	"github.com/AvogadroSG1/civic-summary/internal/markdown"
+)
+
+// LedgerService maintains each body's processed-meetings ledger, the record
+// discovery consults to decide whether a meeting is new. It is safe for
+// concurrent use: meetings processed in parallel share one ledger per body.
+type LedgerService struct {
+	cfg *config.Config
+	mu  sync.Mutex
+}
+
+// NewLedgerService creates a new LedgerService.
+func NewLedgerService(cfg *config.Config) *LedgerService {
+	return &LedgerService{cfg: cfg}
+}
+
+// LedgerImport reports the outcome of building ledger entries from existing
+// summaries.
+type LedgerImport struct {
+	// Added is the number of entries written.
+	Added int
+	// Unmatched lists the summaries that could not be tied to a video.
+	Unmatched []string
+}
+
+// Imported reports whether a body's existing summaries have been imported.
+// An unreadable ledger counts as imported, so that it is not overwritten.
+func (s *LedgerService) Imported(body domain.Body) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ledger, err := s.load(body)
+	return err != nil || ledger.ImportedAt != nil
+}
+
+// List returns every ledger entry for a body, ordered by meeting date and
+// sequence.
+func (s *LedgerService) List(body domain.Body) ([]domain.LedgerEntry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ledger, err := s.load(body)
+	if err != nil {
+		return nil, err
+	}
+	entries := ledger.Entries
+	sort.SliceStable(entries, func(i, j int) bool {
+		if entries[i].MeetingDate != entries[j].MeetingDate {
+			return entries[i].MeetingDate < entries[j].MeetingDate
+		}
+		if entries[i].Sequence != entries[j].Sequence {
+			return entries[i].Sequence < entries[j].Sequence
+		}
+		return entries[i].VideoID < entries[j].VideoID
+	})
+	return entries, nil
+}
+
+// Get returns the ledger entry for a video, if there is one.
+func (s *LedgerService) Get(body domain.Body, videoID string) (domain.LedgerEntry, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ledger, err := s.load(body)
+	if err != nil {
+		return domain.LedgerEntry{}, false, err
+	}
+	for _, e := range ledger.Entries {
+		if e.VideoID == videoID {
+			return e, true, nil
+		}
+	}
+	return domain.LedgerEntry{}, false, nil
+}
+
+// Record adds an entry or replaces the one for the same video, keeping its
+// original CreatedAt.
+func (s *LedgerService) Record(body domain.Body, entry domain.LedgerEntry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ledger, err := s.load(body)
+	if err != nil {
+		return err
+	}
+	ledger.Entries = upsertLedgerEntry(ledger.Entries, entry, time.Now())
+	return s.save(body, ledger)
+}
+
+// SetStatus changes the status of a video's entry, creating a bare entry when
+// the video is not yet in the ledger, as when a meeting is ignored before
+// discovery first lists it.
+func (s *LedgerService) SetStatus(body domain.Body, videoID string, status domain.LedgerStatus) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ledger, err := s.load(body)
+	if err != nil {
+		return err
+	}
+	entry := domain.LedgerEntry{VideoID: videoID}
+	for _, e := range ledger.Entries {
+		if e.VideoID == videoID {
+			entry = e
+		}
+	}
+	entry.Status = status
+	ledger.Entries = upsertLedgerEntry(ledger.Entries, entry, time.Now())
+	return s.save(body, ledger)
+}
+
+// Forget removes a video's entry so the next discovery treats it as new.
+func (s *LedgerService) Forget(body domain.Body, videoID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ledger, err := s.load(body)
+	if err != nil {
+		return err
+	}
+	var kept []domain.LedgerEntry
+	for _, e := range ledger.Entries {
+		if e.VideoID != videoID {
+			kept = append(kept, e)
+		}
+	}
+	if len(kept) == len(ledger.Entries) {
+		return fmt.Errorf("%s is not in the ledger for %s", videoID, body.Slug)
+	}
+	ledger.Entries = kept
+	return s.save(body, ledger)
+}
+
+// Import builds done entries from the summaries already in the body's
+// finalized directory, matching each to one of meetings, the body's current
+// listing. A summary matches the meeting it would be written for under the
+// current filename pattern, or the meeting whose video URL appears as its
+// frontmatter source. A summary of a YouTube video no longer listed is still
+// recorded by the video ID in its source URL. Existing entries are never
+// overwritten, so Import is safe to run again.
+func (s *LedgerService) Import(body domain.Body, meetings []domain.Meeting) (LedgerImport, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var result LedgerImport
+	ledger, err := s.load(body)
+	if err != nil {
+		return result, err
+	}
+	known := make(map[string]bool, len(ledger.Entries))
+	for _, e := range ledger.Entries {
+		known[e.VideoID] = true
+	}
+
+	byPath := make(map[string]domain.Meeting, len(meetings))
+	byURL := make(map[string]domain.Meeting, len(meetings))
+	for _, m := range meetings {
+		byPath[summaryPath(s.cfg, m, body)] = m
+		if u := body.MeetingVideoURL(m); u != "" {
+			byURL[u] = m
+		}
+	}
+
+	summaries, err := finalizedSummaries(s.cfg.FinalizedDir(body))
+	if err != nil {
+		return result, err
+	}
+	now := time.Now()
+	for _, path := range summaries {
+		entry, ok := importEntry(path, body, byPath, byURL)
+		if !ok {
+			result.Unmatched = append(result.Unmatched, path)
+			continue
+		}
+		if known[entry.VideoID] {
+			continue
+		}
+		known[entry.VideoID] = true
+		ledger.Entries = upsertLedgerEntry(ledger.Entries, entry, now)
+		result.Added++
+	}
+
+	ledger.ImportedAt = &now
+	return result, s.save(body, ledger)
+}
+
+// importEntry ties one finalized summary to a video.
+func importEntry(path string, body domain.Body, byPath, byURL map[string]domain.Meeting) (domain.LedgerEntry, bool) {
+	if m, ok := byPath[path]; ok {
+		return domain.NewLedgerEntry(m, domain.LedgerDone, path), true
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return domain.LedgerEntry{}, false
+	}
+	fm, _, err := markdown.ParseFrontmatter(string(content))
+	if err != nil {
+		return domain.LedgerEntry{}, false
+	}
+	source, _ := fm["source"].(string)
+	if source == "" {
+		return domain.LedgerEntry{}, false
+	}
+	folder := filepath.Base(filepath.Dir(path))
+	if m, ok := byURL[source]; ok {
+		// The file name, not the listing, says which sequence it was
+		// written under.
+		m.Sequence = summarySequence(path, body, folder)
+		return domain.NewLedgerEntry(m, domain.LedgerDone, path), true
+	}
+
+	videoID := youTubeVideoID(source)
+	if videoID == "" {
+		return domain.LedgerEntry{}, false
+	}
+	return domain.LedgerEntry{
+		VideoID:     videoID,
+		VideoURL:    source,
+		MeetingDate: folder[:4] + "-" + folder[4:6] + "-" + folder[6:],
+		Sequence:    summarySequence(path, body, folder),
+		OutputPath:  path,
+		Status:      domain.LedgerDone,
+	}, true
+}
+
+// summarySequence recovers the same-date sequence from a summary's file name
+// by comparing it with the body's filename pattern for that date.
+func summarySequence(path string, body domain.Body, folder string) int {
+	date, err := time.Parse("20060102", folder)
+	if err != nil {
+		return 0
+	}
+	base := buildFilename(domain.Meeting{MeetingDate: date}, body)
+	name := strings.TrimSuffix(filepath.Base(path), ".md")
+	suffix, ok := strings.CutPrefix(name, base+"-")
+	if !ok {
+		return 0
+	}
+	n, err := strconv.Atoi(suffix)
+	if err != nil {
+		return 0
+	}
+	return n
+}
+
+// youTubeVideoID extracts the video ID from a YouTube watch or youtu.be URL.
+func youTubeVideoID(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return ""
+	}
+	switch strings.TrimPrefix(u.Hostname(), "www.") {
+	case "youtube.com", "m.youtube.com":
+		return u.Query().Get("v")
+	case "youtu.be":
+		return strings.Trim(u.Path, "/")
+	}
+	return ""
+}
+
+// finalizedSummaries lists the summary files in a finalized directory's
+// YYYYMMDD meeting folders.
+func finalizedSummaries(dir string) ([]string, error) {
+	folders, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("reading finalized dir: %w", err)
+	}
+
+	var paths []string
+	for _, folder := range folders {
+		if !folder.IsDir() || len(folder.Name()) != 8 {
+			continue
+		}
+		if _, err := time.Parse("20060102", folder.Name()); err != nil {
+			continue
+		}
+		mdFiles, err := filepath.Glob(filepath.Join(dir, folder.Name(), "*.md"))
+		if err != nil {
+			continue
+		}
+		sort.Strings(mdFiles)
+		paths = append(paths, mdFiles...)
+	}
+	return paths, nil
+}
+
+// upsertLedgerEntry replaces the entry for entry.VideoID or appends it,
+// stamping UpdatedAt and keeping the original CreatedAt.
+func upsertLedgerEntry(entries []domain.LedgerEntry, entry domain.LedgerEntry, now time.Time) []domain.LedgerEntry {
+	entry.UpdatedAt = now
+	for i := range entries {
+		if entries[i].VideoID == entry.VideoID {
+			entry.CreatedAt = entries[i].CreatedAt
+			entries[i] = entry
+			return entries
+		}
+	}
+	entry.CreatedAt = now
+	return append(entries, entry)
+}
+
+// load reads the ledger. A missing ledger is empty. Callers must hold s.mu.
+func (s *LedgerService) load(body domain.Body) (*domain.Ledger, error) {
+	data, err := os.ReadFile(s.cfg.LedgerPath(body))
+	if errors.Is(err, os.ErrNotExist) {
+		return &domain.Ledger{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading ledger: %w", err)
+	}
+	var ledger domain.Ledger
+	if err := json.Unmarshal(data, &ledger); err != nil {
+		return nil, fmt.Errorf("parsing ledger: %w", err)
+	}
+	return &ledger, nil
+}
+
+// save writes the ledger. Callers must hold s.mu.
+func (s *LedgerService) save(body domain.Body, ledger *domain.Ledger) error {
+	if ledger.Entries == nil {
+		ledger.Entries = []domain.LedgerEntry{}
+	}
+	path := s.cfg.LedgerPath(body)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("creating ledger dir: %w", err)
+	}
+	if err := writeJSON(path, ledger); err != nil {
+		return fmt.Errorf("writing ledger: %w", err)
+	}
+	return nil
+}
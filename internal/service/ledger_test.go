@@ -0,0 +1,147 @@
+package service_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/AvogadroSG1/civic-summary/internal/domain"
+	"github.com/AvogadroSG1/civic-summary/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeSummary writes a finalized summary into its date folder.
+func writeSummary(t *testing.T, dir, folder, name, content string) string {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, folder), 0o755))
+	path := filepath.Join(dir, folder, name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	return path
+}
+
+func TestLedgerService_RecordAndList(t *testing.T) {
+	cfg := testConfig(t)
+	body, _ := cfg.GetBody("hagerstown")
+	ledger := service.NewLedgerService(cfg)
+
+	entries, err := ledger.List(body)
+	require.NoError(t, err)
+	assert.Empty(t, entries, "a missing ledger is empty")
+	assert.False(t, ledger.Imported(body))
+
+	later := testMeeting()
+	earlier := testMeeting()
+	earlier.VideoID = "def456"
+	earlier.MeetingDate = time.Date(2025, 1, 21, 0, 0, 0, 0, time.UTC)
+	require.NoError(t, ledger.Record(body, domain.NewLedgerEntry(later, domain.LedgerQuarantined, "")))
+	require.NoError(t, ledger.Record(body, domain.NewLedgerEntry(earlier, domain.LedgerDone, "/out/a.md")))
+
+	first, ok, err := ledger.Get(body, "abc123")
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	require.NoError(t, ledger.Record(body, domain.NewLedgerEntry(later, domain.LedgerDone, "/out/b.md")))
+
+	entries, err = ledger.List(body)
+	require.NoError(t, err)
+	require.Len(t, entries, 2, "recording a video again replaces its entry")
+	assert.Equal(t, "def456", entries[0].VideoID, "entries are ordered by meeting date")
+	assert.Equal(t, domain.LedgerDone, entries[1].Status)
+	assert.Equal(t, "/out/b.md", entries[1].OutputPath)
+	assert.Equal(t, "2025-02-04", entries[1].MeetingDate)
+	assert.True(t, entries[1].CreatedAt.Equal(first.CreatedAt), "the original creation time is kept")
+	assert.False(t, entries[1].UpdatedAt.Before(first.UpdatedAt))
+}
+
+func TestLedgerService_SetStatusAndForget(t *testing.T) {
+	cfg := testConfig(t)
+	body, _ := cfg.GetBody("hagerstown")
+	ledger := service.NewLedgerService(cfg)
+
+	require.NoError(t, ledger.Record(body, domain.NewLedgerEntry(testMeeting(), domain.LedgerQuarantined, "")))
+	require.NoError(t, ledger.SetStatus(body, "abc123", domain.LedgerSkipped))
+	require.NoError(t, ledger.SetStatus(body, "never-listed", domain.LedgerIgnored))
+
+	skipped, ok, err := ledger.Get(body, "abc123")
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, domain.LedgerSkipped, skipped.Status)
+	assert.Equal(t, "2025-02-04", skipped.MeetingDate, "changing status keeps the rest of the entry")
+
+	ignored, ok, err := ledger.Get(body, "never-listed")
+	require.NoError(t, err)
+	require.True(t, ok, "an unknown video can be ignored ahead of discovery")
+	assert.Equal(t, domain.LedgerIgnored, ignored.Status)
+
+	require.NoError(t, ledger.Forget(body, "abc123"))
+	_, ok, err = ledger.Get(body, "abc123")
+	require.NoError(t, err)
+	assert.False(t, ok)
+	assert.ErrorContains(t, ledger.Forget(body, "abc123"), "not in the ledger")
+}
+
+func TestLedgerService_Import(t *testing.T) {
+	cfg := boccConfig(t)
+	body, _ := cfg.GetBody("bocc")
+	dir := cfg.FinalizedDir(body)
+	ledger := service.NewLedgerService(cfg)
+
+	listed := domain.Meeting{VideoID: "vid_a", MeetingDate: time.Date(2025, 2, 24, 0, 0, 0, 0, time.UTC), Sequence: 1}
+	renamed := domain.Meeting{VideoID: "vid_r", MeetingDate: time.Date(2025, 3, 3, 0, 0, 0, 0, time.UTC)}
+
+	// Named as the current pattern would name it.
+	writeSummary(t, dir, "20250224", "BOCC-2025-02-24-Citizen-Summary-1.md", "# Existing")
+	// Renamed by hand, but its frontmatter names the listed video.
+	byURL := writeSummary(t, dir, "20250303", "Budget Hearing.md",
+		"---\nsource: https://www.youtube.com/watch?v=vid_r\n---\n# Budget")
+	// No longer listed, but a YouTube source still identifies it.
+	unlisted := writeSummary(t, dir, "20240110", "BOCC-2024-01-10-Citizen-Summary-2.md",
+		"---\nsource: https://www.youtube.com/watch?v=old_vid\n---\n# Old")
+	// Nothing ties this one to a video.
+	orphan := writeSummary(t, dir, "20240110", "Notes.md", "# Notes")
+
+	// An ignored video keeps its status through an import.
+	require.NoError(t, ledger.SetStatus(body, "vid_a", domain.LedgerIgnored))
+
+	result, err := ledger.Import(body, []domain.Meeting{listed, renamed})
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.Added)
+	assert.Equal(t, []string{orphan}, result.Unmatched)
+	assert.True(t, ledger.Imported(body))
+
+	entries, err := ledger.List(body)
+	require.NoError(t, err)
+	require.Len(t, entries, 3)
+
+	assert.Equal(t, "vid_a", entries[0].VideoID, "an entry without a date sorts first")
+	assert.Equal(t, domain.LedgerIgnored, entries[0].Status, "existing entries are not overwritten")
+
+	assert.Equal(t, "old_vid", entries[1].VideoID)
+	assert.Equal(t, "2024-01-10", entries[1].MeetingDate)
+	assert.Equal(t, 2, entries[1].Sequence, "the sequence is recovered from the file name")
+	assert.Equal(t, unlisted, entries[1].OutputPath)
+
+	assert.Equal(t, "vid_r", entries[2].VideoID)
+	assert.Equal(t, byURL, entries[2].OutputPath)
+	assert.Equal(t, domain.LedgerDone, entries[2].Status)
+
+	again, err := ledger.Import(body, []domain.Meeting{listed, renamed})
+	require.NoError(t, err)
+	assert.Zero(t, again.Added, "importing again adds nothing")
+}
+
+func TestLedgerService_Corrupt(t *testing.T) {
+	cfg := testConfig(t)
+	body, _ := cfg.GetBody("hagerstown")
+	require.NoError(t, os.MkdirAll(filepath.Dir(cfg.LedgerPath(body)), 0o755))
+	require.NoError(t, os.WriteFile(cfg.LedgerPath(body), []byte("{"), 0o644))
+	ledger := service.NewLedgerService(cfg)
+
+	_, err := ledger.List(body)
+	assert.ErrorContains(t, err, "parsing ledger")
+	assert.True(t, ledger.Imported(body), "a corrupt ledger is not replaced by an import")
+	assert.Error(t, ledger.Record(body, domain.NewLedgerEntry(testMeeting(), domain.LedgerDone, "/out/x.md")),
+		"a corrupt ledger is not overwritten")
+}
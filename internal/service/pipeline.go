@@ -2,10 +2,15 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/AvogadroSG1/civic-summary/internal/config"
 	"github.com/AvogadroSG1/civic-summary/internal/domain"
@@ -17,87 +22,118 @@ import (
 type PipelineOrchestrator struct {
 	discovery     *DiscoveryService
 	transcription *TranscriptionService
+	diarization   *DiarizationService
+	agendas       *AgendaService
 	analysis      *AnalysisService
 	crossref      *CrossReferenceService
+	timestamps    *TimestampLinkService
 	validation    *ValidationService
 	quarantine    *QuarantineService
 	index         *IndexService
+	checkpoints   *CheckpointService
+	deferrals     *DeferralService
 	cfg           *config.Config
 	retryCfg      retry.Config
+
+	// transcriptions bounds caption downloads and Whisper runs across all
+	// bodies, since Whisper is CPU- and memory-heavy.
+	transcriptions semaphore
 }
 
 // NewPipelineOrchestrator creates a fully-wired pipeline orchestrator.
 func NewPipelineOrchestrator(
 	discovery *DiscoveryService,
 	transcription *TranscriptionService,
+	diarization *DiarizationService,
+	agendas *AgendaService,
 	analysis *AnalysisService,
 	crossref *CrossReferenceService,
+	timestamps *TimestampLinkService,
 	validation *ValidationService,
 	quarantine *QuarantineService,
 	index *IndexService,
+	checkpoints *CheckpointService,
+	deferrals *DeferralService,
 	cfg *config.Config,
 ) *PipelineOrchestrator {
 	return &PipelineOrchestrator{
 		discovery:     discovery,
 		transcription: transcription,
+		diarization:   diarization,
+		agendas:       agendas,
 		analysis:      analysis,
 		crossref:      crossref,
+		timestamps:    timestamps,
 		validation:    validation,
 		quarantine:    quarantine,
 		index:         index,
+		checkpoints:   checkpoints,
+		deferrals:     deferrals,
 		cfg:           cfg,
 		retryCfg:      retry.NewConfig(cfg.MaxRetries, cfg.BackoffDelays),
+
+		transcriptions: newSemaphore(cfg.Concurrency.Transcriptions),
 	}
 }
 
+// ProcessOptions controls a pipeline run.
+type ProcessOptions struct {
+	// DryRun lists the meetings that would be processed without processing them.
+	DryRun bool
+	// Window restricts processing to meetings dated within it. When zero, each
+	// body's default window applies; see domain.Body.DiscoveryWindow.
+	Window domain.DateRange
+}
+
 // ProcessBody runs the full pipeline for a single government body.
-func (p *PipelineOrchestrator) ProcessBody(ctx context.Context, body domain.Body, dryRun bool) (*domain.ProcessingStats, error) {
+func (p *PipelineOrchestrator) ProcessBody(ctx context.Context, body domain.Body, opts ProcessOptions) (*domain.ProcessingStats, error) {
 	stats := &domain.ProcessingStats{}
 
 	output.Banner(fmt.Sprintf("Processing: %s", body.Name))
 
 	// Phase 1: Discovery
-	meetings, err := p.discovery.DiscoverNewMeetings(ctx, body)
+	window := body.DiscoveryWindow(opts.Window, time.Now())
+	waiting := p.waitingDeferrals(body, time.Now())
+	due := func(m domain.Meeting) bool {
+		_, ok := waiting[m.VideoID]
+		return !ok
+	}
+	var meetings []domain.Meeting
+	var err error
+	if opts.DryRun {
+		meetings, err = p.discovery.DiscoverNewMeetings(ctx, body, window)
+	} else {
+		meetings, err = p.discovery.PrepareNewMeetings(ctx, body, window, due)
+	}
 	if err != nil {
 		return stats, fmt.Errorf("discovery failed: %w", err)
 	}
 
+	// Meetings waiting for captions sit out until their recheck time. They
+	// are counted as deferred rather than discovered.
+	meetings = p.dueMeetings(meetings, waiting, stats)
 	stats.Discovered = len(meetings)
 
 	if len(meetings) == 0 {
 		output.Success("No new videos to process for %s", body.Name)
 	}
 
-	if dryRun {
+	if opts.DryRun {
 		for _, m := range meetings {
 			output.Info("Would process: %s (%s) - %s", m.ISODate(), m.VideoID, m.Title)
 		}
 		return stats, nil
 	}
 
-	// Phase 2-5: Process each meeting with retry
-	for _, meeting := range meetings {
-		output.Info("Processing: %s (%s)", meeting.ISODate(), meeting.Title)
-
-		err := retry.Do(ctx, p.retryCfg, meeting.VideoID, func() error {
-			return p.processSingleMeeting(ctx, meeting, body)
-		})
-
-		if err != nil {
-			output.Failure("Failed: %s - %s", meeting.ISODate(), err)
-			stats.Failed++
+	// Phase 2-5: Process meetings with retry, up to concurrency.meetings at once.
+	var mu sync.Mutex
+	forEach(ctx, p.cfg.Concurrency.Meetings, meetings, func(meeting domain.Meeting) {
+		outcome := p.processWithRetry(ctx, meeting, body)
 
-			// Quarantine on failure.
-			qErr := p.quarantine.Quarantine(body, meeting, err.Error(), "", "")
-			if qErr != nil {
-				slog.Error("quarantine failed", "error", qErr)
-			}
-			stats.Quarantined++
-		} else {
-			output.Success("Completed: %s", meeting.ISODate())
-			stats.Processed++
-		}
-	}
+		mu.Lock()
+		defer mu.Unlock()
+		outcome.record(stats)
+	})
 
 	// Retry quarantined items.
 	p.retryQuarantined(ctx, body, stats)
@@ -107,29 +143,211 @@ func (p *PipelineOrchestrator) ProcessBody(ctx context.Context, body domain.Body
 		slog.Warn("index update failed", "error", err)
 	}
 
+	if err := ctx.Err(); err != nil {
+		return stats, fmt.Errorf("processing %s interrupted: %w", body.Slug, err)
+	}
+
 	return stats, nil
 }
 
-// ProcessAll runs the pipeline for all configured bodies.
-func (p *PipelineOrchestrator) ProcessAll(ctx context.Context, dryRun bool) (map[string]*domain.ProcessingStats, error) {
+// ProcessAll runs the pipeline for all configured bodies, up to
+// concurrency.bodies at once.
+func (p *PipelineOrchestrator) ProcessAll(ctx context.Context, opts ProcessOptions) (map[string]*domain.ProcessingStats, error) {
 	allStats := make(map[string]*domain.ProcessingStats)
+	var mu sync.Mutex
 
-	for slug, body := range p.cfg.Bodies {
-		stats, err := p.ProcessBody(ctx, body, dryRun)
-		allStats[slug] = stats
+	forEach(ctx, p.cfg.Concurrency.Bodies, p.cfg.BodySlugs(), func(slug string) {
+		stats, err := p.ProcessBody(ctx, p.cfg.Bodies[slug], opts)
 		if err != nil {
 			slog.Error("body processing failed",
 				"body", slug,
 				"error", err,
 			)
 		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		allStats[slug] = stats
+	})
+
+	if err := ctx.Err(); err != nil {
+		return allStats, fmt.Errorf("processing interrupted: %w", err)
 	}
 
 	return allStats, nil
 }
 
-// processSingleMeeting runs phases 2-5 for a single meeting.
+// ProcessMeeting runs one meeting supplied by the caller rather than found by
+// discovery, such as a local recording passed to ingest, through phases 2-5
+// and updates the body's index. A meeting that fails every attempt is
+// quarantined, as in ProcessBody, and an error is returned.
+func (p *PipelineOrchestrator) ProcessMeeting(ctx context.Context, meeting domain.Meeting, body domain.Body) error {
+	outcome := p.processWithRetry(ctx, meeting, body)
+
+	if err := p.index.UpdateIndex(body); err != nil {
+		slog.Warn("index update failed", "error", err)
+	}
+
+	switch outcome {
+	case outcomeQuarantined:
+		return fmt.Errorf("processing %s failed; it has been quarantined", meeting.VideoID)
+	case outcomeDeferred:
+		return fmt.Errorf("captions for %s are not available yet; it has been deferred", meeting.VideoID)
+	case outcomeInterrupted:
+		return fmt.Errorf("processing %s interrupted: %w", meeting.VideoID, ctx.Err())
+	}
+	return nil
+}
+
+// meetingOutcome is the result of one meeting's trip through the pipeline.
+type meetingOutcome int
+
+const (
+	outcomeProcessed meetingOutcome = iota
+	outcomeQuarantined
+	outcomeDeferred
+	outcomeInterrupted
+)
+
+// record adds the outcome to stats. Callers processing meetings in parallel
+// must serialize calls.
+func (o meetingOutcome) record(stats *domain.ProcessingStats) {
+	switch o {
+	case outcomeProcessed:
+		stats.Processed++
+	case outcomeQuarantined:
+		stats.Failed++
+		stats.Quarantined++
+	case outcomeDeferred:
+		stats.Deferred++
+	case outcomeInterrupted:
+		stats.Skipped++
+	}
+}
+
+// processWithRetry runs a meeting through attempt and quarantines it if every
+// try fails.
+func (p *PipelineOrchestrator) processWithRetry(ctx context.Context, meeting domain.Meeting, body domain.Body) meetingOutcome {
+	output.Info("Processing: %s (%s)", meeting.ISODate(), meeting.Title)
+
+	outcome, err := p.attempt(ctx, meeting, body)
+	if outcome != outcomeQuarantined {
+		return outcome
+	}
+
+	output.Failure("Failed: %s - %s", meeting.ISODate(), err)
+
+	// Quarantine on failure, keeping the transcript if one was obtained.
+	qErr := p.quarantine.Quarantine(body, meeting, err.Error(), p.checkpointedTranscript(body, meeting), "")
+	if qErr != nil {
+		slog.Error("quarantine failed", "error", qErr)
+	}
+	if err := p.discovery.MarkQuarantined(meeting, body); err != nil {
+		slog.Warn("failed to record quarantined meeting", "video_id", meeting.VideoID, "error", err)
+	}
+	return outcomeQuarantined
+}
+
+// attempt runs a meeting through phases 2-5 under retry.Do. A meeting
+// interrupted by cancellation is reported as such: its checkpoints let the next
+// run resume. One whose captions have not been published is deferred. Any
+// other failure is returned with outcomeQuarantined, leaving the caller to
+// record it.
+func (p *PipelineOrchestrator) attempt(ctx context.Context, meeting domain.Meeting, body domain.Body) (meetingOutcome, error) {
+	err := retry.Do(ctx, p.retryCfg, meeting.VideoID, func() error {
+		return p.processSingleMeeting(ctx, meeting, body)
+	})
+
+	if err == nil {
+		output.Success("Completed: %s", meeting.ISODate())
+		return outcomeProcessed, nil
+	}
+
+	if ctx.Err() != nil {
+		output.Warning("Interrupted: %s - %s", meeting.ISODate(), err)
+		return outcomeInterrupted, err
+	}
+
+	// A deferral that cannot be recorded would never be rechecked on
+	// schedule, so the meeting is quarantined instead.
+	var pending *CaptionsPendingError
+	if errors.As(err, &pending) {
+		entry, dErr := p.deferrals.Defer(body, meeting, pending.Error(), time.Now())
+		if dErr == nil {
+			output.Warning("Deferred: %s - captions not published yet; checking again after %s",
+				meeting.ISODate(), entry.RecheckAt.Format(time.DateTime))
+			return outcomeDeferred, err
+		}
+		err = fmt.Errorf("%w; deferring failed: %v", err, dErr)
+	}
+	return outcomeQuarantined, err
+}
+
+// processSingleMeeting runs phases 2-5 for a single meeting. Each completed
+// stage is checkpointed, so a retry resumes where the previous attempt failed.
 func (p *PipelineOrchestrator) processSingleMeeting(ctx context.Context, meeting domain.Meeting, body domain.Body) error {
+	state, err := p.checkpoints.Load(body, meeting)
+	if err != nil {
+		return err
+	}
+	if next := state.NextStage(); next != domain.StageTranscription {
+		slog.Info("resuming meeting from checkpoint",
+			"video_id", meeting.VideoID,
+			"stage", next,
+		)
+	}
+
+	if err := p.runStages(ctx, meeting, body, state); err != nil {
+		state.Error = err.Error()
+		p.saveState(body, state)
+		return err
+	}
+
+	if err := p.checkpoints.Clear(body, meeting.VideoID); err != nil {
+		slog.Warn("failed to clear pipeline state", "video_id", meeting.VideoID, "error", err)
+	}
+	if err := p.deferrals.Clear(body, meeting.VideoID); err != nil {
+		slog.Warn("failed to clear deferral", "video_id", meeting.VideoID, "error", err)
+	}
+	return nil
+}
+
+// dueMeetings drops the meetings still waiting out a deferral, counting them
+// in stats.
+func (p *PipelineOrchestrator) dueMeetings(meetings []domain.Meeting, waiting map[string]domain.DeferredMeeting, stats *domain.ProcessingStats) []domain.Meeting {
+	due := meetings[:0:0]
+	for _, m := range meetings {
+		if entry, ok := waiting[m.VideoID]; ok {
+			output.Info("Deferred: %s (%s) until %s", m.ISODate(), m.VideoID, entry.RecheckAt.Format(time.DateTime))
+			stats.Deferred++
+			continue
+		}
+		due = append(due, m)
+	}
+	return due
+}
+
+// waitingDeferrals returns the body's meetings deferred for captions whose
+// recheck time has not come by now, by video ID.
+func (p *PipelineOrchestrator) waitingDeferrals(body domain.Body, now time.Time) map[string]domain.DeferredMeeting {
+	entries, err := p.deferrals.List(body)
+	if err != nil {
+		slog.Warn("failed to read deferrals", "body", body.Slug, "error", err)
+	}
+	waiting := make(map[string]domain.DeferredMeeting)
+	for _, e := range entries {
+		if !e.Due(now) {
+			waiting[e.VideoID] = e
+		}
+	}
+	return waiting
+}
+
+// runStages executes every stage not already checkpointed in state. The
+// meeting's type may give it its own prompt template and validation rules.
+func (p *PipelineOrchestrator) runStages(ctx context.Context, meeting domain.Meeting, body domain.Body, state *domain.PipelineResult) error {
+	body = body.ForMeetingType(meeting.MeetingType)
+
 	// Ensure output directory exists.
 	dateDir := filepath.Join(p.cfg.FinalizedDir(body), meeting.DateFolder())
 	if err := os.MkdirAll(dateDir, 0o755); err != nil {
@@ -137,30 +355,71 @@ func (p *PipelineOrchestrator) processSingleMeeting(ctx context.Context, meeting
 	}
 
 	// Phase 2: Transcription
-	transcript, err := p.transcription.Transcribe(ctx, meeting, dateDir)
+	transcript, err := p.transcribeStage(ctx, meeting, body, dateDir, state)
 	if err != nil {
-		return fmt.Errorf("transcription: %w", err)
+		return err
 	}
 
-	if err := p.transcription.ValidateTranscript(transcript); err != nil {
-		return fmt.Errorf("transcript validation: %w", err)
+	// Phase 3: Analysis, of the meeting itself rather than the whole
+	// recording.
+	transcript, meeting.Window = TrimToMeeting(meeting, body, transcript)
+	analyzed, ok := p.resume(body, state, domain.StageAnalysis)
+	if !ok {
+		meeting.Agenda = p.agendas.Find(ctx, meeting, body)
+		summary, err := p.analysis.Analyze(ctx, meeting, transcript, body)
+		if err != nil {
+			return fmt.Errorf("analysis: %w", err)
+		}
+		analyzed = summary.Content
+		p.checkpoint(body, state, domain.StageAnalysis, analyzed)
 	}
 
-	// Phase 3: Analysis
-	summary, err := p.analysis.Analyze(ctx, meeting, transcript, body)
-	if err != nil {
-		return fmt.Errorf("analysis: %w", err)
+	// Phase 4: Cross-reference and timestamp links (non-critical)
+	content, ok := p.resume(body, state, domain.StageCrossRef)
+	if !ok {
+		content = p.link(analyzed, meeting, body)
+		p.checkpoint(body, state, domain.StageCrossRef, content)
 	}
 
-	// Phase 4: Cross-reference (non-critical)
-	content := p.crossref.AddCrossReferences(summary.Content, meeting, body)
+	// Phase 5: Validation. A rejected draft goes back to the model with its
+	// errors for up to repair_rounds rounds before the attempt fails. Errors a
+	// repair cannot fix are left out, and once they are all that remain the
+	// rounds stop.
+	result := p.validate(content, transcript, meeting, body)
+	round := 1
+	for ; result.HasErrors() && round <= p.cfg.RepairRounds; round++ {
+		fixable := slices.DeleteFunc(result.Errors(), func(issue domain.ValidationIssue) bool {
+			return !issue.Repairable()
+		})
+		if len(fixable) == 0 {
+			break
+		}
+		logValidationErrors(result)
+		p.keepRejectedDraft(body, meeting, round, analyzed, result.Errors())
 
-	// Phase 5: Validation
-	result := p.validation.Validate(content, body)
-	if result.HasErrors() {
-		for _, issue := range result.Errors() {
-			slog.Error("validation error", "issue", issue.String())
+		repaired, err := p.analysis.Repair(ctx, meeting, body, analyzed, fixable, round)
+		if err != nil {
+			state.ResetFrom(domain.StageAnalysis)
+			return fmt.Errorf("repair round %d: %w", round, err)
+		}
+
+		analyzed = repaired.Content
+		state.ResetFrom(domain.StageAnalysis)
+		p.checkpoint(body, state, domain.StageAnalysis, analyzed)
+		content = p.link(analyzed, meeting, body)
+		p.checkpoint(body, state, domain.StageCrossRef, content)
+
+		result = p.validate(content, transcript, meeting, body)
+		if !result.HasErrors() {
+			slog.Info("summary repaired", "video_id", meeting.VideoID, "rounds", round)
 		}
+	}
+	if result.HasErrors() {
+		logValidationErrors(result)
+		p.keepRejectedDraft(body, meeting, round, analyzed, result.Errors())
+		// The draft was rejected, so the next attempt asks the model again;
+		// the transcript is still good and is kept.
+		state.ResetFrom(domain.StageAnalysis)
 		return fmt.Errorf("validation failed with %d errors", len(result.Errors()))
 	}
 
@@ -179,9 +438,196 @@ func (p *PipelineOrchestrator) processSingleMeeting(ctx context.Context, meeting
 		"words", len(content),
 	)
 
+	if err := p.discovery.MarkProcessed(meeting, body, summaryPath); err != nil {
+		slog.Warn("failed to record processed meeting", "video_id", meeting.VideoID, "error", err)
+	}
+
 	return nil
 }
 
+// link applies the phase 4 cross-references and timestamp links to a draft.
+func (p *PipelineOrchestrator) link(analyzed string, meeting domain.Meeting, body domain.Body) string {
+	content := p.crossref.AddCrossReferences(analyzed, meeting, body)
+	return p.timestamps.AddTimestampLinks(content, meeting, body)
+}
+
+// validate runs the phase 5 checks on a linked summary. The word count
+// expected scales with the meeting's length: its detected window when there
+// is one, so pre-roll and closed-session footage do not inflate it, or else
+// the whole recording.
+func (p *PipelineOrchestrator) validate(content string, transcript domain.Transcript, meeting domain.Meeting, body domain.Body) *domain.ValidationResult {
+	duration := recordingDuration(meeting, transcript)
+	if meeting.Window.Found() {
+		duration = meeting.Window.Duration()
+	}
+	result := p.validation.Validate(content, body, duration)
+	p.validation.ValidateTimestamps(content, transcript, body, result)
+	p.validation.ValidateFaithfulness(content, transcript, body.ValidationRules().Check(domain.CheckFaithfulness), result)
+	return result
+}
+
+func logValidationErrors(result *domain.ValidationResult) {
+	for _, issue := range result.Errors() {
+		slog.Error("validation error", "issue", issue.String())
+	}
+}
+
+// keepRejectedDraft saves a draft that failed validation under the body's
+// repair directory, headed by the errors it was rejected for, so repairs can
+// be inspected afterwards. Failures are logged, not fatal.
+func (p *PipelineOrchestrator) keepRejectedDraft(body domain.Body, meeting domain.Meeting, round int, draft string, issues []domain.ValidationIssue) {
+	dir := filepath.Join(p.cfg.RepairDir(body), meeting.VideoID)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		slog.Warn("failed to keep rejected draft", "video_id", meeting.VideoID, "error", err)
+		return
+	}
+
+	var b strings.Builder
+	b.WriteString("<!-- rejected by validation:\n")
+	for _, issue := range issues {
+		fmt.Fprintf(&b, "%s\n", issue)
+	}
+	b.WriteString("-->\n")
+	b.WriteString(draft)
+
+	path := filepath.Join(dir, fmt.Sprintf("round-%d.md", round))
+	if err := os.WriteFile(path, []byte(b.String()), 0o644); err != nil {
+		slog.Warn("failed to keep rejected draft", "video_id", meeting.VideoID, "error", err)
+	}
+}
+
+// transcribeStage returns the meeting's transcript, reusing a checkpointed one
+// while its file is still on disk.
+func (p *PipelineOrchestrator) transcribeStage(ctx context.Context, meeting domain.Meeting, body domain.Body, dateDir string, state *domain.PipelineResult) (domain.Transcript, error) {
+	if cp, ok := state.Checkpoint(domain.StageTranscription); ok {
+		transcript, err := LoadTranscript(cp.Artifact, domain.TranscriptSource(cp.Detail))
+		if err == nil {
+			return p.prepare(ctx, meeting, body, transcript, dateDir)
+		}
+		slog.Warn("checkpointed transcript unreadable, transcribing again",
+			"video_id", meeting.VideoID,
+			"error", err,
+		)
+		state.ResetFrom(domain.StageTranscription)
+	}
+
+	if err := p.transcriptions.acquire(ctx); err != nil {
+		return domain.Transcript{}, fmt.Errorf("waiting for transcription slot: %w", err)
+	}
+	transcript, err := p.transcription.TranscribeOrDefer(ctx, meeting, body, dateDir, p.whisperAfter(body, meeting))
+	p.transcriptions.release()
+	if err != nil {
+		return domain.Transcript{}, fmt.Errorf("transcription: %w", err)
+	}
+
+	if err := p.transcription.ValidateTranscript(transcript); err != nil {
+		return domain.Transcript{}, fmt.Errorf("transcript validation: %w", err)
+	}
+
+	state.Complete(domain.StageTranscription, transcript.Path, string(transcript.Source))
+	p.saveState(body, state)
+
+	return p.prepare(ctx, meeting, body, transcript, dateDir)
+}
+
+// prepare readies a transcript for analysis: it corrects the body's
+// vocabulary, then labels speakers. Both run on the checkpointed transcript
+// too, so the checkpoint always holds the transcript as captioned.
+func (p *PipelineOrchestrator) prepare(ctx context.Context, meeting domain.Meeting, body domain.Body, transcript domain.Transcript, dateDir string) (domain.Transcript, error) {
+	transcript = p.transcription.ApplyVocabulary(meeting, body, transcript)
+	return p.diarize(ctx, meeting, body, transcript, dateDir)
+}
+
+// diarize labels the transcript's speakers for bodies that set diarize. The
+// checkpoint keeps the unlabeled transcript; the speaker turns are kept beside
+// it, so labeling a resumed meeting again is cheap. Diarization shares the
+// transcription slots, being as CPU-heavy as Whisper.
+func (p *PipelineOrchestrator) diarize(ctx context.Context, meeting domain.Meeting, body domain.Body, transcript domain.Transcript, dateDir string) (domain.Transcript, error) {
+	if !body.Diarize {
+		return transcript, nil
+	}
+	if err := p.transcriptions.acquire(ctx); err != nil {
+		return domain.Transcript{}, fmt.Errorf("waiting for transcription slot: %w", err)
+	}
+	defer p.transcriptions.release()
+	return p.diarization.Diarize(ctx, meeting, body, transcript, dateDir), nil
+}
+
+// whisperAfter returns when a meeting whose captions are missing goes to
+// Whisper: captions.grace_hours after it was released, so a recording already
+// older than that is never deferred. Published is no anchor, as a source may
+// report only the day; an evening stream would be dated hours before it
+// began. Without an exact release time, the grace period runs from the first
+// deferral, or from now if it never has been deferred. A meeting retried from
+// quarantine has had its wait, and goes to Whisper at once.
+func (p *PipelineOrchestrator) whisperAfter(body domain.Body, meeting domain.Meeting) time.Time {
+	if p.quarantine.Has(body, meeting.VideoID) {
+		return time.Time{}
+	}
+	since := meeting.Details.Released
+	if since.IsZero() {
+		since = time.Now()
+		if entry, ok, err := p.deferrals.Get(body, meeting.VideoID); err == nil && ok {
+			since = entry.DeferredAt
+		}
+	}
+	return since.Add(p.cfg.Captions.Grace())
+}
+
+// resume returns the artifact content of a checkpointed stage. A missing or
+// unreadable artifact discards the checkpoint so the stage runs again.
+func (p *PipelineOrchestrator) resume(body domain.Body, state *domain.PipelineResult, stage domain.PipelineStage) (string, bool) {
+	cp, ok := state.Checkpoint(stage)
+	if !ok {
+		return "", false
+	}
+	content, err := os.ReadFile(cp.Artifact)
+	if err != nil {
+		slog.Warn("checkpointed artifact unreadable, rerunning stage",
+			"video_id", state.VideoID,
+			"stage", stage,
+			"error", err,
+		)
+		state.ResetFrom(stage)
+		return "", false
+	}
+	slog.Info("reusing checkpoint", "video_id", state.VideoID, "stage", stage)
+	return string(content), true
+}
+
+// checkpoint stores a stage's output and records the stage as complete.
+// Checkpointing only saves work on retry, so failures are logged, not fatal.
+func (p *PipelineOrchestrator) checkpoint(body domain.Body, state *domain.PipelineResult, stage domain.PipelineStage, content string) {
+	path, err := p.checkpoints.SaveArtifact(body, state.VideoID, stage, content)
+	if err != nil {
+		slog.Warn("failed to checkpoint stage", "video_id", state.VideoID, "stage", stage, "error", err)
+		return
+	}
+	state.Complete(stage, path, "")
+	p.saveState(body, state)
+}
+
+// saveState persists state, logging rather than failing on error.
+func (p *PipelineOrchestrator) saveState(body domain.Body, state *domain.PipelineResult) {
+	if err := p.checkpoints.Save(body, state); err != nil {
+		slog.Warn("failed to save pipeline state", "video_id", state.VideoID, "error", err)
+	}
+}
+
+// checkpointedTranscript returns the path of the meeting's checkpointed
+// transcript, or "" if transcription never completed.
+func (p *PipelineOrchestrator) checkpointedTranscript(body domain.Body, meeting domain.Meeting) string {
+	state, err := p.checkpoints.Load(body, meeting)
+	if err != nil {
+		return ""
+	}
+	cp, ok := state.Checkpoint(domain.StageTranscription)
+	if !ok {
+		return ""
+	}
+	return cp.Artifact
+}
+
 // retryQuarantined attempts to reprocess previously quarantined meetings.
 func (p *PipelineOrchestrator) retryQuarantined(ctx context.Context, body domain.Body, stats *domain.ProcessingStats) {
 	entries, err := p.quarantine.ListQuarantined(body)
@@ -192,32 +638,56 @@ func (p *PipelineOrchestrator) retryQuarantined(ctx context.Context, body domain
 	output.Banner("Retrying Quarantined Items")
 	output.Info("Found %d quarantined item(s)", len(entries))
 
-	for _, entry := range entries {
-		output.Info("Retrying: %s (date: %s, retries: %d)",
-			entry.VideoID, entry.MeetingDate, entry.RetryCount)
-
+	// Sequences are settled one meeting at a time before the retries run in
+	// parallel, since numbering a meeting may rename summaries.
+	type retryItem struct {
+		entry   domain.QuarantineEntry
+		meeting domain.Meeting
+	}
+	items := make([]retryItem, len(entries))
+	for i, entry := range entries {
 		meeting := domain.Meeting{
-			VideoID:  entry.VideoID,
-			BodySlug: body.Slug,
-			Sequence: entry.Sequence,
+			VideoID:     entry.VideoID,
+			VideoURL:    entry.VideoURL,
+			Title:       entry.Title,
+			MeetingType: entry.MeetingType,
+			BodySlug:    body.Slug,
+			Sequence:    entry.Sequence,
 		}
 		// Parse the meeting date.
 		if date, err := parseFlexibleDate(entry.MeetingDate); err == nil {
 			meeting.MeetingDate = date
 		}
+		meeting.Sequence = p.discovery.RetrySequence(meeting, body)
+		items[i] = retryItem{entry: entry, meeting: meeting}
+	}
+
+	var mu sync.Mutex
+	forEach(ctx, p.cfg.Concurrency.Meetings, items, func(item retryItem) {
+		entry, meeting := item.entry, item.meeting
+		output.Info("Retrying: %s (date: %s, retries: %d)",
+			entry.VideoID, entry.MeetingDate, entry.RetryCount)
 
 		if err := p.quarantine.IncrementRetry(body, entry.VideoID); err != nil {
 			slog.Warn("failed to increment retry count", "error", err)
 		}
 
-		if err := p.processSingleMeeting(ctx, meeting, body); err != nil {
+		// A meeting that fails again stays quarantined as it is, keeping
+		// its retry count; one deferred or interrupted is retried next run.
+		outcome, err := p.attempt(ctx, meeting, body)
+		switch outcome {
+		case outcomeQuarantined:
 			output.Failure("Retry failed: %s - %s", entry.VideoID, err)
-		} else {
+			return
+		case outcomeProcessed:
 			output.Success("Retry succeeded: %s", entry.VideoID)
 			if err := p.quarantine.Remove(body, entry.VideoID); err != nil {
 				slog.Warn("failed to remove from quarantine", "error", err)
 			}
-			stats.Processed++
 		}
-	}
+
+		mu.Lock()
+		defer mu.Unlock()
+		outcome.record(stats)
+	})
 }
@@ -2,9 +2,12 @@ package service_test
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"net/http"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -12,6 +15,7 @@ import (
 	"github.com/AvogadroSG1/civic-summary/internal/domain"
 	"github.com/AvogadroSG1/civic-summary/internal/executor"
 	"github.com/AvogadroSG1/civic-summary/internal/service"
+	"github.com/AvogadroSG1/civic-summary/internal/source"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -105,10 +109,12 @@ func pipelineConfig(t *testing.T) *config.Config {
 				PlaylistID:      "PLJXxCe9GA2fEf4TIVzTH2O-kFJlS8VVgQ",
 				OutputSubdir:    "Hagerstown Town Council - Citizen Summary",
 				FilenamePattern: "Hagerstown-City-Council-{{.MeetingDate}}-Citizen-Summary",
-				TitleDateRegex:  `^([A-Z][a-z]+ \d{1,2},? \d{4})`,
+				TitleDateRegex:  domain.TitlePatterns{{Regex: `^([A-Z][a-z]+ \d{1,2},? \d{4})`}},
 				Tags:            []string{"City-Council", "Hagerstown"},
 				PromptTemplate:  "hagerstown.prompt.tmpl",
 				Author:          "Peter O'Connor",
+				// Fixture meetings are from 2025; open the default window to them.
+				BackfillFrom: "2025-01-01",
 			},
 		},
 	}
@@ -123,24 +129,30 @@ func buildPipelineOrchestrator(t *testing.T, cfg *config.Config, mock *executor.
 
 	tmplDir := setupTemplateDir(t)
 
-	discovery := service.NewDiscoveryService(ytdlp, cfg)
-	transcription := service.NewTranscriptionService(ytdlp, nil)
-	analysis := service.NewAnalysisService(stubClientFor(model), tmplDir)
+	discovery := service.NewDiscoveryService(youtubeSources(ytdlp), cfg)
+	transcription := service.NewTranscriptionService(youtubeSources(ytdlp), nil)
+	diarization := service.NewDiarizationService(youtubeSources(ytdlp), nil)
+	agendas := service.NewAgendaService(youtubeSources(ytdlp), nil, http.DefaultClient)
+	analysis := service.NewAnalysisService(stubClientFor(model), unknownContextWindow, tmplDir)
 	crossref := service.NewCrossReferenceService(cfg)
+	timestamps := service.NewTimestampLinkService()
 	validation := service.NewValidationService()
 	quarantine := service.NewQuarantineService(cfg)
 	index := service.NewIndexService(cfg)
+	checkpoints := service.NewCheckpointService(cfg)
+	deferrals := service.NewDeferralService(cfg)
 
 	return service.NewPipelineOrchestrator(
-		discovery, transcription, analysis, crossref,
-		validation, quarantine, index, cfg,
+		discovery, transcription, diarization, agendas, analysis, crossref,
+		timestamps, validation, quarantine, index, checkpoints, deferrals, cfg,
 	)
 }
 
-// mockDiscoveryResponse sets up the mock to return playlist entries for a body.
-func mockDiscoveryResponse(mock *executor.MockCommander, playlistOutput string) {
+// mockDiscoveryResponse sets up the mock to return playlist entries, given as
+// "id|title" lines, for a body.
+func mockDiscoveryResponse(mock *executor.MockCommander, listing string) {
 	// ListPlaylist uses DefaultResult since the args include the full playlist URL.
-	mock.DefaultResult = &executor.CommandResult{Stdout: playlistOutput}
+	mock.DefaultResult = &executor.CommandResult{Stdout: playlist(listing)}
 }
 
 func TestPipelineOrchestrator_ProcessBody_DryRun(t *testing.T) {
@@ -153,7 +165,7 @@ func TestPipelineOrchestrator_ProcessBody_DryRun(t *testing.T) {
 	pipeline := buildPipelineOrchestrator(t, cfg, mock, &stubClient{response: validSummaryContent()})
 	body, _ := cfg.GetBody("hagerstown")
 
-	stats, err := pipeline.ProcessBody(context.Background(), body, true)
+	stats, err := pipeline.ProcessBody(context.Background(), body, service.ProcessOptions{DryRun: true})
 	require.NoError(t, err)
 
 	assert.Equal(t, 2, stats.Discovered)
@@ -169,7 +181,7 @@ func TestPipelineOrchestrator_ProcessBody_Success(t *testing.T) {
 
 	// Discovery: 1 meeting.
 	mock.DefaultResult = &executor.CommandResult{
-		Stdout: "abc123|February 04, 2025 | Mayor & Council Regular Session\n",
+		Stdout: playlist("abc123|February 04, 2025 | Mayor & Council Regular Session\n"),
 	}
 
 	// Captions: list-subs shows available.
@@ -191,17 +203,18 @@ func TestPipelineOrchestrator_ProcessBody_Success(t *testing.T) {
 
 	pipeline := buildPipelineOrchestrator(t, cfg, mock, &stubClient{response: validSummaryContent()})
 
-	stats, err := pipeline.ProcessBody(context.Background(), body, false)
+	stats, err := pipeline.ProcessBody(context.Background(), body, service.ProcessOptions{})
 	require.NoError(t, err)
 
 	assert.Equal(t, 1, stats.Discovered)
 	assert.Equal(t, 1, stats.Processed)
 	assert.Equal(t, 0, stats.Failed)
 
-	// Verify the summary file was written.
+	// Verify the summary file was written, with timestamps linked to the video.
 	summaryPath := filepath.Join(dateDir, "Hagerstown-City-Council-2025-02-04-Citizen-Summary.md")
-	_, err = os.Stat(summaryPath)
-	assert.NoError(t, err, "summary file should exist")
+	written, err := os.ReadFile(summaryPath)
+	require.NoError(t, err, "summary file should exist")
+	assert.Contains(t, string(written), "[00:01:00-00:05:00](https://www.youtube.com/watch?v=abc123&t=60s)")
 }
 
 func TestPipelineOrchestrator_ProcessBody_AnalysisFails_Quarantined(t *testing.T) {
@@ -212,7 +225,7 @@ func TestPipelineOrchestrator_ProcessBody_AnalysisFails_Quarantined(t *testing.T
 
 	// Discovery: 1 meeting.
 	mock.DefaultResult = &executor.CommandResult{
-		Stdout: "abc123|February 04, 2025 | Mayor & Council Regular Session\n",
+		Stdout: playlist("abc123|February 04, 2025 | Mayor & Council Regular Session\n"),
 	}
 
 	// Captions available.
@@ -232,7 +245,7 @@ func TestPipelineOrchestrator_ProcessBody_AnalysisFails_Quarantined(t *testing.T
 
 	pipeline := buildPipelineOrchestrator(t, cfg, mock, &stubClient{err: fmt.Errorf("API rate limit exceeded")})
 
-	stats, err := pipeline.ProcessBody(context.Background(), body, false)
+	stats, err := pipeline.ProcessBody(context.Background(), body, service.ProcessOptions{})
 	require.NoError(t, err) // ProcessBody itself doesn't fail, individual meetings do.
 
 	assert.Equal(t, 1, stats.Discovered)
@@ -245,6 +258,12 @@ func TestPipelineOrchestrator_ProcessBody_AnalysisFails_Quarantined(t *testing.T
 	require.NoError(t, qErr)
 	assert.Len(t, qEntries, 1)
 	assert.Equal(t, "abc123", qEntries[0].VideoID)
+
+	// The ledger leaves the meeting to the quarantine retry.
+	entry, ok, err := service.NewLedgerService(cfg).Get(body, "abc123")
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, domain.LedgerQuarantined, entry.Status)
 }
 
 func TestPipelineOrchestrator_ProcessAll_MultipleBodies(t *testing.T) {
@@ -260,7 +279,7 @@ func TestPipelineOrchestrator_ProcessAll_MultipleBodies(t *testing.T) {
 				PlaylistID:      "PLtest1",
 				OutputSubdir:    "Hagerstown",
 				FilenamePattern: "Hagerstown-{{.MeetingDate}}",
-				TitleDateRegex:  `^([A-Z][a-z]+ \d{1,2},? \d{4})`,
+				TitleDateRegex:  domain.TitlePatterns{{Regex: `^([A-Z][a-z]+ \d{1,2},? \d{4})`}},
 				Tags:            []string{"City-Council"},
 				PromptTemplate:  "hagerstown.prompt.tmpl",
 			},
@@ -270,7 +289,7 @@ func TestPipelineOrchestrator_ProcessAll_MultipleBodies(t *testing.T) {
 				PlaylistID:      "PLtest2",
 				OutputSubdir:    "BOCC",
 				FilenamePattern: "BOCC-{{.MeetingDate}}",
-				TitleDateRegex:  `- ([A-Z][a-z]+ \d{1,2}, \d{4})`,
+				TitleDateRegex:  domain.TitlePatterns{{Regex: `- ([A-Z][a-z]+ \d{1,2}, \d{4})`}},
 				Tags:            []string{"BOCC"},
 				PromptTemplate:  "bocc.prompt.tmpl",
 			},
@@ -283,7 +302,7 @@ func TestPipelineOrchestrator_ProcessAll_MultipleBodies(t *testing.T) {
 
 	pipeline := buildPipelineOrchestrator(t, cfg, mock, &stubClient{response: validSummaryContent()})
 
-	allStats, err := pipeline.ProcessAll(context.Background(), true)
+	allStats, err := pipeline.ProcessAll(context.Background(), service.ProcessOptions{DryRun: true})
 	require.NoError(t, err)
 
 	assert.Len(t, allStats, 2, "should have stats for both bodies")
@@ -305,7 +324,7 @@ func TestPipelineOrchestrator_ProcessAll_OneBodyFails(t *testing.T) {
 				PlaylistID:      "PLgood",
 				OutputSubdir:    "Hagerstown",
 				FilenamePattern: "Hagerstown-{{.MeetingDate}}",
-				TitleDateRegex:  `^([A-Z][a-z]+ \d{1,2},? \d{4})`,
+				TitleDateRegex:  domain.TitlePatterns{{Regex: `^([A-Z][a-z]+ \d{1,2},? \d{4})`}},
 				Tags:            []string{"City-Council"},
 				PromptTemplate:  "hagerstown.prompt.tmpl",
 			},
@@ -315,7 +334,7 @@ func TestPipelineOrchestrator_ProcessAll_OneBodyFails(t *testing.T) {
 				PlaylistID:      "PLbad",
 				OutputSubdir:    "BOCC",
 				FilenamePattern: "BOCC-{{.MeetingDate}}",
-				TitleDateRegex:  `- ([A-Z][a-z]+ \d{1,2}, \d{4})`,
+				TitleDateRegex:  domain.TitlePatterns{{Regex: `- ([A-Z][a-z]+ \d{1,2}, \d{4})`}},
 				Tags:            []string{"BOCC"},
 				PromptTemplate:  "bocc.prompt.tmpl",
 			},
@@ -331,7 +350,7 @@ func TestPipelineOrchestrator_ProcessAll_OneBodyFails(t *testing.T) {
 
 	pipeline := buildPipelineOrchestrator(t, cfg, mock, &stubClient{response: validSummaryContent()})
 
-	allStats, err := pipeline.ProcessAll(context.Background(), false)
+	allStats, err := pipeline.ProcessAll(context.Background(), service.ProcessOptions{})
 	require.NoError(t, err)
 
 	// Both bodies should have stats entries (graceful degradation).
@@ -380,7 +399,7 @@ func TestPipelineOrchestrator_RetryQuarantined_Success(t *testing.T) {
 	// The model returns a valid summary.
 	pipeline := buildPipelineOrchestrator(t, cfg, mock, &stubClient{response: validSummaryContent()})
 
-	stats, err := pipeline.ProcessBody(context.Background(), body, false)
+	stats, err := pipeline.ProcessBody(context.Background(), body, service.ProcessOptions{})
 	require.NoError(t, err)
 
 	assert.Equal(t, 0, stats.Discovered)
@@ -392,3 +411,634 @@ func TestPipelineOrchestrator_RetryQuarantined_Success(t *testing.T) {
 	require.NoError(t, err)
 	assert.Empty(t, entries)
 }
+
+func TestPipelineOrchestrator_RetryQuarantined_NumbersOnlyReleasedIngests(t *testing.T) {
+	cfg := pipelineConfig(t)
+	body, _ := cfg.GetBody("hagerstown")
+	date := time.Date(2025, 1, 14, 0, 0, 0, 0, time.UTC)
+
+	qSvc := service.NewQuarantineService(cfg)
+	listed := domain.Meeting{VideoID: "listed1", MeetingDate: date, BodySlug: body.Slug}
+	require.NoError(t, qSvc.Quarantine(body, listed, "previous failure", "", ""))
+	file := filepath.Join(t.TempDir(), "January 14, 2025 Council.mp4")
+	require.NoError(t, os.WriteFile(file, []byte("not really video"), 0o644))
+	ingest := domain.Meeting{VideoID: "ingest1", VideoURL: file, MeetingDate: date, BodySlug: body.Slug}
+	require.NoError(t, qSvc.Quarantine(body, ingest, "previous failure", "", ""))
+	undated := domain.Meeting{VideoID: "undated1", VideoURL: file, BodySlug: body.Slug}
+	require.NoError(t, qSvc.Quarantine(body, undated, "previous failure", "", ""))
+
+	mock := executor.NewMockCommander()
+	mock.DefaultResult = &executor.CommandResult{Stdout: ""}
+	pipeline := buildPipelineOrchestrator(t, cfg, mock, &stubClient{response: validSummaryContent()})
+	_, err := pipeline.ProcessBody(context.Background(), body, service.ProcessOptions{})
+	require.NoError(t, err)
+
+	data, err := os.ReadFile(cfg.SequencesPath(body))
+	require.NoError(t, err)
+	var orders map[string][]string
+	require.NoError(t, json.Unmarshal(data, &orders))
+	assert.Equal(t, map[string][]string{"2025-01-14": {"ingest1"}}, orders,
+		"only the ingest that gave its number back is numbered again")
+}
+
+func TestPipelineOrchestrator_RetryQuarantined_SkipsCaptionsWait(t *testing.T) {
+	cfg := pipelineConfig(t)
+	cfg.Captions = config.CaptionsConfig{GraceHours: 24, RecheckMinutes: 60}
+	body, _ := cfg.GetBody("hagerstown")
+
+	qSvc := service.NewQuarantineService(cfg)
+	meeting := domain.Meeting{
+		VideoID:     "abc123",
+		MeetingDate: time.Date(2025, 2, 4, 0, 0, 0, 0, time.UTC),
+		BodySlug:    body.Slug,
+	}
+	require.NoError(t, qSvc.Quarantine(body, meeting, "previous failure", "", ""))
+
+	mock := executor.NewMockCommander()
+	mock.DefaultResult = &executor.CommandResult{Stdout: ""}
+	mock.OnCommand("yt-dlp --list-subs https://www.youtube.com/watch?v=abc123",
+		&executor.CommandResult{Stdout: "abc123 has no subtitles"}, nil)
+	pipeline := buildPipelineOrchestrator(t, cfg, mock, &stubClient{response: validSummaryContent()})
+
+	stats, err := pipeline.ProcessBody(context.Background(), body, service.ProcessOptions{})
+	require.NoError(t, err)
+
+	// The retry goes straight to Whisper, which is not configured here, so
+	// the meeting stays quarantined rather than waiting for captions again.
+	assert.Equal(t, 0, stats.Deferred)
+	_, ok, err := service.NewDeferralService(cfg).Get(body, "abc123")
+	require.NoError(t, err)
+	assert.False(t, ok, "a quarantined meeting has had its wait")
+	entries, err := qSvc.ListQuarantined(body)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, 1, entries[0].RetryCount)
+}
+
+// countCalls returns how many recorded mock calls start with prefix.
+func countCalls(mock *executor.MockCommander, prefix string) int {
+	n := 0
+	for _, call := range mock.Calls {
+		if strings.HasPrefix(call, prefix) {
+			n++
+		}
+	}
+	return n
+}
+
+// TestPipelineOrchestrator_RetryResumesFromCheckpoint checks that a flaky model
+// call does not cause captions to be fetched again, either on the in-run retry
+// or on the later quarantine retry.
+func TestPipelineOrchestrator_RetryResumesFromCheckpoint(t *testing.T) {
+	cfg := pipelineConfig(t)
+	body, _ := cfg.GetBody("hagerstown")
+
+	mock := executor.NewMockCommander()
+	mock.DefaultResult = &executor.CommandResult{
+		Stdout: playlist("abc123|February 04, 2025 | Mayor & Council Regular Session\n"),
+	}
+	listSubsKey := "yt-dlp --list-subs https://www.youtube.com/watch?v=abc123"
+	mock.OnCommand(listSubsKey, &executor.CommandResult{
+		Stdout: "Available automatic captions\nen  English",
+	}, nil)
+
+	dateDir := filepath.Join(cfg.FinalizedDir(body), "20250204")
+	require.NoError(t, os.MkdirAll(dateDir, 0o755))
+	require.NoError(t, os.WriteFile(
+		filepath.Join(dateDir, "abc123.en.srt"),
+		[]byte(generateWords(600)), 0o644,
+	))
+
+	// First run: the model fails on every attempt.
+	failing := buildPipelineOrchestrator(t, cfg, mock, &stubClient{err: fmt.Errorf("API rate limit exceeded")})
+	stats, err := failing.ProcessBody(context.Background(), body, service.ProcessOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, 1, stats.Quarantined)
+	assert.Equal(t, 1, countCalls(mock, "yt-dlp --list-subs"), "retries must reuse the checkpointed transcript")
+
+	state, err := service.NewCheckpointService(cfg).Load(body, domain.Meeting{VideoID: "abc123", BodySlug: body.Slug})
+	require.NoError(t, err)
+	assert.Equal(t, domain.StageAnalysis, state.NextStage())
+	assert.Contains(t, state.Error, "rate limit")
+
+	// Second run: the quarantine retry resumes at analysis and succeeds.
+	mock.DefaultResult = &executor.CommandResult{Stdout: ""}
+	model := &stubClient{response: validSummaryContent()}
+	pipeline := buildPipelineOrchestrator(t, cfg, mock, model)
+
+	stats, err = pipeline.ProcessBody(context.Background(), body, service.ProcessOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, 1, stats.Processed)
+	assert.Equal(t, 1, countCalls(mock, "yt-dlp --list-subs"))
+	assert.Contains(t, model.lastPrompt(t), "word word word")
+
+	_, err = os.Stat(filepath.Join(dateDir, "Hagerstown-City-Council-2025-02-04-Citizen-Summary.md"))
+	assert.NoError(t, err, "summary file should exist")
+
+	// State is cleared once the summary is written.
+	_, err = os.Stat(filepath.Join(cfg.StateDir(body), "abc123"))
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestPipelineOrchestrator_ProcessBody_ConcurrentMeetings(t *testing.T) {
+	cfg := pipelineConfig(t)
+	cfg.Concurrency = config.ConcurrencyConfig{Meetings: 3, Transcriptions: 2}
+	body, _ := cfg.GetBody("hagerstown")
+
+	mock := executor.NewMockCommander()
+	mockDiscoveryResponse(mock, "aaa111|February 04, 2025 | Regular Session\n"+
+		"bbb222|February 11, 2025 | Regular Session\n"+
+		"ccc333|February 18, 2025 | Regular Session\n")
+
+	for id, folder := range map[string]string{"aaa111": "20250204", "bbb222": "20250211", "ccc333": "20250218"} {
+		mock.OnCommand(fmt.Sprintf("yt-dlp --list-subs https://www.youtube.com/watch?v=%s", id),
+			&executor.CommandResult{Stdout: "Available automatic captions\nen  English"}, nil)
+		dateDir := filepath.Join(cfg.FinalizedDir(body), folder)
+		require.NoError(t, os.MkdirAll(dateDir, 0o755))
+		require.NoError(t, os.WriteFile(filepath.Join(dateDir, id+".en.srt"), []byte(generateWords(600)), 0o644))
+	}
+
+	model := &stubClient{response: validSummaryContent()}
+	pipeline := buildPipelineOrchestrator(t, cfg, mock, model)
+
+	stats, err := pipeline.ProcessBody(context.Background(), body, service.ProcessOptions{})
+	require.NoError(t, err)
+
+	assert.Equal(t, 3, stats.Discovered)
+	assert.Equal(t, 3, stats.Processed)
+	assert.Equal(t, 0, stats.Failed)
+	assert.Len(t, model.prompts, 3)
+}
+
+func TestPipelineOrchestrator_ProcessBody_CancelledDoesNotQuarantine(t *testing.T) {
+	cfg := pipelineConfig(t)
+	body, _ := cfg.GetBody("hagerstown")
+
+	mock := executor.NewMockCommander()
+	mockDiscoveryResponse(mock, "abc123|February 04, 2025 | Mayor & Council Regular Session\n")
+
+	pipeline := buildPipelineOrchestrator(t, cfg, mock, &stubClient{response: validSummaryContent()})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	stats, err := pipeline.ProcessBody(ctx, body, service.ProcessOptions{})
+	require.ErrorIs(t, err, context.Canceled)
+
+	assert.Equal(t, 0, stats.Processed)
+	assert.Equal(t, 0, stats.Quarantined)
+
+	entries, qErr := service.NewQuarantineService(cfg).ListQuarantined(body)
+	require.NoError(t, qErr)
+	assert.Empty(t, entries, "an interrupted run must not quarantine meetings")
+}
+
+// singleMeetingMock returns a mock discovering the abc123 meeting with a
+// captions file already on disk, and the meeting's date directory.
+func singleMeetingMock(t *testing.T, cfg *config.Config, body domain.Body) (*executor.MockCommander, string) {
+	t.Helper()
+	mock := executor.NewMockCommander()
+	mock.DefaultResult = &executor.CommandResult{
+		Stdout: playlist("abc123|February 04, 2025 | Mayor & Council Regular Session\n"),
+	}
+	mock.OnCommand("yt-dlp --list-subs https://www.youtube.com/watch?v=abc123", &executor.CommandResult{
+		Stdout: "Available automatic captions\nen  English",
+	}, nil)
+
+	dateDir := filepath.Join(cfg.FinalizedDir(body), "20250204")
+	require.NoError(t, os.MkdirAll(dateDir, 0o755))
+	require.NoError(t, os.WriteFile(
+		filepath.Join(dateDir, "abc123.en.srt"),
+		[]byte(generateWords(600)), 0o644,
+	))
+	return mock, dateDir
+}
+
+// summaryMissingSection is a summary that fails validation only for lacking
+// its "## 3." section.
+func summaryMissingSection() string {
+	return strings.Replace(validSummaryContent(), "## 3. Actions Taken", "## Actions Taken", 1)
+}
+
+func TestPipelineOrchestrator_ProcessBody_RepairsRejectedSummary(t *testing.T) {
+	cfg := pipelineConfig(t)
+	cfg.RepairRounds = 2
+	body, _ := cfg.GetBody("hagerstown")
+	mock, dateDir := singleMeetingMock(t, cfg, body)
+
+	model := &stubClient{
+		responses: []string{summaryMissingSection(), summaryMissingSection()},
+		response:  validSummaryContent(),
+	}
+	pipeline := buildPipelineOrchestrator(t, cfg, mock, model)
+
+	stats, err := pipeline.ProcessBody(context.Background(), body, service.ProcessOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, 1, stats.Processed)
+	assert.Equal(t, 0, stats.Failed)
+
+	// One analysis plus two repair rounds, without retrying from scratch.
+	require.Len(t, model.prompts, 3)
+	repair := model.lastPrompt(t)
+	assert.Contains(t, repair, "(repair round 2)")
+	assert.Contains(t, repair, "missing required section: ## 3.")
+	assert.Contains(t, repair, "## Actions Taken", "the rejected draft is sent back")
+	assert.NotContains(t, repair, "&t=60s", "the draft is repaired before timestamp links are added")
+
+	written, err := os.ReadFile(filepath.Join(dateDir, "Hagerstown-City-Council-2025-02-04-Citizen-Summary.md"))
+	require.NoError(t, err)
+	assert.Contains(t, string(written), "## 3. Actions Taken")
+
+	// Each rejected draft is kept with the errors it failed on.
+	for _, round := range []string{"round-1.md", "round-2.md"} {
+		kept, err := os.ReadFile(filepath.Join(cfg.RepairDir(body), "abc123", round))
+		require.NoError(t, err, round)
+		assert.True(t, strings.HasPrefix(string(kept), "<!-- rejected by validation:\n[ERROR] missing required section: ## 3.\n-->\n---\n"))
+	}
+}
+
+func TestPipelineOrchestrator_ProcessBody_RepairRoundsExhausted(t *testing.T) {
+	cfg := pipelineConfig(t)
+	cfg.RepairRounds = 1
+	body, _ := cfg.GetBody("hagerstown")
+	mock, _ := singleMeetingMock(t, cfg, body)
+
+	model := &stubClient{response: summaryMissingSection()}
+	pipeline := buildPipelineOrchestrator(t, cfg, mock, model)
+
+	stats, err := pipeline.ProcessBody(context.Background(), body, service.ProcessOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, 1, stats.Quarantined)
+
+	// The two attempts and the two quarantine retries each analyze once and
+	// repair once.
+	assert.Len(t, model.prompts, 8)
+
+	state, err := service.NewCheckpointService(cfg).Load(body, domain.Meeting{VideoID: "abc123", BodySlug: body.Slug})
+	require.NoError(t, err)
+	assert.Equal(t, domain.StageAnalysis, state.NextStage(), "a rejected summary is analyzed again on retry")
+	assert.Contains(t, state.Error, "validation failed")
+
+	// The last round's draft, which failed too, is kept beside the first.
+	for _, round := range []string{"round-1.md", "round-2.md"} {
+		kept, err := os.ReadFile(filepath.Join(cfg.RepairDir(body), "abc123", round))
+		require.NoError(t, err, round)
+		assert.True(t, strings.HasPrefix(string(kept), "<!-- rejected by validation:\n[ERROR] missing required section: ## 3.\n-->\n"), round)
+	}
+}
+
+func TestPipelineOrchestrator_ProcessBody_RepairSkipsUnrepairableErrors(t *testing.T) {
+	cfg := pipelineConfig(t)
+	cfg.RepairRounds = 2
+	body, _ := cfg.GetBody("hagerstown")
+	// The transcript is filler, so the summary's amounts and tallies are not
+	// found in it.
+	body.Validation = &domain.ValidationRules{Checks: map[string]domain.RuleSeverity{domain.CheckFaithfulness: domain.RuleError}}
+	mock, _ := singleMeetingMock(t, cfg, body)
+
+	model := &stubClient{
+		responses: []string{summaryMissingSection()},
+		response:  validSummaryContent(),
+	}
+	pipeline := buildPipelineOrchestrator(t, cfg, mock, model)
+
+	stats, err := pipeline.ProcessBody(context.Background(), body, service.ProcessOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, 1, stats.Quarantined)
+
+	// The missing section is repaired once; the faithfulness errors left
+	// after it are not sent back, and no further round is spent on them.
+	var repairs []string
+	for _, prompt := range model.prompts {
+		if strings.Contains(prompt, "repair round") {
+			repairs = append(repairs, prompt)
+		}
+	}
+	require.Len(t, repairs, 1)
+	assert.Contains(t, repairs[0], "missing required section: ## 3.")
+	assert.NotContains(t, repairs[0], "not found in the transcript")
+}
+
+func TestPipelineOrchestrator_ProcessMeeting_LocalTranscript(t *testing.T) {
+	cfg := pipelineConfig(t)
+	body, _ := cfg.GetBody("hagerstown")
+	mock := executor.NewMockCommander()
+
+	file := filepath.Join(t.TempDir(), "February 04, 2025 Council.txt")
+	require.NoError(t, os.WriteFile(file, []byte(generateWords(600)), 0o644))
+	video, err := source.LocalVideo(file)
+	require.NoError(t, err)
+
+	discovery := service.NewDiscoveryService(youtubeSources(executor.NewYtDlpExecutor(mock, "yt-dlp")), cfg)
+	meeting, err := discovery.MeetingFor(video, body)
+	require.NoError(t, err)
+	assert.Equal(t, "2025-02-04", meeting.ISODate())
+	assert.False(t, discovery.IsProcessed(meeting, body))
+
+	pipeline := buildPipelineOrchestrator(t, cfg, mock, &stubClient{response: validSummaryContent()})
+	require.NoError(t, pipeline.ProcessMeeting(context.Background(), meeting, body))
+
+	assert.Empty(t, mock.Calls, "a local transcript needs no downloads")
+	summaryPath := discovery.SummaryPath(meeting, body)
+	assert.FileExists(t, summaryPath)
+	assert.FileExists(t, filepath.Join(cfg.FinalizedDir(body), "20250204", video.ID+".txt"), "the transcript is copied beside the summary")
+	assert.FileExists(t, file, "the original is left in place")
+
+	entries, err := service.NewLedgerService(cfg).List(body)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, video.ID, entries[0].VideoID)
+	assert.Equal(t, file, entries[0].VideoURL)
+	assert.Equal(t, summaryPath, entries[0].OutputPath)
+	assert.Equal(t, domain.LedgerDone, entries[0].Status)
+
+	// Moving the summary does not make the file look new again.
+	require.NoError(t, os.Remove(summaryPath))
+	assert.True(t, discovery.IsProcessed(meeting, body))
+}
+
+// TestPipelineOrchestrator_ProcessMeeting_SameDate ingests two recordings of
+// one date, as the ingest command does: the second is numbered after the
+// first, whose summary is renamed to match, so neither is overwritten.
+func TestPipelineOrchestrator_ProcessMeeting_SameDate(t *testing.T) {
+	cfg := pipelineConfig(t)
+	body, _ := cfg.GetBody("hagerstown")
+	mock := executor.NewMockCommander()
+	discovery := service.NewDiscoveryService(youtubeSources(executor.NewYtDlpExecutor(mock, "yt-dlp")), cfg)
+	pipeline := buildPipelineOrchestrator(t, cfg, mock, &stubClient{response: validSummaryContent()})
+
+	dir := t.TempDir()
+	var videoIDs []string
+	for _, name := range []string{"February 04, 2025 Council.txt", "February 04, 2025 Council Workshop.txt"} {
+		file := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(file, []byte(generateWords(600)), 0o644))
+		video, err := source.LocalVideo(file)
+		require.NoError(t, err)
+
+		meeting, err := discovery.MeetingFor(video, body)
+		require.NoError(t, err)
+		meeting, _, err = discovery.AssignSequence(meeting, body)
+		require.NoError(t, err)
+		require.NoError(t, pipeline.ProcessMeeting(context.Background(), meeting, body))
+		videoIDs = append(videoIDs, video.ID)
+	}
+
+	dateDir := filepath.Join(cfg.FinalizedDir(body), "20250204")
+	first := filepath.Join(dateDir, "Hagerstown-City-Council-2025-02-04-Citizen-Summary-1.md")
+	second := filepath.Join(dateDir, "Hagerstown-City-Council-2025-02-04-Citizen-Summary-2.md")
+	assert.FileExists(t, first, "the first summary is renumbered")
+	assert.FileExists(t, second)
+	assert.NoFileExists(t, filepath.Join(dateDir, "Hagerstown-City-Council-2025-02-04-Citizen-Summary.md"))
+
+	ledger := service.NewLedgerService(cfg)
+	for i, want := range []string{first, second} {
+		entry, ok, err := ledger.Get(body, videoIDs[i])
+		require.NoError(t, err)
+		require.True(t, ok)
+		assert.Equal(t, want, entry.OutputPath)
+		assert.Equal(t, i+1, entry.Sequence)
+	}
+}
+
+func TestPipelineOrchestrator_ProcessMeeting_SameDateFailureReleasesSequence(t *testing.T) {
+	cfg := pipelineConfig(t)
+	body, _ := cfg.GetBody("hagerstown")
+	mock := executor.NewMockCommander()
+	discovery := service.NewDiscoveryService(youtubeSources(executor.NewYtDlpExecutor(mock, "yt-dlp")), cfg)
+	pipeline := buildPipelineOrchestrator(t, cfg, mock, &stubClient{response: validSummaryContent()})
+
+	dir := t.TempDir()
+	ingest := func(name string, content []byte) (domain.Meeting, bool, error) {
+		file := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(file, content, 0o644))
+		video, err := source.LocalVideo(file)
+		require.NoError(t, err)
+		meeting, err := discovery.MeetingFor(video, body)
+		require.NoError(t, err)
+		meeting, assigned, err := discovery.AssignSequence(meeting, body)
+		require.NoError(t, err)
+		return meeting, assigned, pipeline.ProcessMeeting(context.Background(), meeting, body)
+	}
+
+	first, _, err := ingest("February 04, 2025 Council.txt", []byte(generateWords(600)))
+	require.NoError(t, err)
+
+	// No whisper is configured, so the second recording cannot be transcribed.
+	second, assigned, err := ingest("February 04, 2025 Council Workshop.mp4", []byte("not really video"))
+	require.Error(t, err)
+	require.True(t, assigned)
+	require.NoError(t, discovery.ReleaseSequence(second, body))
+
+	dateDir := filepath.Join(cfg.FinalizedDir(body), "20250204")
+	solo := filepath.Join(dateDir, "Hagerstown-City-Council-2025-02-04-Citizen-Summary.md")
+	assert.FileExists(t, solo, "the first summary keeps its path")
+	assert.NoFileExists(t, filepath.Join(dateDir, "Hagerstown-City-Council-2025-02-04-Citizen-Summary-1.md"))
+	assert.Equal(t, 0, discovery.CurrentSequence(first, body))
+
+	entry, ok, err := service.NewLedgerService(cfg).Get(body, first.VideoID)
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, solo, entry.OutputPath)
+	assert.Equal(t, 0, entry.Sequence)
+}
+
+func TestPipelineOrchestrator_ProcessMeeting_Quarantined(t *testing.T) {
+	cfg := pipelineConfig(t)
+	body, _ := cfg.GetBody("hagerstown")
+
+	file := filepath.Join(t.TempDir(), "February 04, 2025 Council.mp4")
+	require.NoError(t, os.WriteFile(file, []byte("not really video"), 0o644))
+	video, err := source.LocalVideo(file)
+	require.NoError(t, err)
+	meeting := domain.Meeting{VideoID: video.ID, VideoURL: video.URL, MeetingDate: video.Published, BodySlug: body.Slug}
+
+	// No whisper is configured, so the recording cannot be transcribed.
+	pipeline := buildPipelineOrchestrator(t, cfg, executor.NewMockCommander(), &stubClient{response: validSummaryContent()})
+	err = pipeline.ProcessMeeting(context.Background(), meeting, body)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "quarantined")
+	entries, err := service.NewQuarantineService(cfg).ListQuarantined(body)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, file, entries[0].VideoURL, "the file path is kept for retry")
+}
+
+func TestPipelineOrchestrator_ProcessBody_MeetingTypeOverrides(t *testing.T) {
+	cfg := pipelineConfig(t)
+	body, _ := cfg.GetBody("hagerstown")
+	body.MeetingTypes = []domain.MeetingTypeRule{{
+		Name:           "Regular Session",
+		Tag:            "Voting-Meeting",
+		PromptTemplate: "bocc.prompt.tmpl",
+		Validation: &domain.ValidationRules{
+			RequiredHeadings: []domain.TextRule{{Text: "## 1."}, {Text: "## 2."}},
+		},
+	}}
+	cfg.Bodies["hagerstown"] = body
+	mock, dateDir := singleMeetingMock(t, cfg, body)
+
+	model := &stubClient{response: summaryMissingSection()}
+	pipeline := buildPipelineOrchestrator(t, cfg, mock, model)
+
+	stats, err := pipeline.ProcessBody(context.Background(), body, service.ProcessOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, 1, stats.Processed, "the type's validation rules apply")
+
+	prompt := model.lastPrompt(t)
+	assert.Contains(t, prompt, "Commissioners", "the type's prompt template is used")
+	assert.Contains(t, prompt, "- Voting-Meeting")
+	assert.FileExists(t, filepath.Join(dateDir, "Hagerstown-City-Council-2025-02-04-Citizen-Summary.md"))
+}
+
+func TestPipelineOrchestrator_ProcessBody_ValidatesAgainstMeetingWindow(t *testing.T) {
+	cfg := pipelineConfig(t)
+	body, _ := cfg.GetBody("hagerstown")
+	// A full-length meeting of ten hours needs 2000 words; the fixture summary
+	// has fewer than 600, enough only for the 96-minute meeting itself.
+	minWords, fullLength := 2000, 600
+	body.Validation = &domain.ValidationRules{MinWords: &minWords, FullLengthMinutes: &fullLength}
+	cfg.Bodies["hagerstown"] = body
+	mock, dateDir := singleMeetingMock(t, cfg, body)
+	mock.DefaultResult = &executor.CommandResult{
+		Stdout: `{"id": "abc123", "title": "February 04, 2025 | Mayor & Council Regular Session", "duration": 36000}`,
+	}
+
+	// The recording runs on for hours after the adjournment.
+	srt := "1\n00:00:05,000 --> 00:00:10,000\nI call this meeting to order.\n\n" +
+		"2\n00:30:00,000 --> 00:31:00,000\n" + generateWords(600) + "\n\n" +
+		"3\n01:36:00,000 --> 01:36:05,000\nWe are adjourned.\n\n" +
+		"4\n09:59:00,000 --> 10:00:00,000\nClosed session notice.\n"
+	require.NoError(t, os.WriteFile(filepath.Join(dateDir, "abc123.en.srt"), []byte(srt), 0o644))
+
+	pipeline := buildPipelineOrchestrator(t, cfg, mock, &stubClient{response: validSummaryContent()})
+	stats, err := pipeline.ProcessBody(context.Background(), body, service.ProcessOptions{})
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, stats.Processed, "word counts scale to the meeting, not the recording")
+	assert.Equal(t, 0, stats.Failed)
+}
+
+func TestPipelineOrchestrator_ProcessBody_DefersPendingCaptions(t *testing.T) {
+	cfg := pipelineConfig(t)
+	cfg.Captions = config.CaptionsConfig{GraceHours: 24, RecheckMinutes: 60}
+	body, _ := cfg.GetBody("hagerstown")
+
+	mock := executor.NewMockCommander()
+	mockDiscoveryResponse(mock, "abc123|February 04, 2025 | Mayor & Council Regular Session\n")
+	listSubs := "yt-dlp --list-subs https://www.youtube.com/watch?v=abc123"
+	mock.OnCommand(listSubs, &executor.CommandResult{Stdout: "abc123 has no subtitles"}, nil)
+	pipeline := buildPipelineOrchestrator(t, cfg, mock, &stubClient{response: validSummaryContent()})
+
+	stats, err := pipeline.ProcessBody(context.Background(), body, service.ProcessOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, 1, stats.Deferred)
+	assert.Equal(t, 0, stats.Quarantined, "a meeting waiting for captions has not failed")
+	assert.Equal(t, 1, countCalls(mock, listSubs), "a pending meeting is not retried within the run")
+
+	quarantined, err := service.NewQuarantineService(cfg).ListQuarantined(body)
+	require.NoError(t, err)
+	assert.Empty(t, quarantined)
+	entry, ok, err := service.NewDeferralService(cfg).Get(body, "abc123")
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, 1, entry.Checks)
+
+	// Before its recheck time the meeting is not looked at again.
+	stats, err = pipeline.ProcessBody(context.Background(), body, service.ProcessOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, 0, stats.Discovered, "a meeting waiting out its deferral is not counted twice")
+	assert.Equal(t, 1, stats.Deferred)
+	assert.Equal(t, 1, countCalls(mock, listSubs))
+
+	// Once due, a meeting whose captions have appeared is processed and no
+	// longer deferred.
+	deferrals := service.NewDeferralService(cfg)
+	_, err = deferrals.Defer(body, domain.Meeting{VideoID: "abc123"}, "no captions", time.Now().Add(-2*time.Hour))
+	require.NoError(t, err)
+	mock.OnCommand(listSubs, &executor.CommandResult{Stdout: "Available automatic captions\nen  English"}, nil)
+	dateDir := filepath.Join(cfg.FinalizedDir(body), "20250204")
+	require.NoError(t, os.WriteFile(filepath.Join(dateDir, "abc123.en.srt"), []byte(generateWords(600)), 0o644))
+
+	stats, err = pipeline.ProcessBody(context.Background(), body, service.ProcessOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, 1, stats.Processed)
+	assert.Equal(t, 0, stats.Deferred)
+	_, ok, err = deferrals.Get(body, "abc123")
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestPipelineOrchestrator_ProcessBody_QuarantinesWhenDeferralFails(t *testing.T) {
+	cfg := pipelineConfig(t)
+	cfg.Captions = config.CaptionsConfig{GraceHours: 24, RecheckMinutes: 60}
+	body, _ := cfg.GetBody("hagerstown")
+
+	mock := executor.NewMockCommander()
+	mockDiscoveryResponse(mock, "abc123|February 04, 2025 | Mayor & Council Regular Session\n")
+	mock.OnCommand("yt-dlp --list-subs https://www.youtube.com/watch?v=abc123",
+		&executor.CommandResult{Stdout: "abc123 has no subtitles"}, nil)
+	pipeline := buildPipelineOrchestrator(t, cfg, mock, &stubClient{response: validSummaryContent()})
+
+	// A directory where the deferrals file belongs makes it unreadable.
+	require.NoError(t, os.MkdirAll(cfg.DeferredPath(body), 0o755))
+
+	stats, err := pipeline.ProcessBody(context.Background(), body, service.ProcessOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, 0, stats.Deferred)
+	assert.Equal(t, 1, stats.Quarantined)
+
+	quarantined, err := service.NewQuarantineService(cfg).ListQuarantined(body)
+	require.NoError(t, err)
+	require.Len(t, quarantined, 1)
+	assert.Contains(t, quarantined[0].Error, "deferring failed")
+}
+
+func TestPipelineOrchestrator_ProcessMeeting_GraceRunsFromRelease(t *testing.T) {
+	setup := func(t *testing.T) (*config.Config, domain.Body, *service.PipelineOrchestrator) {
+		cfg := pipelineConfig(t)
+		cfg.Captions = config.CaptionsConfig{GraceHours: 24, RecheckMinutes: 60}
+		body, _ := cfg.GetBody("hagerstown")
+
+		mock := executor.NewMockCommander()
+		mock.OnCommand("yt-dlp --list-subs https://www.youtube.com/watch?v=abc123",
+			&executor.CommandResult{Stdout: "abc123 has no subtitles"}, nil)
+		return cfg, body, buildPipelineOrchestrator(t, cfg, mock, &stubClient{response: validSummaryContent()})
+	}
+	meeting := domain.Meeting{
+		VideoID:     "abc123",
+		Title:       "February 04, 2025 | Mayor & Council Regular Session",
+		MeetingDate: time.Date(2025, 2, 4, 0, 0, 0, 0, time.UTC),
+		Published:   time.Now().Add(-48 * time.Hour),
+	}
+
+	t.Run("released past the grace period", func(t *testing.T) {
+		cfg, body, pipeline := setup(t)
+		old := meeting
+		old.BodySlug = body.Slug
+		old.Details.Released = time.Now().Add(-48 * time.Hour)
+		err := pipeline.ProcessMeeting(context.Background(), old, body)
+
+		// The meeting goes straight to Whisper, which is not configured here.
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "quarantined")
+		_, ok, err := service.NewDeferralService(cfg).Get(body, "abc123")
+		require.NoError(t, err)
+		assert.False(t, ok, "a recording older than the grace period is not deferred")
+	})
+
+	t.Run("only the day known", func(t *testing.T) {
+		cfg, body, pipeline := setup(t)
+		dated := meeting
+		dated.BodySlug = body.Slug
+		err := pipeline.ProcessMeeting(context.Background(), dated, body)
+
+		// An upload date says nothing about when on that day a stream ran,
+		// so the grace period starts now.
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "deferred")
+		_, ok, err := service.NewDeferralService(cfg).Get(body, "abc123")
+		require.NoError(t, err)
+		assert.True(t, ok)
+	})
+}
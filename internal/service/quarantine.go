@@ -6,15 +6,18 @@ import (
 	"log/slog"
 	"os"
 	"path/filepath"
+	"sync"
 	"time"
 
 	"github.com/AvogadroSG1/civic-summary/internal/config"
 	"github.com/AvogadroSG1/civic-summary/internal/domain"
 )
 
-// QuarantineService manages failed meetings for later retry.
+// QuarantineService manages failed meetings for later retry. It is safe for
+// concurrent use: meetings processed in parallel share one manifest per body.
 type QuarantineService struct {
 	cfg *config.Config
+	mu  sync.Mutex
 }
 
 // NewQuarantineService creates a new QuarantineService.
@@ -24,6 +27,9 @@ func NewQuarantineService(cfg *config.Config) *QuarantineService {
 
 // Quarantine adds a failed meeting to the quarantine directory.
 func (s *QuarantineService) Quarantine(body domain.Body, meeting domain.Meeting, errMsg string, transcriptPath string, partialOutput string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
 	qDir := filepath.Join(s.cfg.QuarantineDir(body), meeting.VideoID)
 	if err := os.MkdirAll(qDir, 0o755); err != nil {
 		return fmt.Errorf("creating quarantine dir: %w", err)
@@ -32,6 +38,9 @@ func (s *QuarantineService) Quarantine(body domain.Body, meeting domain.Meeting,
 	// Write metadata.
 	entry := domain.QuarantineEntry{
 		VideoID:       meeting.VideoID,
+		VideoURL:      meeting.VideoURL,
+		Title:         meeting.Title,
+		MeetingType:   meeting.MeetingType,
 		MeetingDate:   meeting.ISODate(),
 		BodySlug:      body.Slug,
 		Sequence:      meeting.Sequence,
@@ -74,6 +83,9 @@ func (s *QuarantineService) Quarantine(body domain.Body, meeting domain.Meeting,
 
 // ListQuarantined returns all quarantined entries for a body.
 func (s *QuarantineService) ListQuarantined(body domain.Body) ([]domain.QuarantineEntry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
 	qDir := s.cfg.QuarantineDir(body)
 	entries, err := os.ReadDir(qDir)
 	if err != nil {
@@ -103,8 +115,20 @@ func (s *QuarantineService) ListQuarantined(body domain.Body) ([]domain.Quaranti
 	return result, nil
 }
 
+// Has reports whether a meeting is quarantined.
+func (s *QuarantineService) Has(body domain.Body, videoID string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, err := os.Stat(filepath.Join(s.cfg.QuarantineDir(body), videoID, "metadata.json"))
+	return err == nil
+}
+
 // IncrementRetry increases the retry count for a quarantined entry.
 func (s *QuarantineService) IncrementRetry(body domain.Body, videoID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
 	metadataPath := filepath.Join(s.cfg.QuarantineDir(body), videoID, "metadata.json")
 	data, err := os.ReadFile(metadataPath)
 	if err != nil {
@@ -122,6 +146,9 @@ func (s *QuarantineService) IncrementRetry(body domain.Body, videoID string) err
 
 // Remove removes a meeting from quarantine (typically after successful retry).
 func (s *QuarantineService) Remove(body domain.Body, videoID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
 	qDir := filepath.Join(s.cfg.QuarantineDir(body), videoID)
 	if err := os.RemoveAll(qDir); err != nil {
 		return fmt.Errorf("removing quarantine entry: %w", err)
@@ -0,0 +1,65 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/AvogadroSG1/civic-summary/internal/domain"
+	"github.com/AvogadroSG1/civic-summary/internal/markdown"
+)
+
+// RepairPromptData holds the data injected into a body's repair template,
+// which asks the model to correct a summary that failed validation.
+type RepairPromptData struct {
+	BodyName         string
+	MeetingDateHuman string
+	MeetingType      string
+	Round            int // 1-based
+	Issues           []string
+	Draft            string
+}
+
+// Repair sends a rejected summary back to the model together with the
+// validation errors it failed on and returns the corrected summary. It is far
+// cheaper than analyzing the transcript again, and fixes most near misses: a
+// missing section, a tag with spaces, or leftover meta-commentary.
+func (s *AnalysisService) Repair(ctx context.Context, meeting domain.Meeting, body domain.Body, draft string, issues []domain.ValidationIssue, round int) (domain.Summary, error) {
+	client, err := s.clientFor(body)
+	if err != nil {
+		return domain.Summary{}, fmt.Errorf("building llm client: %w", err)
+	}
+
+	messages := make([]string, len(issues))
+	for i, issue := range issues {
+		messages[i] = issue.Message
+	}
+
+	prompt, err := s.render(body.RepairTemplateName(), RepairPromptData{
+		BodyName:         body.Name,
+		MeetingDateHuman: meeting.HumanDate(),
+		MeetingType:      meeting.MeetingType,
+		Round:            round,
+		Issues:           messages,
+		Draft:            draft,
+	})
+	if err != nil {
+		return domain.Summary{}, fmt.Errorf("building repair prompt: %w", err)
+	}
+
+	slog.Info("repairing summary",
+		"video_id", meeting.VideoID,
+		"body", body.Slug,
+		"round", round,
+		"issues", len(issues),
+	)
+
+	rawOutput, err := client.Complete(ctx, prompt)
+	if err != nil {
+		return domain.Summary{}, fmt.Errorf("repair: %w", err)
+	}
+
+	return domain.Summary{
+		Content: withMeetingDuration(markdown.Sanitize(rawOutput), meeting),
+	}, nil
+}
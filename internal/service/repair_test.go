@@ -0,0 +1,47 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/AvogadroSG1/civic-summary/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAnalysisService_Repair(t *testing.T) {
+	svc, stub := newAnalysisService(t, "Here is the corrected document:\n---\ndate: 2025-02-05\n---\n# Fixed")
+	issues := []domain.ValidationIssue{
+		{Severity: domain.SeverityError, Message: "missing required section: ## 3."},
+		{Severity: domain.SeverityError, Message: "tag contains spaces: City Council"},
+	}
+
+	summary, err := svc.Repair(context.Background(), testMeeting(), testHagerstownBody(), "---\ndate: 2025-02-05\n---\n# Draft", issues, 2)
+	require.NoError(t, err)
+
+	assert.Equal(t, "---\ndate: 2025-02-05\n---\n# Fixed", summary.Content, "meta-commentary should be stripped")
+
+	prompt := stub.lastPrompt(t)
+	assert.Contains(t, prompt, "Hagerstown City Council meeting summary for February 04, 2025")
+	assert.Contains(t, prompt, "(repair round 2)")
+	assert.Contains(t, prompt, "- missing required section: ## 3.\n- tag contains spaces: City Council\n")
+	assert.Contains(t, prompt, "# Draft")
+}
+
+func TestAnalysisService_Repair_MissingTemplate(t *testing.T) {
+	svc, _ := newAnalysisService(t, "unused")
+	body := testHagerstownBody()
+	body.RepairTemplate = "missing.tmpl"
+
+	_, err := svc.Repair(context.Background(), testMeeting(), body, "draft", nil, 1)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "building repair prompt")
+}
+
+func TestAnalysisService_Repair_ModelError(t *testing.T) {
+	stub := &stubClient{err: assert.AnError}
+	svc := newChunkingService(t, stub, unknownContextWindow)
+
+	_, err := svc.Repair(context.Background(), testMeeting(), testHagerstownBody(), "draft", nil, 1)
+	require.ErrorIs(t, err, assert.AnError)
+}
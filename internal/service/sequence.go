@@ -0,0 +1,353 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/AvogadroSG1/civic-summary/internal/config"
+	"github.com/AvogadroSG1/civic-summary/internal/domain"
+)
+
+// SequenceService assigns same-date sequence numbers and remembers them, so a
+// meeting keeps its number, and its summary its file name, when another video
+// for the same date is uploaded later. Assignments are append-only per date:
+// a video seen for the first time goes after every video already numbered. It
+// is safe for concurrent use.
+type SequenceService struct {
+	cfg    *config.Config
+	ledger *LedgerService
+	mu     sync.Mutex
+}
+
+// NewSequenceService creates a new SequenceService. The ledger seeds the
+// order for dates processed before assignments were persisted.
+func NewSequenceService(cfg *config.Config, ledger *LedgerService) *SequenceService {
+	return &SequenceService{cfg: cfg, ledger: ledger}
+}
+
+// SequenceChange is a processed meeting whose summary was written under a
+// different sequence than the one it has now, typically a solo meeting that
+// must gain a "-1" suffix once a second meeting appears on its date.
+type SequenceChange struct {
+	VideoID     string
+	MeetingDate string
+	From        int
+	To          int
+	// OldPath is the summary's recorded path, and NewPath the path it is
+	// renamed to. NewPath equals OldPath for a summary that was renamed by
+	// hand, which is left where it is.
+	OldPath string
+	NewPath string
+	// Missing reports that Repair found no summary at OldPath, as when it was
+	// moved or deleted by hand. Nothing is renamed, NewPath is set back to
+	// OldPath, and the ledger keeps OldPath.
+	Missing bool
+}
+
+// String renders the change for logs and the CLI.
+func (c SequenceChange) String() string {
+	s := fmt.Sprintf("%s (%s): sequence %d -> %d", c.VideoID, c.MeetingDate, c.From, c.To)
+	if c.Missing {
+		s += " (summary not found at " + c.OldPath + ")"
+	}
+	return s
+}
+
+// Assign sets Sequence on each meeting from the body's persisted order,
+// appending videos not seen before. New videos on a date are ordered by
+// upload time when the source reports it, then by video ID. A date with no
+// persisted order starts from the ledger's entries for it, so existing
+// summaries keep their numbers.
+func (s *SequenceService) Assign(body domain.Body, meetings []domain.Meeting) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	orders, changed, err := s.assign(body, meetings)
+	if err != nil || !changed {
+		return err
+	}
+	return s.save(body, orders)
+}
+
+// Preview sets Sequence on each meeting as Assign would, without persisting
+// the order, for listings that must not change anything.
+func (s *SequenceService) Preview(body domain.Body, meetings []domain.Meeting) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, _, err := s.assign(body, meetings)
+	return err
+}
+
+// assign works out the orders that number meetings and sets their sequences,
+// reporting whether any order changed. The caller must hold mu.
+func (s *SequenceService) assign(body domain.Body, meetings []domain.Meeting) (map[string][]string, bool, error) {
+	orders, err := s.load(body)
+	if err != nil {
+		return nil, false, err
+	}
+
+	groups := make(map[string][]int)
+	for i := range meetings {
+		date := meetings[i].ISODate()
+		groups[date] = append(groups[date], i)
+	}
+
+	var processed map[string][]domain.LedgerEntry
+	changed := false
+	for date, indices := range groups {
+		order := orders[date]
+		if len(order) == 0 {
+			if processed == nil {
+				processed = s.processedByDate(body)
+			}
+			order = seedOrder(processed[date])
+		}
+
+		var fresh []domain.Meeting
+		for _, i := range indices {
+			if !slices.Contains(order, meetings[i].VideoID) {
+				fresh = append(fresh, meetings[i])
+			}
+		}
+		sort.SliceStable(fresh, func(a, b int) bool {
+			pa, pb := fresh[a].Published, fresh[b].Published
+			if pa.IsZero() != pb.IsZero() {
+				return !pa.IsZero()
+			}
+			if !pa.Equal(pb) {
+				return pa.Before(pb)
+			}
+			return fresh[a].VideoID < fresh[b].VideoID
+		})
+		for _, m := range fresh {
+			if !slices.Contains(order, m.VideoID) {
+				order = append(order, m.VideoID)
+			}
+		}
+
+		if !slices.Equal(order, orders[date]) {
+			orders[date] = order
+			changed = true
+		}
+		for _, i := range indices {
+			meetings[i].Sequence = sequenceIn(order, meetings[i].VideoID)
+		}
+	}
+	return orders, changed, nil
+}
+
+// Sequence returns the persisted sequence for a video on a date (YYYY-MM-DD),
+// and false when the video has not been assigned one.
+func (s *SequenceService) Sequence(body domain.Body, date, videoID string) (int, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	orders, err := s.load(body)
+	if err != nil {
+		return 0, false, err
+	}
+	if !slices.Contains(orders[date], videoID) {
+		return 0, false, nil
+	}
+	return sequenceIn(orders[date], videoID), true, nil
+}
+
+// Release removes a video from its date's order (YYYY-MM-DD), undoing an
+// Assign that numbered it, so the videos after it move up a place. It is for
+// a video that turned out not to belong on the date, such as a failed ingest;
+// summaries already written under the old numbers are left to Repair.
+func (s *SequenceService) Release(body domain.Body, date, videoID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	orders, err := s.load(body)
+	if err != nil {
+		return err
+	}
+	i := slices.Index(orders[date], videoID)
+	if i < 0 {
+		return nil
+	}
+	orders[date] = slices.Delete(orders[date], i, i+1)
+	if len(orders[date]) == 0 {
+		delete(orders, date)
+	}
+	return s.save(body, orders)
+}
+
+// Check lists the done ledger entries whose recorded sequence no longer
+// matches the persisted order.
+func (s *SequenceService) Check(body domain.Body) ([]SequenceChange, error) {
+	changes, _, err := s.check(body)
+	return changes, err
+}
+
+// Repair applies Check's changes: each summary still at the path its old
+// sequence gave it is renamed to the path for its new sequence, and its
+// ledger entry is updated. A summary whose new path is already taken is left
+// alone and reported in the returned error. A summary missing from its old
+// path only has its sequence updated, and its change is marked Missing.
+func (s *SequenceService) Repair(body domain.Body) ([]SequenceChange, error) {
+	changes, entries, err := s.check(body)
+	if err != nil {
+		return nil, err
+	}
+
+	var repaired []SequenceChange
+	var errs []error
+	for i, change := range changes {
+		entry := entries[i]
+		if change.NewPath != change.OldPath {
+			if _, err := os.Stat(change.NewPath); err == nil {
+				errs = append(errs, fmt.Errorf("renaming %s: %s already exists", change.OldPath, change.NewPath))
+				continue
+			}
+			switch err := os.Rename(change.OldPath, change.NewPath); {
+			case errors.Is(err, os.ErrNotExist):
+				change.NewPath, change.Missing = change.OldPath, true
+			case err != nil:
+				errs = append(errs, fmt.Errorf("renaming summary: %w", err))
+				continue
+			default:
+				entry.OutputPath = change.NewPath
+			}
+		}
+		entry.Sequence = change.To
+		if err := s.ledger.Record(body, entry); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		repaired = append(repaired, change)
+	}
+	return repaired, errors.Join(errs...)
+}
+
+// check returns the changes along with the ledger entry each one updates.
+func (s *SequenceService) check(body domain.Body) ([]SequenceChange, []domain.LedgerEntry, error) {
+	entries, err := s.ledger.List(body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	s.mu.Lock()
+	orders, err := s.load(body)
+	s.mu.Unlock()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var changes []SequenceChange
+	var changed []domain.LedgerEntry
+	for _, e := range entries {
+		order := orders[e.MeetingDate]
+		if e.Status != domain.LedgerDone || !slices.Contains(order, e.VideoID) {
+			continue
+		}
+		want := sequenceIn(order, e.VideoID)
+		if want == e.Sequence {
+			continue
+		}
+		date, err := time.Parse("2006-01-02", e.MeetingDate)
+		if err != nil {
+			continue
+		}
+
+		change := SequenceChange{
+			VideoID:     e.VideoID,
+			MeetingDate: e.MeetingDate,
+			From:        e.Sequence,
+			To:          want,
+			OldPath:     e.OutputPath,
+			NewPath:     e.OutputPath,
+		}
+		if e.OutputPath == summaryPath(s.cfg, domain.Meeting{MeetingDate: date, Sequence: e.Sequence}, body) {
+			change.NewPath = summaryPath(s.cfg, domain.Meeting{MeetingDate: date, Sequence: want}, body)
+		}
+		changes = append(changes, change)
+		changed = append(changed, e)
+	}
+	return changes, changed, nil
+}
+
+// processedByDate groups the ledger's dated entries by meeting date. An
+// unreadable ledger seeds nothing.
+func (s *SequenceService) processedByDate(body domain.Body) map[string][]domain.LedgerEntry {
+	entries, err := s.ledger.List(body)
+	if err != nil {
+		slog.Warn("ledger unreadable; numbering same-date meetings without it",
+			"body", body.Slug,
+			"error", err,
+		)
+		return map[string][]domain.LedgerEntry{}
+	}
+	byDate := make(map[string][]domain.LedgerEntry)
+	for _, e := range entries {
+		if e.MeetingDate != "" {
+			byDate[e.MeetingDate] = append(byDate[e.MeetingDate], e)
+		}
+	}
+	return byDate
+}
+
+// seedOrder orders a date's ledger entries by their recorded sequence.
+func seedOrder(entries []domain.LedgerEntry) []string {
+	sorted := slices.Clone(entries)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].Sequence != sorted[j].Sequence {
+			return sorted[i].Sequence < sorted[j].Sequence
+		}
+		return sorted[i].VideoID < sorted[j].VideoID
+	})
+	order := make([]string, len(sorted))
+	for i, e := range sorted {
+		order[i] = e.VideoID
+	}
+	return order
+}
+
+// sequenceIn numbers a video within its date's order: 0 when it is the only
+// one, otherwise its 1-based position.
+func sequenceIn(order []string, videoID string) int {
+	if len(order) <= 1 {
+		return 0
+	}
+	return slices.Index(order, videoID) + 1
+}
+
+// load reads the persisted orders, keyed by YYYY-MM-DD. Callers must hold
+// s.mu.
+func (s *SequenceService) load(body domain.Body) (map[string][]string, error) {
+	data, err := os.ReadFile(s.cfg.SequencesPath(body))
+	if errors.Is(err, os.ErrNotExist) {
+		return map[string][]string{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading sequences: %w", err)
+	}
+	orders := map[string][]string{}
+	if err := json.Unmarshal(data, &orders); err != nil {
+		return nil, fmt.Errorf("parsing sequences: %w", err)
+	}
+	return orders, nil
+}
+
+// save writes the persisted orders. Callers must hold s.mu.
+func (s *SequenceService) save(body domain.Body, orders map[string][]string) error {
+	path := s.cfg.SequencesPath(body)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("creating sequences dir: %w", err)
+	}
+	if err := writeJSON(path, orders); err != nil {
+		return fmt.Errorf("writing sequences: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,202 @@
+package service_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/AvogadroSG1/civic-summary/internal/domain"
+	"github.com/AvogadroSG1/civic-summary/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func boccMeeting(id string, day int) domain.Meeting {
+	return domain.Meeting{VideoID: id, MeetingDate: time.Date(2025, 2, day, 0, 0, 0, 0, time.UTC), BodySlug: "bocc"}
+}
+
+func TestSequenceService_AssignIsAppendOnly(t *testing.T) {
+	cfg := boccConfig(t)
+	body, _ := cfg.GetBody("bocc")
+	sequences := service.NewSequenceService(cfg, service.NewLedgerService(cfg))
+
+	first := []domain.Meeting{boccMeeting("vid_m", 24), boccMeeting("vid_x", 25)}
+	require.NoError(t, sequences.Assign(body, first))
+	assert.Equal(t, 0, first[0].Sequence)
+	assert.Equal(t, 0, first[1].Sequence)
+
+	// A later upload with a smaller ID goes after the meeting already seen.
+	second := []domain.Meeting{boccMeeting("vid_a", 24), boccMeeting("vid_m", 24)}
+	require.NoError(t, sequences.Assign(body, second))
+	assert.Equal(t, 2, second[0].Sequence)
+	assert.Equal(t, 1, second[1].Sequence)
+
+	// A video that drops out of the listing keeps its place.
+	third := []domain.Meeting{boccMeeting("vid_a", 24)}
+	require.NoError(t, sequences.Assign(body, third))
+	assert.Equal(t, 2, third[0].Sequence)
+
+	seq, ok, err := sequences.Sequence(body, "2025-02-24", "vid_m")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, 1, seq)
+	_, ok, err = sequences.Sequence(body, "2025-02-24", "unknown")
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestSequenceService_Release(t *testing.T) {
+	cfg := boccConfig(t)
+	body, _ := cfg.GetBody("bocc")
+	sequences := service.NewSequenceService(cfg, service.NewLedgerService(cfg))
+
+	meetings := []domain.Meeting{boccMeeting("vid_a", 24), boccMeeting("vid_b", 24), boccMeeting("vid_c", 24)}
+	require.NoError(t, sequences.Assign(body, meetings))
+
+	require.NoError(t, sequences.Release(body, "2025-02-24", "vid_b"))
+	seq, ok, err := sequences.Sequence(body, "2025-02-24", "vid_c")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, 2, seq, "the videos after it move up")
+	_, ok, err = sequences.Sequence(body, "2025-02-24", "vid_b")
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	require.NoError(t, sequences.Release(body, "2025-02-24", "vid_b"), "releasing twice is harmless")
+	require.NoError(t, sequences.Release(body, "2025-02-24", "vid_c"))
+	seq, _, err = sequences.Sequence(body, "2025-02-24", "vid_a")
+	require.NoError(t, err)
+	assert.Equal(t, 0, seq, "a video left alone on its date has no sequence")
+}
+
+func TestSequenceService_AssignOrdersNewVideosByUploadTime(t *testing.T) {
+	cfg := boccConfig(t)
+	body, _ := cfg.GetBody("bocc")
+	sequences := service.NewSequenceService(cfg, service.NewLedgerService(cfg))
+
+	later := boccMeeting("vid_a", 24)
+	later.Published = time.Date(2025, 2, 25, 9, 0, 0, 0, time.UTC)
+	earlier := boccMeeting("vid_b", 24)
+	earlier.Published = time.Date(2025, 2, 24, 21, 0, 0, 0, time.UTC)
+	unknown := boccMeeting("vid_0", 24)
+
+	meetings := []domain.Meeting{later, unknown, earlier}
+	require.NoError(t, sequences.Assign(body, meetings))
+	assert.Equal(t, 2, meetings[0].Sequence)
+	assert.Equal(t, 3, meetings[1].Sequence, "videos without an upload time go last")
+	assert.Equal(t, 1, meetings[2].Sequence)
+}
+
+func TestSequenceService_AssignSeedsFromLedger(t *testing.T) {
+	cfg := boccConfig(t)
+	body, _ := cfg.GetBody("bocc")
+	ledger := service.NewLedgerService(cfg)
+	sequences := service.NewSequenceService(cfg, ledger)
+
+	processed := boccMeeting("vid_z", 24)
+	processed.Sequence = 1
+	require.NoError(t, ledger.Record(body, domain.NewLedgerEntry(processed, domain.LedgerDone, "/out/z.md")))
+
+	meetings := []domain.Meeting{boccMeeting("vid_a", 24), boccMeeting("vid_z", 24)}
+	require.NoError(t, sequences.Assign(body, meetings))
+	assert.Equal(t, 2, meetings[0].Sequence)
+	assert.Equal(t, 1, meetings[1].Sequence, "a processed meeting keeps its number")
+}
+
+func TestSequenceService_Repair(t *testing.T) {
+	cfg := boccConfig(t)
+	body, _ := cfg.GetBody("bocc")
+	dir := cfg.FinalizedDir(body)
+	ledger := service.NewLedgerService(cfg)
+	sequences := service.NewSequenceService(cfg, ledger)
+
+	// Two solo meetings were summarized: one under its pattern name, one
+	// renamed by hand.
+	solo := writeSummary(t, dir, "20250224", "BOCC-2025-02-24-Citizen-Summary.md", "# Solo")
+	renamed := writeSummary(t, dir, "20250225", "Budget Hearing.md", "# Renamed")
+	for _, m := range []struct {
+		meeting domain.Meeting
+		path    string
+	}{{boccMeeting("vid_m", 24), solo}, {boccMeeting("vid_x", 25), renamed}} {
+		require.NoError(t, ledger.Record(body, domain.NewLedgerEntry(m.meeting, domain.LedgerDone, m.path)))
+	}
+
+	// A second meeting appears for each date.
+	meetings := []domain.Meeting{
+		boccMeeting("vid_m", 24), boccMeeting("vid_a", 24),
+		boccMeeting("vid_x", 25), boccMeeting("vid_b", 25),
+	}
+	require.NoError(t, sequences.Assign(body, meetings))
+
+	changes, err := sequences.Check(body)
+	require.NoError(t, err)
+	require.Len(t, changes, 2)
+	want := filepath.Join(dir, "20250224", "BOCC-2025-02-24-Citizen-Summary-1.md")
+	assert.Equal(t, service.SequenceChange{
+		VideoID: "vid_m", MeetingDate: "2025-02-24", From: 0, To: 1, OldPath: solo, NewPath: want,
+	}, changes[0])
+	assert.Equal(t, renamed, changes[1].NewPath, "a summary renamed by hand keeps its name")
+	assert.FileExists(t, solo, "checking changes nothing")
+
+	repaired, err := sequences.Repair(body)
+	require.NoError(t, err)
+	assert.Len(t, repaired, 2)
+	assert.NoFileExists(t, solo)
+	assert.FileExists(t, want)
+	assert.FileExists(t, renamed)
+
+	entry, _, err := ledger.Get(body, "vid_m")
+	require.NoError(t, err)
+	assert.Equal(t, 1, entry.Sequence)
+	assert.Equal(t, want, entry.OutputPath)
+
+	changes, err = sequences.Check(body)
+	require.NoError(t, err)
+	assert.Empty(t, changes, "a repaired layout is current")
+}
+
+func TestSequenceService_RepairMissingSummary(t *testing.T) {
+	cfg := boccConfig(t)
+	body, _ := cfg.GetBody("bocc")
+	ledger := service.NewLedgerService(cfg)
+	sequences := service.NewSequenceService(cfg, ledger)
+
+	// The summary was recorded, then deleted by hand.
+	gone := filepath.Join(cfg.FinalizedDir(body), "20250224", "BOCC-2025-02-24-Citizen-Summary.md")
+	require.NoError(t, ledger.Record(body, domain.NewLedgerEntry(boccMeeting("vid_m", 24), domain.LedgerDone, gone)))
+	require.NoError(t, sequences.Assign(body, []domain.Meeting{boccMeeting("vid_m", 24), boccMeeting("vid_a", 24)}))
+
+	repaired, err := sequences.Repair(body)
+	require.NoError(t, err)
+	require.Len(t, repaired, 1)
+	assert.True(t, repaired[0].Missing)
+	assert.Equal(t, gone, repaired[0].NewPath, "nothing was renamed")
+	assert.Contains(t, repaired[0].String(), "summary not found")
+
+	entry, _, err := ledger.Get(body, "vid_m")
+	require.NoError(t, err)
+	assert.Equal(t, 1, entry.Sequence)
+	assert.Equal(t, gone, entry.OutputPath, "the ledger does not point at a file that was never written")
+}
+
+func TestSequenceService_RepairLeavesConflicts(t *testing.T) {
+	cfg := boccConfig(t)
+	body, _ := cfg.GetBody("bocc")
+	dir := cfg.FinalizedDir(body)
+	ledger := service.NewLedgerService(cfg)
+	sequences := service.NewSequenceService(cfg, ledger)
+
+	solo := writeSummary(t, dir, "20250224", "BOCC-2025-02-24-Citizen-Summary.md", "# Solo")
+	taken := writeSummary(t, dir, "20250224", "BOCC-2025-02-24-Citizen-Summary-1.md", "# Someone else")
+	require.NoError(t, ledger.Record(body, domain.NewLedgerEntry(boccMeeting("vid_m", 24), domain.LedgerDone, solo)))
+	require.NoError(t, sequences.Assign(body, []domain.Meeting{boccMeeting("vid_m", 24), boccMeeting("vid_a", 24)}))
+
+	repaired, err := sequences.Repair(body)
+	assert.ErrorContains(t, err, "already exists")
+	assert.Empty(t, repaired)
+	content, readErr := os.ReadFile(taken)
+	require.NoError(t, readErr)
+	assert.Equal(t, "# Someone else", string(content), "an existing file is never overwritten")
+	assert.FileExists(t, solo)
+}
@@ -0,0 +1,36 @@
+package service
+
+import (
+	"log/slog"
+
+	"github.com/AvogadroSG1/civic-summary/internal/domain"
+	"github.com/AvogadroSG1/civic-summary/internal/markdown"
+)
+
+// TimestampLinkService turns summary timestamps into links that open the
+// meeting video at that moment.
+type TimestampLinkService struct{}
+
+// NewTimestampLinkService creates a new TimestampLinkService.
+func NewTimestampLinkService() *TimestampLinkService {
+	return &TimestampLinkService{}
+}
+
+// AddTimestampLinks links every [HH:MM:SS] timestamp and range in content to
+// the meeting video at its start offset. Returns the updated content, which
+// is unchanged when the recording's URL is unknown.
+func (s *TimestampLinkService) AddTimestampLinks(content string, meeting domain.Meeting, body domain.Body) string {
+	videoURL := body.MeetingVideoURL(meeting)
+	if videoURL == "" {
+		slog.Warn("video url unknown, timestamps left unlinked", "video_id", meeting.VideoID)
+		return content
+	}
+	result := markdown.LinkTimestamps(content, videoURL)
+
+	slog.Info("timestamp links added",
+		"video_id", meeting.VideoID,
+		"timestamps", len(markdown.FindTimestamps(result)),
+	)
+
+	return result
+}
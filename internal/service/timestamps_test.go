@@ -0,0 +1,19 @@
+package service_test
+
+import (
+	"testing"
+
+	"github.com/AvogadroSG1/civic-summary/internal/service"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTimestampLinkService_AddTimestampLinks(t *testing.T) {
+	svc := service.NewTimestampLinkService()
+	content := "### Budget Vote **[01:02:03-01:10:00]**\nApproved 5-0."
+
+	result := svc.AddTimestampLinks(content, testMeeting(), testHagerstownBody())
+
+	assert.Equal(t,
+		"### Budget Vote **[01:02:03-01:10:00](https://www.youtube.com/watch?v=abc123&t=3723s)**\nApproved 5-0.",
+		result)
+}
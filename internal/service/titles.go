@@ -0,0 +1,195 @@
+package service
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/AvogadroSG1/civic-summary/internal/domain"
+)
+
+// titleRules are a body's compiled title filters and date patterns.
+type titleRules struct {
+	include  []*regexp.Regexp
+	exclude  []*regexp.Regexp
+	patterns []domain.TitlePattern
+	compiled []*regexp.Regexp
+}
+
+// compileTitleRules compiles the body's title filters and title_date_regex
+// patterns.
+func compileTitleRules(body domain.Body) (*titleRules, error) {
+	rules := &titleRules{patterns: body.TitleDateRegex}
+	for _, p := range body.TitleDateRegex {
+		re, err := p.Compile()
+		if err != nil {
+			return nil, err
+		}
+		rules.compiled = append(rules.compiled, re)
+	}
+	for _, f := range body.TitleInclude {
+		re, err := regexp.Compile(f)
+		if err != nil {
+			return nil, fmt.Errorf("title_include %q: %w", f, err)
+		}
+		rules.include = append(rules.include, re)
+	}
+	for _, f := range body.TitleExclude {
+		re, err := regexp.Compile(f)
+		if err != nil {
+			return nil, fmt.Errorf("title_exclude %q: %w", f, err)
+		}
+		rules.exclude = append(rules.exclude, re)
+	}
+	return rules, nil
+}
+
+// filter returns why the title filters drop a title, or "" if they keep it.
+func (r *titleRules) filter(title string) string {
+	for _, re := range r.exclude {
+		if re.MatchString(title) {
+			return fmt.Sprintf("title matches title_exclude /%s/", re)
+		}
+	}
+	if len(r.include) == 0 {
+		return ""
+	}
+	for _, re := range r.include {
+		if re.MatchString(title) {
+			return ""
+		}
+	}
+	return "title matches no title_include pattern"
+}
+
+// date tries each title_date_regex pattern in order and returns the first
+// date found, with a label for the pattern that found it. A pattern that
+// matches but whose date does not parse is passed over; if no pattern
+// succeeds, the error reports the last such failure.
+func (r *titleRules) date(title string) (time.Time, string, error) {
+	var lastErr error
+	for i, re := range r.compiled {
+		matches := re.FindStringSubmatch(title)
+		if matches == nil {
+			continue
+		}
+		date, err := patternDate(r.patterns[i], re, matches)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		return date, fmt.Sprintf("title_date_regex[%d] %s", i, r.patterns[i]), nil
+	}
+	if lastErr != nil {
+		return time.Time{}, "", lastErr
+	}
+	return time.Time{}, "", fmt.Errorf("no date found in title %q", title)
+}
+
+var (
+	ordinalSuffix   = regexp.MustCompile(`(\d)(?:st|nd|rd|th)\b`)
+	abbreviationDot = regexp.MustCompile(`\b([A-Za-z]{3,4})\.`)
+	septAbbrev      = regexp.MustCompile(`\bSept\b`)
+)
+
+// flexibleDateFormats are the layouts tried for a date captured by a pattern
+// without layouts of its own. Numeric dates are read month first.
+var flexibleDateFormats = []string{
+	"2006-01-02",
+	"January 2, 2006",
+	"January 2 2006",
+	"Jan 2, 2006",
+	"Jan 2 2006",
+	"2 January 2006",
+	"2 Jan 2006",
+	"1/2/2006",
+}
+
+// parseFlexibleDate tries the built-in date formats, after dropping ordinal
+// suffixes ("4th") and abbreviation periods ("Jan.").
+func parseFlexibleDate(s string) (time.Time, error) {
+	cleaned := ordinalSuffix.ReplaceAllString(s, "$1")
+	cleaned = abbreviationDot.ReplaceAllString(cleaned, "$1")
+	cleaned = septAbbrev.ReplaceAllString(cleaned, "Sep")
+	cleaned = strings.TrimSpace(cleaned)
+
+	for _, format := range flexibleDateFormats {
+		if t, err := time.Parse(format, cleaned); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("unable to parse date: %q", s)
+}
+
+// patternDate parses the date one pattern captured.
+func patternDate(p domain.TitlePattern, re *regexp.Regexp, matches []string) (time.Time, error) {
+	if re.SubexpIndex(domain.TitleGroupYear) >= 0 {
+		return namedGroupDate(
+			matches[re.SubexpIndex(domain.TitleGroupYear)],
+			matches[re.SubexpIndex(domain.TitleGroupMonth)],
+			matches[re.SubexpIndex(domain.TitleGroupDay)],
+		)
+	}
+
+	dateStr := matches[1]
+	if len(p.Layouts) == 0 {
+		parsed, err := parseFlexibleDate(dateStr)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("parsing date %q from title: %w", dateStr, err)
+		}
+		return parsed, nil
+	}
+	for _, layout := range p.Layouts {
+		if t, err := time.Parse(layout, strings.TrimSpace(dateStr)); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("parsing date %q from title: no layout of %v matches", dateStr, p.Layouts)
+}
+
+// namedGroupDate builds a date from year, month, and day captures. The month
+// is a number or an English month name, full or abbreviated; a two-digit year
+// is taken to be in this century; ordinal suffixes on the day are ignored.
+func namedGroupDate(year, month, day string) (time.Time, error) {
+	y, err := strconv.Atoi(year)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("year %q is not a number", year)
+	}
+	if len(year) == 2 {
+		y += 2000
+	}
+	m, ok := parseMonth(month)
+	if !ok {
+		return time.Time{}, fmt.Errorf("month %q is not recognized", month)
+	}
+	d, err := strconv.Atoi(strings.TrimRight(strings.ToLower(day), "stndrh"))
+	if err != nil {
+		return time.Time{}, fmt.Errorf("day %q is not a number", day)
+	}
+
+	date := time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+	if date.Month() != m || date.Day() != d {
+		return time.Time{}, fmt.Errorf("%s %d, %d is not a date", m, d, y)
+	}
+	return date, nil
+}
+
+// parseMonth reads a month number or an English month name or abbreviation
+// of at least three letters, such as "Jan.", "Sept", or "December".
+func parseMonth(s string) (time.Month, bool) {
+	s = strings.ToLower(strings.TrimSuffix(strings.TrimSpace(s), "."))
+	if n, err := strconv.Atoi(s); err == nil {
+		return time.Month(n), n >= 1 && n <= 12
+	}
+	if len(s) < 3 {
+		return 0, false
+	}
+	for m := time.January; m <= time.December; m++ {
+		if strings.HasPrefix(strings.ToLower(m.String()), s) {
+			return m, true
+		}
+	}
+	return 0, false
+}
@@ -2,53 +2,132 @@ package service
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"log/slog"
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/AvogadroSG1/civic-summary/internal/domain"
-	"github.com/AvogadroSG1/civic-summary/internal/executor"
+	"github.com/AvogadroSG1/civic-summary/internal/source"
+	"github.com/AvogadroSG1/civic-summary/internal/transcriber"
 )
 
 const minTranscriptWords = 500
 
+// TranscriberFor returns the speech-to-text backend for a body, or nil when
+// the body has none configured.
+type TranscriberFor func(body domain.Body) (transcriber.Transcriber, error)
+
 // TranscriptionService obtains meeting transcripts via captions or Whisper fallback.
 type TranscriptionService struct {
-	ytdlp   *executor.YtDlpExecutor
-	whisper *executor.WhisperExecutor
+	sources      VideoSourceFor
+	transcribers TranscriberFor
+}
+
+// NewTranscriptionService creates a new TranscriptionService. A nil
+// transcribers disables the Whisper fallback.
+func NewTranscriptionService(sources VideoSourceFor, transcribers TranscriberFor) *TranscriptionService {
+	return &TranscriptionService{sources: sources, transcribers: transcribers}
+}
+
+// CaptionsPendingError reports that a meeting's source is expected to publish
+// captions but has not yet. The pipeline defers such a meeting instead of
+// quarantining it.
+type CaptionsPendingError struct {
+	VideoID string
+}
+
+func (e *CaptionsPendingError) Error() string {
+	return fmt.Sprintf("captions for %s are not available yet", e.VideoID)
 }
 
-// NewTranscriptionService creates a new TranscriptionService.
-func NewTranscriptionService(ytdlp *executor.YtDlpExecutor, whisper *executor.WhisperExecutor) *TranscriptionService {
-	return &TranscriptionService{ytdlp: ytdlp, whisper: whisper}
+// Permanent stops retry.Do from retrying: captions missing now will still be
+// missing seconds later.
+func (e *CaptionsPendingError) Permanent() bool { return true }
+
+// Transcribe obtains a transcript for a meeting from the body's video source,
+// trying captions first then falling back to Whisper audio transcription.
+func (s *TranscriptionService) Transcribe(ctx context.Context, meeting domain.Meeting, body domain.Body, outputDir string) (domain.Transcript, error) {
+	return s.TranscribeOrDefer(ctx, meeting, body, outputDir, time.Time{})
 }
 
-// Transcribe obtains a transcript for a meeting, trying captions first
-// then falling back to Whisper audio transcription.
-func (s *TranscriptionService) Transcribe(ctx context.Context, meeting domain.Meeting, outputDir string) (domain.Transcript, error) {
+// TranscribeOrDefer is Transcribe for a meeting whose captions may not have
+// been generated yet. If the source normally publishes captions but lists
+// none for the meeting, it returns a *CaptionsPendingError until whisperAfter
+// rather than falling back to Whisper. Until then, a failure to check for
+// captions is returned as is, so the caller can retry it, rather than taken
+// as a reason either to wait or to transcribe.
+func (s *TranscriptionService) TranscribeOrDefer(ctx context.Context, meeting domain.Meeting, body domain.Body, outputDir string, whisperAfter time.Time) (domain.Transcript, error) {
 	if err := os.MkdirAll(outputDir, 0o755); err != nil {
 		return domain.Transcript{}, fmt.Errorf("creating output dir: %w", err)
 	}
 
+	videoSource, err := s.sources(body)
+	if err != nil {
+		return domain.Transcript{}, fmt.Errorf("video source for %s: %w", body.Slug, err)
+	}
+
 	// Try captions first (fast path).
-	transcript, err := s.tryCaptions(ctx, meeting, outputDir)
-	if err == nil && !transcript.IsEmpty() {
-		slog.Info("obtained transcript via captions",
+	policy := body.CaptionPolicy()
+	captions, err := s.tryCaptions(ctx, videoSource, meeting, policy, outputDir)
+	switch {
+	case err == nil && !captions.IsEmpty():
+		quality, known := captions.Quality(recordingDuration(meeting, captions))
+		if !known || quality.Score >= policy.Threshold() {
+			slog.Info("obtained transcript via captions",
+				"video_id", meeting.VideoID,
+				"words", captions.WordCount(),
+				"quality", quality.Score,
+			)
+			return captions, nil
+		}
+		if stt, err := s.transcriber(body); stt == nil || err != nil {
+			slog.Warn("captions below min_quality, but no transcriber is available; using them",
+				"video_id", meeting.VideoID,
+				"quality", quality.String(),
+				"error", err,
+			)
+			return captions, nil
+		}
+		slog.Info("captions below min_quality, transcribing with whisper instead",
 			"video_id", meeting.VideoID,
-			"words", transcript.WordCount(),
+			"quality", quality.String(),
+			"min_quality", policy.Threshold(),
 		)
-		return transcript, nil
-	}
 
-	slog.Info("captions unavailable, falling back to whisper",
-		"video_id", meeting.VideoID,
-	)
+	case ctx.Err() != nil:
+		return domain.Transcript{}, ctx.Err()
 
-	// Whisper fallback.
-	transcript, err = s.tryWhisper(ctx, meeting, outputDir)
+	case err != nil && awaitingCaptions(meeting, body, whisperAfter):
+		return domain.Transcript{}, fmt.Errorf("checking captions: %w", err)
+
+	case err == nil && awaitingCaptions(meeting, body, whisperAfter):
+		slog.Info("captions not published yet, deferring",
+			"video_id", meeting.VideoID,
+			"whisper_after", whisperAfter,
+		)
+		return domain.Transcript{}, &CaptionsPendingError{VideoID: meeting.VideoID}
+
+	default:
+		slog.Info("captions unavailable, falling back to whisper",
+			"video_id", meeting.VideoID,
+			"error", err,
+		)
+	}
+
+	// Whisper fallback. Poor captions still beat none if Whisper fails.
+	transcript, err := s.tryWhisper(ctx, videoSource, meeting, body, outputDir)
 	if err != nil {
+		if !captions.IsEmpty() {
+			slog.Warn("whisper failed, using low-quality captions",
+				"video_id", meeting.VideoID,
+				"error", err,
+			)
+			return captions, nil
+		}
 		return domain.Transcript{}, fmt.Errorf("whisper fallback failed: %w", err)
 	}
 
@@ -60,7 +139,31 @@ func (s *TranscriptionService) Transcribe(ctx context.Context, meeting domain.Me
 	return transcript, nil
 }
 
-// ValidateTranscript checks that a transcript meets minimum quality requirements.
+// recordingDuration returns a meeting's length: the source's reported
+// duration, or failing that the end of the transcript's last cue.
+func recordingDuration(meeting domain.Meeting, transcript domain.Transcript) time.Duration {
+	if meeting.Details.Duration > 0 {
+		return meeting.Details.Duration
+	}
+	return transcript.Duration()
+}
+
+// captionsExpected reports whether a meeting's captions are worth waiting
+// for: YouTube generates them for every video it hosts, while feeds, URL
+// listings, and local files have captions from the start or not at all.
+func captionsExpected(meeting domain.Meeting, body domain.Body) bool {
+	return body.SourceType() == domain.SourceYouTube && !domain.IsLocalFile(meeting.VideoURL)
+}
+
+// awaitingCaptions reports whether a meeting without captions should wait for
+// them rather than be transcribed with Whisper.
+func awaitingCaptions(meeting domain.Meeting, body domain.Body, whisperAfter time.Time) bool {
+	return captionsExpected(meeting, body) && time.Now().Before(whisperAfter)
+}
+
+// ValidateTranscript checks that a transcript meets minimum quality
+// requirements. Only spoken words count toward the minimum, so cue numbers,
+// timings, and repeated rolling-caption rows cannot pad out a thin transcript.
 func (s *TranscriptionService) ValidateTranscript(transcript domain.Transcript) error {
 	if transcript.IsEmpty() {
 		return fmt.Errorf("transcript is empty")
@@ -72,64 +175,127 @@ func (s *TranscriptionService) ValidateTranscript(transcript domain.Transcript)
 	return nil
 }
 
-// tryCaptions attempts to download auto-generated captions.
-func (s *TranscriptionService) tryCaptions(ctx context.Context, meeting domain.Meeting, outputDir string) (domain.Transcript, error) {
-	srtPath, err := s.ytdlp.DownloadCaptions(ctx, meeting.VideoID, outputDir)
+// tryCaptions attempts to download the captions the policy selects. It
+// returns an empty transcript and no error when the source lists no track the
+// policy accepts.
+func (s *TranscriptionService) tryCaptions(ctx context.Context, videoSource source.VideoSource, meeting domain.Meeting, policy domain.CaptionPolicy, outputDir string) (domain.Transcript, error) {
+	captionPath, err := videoSource.Captions(ctx, meeting, policy, outputDir)
 	if err != nil {
 		return domain.Transcript{}, err
 	}
-	if srtPath == "" {
-		return domain.Transcript{}, fmt.Errorf("no captions available")
+	if captionPath == "" {
+		return domain.Transcript{}, nil
 	}
 
-	content, err := os.ReadFile(srtPath)
+	// Rename from .en.srt to .srt, or .es.vtt to .vtt, for consistency.
+	// Local transcripts keep their own extension.
+	finalPath := filepath.Join(outputDir, meeting.VideoID+filepath.Ext(captionPath))
+	if captionPath != finalPath {
+		if err := os.Rename(captionPath, finalPath); err != nil {
+			// Non-fatal: keep original path.
+			finalPath = captionPath
+		}
+	}
+
+	transcript, err := LoadTranscript(finalPath, domain.TranscriptSourceCaptions)
 	if err != nil {
 		return domain.Transcript{}, fmt.Errorf("reading captions: %w", err)
 	}
+	return transcript, nil
+}
 
-	// Rename from .en.srt to .srt for consistency.
-	finalPath := filepath.Join(outputDir, meeting.VideoID+".srt")
-	if srtPath != finalPath {
-		if err := os.Rename(srtPath, finalPath); err != nil {
-			// Non-fatal: keep original path.
-			finalPath = srtPath
+// ApplyVocabulary corrects the transcript against the body's vocabulary and
+// saves a report of the corrections beside the transcript file, replacing any
+// earlier one. A body without a vocabulary gets the transcript back unchanged.
+func (s *TranscriptionService) ApplyVocabulary(meeting domain.Meeting, body domain.Body, transcript domain.Transcript) domain.Transcript {
+	if len(body.Vocabulary) == 0 {
+		return transcript
+	}
+	corrected, corrections := body.Vocabulary.Correct(transcript)
+
+	if transcript.Path != "" {
+		path := CorrectionReportPath(transcript.Path)
+		report := domain.NewCorrectionReport(meeting.VideoID, transcript.Path, corrections, time.Now())
+		data, err := json.MarshalIndent(report, "", "  ")
+		if err == nil {
+			err = os.WriteFile(path, append(data, '\n'), 0o644)
+		}
+		if err != nil {
+			slog.Warn("failed to save vocabulary corrections", "video_id", meeting.VideoID, "error", err)
 		}
 	}
 
-	return domain.Transcript{
-		Content: string(content),
-		Path:    finalPath,
-		Source:  domain.TranscriptSourceCaptions,
-	}, nil
+	slog.Info("vocabulary applied",
+		"video_id", meeting.VideoID,
+		"corrections", len(corrections),
+	)
+	return corrected
+}
+
+// CorrectionReportPath returns where the vocabulary corrections for a
+// transcript are saved: beside it, as <name>.corrections.json.
+func CorrectionReportPath(transcriptPath string) string {
+	return strings.TrimSuffix(transcriptPath, filepath.Ext(transcriptPath)) + ".corrections.json"
+}
+
+// transcriber returns the body's speech-to-text backend, or nil when it has
+// none.
+func (s *TranscriptionService) transcriber(body domain.Body) (transcriber.Transcriber, error) {
+	if s.transcribers == nil {
+		return nil, nil
+	}
+	return s.transcribers(body)
 }
 
-// tryWhisper downloads audio and transcribes with whisper.cpp.
-func (s *TranscriptionService) tryWhisper(ctx context.Context, meeting domain.Meeting, outputDir string) (domain.Transcript, error) {
-	if s.whisper == nil {
+// tryWhisper downloads audio and transcribes it with the body's
+// speech-to-text backend.
+func (s *TranscriptionService) tryWhisper(ctx context.Context, videoSource source.VideoSource, meeting domain.Meeting, body domain.Body, outputDir string) (domain.Transcript, error) {
+	stt, err := s.transcriber(body)
+	if err != nil {
+		return domain.Transcript{}, err
+	}
+	if stt == nil {
 		return domain.Transcript{}, fmt.Errorf("whisper not configured")
 	}
 
-	// Download audio.
-	audioPath := filepath.Join(outputDir, meeting.VideoID+".mp3")
-	if err := s.ytdlp.DownloadAudio(ctx, meeting.VideoID, audioPath); err != nil {
+	// Download audio, or locate it for a local recording.
+	audioPath, err := videoSource.Audio(ctx, meeting, outputDir)
+	if err != nil {
 		return domain.Transcript{}, fmt.Errorf("downloading audio: %w", err)
 	}
 
 	// Transcribe.
 	outputBase := filepath.Join(outputDir, meeting.VideoID)
-	srtPath, err := s.whisper.Transcribe(ctx, audioPath, outputBase)
+	srtPath, err := stt.Transcribe(ctx, audioPath, outputBase)
 	if err != nil {
-		return domain.Transcript{}, fmt.Errorf("transcribing audio: %w", err)
+		return domain.Transcript{}, fmt.Errorf("transcribing audio with %s: %w", stt.Describe(), err)
+	}
+
+	// openai-whisper names its output after the audio file, which for a local
+	// recording is the original file name rather than the video ID.
+	if finalPath := outputBase + filepath.Ext(srtPath); srtPath != finalPath {
+		if err := os.Rename(srtPath, finalPath); err == nil {
+			srtPath = finalPath
+		}
 	}
 
-	content, err := os.ReadFile(srtPath)
+	transcript, err := LoadTranscript(srtPath, domain.TranscriptSourceWhisper)
 	if err != nil {
 		return domain.Transcript{}, fmt.Errorf("reading transcript: %w", err)
 	}
+	return transcript, nil
+}
 
-	return domain.Transcript{
-		Content: strings.TrimSpace(string(content)),
-		Path:    srtPath,
-		Source:  domain.TranscriptSourceWhisper,
-	}, nil
+// LoadTranscript reads and parses a transcript file. SRT, WebVTT, whisper
+// JSON, and plain text are accepted; see domain.DetectTranscriptFormat.
+func LoadTranscript(path string, source domain.TranscriptSource) (domain.Transcript, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return domain.Transcript{}, err
+	}
+	transcript, err := domain.ParseTranscript(string(content), path, source)
+	if err != nil {
+		return domain.Transcript{}, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	return transcript, nil
 }
@@ -2,15 +2,19 @@ package service_test
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/AvogadroSG1/civic-summary/internal/domain"
 	"github.com/AvogadroSG1/civic-summary/internal/executor"
 	"github.com/AvogadroSG1/civic-summary/internal/service"
+	"github.com/AvogadroSG1/civic-summary/internal/transcriber"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -18,7 +22,7 @@ import (
 func TestTranscriptionService_ValidateTranscript(t *testing.T) {
 	mock := executor.NewMockCommander()
 	ytdlp := executor.NewYtDlpExecutor(mock, "yt-dlp")
-	svc := service.NewTranscriptionService(ytdlp, nil)
+	svc := service.NewTranscriptionService(youtubeSources(ytdlp), nil)
 
 	tests := []struct {
 		name       string
@@ -27,19 +31,29 @@ func TestTranscriptionService_ValidateTranscript(t *testing.T) {
 	}{
 		{
 			"empty transcript",
-			domain.Transcript{Content: ""},
+			domain.Transcript{},
 			true,
 		},
 		{
 			"too short",
-			domain.Transcript{Content: "just a few words here"},
+			domain.Transcript{Cues: domain.ParsePlainText("just a few words here")},
 			true,
 		},
 		{
 			"valid transcript",
-			domain.Transcript{Content: generateWords(600)},
+			domain.Transcript{Cues: domain.ParsePlainText(generateWords(600))},
 			false,
 		},
+		{
+			"cue numbers and timings do not count",
+			domain.Transcript{Cues: domain.ParseSRT(generateCues(150, "word word"))},
+			true,
+		},
+		{
+			"repeated rolling captions do not count",
+			domain.Transcript{Cues: domain.ParseSRT(generateCues(300, "same four words\nsame four words"))},
+			true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -54,6 +68,15 @@ func TestTranscriptionService_ValidateTranscript(t *testing.T) {
 	}
 }
 
+// generateCues returns an SRT transcript of n one-second cues with the same text.
+func generateCues(n int, text string) string {
+	var b strings.Builder
+	for i := 0; i < n; i++ {
+		fmt.Fprintf(&b, "%d\n00:%02d:%02d,000 --> 00:%02d:%02d,500\n%s\n\n", i+1, i/60, i%60, i/60, i%60, text)
+	}
+	return b.String()
+}
+
 func generateWords(n int) string {
 	result := ""
 	for i := 0; i < n; i++ {
@@ -65,6 +88,19 @@ func generateWords(n int) string {
 	return result
 }
 
+// whisperFor returns an openai-whisper backend, run as whisper-cli, for every
+// body.
+func whisperFor(t *testing.T, commander executor.Commander) service.TranscriberFor {
+	t.Helper()
+	stt, err := transcriber.New(domain.TranscriberConfig{
+		Backend: domain.TranscriberOpenAIWhisper,
+		Binary:  "whisper-cli",
+		Model:   "medium",
+	}.WithDefaults(), commander)
+	require.NoError(t, err)
+	return func(domain.Body) (transcriber.Transcriber, error) { return stt, nil }
+}
+
 // transcribeMeeting returns a deterministic meeting for transcription tests.
 func transcribeMeeting() domain.Meeting {
 	return domain.Meeting{
@@ -100,12 +136,14 @@ func TestTranscriptionService_Transcribe_CaptionsSuccess(t *testing.T) {
 	require.NoError(t, os.WriteFile(srtPath, []byte(srtContent), 0o644))
 
 	ytdlp := executor.NewYtDlpExecutor(mock, "yt-dlp")
-	svc := service.NewTranscriptionService(ytdlp, nil)
+	svc := service.NewTranscriptionService(youtubeSources(ytdlp), nil)
 
-	transcript, err := svc.Transcribe(context.Background(), meeting, tmpDir)
+	transcript, err := svc.Transcribe(context.Background(), meeting, testHagerstownBody(), tmpDir)
 	require.NoError(t, err)
 
-	assert.Equal(t, srtContent, transcript.Content)
+	require.Len(t, transcript.Cues, 1)
+	assert.Equal(t, "The meeting will come to order.", transcript.Cues[0].Text)
+	assert.Equal(t, domain.TranscriptFormatSRT, transcript.Format)
 	assert.Equal(t, domain.TranscriptSourceCaptions, transcript.Source)
 	// Should have been renamed from .en.srt to .srt.
 	assert.Equal(t, filepath.Join(tmpDir, meeting.VideoID+".srt"), transcript.Path)
@@ -139,17 +177,43 @@ func TestTranscriptionService_Transcribe_CaptionsFail_WhisperFallback(t *testing
 	require.NoError(t, os.WriteFile(whisperSrtPath, []byte(srtContent), 0o644))
 
 	ytdlp := executor.NewYtDlpExecutor(mock, "yt-dlp")
-	whisper := executor.NewWhisperExecutor(mock, "whisper-cli", "medium")
-	svc := service.NewTranscriptionService(ytdlp, whisper)
+	whisper := whisperFor(t, mock)
+	svc := service.NewTranscriptionService(youtubeSources(ytdlp), whisper)
 
-	transcript, err := svc.Transcribe(context.Background(), meeting, tmpDir)
+	transcript, err := svc.Transcribe(context.Background(), meeting, testHagerstownBody(), tmpDir)
 	require.NoError(t, err)
 
-	assert.Equal(t, "1\n00:00:01,000 --> 00:00:05,000\nWhisper transcription output.", transcript.Content)
+	require.Len(t, transcript.Cues, 1)
+	assert.Equal(t, "Whisper transcription output.", transcript.Cues[0].Text)
 	assert.Equal(t, domain.TranscriptSourceWhisper, transcript.Source)
 	assert.Equal(t, whisperSrtPath, transcript.Path)
 }
 
+func TestTranscriptionService_Transcribe_LocalRecording(t *testing.T) {
+	tmpDir := t.TempDir()
+	recording := filepath.Join(t.TempDir(), "GMT20250304-180000_Recording.mp4")
+	require.NoError(t, os.WriteFile(recording, []byte("video"), 0o644))
+	meeting := domain.Meeting{VideoID: "GMT20250304-180000_Recording", VideoURL: recording}
+
+	// Whisper reads the recording in place and names its output after it.
+	mock := executor.NewMockCommander()
+	whisperKey := fmt.Sprintf("whisper-cli %s --model medium --output_format srt --output_dir %s --language en", recording, tmpDir)
+	mock.OnCommand(whisperKey, &executor.CommandResult{}, nil)
+	srtContent := "1\n00:00:01,000 --> 00:00:05,000\nCall to order.\n"
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "GMT20250304-180000_Recording.srt"), []byte(srtContent), 0o644))
+
+	ytdlp := executor.NewYtDlpExecutor(mock, "yt-dlp")
+	whisper := whisperFor(t, mock)
+	svc := service.NewTranscriptionService(youtubeSources(ytdlp), whisper)
+
+	transcript, err := svc.Transcribe(context.Background(), meeting, testHagerstownBody(), tmpDir)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{whisperKey}, mock.Calls, "nothing is downloaded for a local recording")
+	assert.Equal(t, domain.TranscriptSourceWhisper, transcript.Source)
+	assert.Equal(t, filepath.Join(tmpDir, meeting.VideoID+".srt"), transcript.Path)
+}
+
 func TestTranscriptionService_Transcribe_WhisperNotConfigured(t *testing.T) {
 	tmpDir := t.TempDir()
 	meeting := transcribeMeeting()
@@ -165,13 +229,62 @@ func TestTranscriptionService_Transcribe_WhisperNotConfigured(t *testing.T) {
 
 	ytdlp := executor.NewYtDlpExecutor(mock, "yt-dlp")
 	// Whisper is nil — not configured.
-	svc := service.NewTranscriptionService(ytdlp, nil)
+	svc := service.NewTranscriptionService(youtubeSources(ytdlp), nil)
 
-	_, err := svc.Transcribe(context.Background(), meeting, tmpDir)
+	_, err := svc.Transcribe(context.Background(), meeting, testHagerstownBody(), tmpDir)
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "whisper not configured")
 }
 
+func TestTranscriptionService_TranscribeOrDefer(t *testing.T) {
+	meeting := transcribeMeeting()
+	mock := executor.NewMockCommander()
+	mock.OnCommand("yt-dlp --list-subs https://www.youtube.com/watch?v="+meeting.VideoID, &executor.CommandResult{
+		Stdout: "test123 has no subtitles",
+	}, nil)
+	svc := service.NewTranscriptionService(youtubeSources(executor.NewYtDlpExecutor(mock, "yt-dlp")), nil)
+
+	t.Run("captions pending", func(t *testing.T) {
+		_, err := svc.TranscribeOrDefer(context.Background(), meeting, testHagerstownBody(), t.TempDir(), time.Now().Add(time.Hour))
+		var pending *service.CaptionsPendingError
+		require.ErrorAs(t, err, &pending)
+		assert.Equal(t, "test123", pending.VideoID)
+	})
+
+	t.Run("grace period over", func(t *testing.T) {
+		_, err := svc.TranscribeOrDefer(context.Background(), meeting, testHagerstownBody(), t.TempDir(), time.Now().Add(-time.Hour))
+		assert.ErrorContains(t, err, "whisper not configured", "falls back to whisper")
+	})
+
+	t.Run("source without generated captions", func(t *testing.T) {
+		body := testHagerstownBody()
+		body.Source = domain.SourceConfig{Type: domain.SourceFeed, URL: "https://example.gov/meetings.rss"}
+		_, err := svc.TranscribeOrDefer(context.Background(), meeting, body, t.TempDir(), time.Now().Add(time.Hour))
+		assert.ErrorContains(t, err, "whisper not configured", "only YouTube captions are waited for")
+	})
+
+	t.Run("listing captions fails", func(t *testing.T) {
+		failing := executor.NewMockCommander()
+		failing.OnCommand("yt-dlp --list-subs https://www.youtube.com/watch?v="+meeting.VideoID,
+			&executor.CommandResult{}, errors.New("HTTP Error 503"))
+		svc := service.NewTranscriptionService(youtubeSources(executor.NewYtDlpExecutor(failing, "yt-dlp")), nil)
+
+		_, err := svc.TranscribeOrDefer(context.Background(), meeting, testHagerstownBody(), t.TempDir(), time.Now().Add(time.Hour))
+		var pending *service.CaptionsPendingError
+		assert.False(t, errors.As(err, &pending), "a failed check is not a missing track")
+		assert.ErrorContains(t, err, "HTTP Error 503")
+		var permanent interface{ Permanent() bool }
+		assert.False(t, errors.As(err, &permanent), "left for retry.Do to retry")
+	})
+
+	t.Run("cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		_, err := svc.TranscribeOrDefer(ctx, meeting, testHagerstownBody(), t.TempDir(), time.Now().Add(time.Hour))
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+}
+
 func TestTranscriptionService_Transcribe_CaptionsRenamesSrt(t *testing.T) {
 	tmpDir := t.TempDir()
 	meeting := transcribeMeeting()
@@ -193,9 +306,9 @@ func TestTranscriptionService_Transcribe_CaptionsRenamesSrt(t *testing.T) {
 	require.NoError(t, os.WriteFile(enSrtPath, []byte("subtitle content"), 0o644))
 
 	ytdlp := executor.NewYtDlpExecutor(mock, "yt-dlp")
-	svc := service.NewTranscriptionService(ytdlp, nil)
+	svc := service.NewTranscriptionService(youtubeSources(ytdlp), nil)
 
-	transcript, err := svc.Transcribe(context.Background(), meeting, tmpDir)
+	transcript, err := svc.Transcribe(context.Background(), meeting, testHagerstownBody(), tmpDir)
 	require.NoError(t, err)
 
 	// Verify rename happened: .en.srt should no longer exist.
@@ -208,3 +321,155 @@ func TestTranscriptionService_Transcribe_CaptionsRenamesSrt(t *testing.T) {
 	_, err = os.Stat(finalPath)
 	assert.NoError(t, err, ".srt should exist after rename")
 }
+
+func TestTranscriptionService_Transcribe_LowQualityCaptions(t *testing.T) {
+	meeting := transcribeMeeting()
+	meeting.Details.Duration = time.Hour
+	videoURL := "https://www.youtube.com/watch?v=" + meeting.VideoID
+
+	// Ten minutes of captions for an hour-long meeting.
+	setup := func(t *testing.T, whisperErr error) (*executor.MockCommander, string) {
+		tmpDir := t.TempDir()
+		mock := executor.NewMockCommander()
+		mock.OnCommand("yt-dlp --list-subs "+videoURL, &executor.CommandResult{
+			Stdout: "[info] Available automatic captions for test123:\nLanguage Name Formats\nen English vtt, ttml\n",
+		}, nil)
+		mock.OnCommand(fmt.Sprintf("yt-dlp --write-auto-subs --sub-lang en --sub-format vtt --skip-download --output %s/%s %s", tmpDir, meeting.VideoID, videoURL),
+			&executor.CommandResult{}, nil)
+		require.NoError(t, os.WriteFile(filepath.Join(tmpDir, meeting.VideoID+".en.vtt"),
+			[]byte("WEBVTT\n\n"+strings.ReplaceAll(generateCues(600, "the council discussed the budget"), ",", ".")), 0o644))
+
+		audioPath := filepath.Join(tmpDir, meeting.VideoID+".mp3")
+		mock.OnCommand(fmt.Sprintf("yt-dlp --extract-audio --audio-format mp3 --output %s %s", audioPath, videoURL), &executor.CommandResult{}, nil)
+		mock.OnCommand(fmt.Sprintf("whisper-cli %s --model medium --output_format srt --output_dir %s --language en", audioPath, tmpDir),
+			&executor.CommandResult{}, whisperErr)
+		require.NoError(t, os.WriteFile(filepath.Join(tmpDir, meeting.VideoID+".srt"),
+			[]byte("1\n00:00:01,000 --> 00:00:05,000\nWhisper transcription output.\n"), 0o644))
+		return mock, tmpDir
+	}
+
+	t.Run("whisper instead", func(t *testing.T) {
+		mock, tmpDir := setup(t, nil)
+		ytdlp := executor.NewYtDlpExecutor(mock, "yt-dlp")
+		svc := service.NewTranscriptionService(youtubeSources(ytdlp), whisperFor(t, mock))
+
+		transcript, err := svc.Transcribe(context.Background(), meeting, testHagerstownBody(), tmpDir)
+		require.NoError(t, err)
+		assert.Equal(t, domain.TranscriptSourceWhisper, transcript.Source)
+	})
+
+	t.Run("threshold lowered", func(t *testing.T) {
+		mock, tmpDir := setup(t, nil)
+		ytdlp := executor.NewYtDlpExecutor(mock, "yt-dlp")
+		svc := service.NewTranscriptionService(youtubeSources(ytdlp), whisperFor(t, mock))
+		body := testHagerstownBody()
+		zero := 0
+		body.Captions = domain.CaptionPolicy{MinQuality: &zero}
+
+		transcript, err := svc.Transcribe(context.Background(), meeting, body, tmpDir)
+		require.NoError(t, err)
+		assert.Equal(t, domain.TranscriptSourceCaptions, transcript.Source)
+		assert.Equal(t, domain.TranscriptFormatVTT, transcript.Format)
+	})
+
+	t.Run("whisper not configured", func(t *testing.T) {
+		mock, tmpDir := setup(t, nil)
+		svc := service.NewTranscriptionService(youtubeSources(executor.NewYtDlpExecutor(mock, "yt-dlp")), nil)
+
+		transcript, err := svc.Transcribe(context.Background(), meeting, testHagerstownBody(), tmpDir)
+		require.NoError(t, err)
+		assert.Equal(t, domain.TranscriptSourceCaptions, transcript.Source)
+	})
+
+	t.Run("whisper fails", func(t *testing.T) {
+		mock, tmpDir := setup(t, fmt.Errorf("out of memory"))
+		ytdlp := executor.NewYtDlpExecutor(mock, "yt-dlp")
+		svc := service.NewTranscriptionService(youtubeSources(ytdlp), whisperFor(t, mock))
+
+		transcript, err := svc.Transcribe(context.Background(), meeting, testHagerstownBody(), tmpDir)
+		require.NoError(t, err)
+		assert.Equal(t, domain.TranscriptSourceCaptions, transcript.Source, "poor captions beat none")
+	})
+}
+
+func TestTranscriptionService_ApplyVocabulary(t *testing.T) {
+	tmpDir := t.TempDir()
+	transcript := domain.Transcript{
+		Path: filepath.Join(tmpDir, "test123.srt"),
+		Cues: []domain.Cue{
+			{Index: 1, Start: 5 * time.Second, End: 8 * time.Second, Text: "Mayor brewskie called the meeting to order."},
+			{Index: 2, Start: 8 * time.Second, End: 12 * time.Second, Text: "Roll call."},
+		},
+	}
+	body := testHagerstownBody()
+	body.Vocabulary = domain.Vocabulary{{Term: "Bruchey", Variants: []string{"brewskie"}}}
+	svc := service.NewTranscriptionService(nil, nil)
+
+	corrected := svc.ApplyVocabulary(transcribeMeeting(), body, transcript)
+
+	assert.Equal(t, "Mayor Bruchey called the meeting to order.", corrected.Cues[0].Text)
+
+	reportPath := service.CorrectionReportPath(transcript.Path)
+	assert.Equal(t, filepath.Join(tmpDir, "test123.corrections.json"), reportPath)
+	data, err := os.ReadFile(reportPath)
+	require.NoError(t, err)
+	var report domain.CorrectionReport
+	require.NoError(t, json.Unmarshal(data, &report))
+	assert.Equal(t, "test123", report.VideoID)
+	assert.Equal(t, map[string]int{"Bruchey": 1}, report.Counts)
+	assert.Equal(t, []domain.Correction{{Cue: 1, Time: "00:00:05", From: "brewskie", To: "Bruchey"}}, report.Corrections)
+}
+
+func TestTranscriptionService_ApplyVocabulary_NoVocabulary(t *testing.T) {
+	tmpDir := t.TempDir()
+	transcript := domain.Transcript{
+		Path: filepath.Join(tmpDir, "test123.srt"),
+		Cues: []domain.Cue{{Index: 1, Text: "Mayor brewskie called the meeting to order."}},
+	}
+
+	corrected := service.NewTranscriptionService(nil, nil).ApplyVocabulary(transcribeMeeting(), testHagerstownBody(), transcript)
+
+	assert.Equal(t, transcript, corrected)
+	assert.NoFileExists(t, service.CorrectionReportPath(transcript.Path))
+}
+
+func TestLoadTranscript(t *testing.T) {
+	dir := t.TempDir()
+
+	t.Run("vtt", func(t *testing.T) {
+		path := filepath.Join(dir, "abc123.vtt")
+		require.NoError(t, os.WriteFile(path, []byte("WEBVTT\n\n00:00:01.000 --> 00:00:03.000\nCall to order.\n"), 0o644))
+
+		transcript, err := service.LoadTranscript(path, domain.TranscriptSourceCaptions)
+
+		require.NoError(t, err)
+		assert.Equal(t, domain.TranscriptFormatVTT, transcript.Format)
+		assert.Equal(t, path, transcript.Path)
+		assert.Equal(t, "[00:00:01] Call to order.", transcript.Compact())
+	})
+
+	t.Run("whisper json", func(t *testing.T) {
+		path := filepath.Join(dir, "abc123.json")
+		require.NoError(t, os.WriteFile(path, []byte(`{"segments":[{"start":1.0,"end":3.0,"text":" Call to order."}]}`), 0o644))
+
+		transcript, err := service.LoadTranscript(path, domain.TranscriptSourceWhisper)
+
+		require.NoError(t, err)
+		assert.Equal(t, domain.TranscriptFormatWhisperJSON, transcript.Format)
+		assert.Equal(t, 3, transcript.WordCount())
+	})
+
+	t.Run("malformed json", func(t *testing.T) {
+		path := filepath.Join(dir, "bad.json")
+		require.NoError(t, os.WriteFile(path, []byte(`{"segments":`), 0o644))
+
+		_, err := service.LoadTranscript(path, domain.TranscriptSourceWhisper)
+
+		assert.ErrorContains(t, err, "parsing "+path)
+	})
+
+	t.Run("missing", func(t *testing.T) {
+		_, err := service.LoadTranscript(filepath.Join(dir, "missing.srt"), domain.TranscriptSourceCaptions)
+		assert.Error(t, err)
+	})
+}
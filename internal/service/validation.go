@@ -4,25 +4,12 @@ import (
 	"fmt"
 	"regexp"
 	"strings"
+	"time"
 
 	"github.com/AvogadroSG1/civic-summary/internal/domain"
 	"github.com/AvogadroSG1/civic-summary/internal/markdown"
 )
 
-const (
-	minSummaryWords         = 500
-	warningSummaryWordCount = 1000
-)
-
-// Required section headings (## 1. through ## 5.)
-var requiredSections = []string{
-	"## 1.",
-	"## 2.",
-	"## 3.",
-	"## 4.",
-	"## 5.",
-}
-
 var timestampPattern = regexp.MustCompile(`\[\d{1,2}:\d{2}:\d{2}`)
 
 // ValidationService validates that summaries meet quality requirements.
@@ -33,20 +20,25 @@ func NewValidationService() *ValidationService {
 	return &ValidationService{}
 }
 
-// Validate performs comprehensive validation on a summary.
-func (s *ValidationService) Validate(content string, body domain.Body) *domain.ValidationResult {
+// Validate performs comprehensive validation on a summary against the body's
+// validation rules. duration is the meeting's length, which scales the
+// expected word count; pass 0 when it is unknown.
+func (s *ValidationService) Validate(content string, body domain.Body, duration time.Duration) *domain.ValidationResult {
 	result := &domain.ValidationResult{}
+	rules := body.ValidationRules()
 
-	s.validateFrontmatter(content, result)
-	s.validateStructure(content, body, result)
-	s.validateContent(content, result)
-	s.validateMetaCommentary(content, result)
+	s.validateFrontmatter(content, rules, result)
+	s.validateStructure(content, body, rules, result)
+	s.validateContent(content, rules, duration, result)
+	s.validateForbiddenPhrases(content, rules, result)
+	s.validateMetaCommentary(content, rules, result)
+	s.validateRoster(content, body, rules, result)
 
 	return result
 }
 
 // validateFrontmatter checks YAML frontmatter presence and required fields.
-func (s *ValidationService) validateFrontmatter(content string, result *domain.ValidationResult) {
+func (s *ValidationService) validateFrontmatter(content string, rules domain.ValidationRules, result *domain.ValidationResult) {
 	if !markdown.HasFrontmatter(content) {
 		result.AddError("missing frontmatter: document must start with '---'")
 		return
@@ -58,7 +50,7 @@ func (s *ValidationService) validateFrontmatter(content string, result *domain.V
 		return
 	}
 
-	missing := markdown.ValidateFrontmatter(fm)
+	missing := markdown.ValidateFrontmatter(fm, rules.RequiredFrontmatter)
 	for _, key := range missing {
 		result.AddError("missing required frontmatter key: %s", key)
 	}
@@ -69,7 +61,7 @@ func (s *ValidationService) validateFrontmatter(content string, result *domain.V
 			for _, tag := range tagList {
 				if tagStr, ok := tag.(string); ok {
 					if strings.Contains(tagStr, " ") {
-						result.AddError("tag contains spaces (use hyphens): %q", tagStr)
+						result.Add(rules.Check(domain.CheckTagSpaces), "tag contains spaces (use hyphens): %q", tagStr)
 					}
 				}
 			}
@@ -77,11 +69,11 @@ func (s *ValidationService) validateFrontmatter(content string, result *domain.V
 	}
 }
 
-// validateStructure checks for required sections and headings.
-func (s *ValidationService) validateStructure(content string, body domain.Body, result *domain.ValidationResult) {
-	for _, section := range requiredSections {
-		if !strings.Contains(content, section) {
-			result.AddError("missing required section: %s", section)
+// validateStructure checks for required headings, the title, and the footer.
+func (s *ValidationService) validateStructure(content string, body domain.Body, rules domain.ValidationRules, result *domain.ValidationResult) {
+	for _, heading := range rules.RequiredHeadings {
+		if !heading.Match(content) {
+			result.Add(heading.Level(), "missing required section: %s", heading)
 		}
 	}
 
@@ -95,12 +87,7 @@ func (s *ValidationService) validateStructure(content string, body domain.Body,
 		}
 	}
 	if !hasTitle {
-		result.AddError("missing main title (# heading)")
-	}
-
-	// Check for conclusion section.
-	if !strings.Contains(content, "## Conclusion") {
-		result.AddWarning("missing Conclusion section")
+		result.Add(rules.Check(domain.CheckTitle), "missing main title (# heading)")
 	}
 
 	// Check for attribution footer.
@@ -109,44 +96,103 @@ func (s *ValidationService) validateStructure(content string, body domain.Body,
 		footerText = body.FooterText
 	}
 	if !strings.Contains(strings.ToLower(content), strings.ToLower(footerText)) {
-		result.AddWarning("missing attribution footer text")
+		result.Add(rules.Check(domain.CheckFooter), "missing attribution footer text")
 	}
 }
 
 // validateContent checks word count and timestamp presence.
-func (s *ValidationService) validateContent(content string, result *domain.ValidationResult) {
+func (s *ValidationService) validateContent(content string, rules domain.ValidationRules, duration time.Duration, result *domain.ValidationResult) {
 	wordCount := len(strings.Fields(content))
+	minWords, warnWords := rules.WordsFor(duration)
 
-	if wordCount < minSummaryWords {
-		result.AddError("summary too short (%d words, minimum %d)", wordCount, minSummaryWords)
-	} else if wordCount < warningSummaryWordCount {
-		result.AddWarning("summary is short (%d words, recommend %d+)", wordCount, warningSummaryWordCount)
+	if minWords > 0 && wordCount < minWords {
+		result.AddError("summary too short (%d words, minimum %d)", wordCount, minWords)
+	} else if warnWords > 0 && wordCount < warnWords {
+		result.AddWarning("summary is short (%d words, recommend %d+)", wordCount, warnWords)
 	}
 
 	if !timestampPattern.MatchString(content) {
-		result.AddWarning("no timestamps found (expected [HH:MM:SS] format)")
+		result.Add(rules.Check(domain.CheckTimestamps), "no timestamps found (expected [HH:MM:SS] format)")
 	}
 }
 
-// validateMetaCommentary checks for model meta-commentary in the output.
-func (s *ValidationService) validateMetaCommentary(content string, result *domain.ValidationResult) {
-	// Only check the first few lines (before frontmatter should be clean).
-	if !markdown.HasFrontmatter(content) {
-		// If no frontmatter, the whole start is suspect.
-		if markdown.HasMetaCommentary(content) {
-			result.AddError("contains model meta-commentary (output must start with frontmatter)")
+// validateRoster flags officials the summary names by title who are not on
+// the body's roster, such as a misheard "Councilmember Martines". Bodies
+// without a roster are not checked.
+func (s *ValidationService) validateRoster(content string, body domain.Body, rules domain.ValidationRules, result *domain.ValidationResult) {
+	severity := rules.Check(domain.CheckRoster)
+	if len(body.Roster) == 0 || severity == domain.RuleOff {
+		return
+	}
+	if _, rest, err := markdown.ParseFrontmatter(content); err == nil {
+		content = rest
+	}
+
+	seen := make(map[string]bool)
+	for _, m := range body.Roster.Mentions(content) {
+		mention := m.String()
+		if seen[mention] {
+			continue
+		}
+		seen[mention] = true
+		if _, ok := body.Roster.Lookup(mention); !ok {
+			result.AddCheck(domain.CheckRoster, severity, "%q is not on the %s roster", mention, body.Name)
 		}
 	}
+}
 
-	// Also check after frontmatter for embedded commentary.
-	_, body, err := markdown.ParseFrontmatter(content)
-	if err == nil && body != "" {
-		firstLine := strings.TrimSpace(strings.Split(body, "\n")[0])
-		for _, pattern := range []string{"Based on", "I'll ", "I will ", "Let me ", "Here's ", "Here is "} {
-			if strings.HasPrefix(firstLine, pattern) {
-				result.AddError("body starts with model meta-commentary: %q", fmt.Sprintf("%.50s...", firstLine))
-				break
+// validateForbiddenPhrases checks the summary body, after any frontmatter,
+// against the body's forbidden phrases. Without frontmatter, a preamble the
+// meta-commentary check reports is not reported again as a phrase.
+func (s *ValidationService) validateForbiddenPhrases(content string, rules domain.ValidationRules, result *domain.ValidationResult) {
+	_, text, err := markdown.ParseFrontmatter(content)
+	if err != nil {
+		text = content
+	}
+	preamble := metaCommentary(content, rules)
+	for _, phrase := range rules.ForbiddenPhrases {
+		if match, ok := phrase.Find(text); ok {
+			if preamble && strings.HasPrefix(strings.TrimSpace(text), match) {
+				continue
 			}
+			result.Add(phrase.Level(), "contains forbidden phrase %s: %q", phrase, fmt.Sprintf("%.50s", match))
 		}
 	}
 }
+
+// ValidateTimestamps flags summary timestamps that fall beyond the end of the
+// transcript, which the model can only have invented. It adds nothing for an
+// untimed transcript.
+func (s *ValidationService) ValidateTimestamps(content string, transcript domain.Transcript, body domain.Body, result *domain.ValidationResult) {
+	severity := body.ValidationRules().Check(domain.CheckTimestampRange)
+	duration := transcript.Duration()
+	if duration == 0 || severity == domain.RuleOff {
+		return
+	}
+	last := domain.FormatTimestamp(duration)
+	for _, ref := range markdown.FindTimestamps(content) {
+		for _, ts := range []time.Duration{ref.Start, ref.End} {
+			// Cue times are truncated to whole seconds in the prompt, so allow
+			// the final second.
+			if ts > duration+time.Second {
+				result.Add(severity, "timestamp [%s] is beyond the end of the transcript (%s)",
+					domain.FormatTimestamp(ts), last)
+			}
+		}
+	}
+}
+
+// validateMetaCommentary checks for model meta-commentary before the
+// frontmatter. Commentary at the start of the body is caught by the default
+// forbidden phrases instead.
+func (s *ValidationService) validateMetaCommentary(content string, rules domain.ValidationRules, result *domain.ValidationResult) {
+	if metaCommentary(content, rules) {
+		result.Add(rules.Check(domain.CheckMetaCommentary), "contains model meta-commentary (output must start with frontmatter)")
+	}
+}
+
+// metaCommentary reports whether validateMetaCommentary flags content.
+func metaCommentary(content string, rules domain.ValidationRules) bool {
+	return rules.Check(domain.CheckMetaCommentary) != domain.RuleOff &&
+		!markdown.HasFrontmatter(content) && markdown.HasMetaCommentary(content)
+}
@@ -3,8 +3,10 @@ package service_test
 import (
 	"os"
 	"path/filepath"
+	"regexp"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/AvogadroSG1/civic-summary/internal/domain"
 	"github.com/AvogadroSG1/civic-summary/internal/service"
@@ -34,7 +36,7 @@ func TestValidationService_ValidSummary(t *testing.T) {
 	content := loadFixture(t, "valid-summary.md")
 	svc := service.NewValidationService()
 
-	result := svc.Validate(content, testBody())
+	result := svc.Validate(content, testBody(), 0)
 
 	assert.True(t, result.IsValid(), "Expected valid but got errors: %v", result.Errors())
 	// May have warnings but no errors.
@@ -47,7 +49,7 @@ func TestValidationService_MissingFrontmatter(t *testing.T) {
 	content := "# Title\n\nNo frontmatter here."
 	svc := service.NewValidationService()
 
-	result := svc.Validate(content, testBody())
+	result := svc.Validate(content, testBody(), 0)
 
 	assert.False(t, result.IsValid())
 	assert.True(t, result.HasErrors())
@@ -81,7 +83,7 @@ Done.`
 	content += "\n\n" + strings.Repeat("word ", 500)
 
 	svc := service.NewValidationService()
-	result := svc.Validate(content, testBody())
+	result := svc.Validate(content, testBody(), 0)
 
 	// Should have errors for missing sections 2, 4, 5.
 	errors := result.Errors()
@@ -114,7 +116,7 @@ None.
 Done.`
 
 	svc := service.NewValidationService()
-	result := svc.Validate(content, testBody())
+	result := svc.Validate(content, testBody(), 0)
 
 	assert.False(t, result.IsValid())
 	hasWordCountError := false
@@ -126,6 +128,39 @@ Done.`
 	assert.True(t, hasWordCountError, "Expected word count error")
 }
 
+func TestValidationService_ShortMeetingScalesWordCount(t *testing.T) {
+	content := `---
+date: 2025-02-05
+author: Peter O'Connor
+tags:
+  - City-Council
+source: https://youtube.com/watch?v=abc
+meeting_date: 2025-02-04
+---
+
+# Title
+
+## 1. Updates
+` + generateWords(200)
+
+	hasIssue := func(issues []domain.ValidationIssue, text string) bool {
+		for _, issue := range issues {
+			if strings.Contains(issue.Message, text) {
+				return true
+			}
+		}
+		return false
+	}
+
+	svc := service.NewValidationService()
+	unknown := svc.Validate(content, testBody(), 0)
+	assert.True(t, hasIssue(unknown.Errors(), "too short"), "an unknown length expects a full summary")
+
+	short := svc.Validate(content, testBody(), 20*time.Minute)
+	assert.False(t, hasIssue(short.Errors(), "too short"))
+	assert.True(t, hasIssue(short.Warnings(), "recommend 333+"), "a 20-minute meeting expects a third of the words")
+}
+
 func TestValidationService_MetaCommentary(t *testing.T) {
 	content := `Based on the transcript, here is the summary.
 
@@ -135,7 +170,7 @@ func TestValidationService_MetaCommentary(t *testing.T) {
 ` + strings.Repeat("word ", 500)
 
 	svc := service.NewValidationService()
-	result := svc.Validate(content, testBody())
+	result := svc.Validate(content, testBody(), 0)
 
 	assert.False(t, result.IsValid())
 }
@@ -165,7 +200,7 @@ meeting_date: 2025-02-04
 *This citizen summary was created from the official meeting video and transcript.*`
 
 	svc := service.NewValidationService()
-	result := svc.Validate(content, testBody())
+	result := svc.Validate(content, testBody(), 0)
 
 	hasTagError := false
 	for _, e := range result.Errors() {
@@ -175,3 +210,215 @@ meeting_date: 2025-02-04
 	}
 	assert.True(t, hasTagError, "Expected tag spacing error")
 }
+
+func TestValidationService_ValidateTimestamps(t *testing.T) {
+	svc := service.NewValidationService()
+	transcript := domain.Transcript{Cues: []domain.Cue{
+		{Index: 1, Start: 0, End: 5 * time.Second, Text: "Call to order."},
+		{Index: 2, Start: 50 * time.Minute, End: time.Hour, Text: "Adjourned."},
+	}}
+
+	t.Run("within transcript", func(t *testing.T) {
+		result := &domain.ValidationResult{}
+		svc.ValidateTimestamps("[00:00:01] opening and [00:50:00-01:00:00] close", transcript, testBody(), result)
+		assert.Empty(t, result.Issues)
+	})
+
+	t.Run("beyond last cue", func(t *testing.T) {
+		result := &domain.ValidationResult{}
+		svc.ValidateTimestamps("[00:59:00-01:10:00](https://x?t=3540s) and [02:00:00]", transcript, testBody(), result)
+
+		assert.False(t, result.HasErrors(), "out-of-range timestamps are warnings, not errors")
+		require.Len(t, result.Warnings(), 2)
+		assert.Contains(t, result.Warnings()[0].Message, "[01:10:00] is beyond the end of the transcript (01:00:00)")
+		assert.Contains(t, result.Warnings()[1].Message, "[02:00:00]")
+	})
+
+	t.Run("untimed transcript", func(t *testing.T) {
+		result := &domain.ValidationResult{}
+		svc.ValidateTimestamps("[09:00:00]", domain.Transcript{Cues: domain.ParsePlainText("words")}, testBody(), result)
+		assert.Empty(t, result.Issues)
+	})
+}
+
+// planningSummary has four sections and an "Adjournment" closing instead of
+// the shipped templates' five sections and conclusion.
+func planningSummary() string {
+	return `---
+date: 2025-02-05
+author: Peter O'Connor
+tags:
+  - Planning-Commission
+source: https://youtube.com/watch?v=abc
+meeting_date: 2025-02-04
+---
+
+# Planning Commission Meeting
+
+## Site Plans **[00:01:00]**
+## Subdivisions
+## Zoning Text Amendments
+## Other Business
+
+` + strings.Repeat("word ", 400) + `
+
+## Adjournment
+
+*This citizen summary was created from the official meeting video and transcript.*`
+}
+
+func TestValidationService_DefaultRulesRejectCustomStructure(t *testing.T) {
+	result := service.NewValidationService().Validate(planningSummary(), testBody(), 0)
+
+	assert.False(t, result.IsValid())
+}
+
+func TestValidationService_BodyRules(t *testing.T) {
+	minWords, warnWords := 300, 0
+	body := testBody()
+	body.Validation = &domain.ValidationRules{
+		RequiredHeadings: []domain.TextRule{
+			{Text: "## Site Plans"},
+			{Text: "## Subdivisions"},
+			{Text: "## Zoning Text Amendments"},
+			{Text: "## Other Business"},
+			{Pattern: `^## Adjourn`, Severity: domain.RuleWarning},
+		},
+		MinWords:  &minWords,
+		WarnWords: &warnWords,
+	}
+
+	result := service.NewValidationService().Validate(planningSummary(), body, 0)
+
+	assert.True(t, result.IsValid(), "unexpected errors: %v", result.Errors())
+	assert.Empty(t, result.Warnings())
+}
+
+func TestValidationService_BodyRules_MissingHeadingSeverity(t *testing.T) {
+	body := testBody()
+	body.Validation = &domain.ValidationRules{
+		RequiredHeadings: []domain.TextRule{
+			{Text: "## Site Plans"},
+			{Pattern: `^## Public Hearings`, Severity: domain.RuleWarning},
+			{Text: "## Staff Reports", Severity: domain.RuleOff},
+		},
+	}
+
+	result := service.NewValidationService().Validate(planningSummary(), body, 0)
+
+	assert.Contains(t, issueMessages(result.Warnings()), "missing required section: /^## Public Hearings/")
+	assert.NotContains(t, issueMessages(result.Issues), "missing required section: ## Staff Reports")
+}
+
+func TestValidationService_RequiredFrontmatter(t *testing.T) {
+	body := testBody()
+	body.Validation = &domain.ValidationRules{RequiredFrontmatter: []string{"date", "agenda_url"}}
+
+	result := service.NewValidationService().Validate(loadFixture(t, "valid-summary.md"), body, 0)
+
+	assert.Contains(t, issueMessages(result.Errors()), "missing required frontmatter key: agenda_url")
+}
+
+func TestValidationService_ForbiddenPhrases(t *testing.T) {
+	body := testBody()
+	body.Validation = &domain.ValidationRules{
+		ForbiddenPhrases: []domain.TextRule{
+			{Text: "As an AI"},
+			{Pattern: `(?i)\bin conclusion\b`, Severity: domain.RuleWarning},
+		},
+	}
+	content := loadFixture(t, "valid-summary.md") + "\nAs an AI, I cannot vote. In conclusion, thanks.\n"
+
+	result := service.NewValidationService().Validate(content, body, 0)
+
+	assert.Contains(t, issueMessages(result.Errors()), `contains forbidden phrase As an AI: "As an AI"`)
+	assert.Contains(t, issueMessages(result.Warnings()), `contains forbidden phrase /(?i)\bin conclusion\b/: "In conclusion"`)
+}
+
+func TestValidationService_DefaultForbiddenPhraseCatchesBodyPreamble(t *testing.T) {
+	content := strings.Replace(loadFixture(t, "valid-summary.md"), "\n# ", "\nHere's the summary you asked for.\n\n# ", 1)
+
+	result := service.NewValidationService().Validate(content, testBody(), 0)
+
+	assert.False(t, result.IsValid())
+}
+
+func TestValidationService_PreambleWithoutFrontmatterReportedOnce(t *testing.T) {
+	content := "Based on the transcript, here is the summary.\n\n# Title\n"
+
+	result := service.NewValidationService().Validate(content, testBody(), 0)
+
+	messages := issueMessages(result.Errors())
+	assert.Contains(t, messages, "contains model meta-commentary (output must start with frontmatter)")
+	for _, msg := range messages {
+		assert.NotContains(t, msg, "forbidden phrase")
+	}
+
+	// With the meta-commentary check off, the default phrase still catches it.
+	body := testBody()
+	body.Validation = &domain.ValidationRules{Checks: map[string]domain.RuleSeverity{domain.CheckMetaCommentary: domain.RuleOff}}
+	result = service.NewValidationService().Validate(content, body, 0)
+	assert.Contains(t, strings.Join(issueMessages(result.Errors()), "\n"), "forbidden phrase")
+}
+
+func TestValidationService_ChecksOff(t *testing.T) {
+	body := testBody()
+	body.Validation = &domain.ValidationRules{
+		Checks: map[string]domain.RuleSeverity{
+			domain.CheckTimestamps:     domain.RuleOff,
+			domain.CheckTagSpaces:      domain.RuleWarning,
+			domain.CheckTimestampRange: domain.RuleOff,
+		},
+	}
+	content := strings.Replace(loadFixture(t, "valid-summary.md"), "  - City-Council", "  - City Council", 1)
+	content = summaryTimestampPattern.ReplaceAllString(content, "[time")
+
+	result := service.NewValidationService().Validate(content, body, 0)
+	service.NewValidationService().ValidateTimestamps("[09:00:00]",
+		domain.Transcript{Cues: []domain.Cue{{Index: 1, End: time.Second, Text: "hi"}}}, body, result)
+
+	assert.True(t, result.IsValid(), "unexpected errors: %v", result.Errors())
+	messages := issueMessages(result.Issues)
+	assert.NotContains(t, messages, "no timestamps found (expected [HH:MM:SS] format)")
+	assert.Contains(t, messages, `tag contains spaces (use hyphens): "City Council"`)
+	for _, m := range messages {
+		assert.NotContains(t, m, "beyond the end of the transcript")
+	}
+}
+
+var summaryTimestampPattern = regexp.MustCompile(`\[\d{1,2}:\d{2}:\d{2}`)
+
+func issueMessages(issues []domain.ValidationIssue) []string {
+	messages := make([]string, len(issues))
+	for i, issue := range issues {
+		messages[i] = issue.Message
+	}
+	return messages
+}
+
+func TestValidationService_Roster(t *testing.T) {
+	content := loadFixture(t, "valid-summary.md")
+	body := testBody()
+	body.Roster = domain.Roster{
+		{Name: "Emily Davis", Title: "Council Member"},
+		{Name: "Mark Johnson", Title: "Council Member"},
+	}
+
+	result := service.NewValidationService().Validate(content, body, 0)
+
+	assert.True(t, result.IsValid(), "roster issues are warnings by default: %v", result.Errors())
+	messages := issueMessages(result.Warnings())
+	assert.Contains(t, messages, `"Council Member Garcia" is not on the Hagerstown City Council roster`)
+	assert.NotContains(t, messages, `"Council Member Davis" is not on the Hagerstown City Council roster`)
+
+	body.Validation = &domain.ValidationRules{Checks: map[string]domain.RuleSeverity{domain.CheckRoster: domain.RuleError}}
+	assert.False(t, service.NewValidationService().Validate(content, body, 0).IsValid())
+}
+
+func TestValidationService_Roster_NoRoster(t *testing.T) {
+	result := service.NewValidationService().Validate(loadFixture(t, "valid-summary.md"), testBody(), 0)
+
+	for _, m := range issueMessages(result.Issues) {
+		assert.NotContains(t, m, "roster")
+	}
+}
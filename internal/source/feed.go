@@ -0,0 +1,235 @@
+package source
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/AvogadroSG1/civic-summary/internal/domain"
+	"github.com/AvogadroSG1/civic-summary/internal/executor"
+)
+
+// mediaRSSNamespace is the Media RSS namespace used for <media:content>.
+const mediaRSSNamespace = "http://search.yahoo.com/mrss/"
+
+// Feed lists the items of an RSS 2.0 or Atom feed. Each item's recording is
+// its enclosure, falling back to its <media:content> and then its link.
+type Feed struct {
+	ytdlpMedia
+	httpClient *http.Client
+	url        string
+}
+
+// NewFeed creates a Feed source for a feed URL or local file path.
+func NewFeed(ytdlp *executor.YtDlpExecutor, httpClient *http.Client, url string) *Feed {
+	f := &Feed{httpClient: httpClient, url: url}
+	f.ytdlpMedia = ytdlpMedia{ytdlp: ytdlp, resolve: listedURL(f.Videos)}
+	return f
+}
+
+// Videos implements VideoSource.
+func (f *Feed) Videos(ctx context.Context) ([]domain.Video, error) {
+	items, err := f.items(ctx)
+	if err != nil {
+		return nil, err
+	}
+	videos := make([]domain.Video, len(items))
+	for i, item := range items {
+		videos[i] = item.video
+	}
+	return videos, nil
+}
+
+// Description implements VideoSource. The feed item's own description is
+// used when it has one, since feed media URLs often point at bare files with
+// no page for yt-dlp to read.
+func (f *Feed) Description(ctx context.Context, meeting domain.Meeting) (string, error) {
+	items, err := f.items(ctx)
+	if err != nil {
+		return "", err
+	}
+	for _, item := range items {
+		if item.video.ID == meeting.VideoID && item.description != "" {
+			return item.description, nil
+		}
+	}
+	return f.ytdlpMedia.Description(ctx, meeting)
+}
+
+// feedItem is a parsed feed entry with a recording.
+type feedItem struct {
+	video       domain.Video
+	description string
+}
+
+// items fetches and parses the feed. Entries without a recording URL are
+// skipped.
+func (f *Feed) items(ctx context.Context) ([]feedItem, error) {
+	data, err := fetch(ctx, f.httpClient, f.url)
+	if err != nil {
+		return nil, fmt.Errorf("listing feed: %w", err)
+	}
+	items, err := parseFeed(data)
+	if err != nil {
+		return nil, fmt.Errorf("parsing feed %s: %w", f.url, err)
+	}
+	return items, nil
+}
+
+type rssDocument struct {
+	Items []rssItem `xml:"channel>item"`
+}
+
+type rssItem struct {
+	Title       string `xml:"title"`
+	Link        string `xml:"link"`
+	GUID        string `xml:"guid"`
+	PubDate     string `xml:"pubDate"`
+	Description string `xml:"description"`
+	Enclosure   struct {
+		URL string `xml:"url,attr"`
+	} `xml:"enclosure"`
+	MediaContent []struct {
+		URL string `xml:"url,attr"`
+	} `xml:"http://search.yahoo.com/mrss/ content"`
+}
+
+type atomDocument struct {
+	Entries []atomEntry `xml:"entry"`
+}
+
+type atomEntry struct {
+	Title     string `xml:"title"`
+	ID        string `xml:"id"`
+	Published string `xml:"published"`
+	Updated   string `xml:"updated"`
+	Summary   string `xml:"summary"`
+	Content   string `xml:"content"`
+	Links     []struct {
+		Href string `xml:"href,attr"`
+		Rel  string `xml:"rel,attr"`
+	} `xml:"link"`
+	MediaContent []struct {
+		URL string `xml:"url,attr"`
+	} `xml:"http://search.yahoo.com/mrss/ group>content"`
+}
+
+// parseFeed parses RSS 2.0 or Atom, chosen by the root element.
+func parseFeed(data []byte) ([]feedItem, error) {
+	root, err := rootElement(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var items []feedItem
+	switch root {
+	case "rss":
+		var doc rssDocument
+		if err := xml.Unmarshal(data, &doc); err != nil {
+			return nil, err
+		}
+		for _, it := range doc.Items {
+			url := firstNonEmpty(it.Enclosure.URL, firstMediaURL(it.MediaContent), strings.TrimSpace(it.Link))
+			if url == "" {
+				continue
+			}
+			items = append(items, feedItem{
+				video: domain.Video{
+					ID:        stableID(firstNonEmpty(strings.TrimSpace(it.GUID), url)),
+					Title:     strings.TrimSpace(it.Title),
+					URL:       url,
+					Published: parseFeedTime(it.PubDate),
+				},
+				description: strings.TrimSpace(it.Description),
+			})
+		}
+	case "feed":
+		var doc atomDocument
+		if err := xml.Unmarshal(data, &doc); err != nil {
+			return nil, err
+		}
+		for _, e := range doc.Entries {
+			url := firstNonEmpty(atomLink(e, "enclosure"), firstMediaURL(e.MediaContent), atomLink(e, "alternate"))
+			if url == "" {
+				continue
+			}
+			items = append(items, feedItem{
+				video: domain.Video{
+					ID:        stableID(firstNonEmpty(strings.TrimSpace(e.ID), url)),
+					Title:     strings.TrimSpace(e.Title),
+					URL:       url,
+					Published: parseFeedTime(firstNonEmpty(e.Published, e.Updated)),
+				},
+				description: strings.TrimSpace(firstNonEmpty(e.Summary, e.Content)),
+			})
+		}
+	default:
+		return nil, fmt.Errorf("unsupported feed root element <%s>; expected <rss> or <feed>", root)
+	}
+	return items, nil
+}
+
+// rootElement returns the local name of the document's first element.
+func rootElement(data []byte) (string, error) {
+	dec := xml.NewDecoder(bytes.NewReader(data))
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			return "", fmt.Errorf("reading root element: %w", err)
+		}
+		if start, ok := tok.(xml.StartElement); ok {
+			return start.Name.Local, nil
+		}
+	}
+}
+
+// atomLink returns the href of the entry's first link with rel. An Atom link
+// without rel is an alternate link.
+func atomLink(e atomEntry, rel string) string {
+	for _, l := range e.Links {
+		r := l.Rel
+		if r == "" {
+			r = "alternate"
+		}
+		if r == rel && l.Href != "" {
+			return strings.TrimSpace(l.Href)
+		}
+	}
+	return ""
+}
+
+func firstMediaURL(contents []struct {
+	URL string `xml:"url,attr"`
+}) string {
+	for _, c := range contents {
+		if c.URL != "" {
+			return strings.TrimSpace(c.URL)
+		}
+	}
+	return ""
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// parseFeedTime parses an RSS pubDate or Atom timestamp, returning the zero
+// time when the value is missing or malformed.
+func parseFeedTime(s string) time.Time {
+	s = strings.TrimSpace(s)
+	for _, layout := range []string{time.RFC1123Z, time.RFC1123, time.RFC3339} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t
+		}
+	}
+	return time.Time{}
+}
@@ -0,0 +1,147 @@
+package source_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/AvogadroSG1/civic-summary/internal/domain"
+	"github.com/AvogadroSG1/civic-summary/internal/executor"
+	"github.com/AvogadroSG1/civic-summary/internal/source"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testRSS = `<?xml version="1.0" encoding="UTF-8"?>
+<rss version="2.0" xmlns:media="http://search.yahoo.com/mrss/">
+  <channel>
+    <title>City Council Meetings</title>
+    <item>
+      <title>City Council - March 4, 2025</title>
+      <guid isPermaLink="false">meeting-2025-03-04</guid>
+      <pubDate>Wed, 05 Mar 2025 14:00:00 +0000</pubDate>
+      <description>Agenda: https://example.gov/agenda/2025-03-04.pdf</description>
+      <enclosure url="https://media.example.gov/council-2025-03-04.mp4" type="video/mp4" length="0"/>
+    </item>
+    <item>
+      <title>Planning Commission</title>
+      <guid>https://example.gov/videos?id=42&amp;view=full</guid>
+      <pubDate>Tue, 11 Mar 2025 18:30:00 EST</pubDate>
+      <media:content url="https://media.example.gov/planning-42.mp4" medium="video"/>
+    </item>
+    <item>
+      <title>Text-only announcement</title>
+    </item>
+  </channel>
+</rss>`
+
+const testAtom = `<?xml version="1.0" encoding="utf-8"?>
+<feed xmlns="http://www.w3.org/2005/Atom">
+  <title>Board Meetings</title>
+  <entry>
+    <title>Board of Education - January 14, 2025</title>
+    <id>tag:example.gov,2025:boe-0114</id>
+    <published>2025-01-15T09:00:00Z</published>
+    <summary>Board meeting recording.</summary>
+    <link href="https://example.gov/boe/0114"/>
+    <link rel="enclosure" href="https://media.example.gov/boe-0114.mp4" type="video/mp4"/>
+  </entry>
+  <entry>
+    <title>Board Work Session</title>
+    <id>boe-0121</id>
+    <updated>2025-01-21T23:00:00Z</updated>
+    <link rel="alternate" href="https://vimeo.com/987654321"/>
+  </entry>
+</feed>`
+
+func serveFeed(t *testing.T, body string) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestFeed_VideosRSS(t *testing.T) {
+	srv := serveFeed(t, testRSS)
+	feed := source.NewFeed(nil, srv.Client(), srv.URL)
+
+	videos, err := feed.Videos(context.Background())
+
+	require.NoError(t, err)
+	require.Len(t, videos, 2, "items without media are skipped")
+
+	assert.Equal(t, "meeting-2025-03-04", videos[0].ID)
+	assert.Equal(t, "City Council - March 4, 2025", videos[0].Title)
+	assert.Equal(t, "https://media.example.gov/council-2025-03-04.mp4", videos[0].URL)
+	assert.WithinDuration(t, time.Date(2025, 3, 5, 14, 0, 0, 0, time.UTC), videos[0].Published, 0)
+
+	assert.Equal(t, "https://media.example.gov/planning-42.mp4", videos[1].URL, "media:content is used without an enclosure")
+	assert.Regexp(t, `^[0-9a-f]{16}$`, videos[1].ID, "URL-like GUIDs are hashed into a filename-safe ID")
+	assert.Equal(t, 2025, videos[1].Published.Year())
+}
+
+func TestFeed_VideosAtom(t *testing.T) {
+	srv := serveFeed(t, testAtom)
+	feed := source.NewFeed(nil, srv.Client(), srv.URL)
+
+	videos, err := feed.Videos(context.Background())
+
+	require.NoError(t, err)
+	require.Len(t, videos, 2)
+	assert.Equal(t, "https://media.example.gov/boe-0114.mp4", videos[0].URL, "enclosure links win over alternate links")
+	assert.WithinDuration(t, time.Date(2025, 1, 15, 9, 0, 0, 0, time.UTC), videos[0].Published, 0)
+	assert.Equal(t, "boe-0121", videos[1].ID)
+	assert.Equal(t, "https://vimeo.com/987654321", videos[1].URL)
+	assert.WithinDuration(t, time.Date(2025, 1, 21, 23, 0, 0, 0, time.UTC), videos[1].Published, 0, "updated is used without published")
+}
+
+func TestFeed_VideosStableIDs(t *testing.T) {
+	srv := serveFeed(t, testRSS)
+	feed := source.NewFeed(nil, srv.Client(), srv.URL)
+
+	first, err := feed.Videos(context.Background())
+	require.NoError(t, err)
+	second, err := feed.Videos(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, first[1].ID, second[1].ID)
+}
+
+func TestFeed_Description(t *testing.T) {
+	srv := serveFeed(t, testRSS)
+	mock := executor.NewMockCommander()
+	mock.DefaultResult = &executor.CommandResult{Stdout: "from yt-dlp"}
+	feed := source.NewFeed(executor.NewYtDlpExecutor(mock, "yt-dlp"), srv.Client(), srv.URL)
+
+	description, err := feed.Description(context.Background(), domain.Meeting{VideoID: "meeting-2025-03-04"})
+	require.NoError(t, err)
+	assert.Equal(t, "Agenda: https://example.gov/agenda/2025-03-04.pdf", description)
+	assert.Empty(t, mock.Calls, "the feed's own description needs no yt-dlp call")
+
+	videos, err := feed.Videos(context.Background())
+	require.NoError(t, err)
+	description, err = feed.Description(context.Background(), domain.Meeting{VideoID: videos[1].ID, VideoURL: videos[1].URL})
+	require.NoError(t, err)
+	assert.Equal(t, "from yt-dlp", description, "items without a description fall back to yt-dlp")
+}
+
+func TestFeed_Errors(t *testing.T) {
+	t.Run("HTTPStatus", func(t *testing.T) {
+		srv := httptest.NewServer(http.NotFoundHandler())
+		defer srv.Close()
+
+		_, err := source.NewFeed(nil, srv.Client(), srv.URL).Videos(context.Background())
+		assert.ErrorContains(t, err, "HTTP 404")
+	})
+
+	t.Run("NotAFeed", func(t *testing.T) {
+		srv := serveFeed(t, "<html><body>Meetings</body></html>")
+
+		_, err := source.NewFeed(nil, srv.Client(), srv.URL).Videos(context.Background())
+		assert.ErrorContains(t, err, "unsupported feed root element <html>")
+	})
+}
@@ -0,0 +1,73 @@
+package source
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/AvogadroSG1/civic-summary/internal/domain"
+	"github.com/AvogadroSG1/civic-summary/internal/executor"
+)
+
+// minFileAge is how long a file must go unmodified before a watch folder
+// lists it, so a recording still being copied onto a shared drive is picked
+// up on a later run rather than half-read.
+const minFileAge = time.Minute
+
+// Folder watches a local directory for recordings and transcripts. Each file
+// is one meeting; when a recording and a transcript share a file name, the
+// transcript is used. Hidden files and subdirectories are ignored.
+type Folder struct {
+	ytdlpMedia
+	dir string
+}
+
+// NewFolder creates a Folder source for a directory.
+func NewFolder(ytdlp *executor.YtDlpExecutor, dir string) *Folder {
+	f := &Folder{dir: dir}
+	f.ytdlpMedia = ytdlpMedia{ytdlp: ytdlp, resolve: listedURL(f.Videos)}
+	return f
+}
+
+// Videos implements VideoSource.
+func (f *Folder) Videos(_ context.Context) ([]domain.Video, error) {
+	entries, err := os.ReadDir(f.dir)
+	if err != nil {
+		return nil, fmt.Errorf("listing folder: %w", err)
+	}
+
+	byID := make(map[string]domain.Video)
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || strings.HasPrefix(name, ".") || !IsRecordingFile(name) {
+			continue
+		}
+		path := filepath.Join(f.dir, name)
+		if info, err := entry.Info(); err != nil || time.Since(info.ModTime()) < minFileAge {
+			slog.Debug("skipping file still being written", "path", path)
+			continue
+		}
+
+		video, err := LocalVideo(path)
+		if err != nil {
+			slog.Warn("skipping unreadable file", "path", path, "error", err)
+			continue
+		}
+		if prev, ok := byID[video.ID]; ok && transcriptExtensions[strings.ToLower(filepath.Ext(prev.URL))] {
+			continue
+		}
+		byID[video.ID] = video
+	}
+
+	videos := make([]domain.Video, 0, len(byID))
+	for _, v := range byID {
+		videos = append(videos, v)
+	}
+	sort.Slice(videos, func(i, j int) bool { return videos[i].URL < videos[j].URL })
+	return videos, nil
+}
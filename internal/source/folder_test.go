@@ -0,0 +1,118 @@
+package source_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/AvogadroSG1/civic-summary/internal/domain"
+	"github.com/AvogadroSG1/civic-summary/internal/source"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeOld writes a file and backdates it past the watch folder's settle time.
+func writeOld(t *testing.T, path, content string) {
+	t.Helper()
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	old := time.Date(2025, 3, 20, 12, 0, 0, 0, time.Local)
+	require.NoError(t, os.Chtimes(path, old, old))
+}
+
+func TestFolder_Videos(t *testing.T) {
+	dir := t.TempDir()
+	writeOld(t, filepath.Join(dir, "GMT20250304-180000_Recording.mp4"), "video")
+	writeOld(t, filepath.Join(dir, "Planning Commission.m4a"), "audio")
+	writeOld(t, filepath.Join(dir, "board-2025-03-11.mp4"), "video")
+	writeOld(t, filepath.Join(dir, "board-2025-03-11.vtt"), "WEBVTT\n")
+	writeOld(t, filepath.Join(dir, "notes.docx"), "ignored")
+	writeOld(t, filepath.Join(dir, ".hidden.mp4"), "ignored")
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "archive"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "still-copying.mp4"), []byte("partial"), 0o644))
+
+	videos, err := source.NewFolder(nil, dir).Videos(context.Background())
+	require.NoError(t, err)
+
+	require.Len(t, videos, 3)
+	byTitle := make(map[string]domain.Video)
+	for _, v := range videos {
+		byTitle[v.Title] = v
+	}
+
+	zoom := byTitle["GMT20250304-180000_Recording"]
+	assert.Regexp(t, `^[0-9a-f]{16}$`, zoom.ID)
+	assert.Equal(t, filepath.Join(dir, "GMT20250304-180000_Recording.mp4"), zoom.URL)
+	assert.Equal(t, time.Date(2025, 3, 4, 0, 0, 0, 0, time.UTC), zoom.Published, "the date comes from the file name")
+
+	assert.Equal(t, filepath.Join(dir, "board-2025-03-11.vtt"), byTitle["board-2025-03-11"].URL,
+		"a transcript wins over a recording with the same name")
+
+	planning := byTitle["Planning Commission"]
+	assert.Regexp(t, `^[0-9a-f]{16}$`, planning.ID)
+	assert.Equal(t, 20, planning.Published.Day(), "without a date in the name, the modification time is used")
+}
+
+func TestFolder_MissingDirectory(t *testing.T) {
+	_, err := source.NewFolder(nil, filepath.Join(t.TempDir(), "missing")).Videos(context.Background())
+	assert.ErrorContains(t, err, "listing folder")
+}
+
+func TestLocalVideo(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "council_2025_02_18.srt")
+	require.NoError(t, os.WriteFile(path, []byte("1\n00:00:01,000 --> 00:00:02,000\nHi\n"), 0o644))
+
+	video, err := source.LocalVideo(path)
+	require.NoError(t, err)
+	assert.Regexp(t, `^[0-9a-f]{16}$`, video.ID)
+	assert.Equal(t, "council_2025_02_18", video.Title)
+	assert.Equal(t, time.Date(2025, 2, 18, 0, 0, 0, 0, time.UTC), video.Published)
+
+	_, err = source.LocalVideo(filepath.Join(dir, "missing.mp4"))
+	assert.Error(t, err)
+
+	// Files with the same name in different folders are different meetings.
+	other := filepath.Join(t.TempDir(), "council_2025_02_18.srt")
+	require.NoError(t, os.WriteFile(other, []byte("1\n00:00:01,000 --> 00:00:02,000\nHi\n"), 0o644))
+	otherVideo, err := source.LocalVideo(other)
+	require.NoError(t, err)
+	assert.NotEqual(t, video.ID, otherVideo.ID)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "agenda.pdf"), []byte("%PDF"), 0o644))
+	_, err = source.LocalVideo(filepath.Join(dir, "agenda.pdf"))
+	assert.ErrorContains(t, err, "not a media or transcript file")
+}
+
+func TestLocalMedia(t *testing.T) {
+	dir := t.TempDir()
+	transcript := filepath.Join(dir, "council.vtt")
+	recording := filepath.Join(dir, "council.mp3")
+	require.NoError(t, os.WriteFile(transcript, []byte("WEBVTT\n"), 0o644))
+	require.NoError(t, os.WriteFile(recording, []byte("audio"), 0o644))
+
+	// Local files are read in place whatever the body's source type.
+	yt := source.NewYouTube(nil, "")
+	outputDir := t.TempDir()
+
+	path, err := yt.Captions(context.Background(), domain.Meeting{VideoID: "council", VideoURL: transcript}, domain.DefaultCaptionPolicy(), outputDir)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(outputDir, "council.vtt"), path)
+	assert.FileExists(t, transcript, "the original is copied, not moved")
+
+	path, err = yt.Captions(context.Background(), domain.Meeting{VideoID: "council", VideoURL: recording}, domain.DefaultCaptionPolicy(), outputDir)
+	require.NoError(t, err)
+	assert.Empty(t, path, "a recording has no captions")
+
+	path, err = yt.Audio(context.Background(), domain.Meeting{VideoID: "council", VideoURL: "file://" + recording}, outputDir)
+	require.NoError(t, err)
+	assert.Equal(t, recording, path)
+
+	_, err = yt.Audio(context.Background(), domain.Meeting{VideoID: "council", VideoURL: transcript}, outputDir)
+	assert.ErrorContains(t, err, "not an audio or video file")
+
+	description, err := yt.Description(context.Background(), domain.Meeting{VideoID: "council", VideoURL: recording})
+	require.NoError(t, err)
+	assert.Empty(t, description)
+}
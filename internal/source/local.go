@@ -0,0 +1,126 @@
+package source
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/AvogadroSG1/civic-summary/internal/domain"
+)
+
+var (
+	// mediaExtensions are the audio and video files Whisper can transcribe.
+	mediaExtensions = map[string]bool{
+		".mp4": true, ".m4v": true, ".mov": true, ".mkv": true, ".webm": true, ".avi": true,
+		".mp3": true, ".m4a": true, ".wav": true, ".aac": true, ".flac": true, ".ogg": true, ".opus": true,
+	}
+	// transcriptExtensions are the transcript files domain.ParseTranscript reads.
+	transcriptExtensions = map[string]bool{".srt": true, ".vtt": true, ".json": true, ".txt": true}
+
+	// fileNameDatePattern finds a date in a file name: 2025-03-04, 2025_03_04,
+	// or 20250304 as in Zoom's "GMT20250304-180000_Recording".
+	fileNameDatePattern = regexp.MustCompile(`(?:^|\D)((?:19|20)\d{2})[-_.]?(\d{2})[-_.]?(\d{2})(?:\D|$)`)
+)
+
+// IsRecordingFile reports whether path has an extension that can be ingested:
+// an audio or video file, or a transcript.
+func IsRecordingFile(path string) bool {
+	ext := strings.ToLower(filepath.Ext(path))
+	return mediaExtensions[ext] || transcriptExtensions[ext]
+}
+
+// LocalVideo describes a local recording or transcript file as a Video. The
+// ID is a hash of the file's absolute path without its extension, so the same
+// file gets the same ID whether it is ingested directly or found in a watch
+// folder, a recording and its transcript beside it share one, and files with
+// the same name in different folders, such as Zoom's per-meeting
+// "zoom_0.mp4", do not. Published is the date in the file name, or else the
+// file's modification time.
+func LocalVideo(path string) (domain.Video, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return domain.Video{}, fmt.Errorf("resolving %s: %w", path, err)
+	}
+	info, err := os.Stat(abs)
+	if err != nil {
+		return domain.Video{}, err
+	}
+	if info.IsDir() {
+		return domain.Video{}, fmt.Errorf("%s is a directory", path)
+	}
+	if !IsRecordingFile(abs) {
+		return domain.Video{}, fmt.Errorf("%s is not a media or transcript file", path)
+	}
+
+	stem := strings.TrimSuffix(filepath.Base(abs), filepath.Ext(abs))
+	published := info.ModTime()
+	if date, ok := dateFromFileName(stem); ok {
+		published = date
+	}
+	return domain.Video{
+		ID:        stableID(strings.TrimSuffix(abs, filepath.Ext(abs))),
+		Title:     stem,
+		URL:       abs,
+		Published: published,
+	}, nil
+}
+
+// dateFromFileName returns the first valid date found in a file name.
+func dateFromFileName(name string) (time.Time, bool) {
+	for _, m := range fileNameDatePattern.FindAllStringSubmatch(name, -1) {
+		if t, err := time.Parse("2006-01-02", m[1]+"-"+m[2]+"-"+m[3]); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// localPath turns a local recording URL into a path.
+func localPath(url string) string {
+	return strings.TrimPrefix(url, "file://")
+}
+
+// localCaptions copies a local transcript file into outputDir, leaving the
+// original in place. Media files have no captions, so "" is returned and
+// transcription falls back to Whisper.
+func localCaptions(path, outputDir, name string) (string, error) {
+	ext := strings.ToLower(filepath.Ext(path))
+	if !transcriptExtensions[ext] {
+		return "", nil
+	}
+
+	src, err := os.Open(path)
+	if err != nil {
+		return "", fmt.Errorf("opening transcript: %w", err)
+	}
+	defer func() { _ = src.Close() }()
+
+	dest := filepath.Join(outputDir, name+ext)
+	out, err := os.Create(dest)
+	if err != nil {
+		return "", fmt.Errorf("copying transcript: %w", err)
+	}
+	if _, err := io.Copy(out, src); err != nil {
+		_ = out.Close()
+		return "", fmt.Errorf("copying transcript: %w", err)
+	}
+	if err := out.Close(); err != nil {
+		return "", fmt.Errorf("copying transcript: %w", err)
+	}
+	return dest, nil
+}
+
+// localAudio returns a local media file for Whisper to read in place.
+func localAudio(path string) (string, error) {
+	if !mediaExtensions[strings.ToLower(filepath.Ext(path))] {
+		return "", fmt.Errorf("%s is not an audio or video file", filepath.Base(path))
+	}
+	if _, err := os.Stat(path); err != nil {
+		return "", err
+	}
+	return path, nil
+}
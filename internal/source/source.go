@@ -0,0 +1,194 @@
+// Package source lists a body's meeting recordings and fetches their
+// captions, audio, and descriptions. Each body selects an implementation with
+// source.type: a YouTube playlist or channel, an RSS or Atom feed with media
+// enclosures, a plain listing of video URLs, or a local watch folder.
+//
+// Media for every source type is fetched with yt-dlp, which handles YouTube,
+// Vimeo, Granicus, Swagit, and direct media files alike; the source types
+// differ in how recordings are listed. A recording whose URL is a local path
+// is read in place instead.
+package source
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/AvogadroSG1/civic-summary/internal/domain"
+	"github.com/AvogadroSG1/civic-summary/internal/executor"
+)
+
+// maxListingBytes caps the size of a downloaded feed or URL listing.
+const maxListingBytes = 10 << 20
+
+// safeIDPattern matches identifiers usable as-is in file names.
+var safeIDPattern = regexp.MustCompile(`^[A-Za-z0-9_-]{1,64}$`)
+
+// VideoSource lists a body's recordings and fetches their media.
+type VideoSource interface {
+	// Videos lists every recording the source currently publishes. Filtering
+	// by date is left to the caller, which parses the meeting date from each
+	// title.
+	Videos(ctx context.Context) ([]domain.Video, error)
+
+	// Captions downloads the recording's captions, choosing the track by
+	// policy, into outputDir and returns the file's path, or "" when the
+	// recording has none the policy accepts. A local transcript file is
+	// used whatever the policy.
+	Captions(ctx context.Context, meeting domain.Meeting, policy domain.CaptionPolicy, outputDir string) (string, error)
+
+	// Audio returns the path of an audio or video file for Whisper to
+	// transcribe, downloading the audio track into outputDir when the
+	// recording is not already on disk.
+	Audio(ctx context.Context, meeting domain.Meeting, outputDir string) (string, error)
+
+	// Description returns the recording's description text, which may link
+	// the meeting's agenda.
+	Description(ctx context.Context, meeting domain.Meeting) (string, error)
+}
+
+// Detailer is implemented by sources whose listing leaves out metadata, such
+// as a flat YouTube playlist, which has no descriptions, chapters, or caption
+// tracks. Discovery asks it for the full details of each new meeting.
+type Detailer interface {
+	Details(ctx context.Context, meeting domain.Meeting) (domain.VideoDetails, error)
+}
+
+// New builds the VideoSource selected by body.source.type. httpClient fetches
+// feeds and remote URL listings.
+func New(body domain.Body, ytdlp *executor.YtDlpExecutor, httpClient *http.Client) (VideoSource, error) {
+	switch body.SourceType() {
+	case domain.SourceYouTube:
+		return NewYouTube(ytdlp, body.DiscoveryURL()), nil
+	case domain.SourceFeed:
+		return NewFeed(ytdlp, httpClient, body.Source.URL), nil
+	case domain.SourceURLList:
+		return NewURLList(ytdlp, httpClient, body.Source.URL), nil
+	case domain.SourceFolder:
+		return NewFolder(ytdlp, body.Source.URL), nil
+	default:
+		return nil, fmt.Errorf("source: unknown type %q for %s; supported: %v",
+			body.SourceType(), body.Slug, domain.SourceTypes())
+	}
+}
+
+// ytdlpMedia fetches captions, audio, and descriptions with yt-dlp, or reads
+// them from disk for local files. The recording's URL comes from resolve, so
+// each source decides how a meeting maps back to its listing.
+type ytdlpMedia struct {
+	ytdlp   *executor.YtDlpExecutor
+	resolve func(ctx context.Context, meeting domain.Meeting) (string, error)
+}
+
+// Captions implements VideoSource.
+func (m ytdlpMedia) Captions(ctx context.Context, meeting domain.Meeting, policy domain.CaptionPolicy, outputDir string) (string, error) {
+	url, err := m.resolve(ctx, meeting)
+	if err != nil {
+		return "", err
+	}
+	if domain.IsLocalFile(url) {
+		return localCaptions(localPath(url), outputDir, meeting.VideoID)
+	}
+	return m.ytdlp.DownloadCaptions(ctx, url, outputDir, meeting.VideoID, policy)
+}
+
+// Audio implements VideoSource.
+func (m ytdlpMedia) Audio(ctx context.Context, meeting domain.Meeting, outputDir string) (string, error) {
+	url, err := m.resolve(ctx, meeting)
+	if err != nil {
+		return "", err
+	}
+	if domain.IsLocalFile(url) {
+		return localAudio(localPath(url))
+	}
+	audioPath := filepath.Join(outputDir, meeting.VideoID+".mp3")
+	if err := m.ytdlp.DownloadAudio(ctx, url, audioPath); err != nil {
+		return "", err
+	}
+	return audioPath, nil
+}
+
+// Description implements VideoSource. A description already in the meeting's
+// details is used without asking yt-dlp again.
+func (m ytdlpMedia) Description(ctx context.Context, meeting domain.Meeting) (string, error) {
+	if meeting.Details.Description != "" {
+		return meeting.Details.Description, nil
+	}
+	url, err := m.resolve(ctx, meeting)
+	if err != nil {
+		return "", err
+	}
+	if domain.IsLocalFile(url) {
+		return "", nil
+	}
+	return m.ytdlp.GetDescription(ctx, url)
+}
+
+// listedURL returns a resolver for sources whose recordings have no URL
+// derivable from their ID. A meeting discovered in this run carries its URL;
+// otherwise, as when retrying from quarantine, the listing is searched.
+func listedURL(list func(ctx context.Context) ([]domain.Video, error)) func(context.Context, domain.Meeting) (string, error) {
+	return func(ctx context.Context, meeting domain.Meeting) (string, error) {
+		if meeting.VideoURL != "" {
+			return meeting.VideoURL, nil
+		}
+		videos, err := list(ctx)
+		if err != nil {
+			return "", err
+		}
+		for _, v := range videos {
+			if v.ID == meeting.VideoID {
+				return v.URL, nil
+			}
+		}
+		return "", fmt.Errorf("video %s not found in source", meeting.VideoID)
+	}
+}
+
+// stableID derives a video ID from a feed GUID or URL. Keys that are already
+// short and filename-safe are kept; others are hashed so that the same
+// recording gets the same ID on every listing.
+func stableID(key string) string {
+	if safeIDPattern.MatchString(key) {
+		return key
+	}
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])[:16]
+}
+
+// fetch reads a listing from an http(s) URL or, failing that, a local path.
+func fetch(ctx context.Context, httpClient *http.Client, location string) ([]byte, error) {
+	if !strings.HasPrefix(location, "http://") && !strings.HasPrefix(location, "https://") {
+		data, err := os.ReadFile(location)
+		if err != nil {
+			return nil, fmt.Errorf("reading %s: %w", location, err)
+		}
+		return data, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, location, nil)
+	if err != nil {
+		return nil, fmt.Errorf("building request: %w", err)
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetching %s: %w", location, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetching %s: HTTP %d", location, resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxListingBytes))
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", location, err)
+	}
+	return data, nil
+}
@@ -0,0 +1,128 @@
+package source_test
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/AvogadroSG1/civic-summary/internal/domain"
+	"github.com/AvogadroSG1/civic-summary/internal/executor"
+	"github.com/AvogadroSG1/civic-summary/internal/source"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew(t *testing.T) {
+	ytdlp := executor.NewYtDlpExecutor(executor.NewMockCommander(), "yt-dlp")
+
+	tests := []struct {
+		name   string
+		source domain.SourceConfig
+		want   any
+	}{
+		{"default", domain.SourceConfig{}, &source.YouTube{}},
+		{"youtube", domain.SourceConfig{Type: domain.SourceYouTube}, &source.YouTube{}},
+		{"feed", domain.SourceConfig{Type: domain.SourceFeed, URL: "https://example.gov/feed"}, &source.Feed{}},
+		{"urls", domain.SourceConfig{Type: domain.SourceURLList, URL: "videos.txt"}, &source.URLList{}},
+		{"folder", domain.SourceConfig{Type: domain.SourceFolder, URL: "/srv/recordings"}, &source.Folder{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := source.New(domain.Body{Slug: "test", PlaylistID: "PLtest", Source: tt.source}, ytdlp, http.DefaultClient)
+			require.NoError(t, err)
+			assert.IsType(t, tt.want, got)
+		})
+	}
+}
+
+func TestNew_UnknownType(t *testing.T) {
+	body := domain.Body{Slug: "test", Source: domain.SourceConfig{Type: "granicus"}}
+
+	_, err := source.New(body, nil, http.DefaultClient)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `unknown type "granicus"`)
+}
+
+func TestYouTube_Videos(t *testing.T) {
+	mock := executor.NewMockCommander()
+	mock.DefaultResult = &executor.CommandResult{
+		Stdout: `{"id": "abc123", "title": "February 04, 2025 | Regular Session", "upload_date": "20250205", "duration": 5400, "live_status": "was_live"}` + "\n",
+	}
+	yt := source.NewYouTube(executor.NewYtDlpExecutor(mock, "yt-dlp"), "https://www.youtube.com/playlist?list=PLtest")
+
+	videos, err := yt.Videos(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, []domain.Video{{
+		ID:        "abc123",
+		Title:     "February 04, 2025 | Regular Session",
+		URL:       "https://www.youtube.com/watch?v=abc123",
+		Published: time.Date(2025, 2, 5, 0, 0, 0, 0, time.UTC),
+		Details:   domain.VideoDetails{Duration: 90 * time.Minute, LiveStatus: domain.LiveStatusWasLive},
+	}}, videos)
+	assert.Equal(t, []string{"yt-dlp --flat-playlist --dump-json --extractor-args youtubetab:approximate_date https://www.youtube.com/playlist?list=PLtest"}, mock.Calls)
+}
+
+func TestYouTube_DescriptionUsesWatchURL(t *testing.T) {
+	mock := executor.NewMockCommander()
+	mock.OnCommand("yt-dlp --skip-download --print %(description)s https://www.youtube.com/watch?v=abc123",
+		&executor.CommandResult{Stdout: "Agenda: https://example.gov/agenda.pdf\n"}, nil)
+	yt := source.NewYouTube(executor.NewYtDlpExecutor(mock, "yt-dlp"), "")
+
+	description, err := yt.Description(context.Background(), domain.Meeting{VideoID: "abc123"})
+
+	require.NoError(t, err)
+	assert.Equal(t, "Agenda: https://example.gov/agenda.pdf", description)
+}
+
+func TestYouTube_DescriptionFromDetails(t *testing.T) {
+	mock := executor.NewMockCommander()
+	yt := source.NewYouTube(executor.NewYtDlpExecutor(mock, "yt-dlp"), "")
+
+	meeting := domain.Meeting{VideoID: "abc123", Details: domain.VideoDetails{Description: "Agenda: https://example.gov/agenda.pdf"}}
+	description, err := yt.Description(context.Background(), meeting)
+
+	require.NoError(t, err)
+	assert.Equal(t, "Agenda: https://example.gov/agenda.pdf", description)
+	assert.Empty(t, mock.Calls, "a known description needs no yt-dlp call")
+}
+
+func TestYouTube_Details(t *testing.T) {
+	mock := executor.NewMockCommander()
+	mock.OnCommand("yt-dlp --skip-download --dump-json https://www.youtube.com/watch?v=abc123",
+		&executor.CommandResult{Stdout: `{"id": "abc123", "description": "Agenda attached", "subtitles": {"en": []}}`}, nil)
+	yt := source.NewYouTube(executor.NewYtDlpExecutor(mock, "yt-dlp"), "")
+
+	details, err := yt.Details(context.Background(), domain.Meeting{VideoID: "abc123"})
+
+	require.NoError(t, err)
+	assert.Equal(t, "Agenda attached", details.Description)
+	assert.Equal(t, []string{"en"}, details.Captions)
+}
+
+func TestURLList_CaptionsLooksUpUnknownURL(t *testing.T) {
+	listing := filepath.Join(t.TempDir(), "videos.txt")
+	require.NoError(t, os.WriteFile(listing, []byte("https://vimeo.com/123456789 | Council - March 4, 2025\n"), 0o644))
+
+	mock := executor.NewMockCommander()
+	mock.DefaultResult = &executor.CommandResult{Stdout: "No subtitles available\n"}
+	list := source.NewURLList(executor.NewYtDlpExecutor(mock, "yt-dlp"), http.DefaultClient, listing)
+
+	videos, err := list.Videos(context.Background())
+	require.NoError(t, err)
+	require.Len(t, videos, 1)
+
+	// A meeting restored from quarantine carries only its ID.
+	path, err := list.Captions(context.Background(), domain.Meeting{VideoID: videos[0].ID}, domain.DefaultCaptionPolicy(), t.TempDir())
+
+	require.NoError(t, err)
+	assert.Empty(t, path)
+	assert.Equal(t, []string{"yt-dlp --list-subs https://vimeo.com/123456789"}, mock.Calls)
+
+	_, err = list.Captions(context.Background(), domain.Meeting{VideoID: "missing"}, domain.DefaultCaptionPolicy(), t.TempDir())
+	assert.ErrorContains(t, err, "video missing not found in source")
+}
@@ -0,0 +1,59 @@
+package source
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/AvogadroSG1/civic-summary/internal/domain"
+	"github.com/AvogadroSG1/civic-summary/internal/executor"
+)
+
+// URLList reads a plain listing of recordings, one per line:
+//
+//	https://vimeo.com/123456789 | City Council - March 4, 2025
+//	https://example.gov/video/council-2025-03-18.mp4
+//
+// Blank lines and lines starting with # are ignored. A line without a title
+// is titled by its URL's file name.
+type URLList struct {
+	ytdlpMedia
+	httpClient *http.Client
+	location   string
+}
+
+// NewURLList creates a URLList source for a listing's URL or local file path.
+func NewURLList(ytdlp *executor.YtDlpExecutor, httpClient *http.Client, location string) *URLList {
+	l := &URLList{httpClient: httpClient, location: location}
+	l.ytdlpMedia = ytdlpMedia{ytdlp: ytdlp, resolve: listedURL(l.Videos)}
+	return l
+}
+
+// Videos implements VideoSource.
+func (l *URLList) Videos(ctx context.Context) ([]domain.Video, error) {
+	data, err := fetch(ctx, l.httpClient, l.location)
+	if err != nil {
+		return nil, fmt.Errorf("listing urls: %w", err)
+	}
+	return parseURLList(string(data)), nil
+}
+
+// parseURLList parses "URL | Title" lines.
+func parseURLList(content string) []domain.Video {
+	var videos []domain.Video
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		url, title, _ := strings.Cut(line, "|")
+		url, title = strings.TrimSpace(url), strings.TrimSpace(title)
+		if title == "" {
+			title = path.Base(strings.SplitN(url, "?", 2)[0])
+		}
+		videos = append(videos, domain.Video{ID: stableID(url), Title: title, URL: url})
+	}
+	return videos
+}
@@ -0,0 +1,51 @@
+package source_test
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/AvogadroSG1/civic-summary/internal/source"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestURLList_Videos(t *testing.T) {
+	listing := `# Council recordings not on YouTube
+https://vimeo.com/123456789 | City Council - March 4, 2025
+
+https://example.gov/video/council-2025-03-18.mp4?download=1
+  https://example.swagit.com/play/03252025-1234 |   Work Session - March 25, 2025
+`
+	srv := serveFeed(t, listing)
+	list := source.NewURLList(nil, srv.Client(), srv.URL)
+
+	videos, err := list.Videos(context.Background())
+
+	require.NoError(t, err)
+	require.Len(t, videos, 3)
+	assert.Equal(t, "https://vimeo.com/123456789", videos[0].URL)
+	assert.Equal(t, "City Council - March 4, 2025", videos[0].Title)
+	assert.Equal(t, "council-2025-03-18.mp4", videos[1].Title, "untitled lines are titled by file name")
+	assert.Equal(t, "Work Session - March 25, 2025", videos[2].Title)
+	for _, v := range videos {
+		assert.Regexp(t, `^[0-9a-f]{16}$`, v.ID)
+		assert.True(t, v.Published.IsZero())
+	}
+}
+
+func TestURLList_LocalFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "videos.txt")
+	require.NoError(t, os.WriteFile(path, []byte("https://vimeo.com/1 | One\n"), 0o644))
+
+	videos, err := source.NewURLList(nil, http.DefaultClient, path).Videos(context.Background())
+
+	require.NoError(t, err)
+	require.Len(t, videos, 1)
+	assert.Equal(t, "One", videos[0].Title)
+
+	_, err = source.NewURLList(nil, http.DefaultClient, filepath.Join(t.TempDir(), "missing.txt")).Videos(context.Background())
+	assert.ErrorContains(t, err, "listing urls")
+}
@@ -0,0 +1,67 @@
+package source
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/AvogadroSG1/civic-summary/internal/domain"
+	"github.com/AvogadroSG1/civic-summary/internal/executor"
+)
+
+// YouTube lists a YouTube playlist or channel with yt-dlp.
+type YouTube struct {
+	ytdlpMedia
+	listURL string
+}
+
+// NewYouTube creates a YouTube source for a playlist or channel URL. A meeting
+// that carries its own URL, such as a local file passed to ingest, is fetched
+// from there instead of from YouTube.
+func NewYouTube(ytdlp *executor.YtDlpExecutor, listURL string) *YouTube {
+	resolve := func(_ context.Context, meeting domain.Meeting) (string, error) {
+		if meeting.VideoURL != "" {
+			return meeting.VideoURL, nil
+		}
+		return domain.YouTubeVideoURL(meeting.VideoID), nil
+	}
+	return &YouTube{ytdlpMedia: ytdlpMedia{ytdlp: ytdlp, resolve: resolve}, listURL: listURL}
+}
+
+// Videos implements VideoSource. Published is yt-dlp's upload date, which for
+// a flat listing is approximate and may be zero; Details carries whatever
+// other metadata the listing reports.
+func (y *YouTube) Videos(ctx context.Context) ([]domain.Video, error) {
+	entries, err := y.ytdlp.ListPlaylist(ctx, y.listURL)
+	if err != nil {
+		return nil, fmt.Errorf("listing youtube videos: %w", err)
+	}
+
+	videos := make([]domain.Video, 0, len(entries))
+	for _, e := range entries {
+		videos = append(videos, domain.Video{
+			ID:        e.VideoID,
+			Title:     e.Title,
+			URL:       domain.YouTubeVideoURL(e.VideoID),
+			Published: e.UploadDate,
+			Details:   e.Details,
+		})
+	}
+	return videos, nil
+}
+
+// Details implements Detailer, fetching the video's full metadata, which the
+// flat playlist listing leaves out. A local file keeps the details it has.
+func (y *YouTube) Details(ctx context.Context, meeting domain.Meeting) (domain.VideoDetails, error) {
+	url, err := y.resolve(ctx, meeting)
+	if err != nil {
+		return domain.VideoDetails{}, err
+	}
+	if domain.IsLocalFile(url) {
+		return meeting.Details, nil
+	}
+	entry, err := y.ytdlp.VideoInfo(ctx, url)
+	if err != nil {
+		return domain.VideoDetails{}, fmt.Errorf("fetching details of %s: %w", meeting.VideoID, err)
+	}
+	return entry.Details, nil
+}
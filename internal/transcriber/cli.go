@@ -0,0 +1,121 @@
+package transcriber
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/AvogadroSG1/civic-summary/internal/domain"
+	"github.com/AvogadroSG1/civic-summary/internal/executor"
+)
+
+// whisperCLI runs openai-whisper, or whisper-ctranslate2 for faster-whisper,
+// which takes the same flags.
+type whisperCLI struct {
+	cfg       domain.TranscriberConfig
+	commander executor.Commander
+}
+
+func newWhisperCLI(cfg domain.TranscriberConfig, commander executor.Commander) *whisperCLI {
+	return &whisperCLI{cfg: cfg, commander: commander}
+}
+
+// Describe returns a "backend/model" label.
+func (w *whisperCLI) Describe() string {
+	return w.cfg.Describe()
+}
+
+// Transcribe runs the CLI on an audio file and outputs SRT. Both CLIs name
+// their output after the input file: <output_dir>/<audio_stem>.srt.
+func (w *whisperCLI) Transcribe(ctx context.Context, audioPath, outputBase string) (string, error) {
+	outputDir := filepath.Dir(outputBase)
+
+	args := []string{
+		audioPath,
+		"--model", w.cfg.Model,
+		"--output_format", "srt",
+		"--output_dir", outputDir,
+		"--language", w.cfg.Language,
+	}
+	if w.cfg.Threads > 0 {
+		args = append(args, "--threads", strconv.Itoa(w.cfg.Threads))
+	}
+	if prompt := w.cfg.Prompt(); prompt != "" {
+		args = append(args, "--initial_prompt", prompt)
+	}
+
+	if _, err := w.commander.Execute(ctx, w.cfg.Binary, args...); err != nil {
+		return "", fmt.Errorf("%s transcription: %w", w.cfg.Backend, err)
+	}
+
+	audioStem := strings.TrimSuffix(filepath.Base(audioPath), filepath.Ext(audioPath))
+	return filepath.Join(outputDir, audioStem+".srt"), nil
+}
+
+// Check runs the CLI's --help, which fails if it is not installed.
+func (w *whisperCLI) Check(ctx context.Context) error {
+	return checkBinary(ctx, w.commander, w.cfg.Binary)
+}
+
+// whisperCpp runs whisper.cpp's whisper-cli. Unlike the Python CLIs it takes
+// a model file rather than a model name and writes to a given output base.
+type whisperCpp struct {
+	cfg       domain.TranscriberConfig
+	commander executor.Commander
+}
+
+func newWhisperCpp(cfg domain.TranscriberConfig, commander executor.Commander) *whisperCpp {
+	return &whisperCpp{cfg: cfg, commander: commander}
+}
+
+// Describe returns a "backend/model" label, with the model file's name rather
+// than its full path.
+func (w *whisperCpp) Describe() string {
+	return w.cfg.Backend + "/" + filepath.Base(w.cfg.Model)
+}
+
+// Transcribe runs whisper-cli on an audio file and outputs outputBase.srt.
+// whisper-cli reads WAV, MP3, FLAC, and Ogg input.
+func (w *whisperCpp) Transcribe(ctx context.Context, audioPath, outputBase string) (string, error) {
+	args := []string{
+		"--model", w.cfg.Model,
+		"--file", audioPath,
+		"--language", w.cfg.Language,
+		"--output-srt",
+		"--output-file", outputBase,
+	}
+	if w.cfg.Threads > 0 {
+		args = append(args, "--threads", strconv.Itoa(w.cfg.Threads))
+	}
+	if prompt := w.cfg.Prompt(); prompt != "" {
+		args = append(args, "--prompt", prompt)
+	}
+
+	if _, err := w.commander.Execute(ctx, w.cfg.Binary, args...); err != nil {
+		return "", fmt.Errorf("whisper.cpp transcription: %w", err)
+	}
+	return outputBase + ".srt", nil
+}
+
+// Check runs whisper-cli --help, which fails if it is not installed, and
+// confirms the model file exists.
+func (w *whisperCpp) Check(ctx context.Context) error {
+	if err := checkBinary(ctx, w.commander, w.cfg.Binary); err != nil {
+		return err
+	}
+	if _, err := os.Stat(w.cfg.Model); err != nil {
+		return fmt.Errorf("whisper.cpp model: %w", err)
+	}
+	return nil
+}
+
+// checkBinary reports whether binary runs.
+func checkBinary(ctx context.Context, commander executor.Commander, binary string) error {
+	if _, err := commander.Execute(ctx, binary, "--help"); err != nil {
+		return fmt.Errorf("%s is not runnable: %w", binary, err)
+	}
+	return nil
+}
@@ -0,0 +1,123 @@
+package transcriber_test
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/AvogadroSG1/civic-summary/internal/domain"
+	"github.com/AvogadroSG1/civic-summary/internal/executor"
+	"github.com/AvogadroSG1/civic-summary/internal/transcriber"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTranscriber(t *testing.T, cfg domain.TranscriberConfig, commander executor.Commander) transcriber.Transcriber {
+	t.Helper()
+	stt, err := transcriber.New(cfg.WithDefaults(), commander)
+	require.NoError(t, err)
+	return stt
+}
+
+func TestWhisperCLI_Transcribe(t *testing.T) {
+	mock := executor.NewMockCommander()
+
+	audioPath := "/tmp/test/video123.mp3"
+	outputBase := "/tmp/test/video123"
+
+	key := fmt.Sprintf("whisper %s --model medium --output_format srt --output_dir /tmp/test --language en", audioPath)
+	mock.OnCommand(key, &executor.CommandResult{}, nil)
+
+	w := newTranscriber(t, domain.TranscriberConfig{Backend: domain.TranscriberOpenAIWhisper}, mock)
+	srtPath, err := w.Transcribe(context.Background(), audioPath, outputBase)
+	require.NoError(t, err)
+
+	assert.Equal(t, "/tmp/test/video123.srt", srtPath)
+	assert.Equal(t, []string{key}, mock.Calls)
+	assert.Equal(t, "openai-whisper/medium", w.Describe())
+}
+
+func TestWhisperCLI_Transcribe_Error(t *testing.T) {
+	mock := executor.NewMockCommander()
+
+	audioPath := "/tmp/test/video123.mp3"
+	outputBase := "/tmp/test/video123"
+
+	key := fmt.Sprintf("whisper %s --model medium --output_format srt --output_dir /tmp/test --language en", audioPath)
+	mock.OnCommand(key, nil, fmt.Errorf("whisper binary not found"))
+
+	w := newTranscriber(t, domain.TranscriberConfig{Backend: domain.TranscriberOpenAIWhisper}, mock)
+	_, err := w.Transcribe(context.Background(), audioPath, outputBase)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "openai-whisper transcription")
+}
+
+func TestFasterWhisper_Transcribe(t *testing.T) {
+	mock := executor.NewMockCommander()
+
+	w := newTranscriber(t, domain.TranscriberConfig{
+		Backend:    domain.TranscriberFasterWhisper,
+		Model:      "large-v3",
+		Language:   "es",
+		Threads:    8,
+		Vocabulary: []string{"Councilmember Nigh", "Potomac Street"},
+	}, mock)
+	srtPath, err := w.Transcribe(context.Background(), "/tmp/test/GMT20250304.mp4", "/tmp/test/video123")
+	require.NoError(t, err)
+
+	assert.Equal(t, "/tmp/test/GMT20250304.srt", srtPath, "named after the input")
+	assert.Equal(t, []string{
+		"whisper-ctranslate2 /tmp/test/GMT20250304.mp4 --model large-v3 --output_format srt --output_dir /tmp/test --language es" +
+			" --threads 8 --initial_prompt Councilmember Nigh, Potomac Street",
+	}, mock.Calls)
+}
+
+func TestWhisperCpp_Transcribe(t *testing.T) {
+	mock := executor.NewMockCommander()
+
+	w := newTranscriber(t, domain.TranscriberConfig{
+		Backend:    domain.TranscriberWhisperCpp,
+		Binary:     "/opt/whisper.cpp/build/bin/whisper-cli",
+		Model:      "/opt/models/ggml-medium.en.bin",
+		Threads:    4,
+		Vocabulary: []string{"Hagerstown"},
+	}, mock)
+	srtPath, err := w.Transcribe(context.Background(), "/tmp/test/video123.mp3", "/tmp/test/video123")
+	require.NoError(t, err)
+
+	assert.Equal(t, "/tmp/test/video123.srt", srtPath)
+	assert.Equal(t, []string{
+		"/opt/whisper.cpp/build/bin/whisper-cli --model /opt/models/ggml-medium.en.bin --file /tmp/test/video123.mp3" +
+			" --language en --output-srt --output-file /tmp/test/video123 --threads 4 --prompt Hagerstown",
+	}, mock.Calls)
+	assert.Equal(t, "whisper.cpp/ggml-medium.en.bin", w.Describe())
+}
+
+func TestWhisperCpp_Check(t *testing.T) {
+	model := filepath.Join(t.TempDir(), "ggml-base.en.bin")
+	cfg := domain.TranscriberConfig{Backend: domain.TranscriberWhisperCpp, Model: model}
+
+	t.Run("model missing", func(t *testing.T) {
+		err := newTranscriber(t, cfg, executor.NewMockCommander()).Check(context.Background())
+		assert.ErrorContains(t, err, "whisper.cpp model")
+	})
+
+	t.Run("binary missing", func(t *testing.T) {
+		mock := executor.NewMockCommander()
+		mock.OnCommand("whisper-cli --help", nil, fmt.Errorf("executable file not found"))
+		err := newTranscriber(t, cfg, mock).Check(context.Background())
+		assert.ErrorContains(t, err, "whisper-cli is not runnable")
+	})
+}
+
+func TestNew_Invalid(t *testing.T) {
+	_, err := transcriber.New(domain.TranscriberConfig{Backend: "vosk", Model: "small"}, executor.NewMockCommander())
+	assert.ErrorContains(t, err, `unknown backend "vosk"`)
+
+	_, err = transcriber.New(domain.TranscriberConfig{Backend: domain.TranscriberWhisperCpp}.WithDefaults(), executor.NewMockCommander())
+	assert.ErrorContains(t, err, "ggml model file")
+
+	_, err = transcriber.New(domain.TranscriberConfig{}, executor.NewMockCommander())
+	assert.ErrorContains(t, err, "no backend configured")
+}
@@ -0,0 +1,193 @@
+package transcriber
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/AvogadroSG1/civic-summary/internal/domain"
+)
+
+// maxErrorBody caps how much of an error response is quoted in the error.
+const maxErrorBody = 512
+
+// httpTranscriber posts audio to an OpenAI-compatible transcription endpoint.
+type httpTranscriber struct {
+	cfg    domain.TranscriberConfig
+	apiKey string
+	client *http.Client
+}
+
+func newHTTPTranscriber(cfg domain.TranscriberConfig, apiKey string, client *http.Client) *httpTranscriber {
+	return &httpTranscriber{cfg: cfg, apiKey: apiKey, client: client}
+}
+
+// Describe returns a "backend/model" label.
+func (h *httpTranscriber) Describe() string {
+	return h.cfg.Describe()
+}
+
+// maxUploadBytes implements uploader.
+func (h *httpTranscriber) maxUploadBytes() int64 {
+	return h.cfg.MaxUploadBytes()
+}
+
+// UploadTooLargeError reports an audio file over transcriber.max_upload_mb,
+// refused before it was uploaded.
+type UploadTooLargeError struct {
+	Path  string
+	Size  int64
+	Limit int64
+}
+
+func (e *UploadTooLargeError) Error() string {
+	return fmt.Sprintf("%s is %d MB, over transcriber.max_upload_mb (%d MB); set tools.ffmpeg to split the audio into audio.segment_minutes segments, or lower audio.segment_minutes",
+		filepath.Base(e.Path), e.Size>>20, e.Limit>>20)
+}
+
+// Permanent stops retry.Do from retrying: the file will be no smaller next
+// time.
+func (e *UploadTooLargeError) Permanent() bool { return true }
+
+// Transcribe uploads the audio file, asking for SRT, and writes the response
+// to outputBase.srt. A server that answers with JSON segments instead still
+// works: the transcript parser detects the format from the content. A file
+// over the upload limit is refused with an *UploadTooLargeError.
+func (h *httpTranscriber) Transcribe(ctx context.Context, audioPath, outputBase string) (string, error) {
+	if limit := h.cfg.MaxUploadBytes(); limit > 0 {
+		info, err := os.Stat(audioPath)
+		if err != nil {
+			return "", fmt.Errorf("opening audio: %w", err)
+		}
+		if info.Size() > limit {
+			return "", &UploadTooLargeError{Path: audioPath, Size: info.Size(), Limit: limit}
+		}
+	}
+
+	body, contentType, err := h.form(audioPath)
+	if err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, h.url("audio/transcriptions"), body)
+	if err != nil {
+		return "", fmt.Errorf("building transcription request: %w", err)
+	}
+	req.Header.Set("Content-Type", contentType)
+	h.authorize(req)
+
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("transcription request: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("reading transcription response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("transcription request: HTTP %d: %s", resp.StatusCode, truncate(data))
+	}
+
+	srtPath := outputBase + ".srt"
+	if err := os.WriteFile(srtPath, data, 0o644); err != nil {
+		return "", fmt.Errorf("writing transcript: %w", err)
+	}
+	return srtPath, nil
+}
+
+// form streams the multipart upload: the file plus model, format, language,
+// and the vocabulary as the prompt. Meeting audio runs to hundreds of
+// megabytes, so it is not buffered.
+func (h *httpTranscriber) form(audioPath string) (io.Reader, string, error) {
+	audio, err := os.Open(audioPath)
+	if err != nil {
+		return nil, "", fmt.Errorf("opening audio: %w", err)
+	}
+
+	fields := [][2]string{
+		{"model", h.cfg.Model},
+		{"response_format", "srt"},
+		{"language", h.cfg.Language},
+	}
+	if prompt := h.cfg.Prompt(); prompt != "" {
+		fields = append(fields, [2]string{"prompt", prompt})
+	}
+
+	pr, pw := io.Pipe()
+	w := multipart.NewWriter(pw)
+	go func() {
+		defer func() { _ = audio.Close() }()
+		_ = pw.CloseWithError(writeForm(w, fields, filepath.Base(audioPath), audio))
+	}()
+	return pr, w.FormDataContentType(), nil
+}
+
+// writeForm writes the fields and then the file to w.
+func writeForm(w *multipart.Writer, fields [][2]string, filename string, file io.Reader) error {
+	for _, f := range fields {
+		if err := w.WriteField(f[0], f[1]); err != nil {
+			return err
+		}
+	}
+	part, err := w.CreateFormFile("file", filename)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(part, file); err != nil {
+		return fmt.Errorf("reading audio: %w", err)
+	}
+	return w.Close()
+}
+
+// Check requests GET /models, which OpenAI-compatible servers list their
+// models at. Any answer short of an authentication failure or server error
+// means the endpoint is up: some servers do not implement the route.
+func (h *httpTranscriber) Check(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, h.url("models"), nil)
+	if err != nil {
+		return fmt.Errorf("building check request: %w", err)
+	}
+	h.authorize(req)
+
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("transcription endpoint unreachable: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	switch {
+	case resp.StatusCode == http.StatusUnauthorized, resp.StatusCode == http.StatusForbidden:
+		return fmt.Errorf("transcription endpoint rejected the API key: HTTP %d", resp.StatusCode)
+	case resp.StatusCode >= 500:
+		return fmt.Errorf("transcription endpoint failed: HTTP %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// url joins a route onto the base URL.
+func (h *httpTranscriber) url(route string) string {
+	return strings.TrimSuffix(h.cfg.BaseURL, "/") + "/" + route
+}
+
+// authorize adds the bearer token, if there is one.
+func (h *httpTranscriber) authorize(req *http.Request) {
+	if h.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+h.apiKey)
+	}
+}
+
+// truncate shortens an error body for quoting.
+func truncate(data []byte) string {
+	s := strings.TrimSpace(string(data))
+	if len(s) > maxErrorBody {
+		return s[:maxErrorBody] + "…"
+	}
+	return s
+}
@@ -0,0 +1,159 @@
+package transcriber_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/AvogadroSG1/civic-summary/internal/domain"
+	"github.com/AvogadroSG1/civic-summary/internal/executor"
+	"github.com/AvogadroSG1/civic-summary/internal/transcriber"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const serverSRT = "1\n00:00:01,000 --> 00:00:04,000\nThe meeting will come to order.\n"
+
+func httpConfig(baseURL string) domain.TranscriberConfig {
+	return domain.TranscriberConfig{
+		Backend:    domain.TranscriberHTTP,
+		BaseURL:    baseURL + "/v1/",
+		Model:      "Systran/faster-whisper-medium",
+		Vocabulary: []string{"Keller", "Bowers"},
+	}
+}
+
+func TestHTTPTranscriber_Transcribe(t *testing.T) {
+	var got struct {
+		path, auth, model, format, language, prompt, filename string
+		audio                                                 []byte
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got.path = r.URL.Path
+		got.auth = r.Header.Get("Authorization")
+		require.NoError(t, r.ParseMultipartForm(1<<20))
+		got.model = r.FormValue("model")
+		got.format = r.FormValue("response_format")
+		got.language = r.FormValue("language")
+		got.prompt = r.FormValue("prompt")
+		file, header, err := r.FormFile("file")
+		require.NoError(t, err)
+		got.filename = header.Filename
+		got.audio, _ = io.ReadAll(file)
+		_, _ = io.WriteString(w, serverSRT)
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	audioPath := filepath.Join(dir, "video123.mp3")
+	require.NoError(t, os.WriteFile(audioPath, []byte("ID3 audio"), 0o644))
+	t.Setenv("TEST_TRANSCRIBE_KEY", "sk-test")
+	cfg := httpConfig(server.URL)
+	cfg.APIKeyEnv = "TEST_TRANSCRIBE_KEY"
+
+	stt := newTranscriber(t, cfg, executor.NewMockCommander())
+	srtPath, err := stt.Transcribe(context.Background(), audioPath, filepath.Join(dir, "video123"))
+	require.NoError(t, err)
+
+	assert.Equal(t, filepath.Join(dir, "video123.srt"), srtPath)
+	written, err := os.ReadFile(srtPath)
+	require.NoError(t, err)
+	assert.Equal(t, serverSRT, string(written))
+
+	assert.Equal(t, "/v1/audio/transcriptions", got.path)
+	assert.Equal(t, "Bearer sk-test", got.auth)
+	assert.Equal(t, "Systran/faster-whisper-medium", got.model)
+	assert.Equal(t, "srt", got.format)
+	assert.Equal(t, "en", got.language)
+	assert.Equal(t, "Keller, Bowers", got.prompt)
+	assert.Equal(t, "video123.mp3", got.filename)
+	assert.Equal(t, "ID3 audio", string(got.audio))
+}
+
+func TestHTTPTranscriber_Transcribe_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Empty(t, r.Header.Get("Authorization"), "no key is sent without api_key_env")
+		http.Error(w, `{"error":"file too large"}`, http.StatusRequestEntityTooLarge)
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	audioPath := filepath.Join(dir, "video123.mp3")
+	require.NoError(t, os.WriteFile(audioPath, []byte("audio"), 0o644))
+
+	stt := newTranscriber(t, httpConfig(server.URL), executor.NewMockCommander())
+	_, err := stt.Transcribe(context.Background(), audioPath, filepath.Join(dir, "video123"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "HTTP 413")
+	assert.Contains(t, err.Error(), "file too large")
+}
+
+func TestHTTPTranscriber_Transcribe_TooLarge(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("nothing is uploaded")
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	audioPath := filepath.Join(dir, "video123.mp3")
+	require.NoError(t, os.WriteFile(audioPath, make([]byte, 2<<20+1), 0o644))
+	cfg := httpConfig(server.URL)
+	cfg.MaxUploadMB = 2
+
+	stt := newTranscriber(t, cfg, executor.NewMockCommander())
+	_, err := stt.Transcribe(context.Background(), audioPath, filepath.Join(dir, "video123"))
+
+	var tooLarge *transcriber.UploadTooLargeError
+	require.ErrorAs(t, err, &tooLarge)
+	assert.True(t, tooLarge.Permanent())
+	assert.Contains(t, err.Error(), "tools.ffmpeg")
+}
+
+func TestHTTPTranscriber_Check(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		wantErr string
+	}{
+		{"models listed", http.StatusOK, ""},
+		{"route not implemented", http.StatusNotFound, ""},
+		{"bad key", http.StatusUnauthorized, "rejected the API key"},
+		{"server error", http.StatusBadGateway, "HTTP 502"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/v1/models", r.URL.Path)
+				w.WriteHeader(tt.status)
+			}))
+			defer server.Close()
+
+			err := newTranscriber(t, httpConfig(server.URL), executor.NewMockCommander()).Check(context.Background())
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+
+	t.Run("unreachable", func(t *testing.T) {
+		server := httptest.NewServer(http.NotFoundHandler())
+		server.Close()
+		err := newTranscriber(t, httpConfig(server.URL), executor.NewMockCommander()).Check(context.Background())
+		assert.ErrorContains(t, err, "unreachable")
+	})
+}
+
+func TestNew_HTTPMissingAPIKey(t *testing.T) {
+	t.Setenv("TEST_TRANSCRIBE_KEY", "")
+	cfg := httpConfig("http://localhost:8000")
+	cfg.APIKeyEnv = "TEST_TRANSCRIBE_KEY"
+
+	_, err := transcriber.New(cfg.WithDefaults(), executor.NewMockCommander())
+	assert.ErrorContains(t, err, "TEST_TRANSCRIBE_KEY is not set")
+}
@@ -0,0 +1,186 @@
+package transcriber
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/AvogadroSG1/civic-summary/internal/domain"
+	"github.com/AvogadroSG1/civic-summary/internal/executor"
+)
+
+// segmented prepares audio with ffmpeg before handing it to another backend:
+// it trims the silence around the meeting, splits a long recording into
+// overlapping segments, normalizes each one's loudness, transcribes the
+// segments in parallel, and stitches their transcripts back onto the
+// recording's timeline.
+type segmented struct {
+	inner  Transcriber
+	ffmpeg *executor.FFmpegExecutor
+	cfg    domain.AudioConfig
+}
+
+// uploader is a backend that sends the audio elsewhere rather than reading it
+// in place, so the size of each segment matters.
+type uploader interface {
+	// maxUploadBytes returns the largest file the backend accepts, or zero
+	// when there is no limit.
+	maxUploadBytes() int64
+}
+
+// NewSegmented wraps a Transcriber with ffmpeg preprocessing. The result
+// writes outputBase.srt whatever the wrapped backend's naming.
+func NewSegmented(inner Transcriber, ffmpeg *executor.FFmpegExecutor, cfg domain.AudioConfig) Transcriber {
+	return &segmented{inner: inner, ffmpeg: ffmpeg, cfg: cfg}
+}
+
+// Describe returns the wrapped backend's label.
+func (s *segmented) Describe() string {
+	return s.inner.Describe()
+}
+
+// Check verifies that ffmpeg and the wrapped backend can run.
+func (s *segmented) Check(ctx context.Context) error {
+	if err := s.ffmpeg.Check(ctx); err != nil {
+		return err
+	}
+	return s.inner.Check(ctx)
+}
+
+// Transcribe prepares and transcribes audioPath. The segments are written to
+// a working directory beside outputBase, which is removed afterwards.
+func (s *segmented) Transcribe(ctx context.Context, audioPath, outputBase string) (string, error) {
+	duration, silences, err := s.ffmpeg.DetectSilence(ctx, audioPath, s.cfg.SilenceThresholdDB, s.cfg.MinSilence())
+	if err != nil {
+		return "", err
+	}
+	var start, end time.Duration = 0, duration
+	if s.cfg.TrimSilence {
+		start, end = domain.SpeechBounds(duration, silences)
+	}
+	length, ext := s.segmentFormat()
+	segments := domain.PlanSegments(start, end, length, min(s.cfg.Overlap(), length/2))
+	slog.Info("transcribing audio",
+		"audio", audioPath,
+		"duration", duration,
+		"speech_start", start,
+		"speech_end", end,
+		"segments", len(segments),
+	)
+
+	workDir := outputBase + ".segments"
+	if err := os.MkdirAll(workDir, 0o755); err != nil {
+		return "", fmt.Errorf("creating segment directory: %w", err)
+	}
+	defer func() {
+		if err := os.RemoveAll(workDir); err != nil {
+			slog.Warn("failed to remove segment directory", "path", workDir, "error", err)
+		}
+	}()
+
+	parts, err := s.transcribeSegments(ctx, audioPath, filepath.Join(workDir, filepath.Base(outputBase)), ext, segments)
+	if err != nil {
+		return "", err
+	}
+
+	srtPath := outputBase + ".srt"
+	if err := os.WriteFile(srtPath, []byte(domain.StitchTranscripts(segments, parts).SRT()), 0o644); err != nil {
+		return "", fmt.Errorf("writing transcript: %w", err)
+	}
+	return srtPath, nil
+}
+
+// segmentFormat returns the longest segment to transcribe and the extension,
+// and so the encoding, of its audio. A backend that uploads the audio gets
+// MP3, since 30 minutes of WAV is some 57 MB, more than OpenAI accepts, and
+// segments short enough that each fits its upload limit with a tenth to
+// spare. Zero length transcribes the recording whole.
+func (s *segmented) segmentFormat() (time.Duration, string) {
+	length := s.cfg.SegmentLength()
+	up, ok := s.inner.(uploader)
+	if !ok {
+		return length, ".wav"
+	}
+	limit := up.maxUploadBytes()
+	if limit <= 0 {
+		return length, ".mp3"
+	}
+	fits := time.Duration(limit*8*9/10) * time.Second / executor.CompressedBitrate
+	if length <= 0 || length > fits {
+		length = fits
+	}
+	return length, ".mp3"
+}
+
+// transcribeSegments extracts and transcribes the segments, cfg.Parallel at a
+// time. The first failure cancels the rest and is the one returned.
+func (s *segmented) transcribeSegments(ctx context.Context, audioPath, partBase, ext string, segments []domain.AudioSegment) ([]domain.Transcript, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		firstErr error
+	)
+	parts := make([]domain.Transcript, len(segments))
+	slots := make(chan struct{}, max(s.cfg.Parallel, 1))
+	for i, seg := range segments {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			select {
+			case slots <- struct{}{}:
+				defer func() { <-slots }()
+			case <-ctx.Done():
+				return
+			}
+			part, err := s.transcribeSegment(ctx, audioPath, fmt.Sprintf("%s.part%02d", partBase, seg.Index), ext, seg)
+			if err != nil {
+				mu.Lock()
+				if firstErr == nil {
+					firstErr = err
+					cancel()
+				}
+				mu.Unlock()
+				return
+			}
+			parts[i] = part
+		}()
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return parts, nil
+}
+
+// transcribeSegment extracts one segment to partBase plus ext and transcribes
+// it. The transcript's times are relative to the segment's start.
+func (s *segmented) transcribeSegment(ctx context.Context, audioPath, partBase, ext string, seg domain.AudioSegment) (domain.Transcript, error) {
+	segPath := partBase + ext
+	if err := s.ffmpeg.Extract(ctx, audioPath, segPath, seg.Start, seg.Duration(), s.cfg.Normalize); err != nil {
+		return domain.Transcript{}, fmt.Errorf("segment %d: %w", seg.Index, err)
+	}
+	path, err := s.inner.Transcribe(ctx, segPath, partBase)
+	if err != nil {
+		return domain.Transcript{}, fmt.Errorf("segment %d: %w", seg.Index, err)
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return domain.Transcript{}, fmt.Errorf("segment %d: reading transcript: %w", seg.Index, err)
+	}
+	transcript, err := domain.ParseTranscript(string(content), path, domain.TranscriptSourceWhisper)
+	if err != nil {
+		return domain.Transcript{}, fmt.Errorf("segment %d: parsing %s: %w", seg.Index, path, err)
+	}
+	return transcript, nil
+}
@@ -0,0 +1,165 @@
+package transcriber_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/AvogadroSG1/civic-summary/internal/domain"
+	"github.com/AvogadroSG1/civic-summary/internal/executor"
+	"github.com/AvogadroSG1/civic-summary/internal/transcriber"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeTranscriber writes a canned SRT for each segment, chosen by the suffix
+// of its output base, e.g. "part02".
+type fakeTranscriber struct {
+	mu     sync.Mutex
+	srts   map[string]string
+	fail   string
+	audios []string
+}
+
+func (f *fakeTranscriber) Transcribe(_ context.Context, audioPath, outputBase string) (string, error) {
+	f.mu.Lock()
+	f.audios = append(f.audios, filepath.Base(audioPath))
+	f.mu.Unlock()
+
+	part := outputBase[strings.LastIndex(outputBase, ".")+1:]
+	if part == f.fail {
+		return "", fmt.Errorf("whisper crashed")
+	}
+	srtPath := outputBase + ".srt"
+	return srtPath, os.WriteFile(srtPath, []byte(f.srts[part]), 0o644)
+}
+
+func (f *fakeTranscriber) Check(context.Context) error { return nil }
+func (f *fakeTranscriber) Describe() string            { return "fake/model" }
+
+func segmentedAudioConfig() domain.AudioConfig {
+	return domain.AudioConfig{
+		Normalize:          true,
+		TrimSilence:        true,
+		SilenceThresholdDB: -50,
+		MinSilenceSeconds:  5,
+		SegmentMinutes:     1,
+		OverlapSeconds:     10,
+		Parallel:           2,
+	}
+}
+
+// ffmpegMock reports a 2:30 recording whose first 20 seconds are silent.
+func ffmpegMock() *executor.MockCommander {
+	mock := executor.NewMockCommander()
+	mock.OnCommand("ffmpeg -hide_banner -nostdin -nostats -i /tmp/in/meeting.mp3 -af silencedetect=noise=-50dB:d=5.000 -f null -",
+		&executor.CommandResult{Stderr: "  Duration: 00:02:30.00, start: 0.000000\n" +
+			"[silencedetect @ 0x1] silence_start: 0\n[silencedetect @ 0x1] silence_end: 20 | silence_duration: 20\n"}, nil)
+	return mock
+}
+
+func TestSegmented_Transcribe(t *testing.T) {
+	dir := t.TempDir()
+	mock := ffmpegMock()
+	inner := &fakeTranscriber{srts: map[string]string{
+		"part01": "1\n00:00:01,000 --> 00:00:04,000\nCall to order.\n\n2\n00:00:52,000 --> 00:00:56,000\nRoll call.\n",
+		"part02": "1\n00:00:02,000 --> 00:00:06,000\ncall.\n\n2\n00:00:06,000 --> 00:00:09,000\nPledge of allegiance.\n",
+		"part03": "1\n00:00:05,000 --> 00:00:08,000\nAdjourned.\n",
+	}}
+	stt := transcriber.NewSegmented(inner, executor.NewFFmpegExecutor(mock, "ffmpeg"), segmentedAudioConfig())
+
+	srtPath, err := stt.Transcribe(context.Background(), "/tmp/in/meeting.mp3", filepath.Join(dir, "abc123"))
+	require.NoError(t, err)
+
+	assert.Equal(t, filepath.Join(dir, "abc123.srt"), srtPath)
+	content, err := os.ReadFile(srtPath)
+	require.NoError(t, err)
+	var lines []string
+	for _, c := range domain.ParseSRT(string(content)) {
+		lines = append(lines, c.Compact())
+	}
+	assert.Equal(t, []string{
+		"[00:00:21] Call to order.",
+		"[00:01:12] Roll call.",
+		"[00:01:16] Pledge of allegiance.",
+		"[00:02:05] Adjourned.",
+	}, lines, "on the recording's timeline, starting after the silence")
+
+	work := filepath.Join(dir, "abc123.segments")
+	assert.Contains(t, mock.Calls,
+		"ffmpeg -hide_banner -nostdin -loglevel error -y -ss 70.000 -t 60.000 -i /tmp/in/meeting.mp3 -af loudnorm -ar 16000 -ac 1 "+filepath.Join(work, "abc123.part02.wav"))
+	assert.ElementsMatch(t, []string{"abc123.part01.wav", "abc123.part02.wav", "abc123.part03.wav"}, inner.audios)
+	assert.NoDirExists(t, work, "segments are cleaned up")
+	assert.Equal(t, "fake/model", stt.Describe())
+}
+
+func TestSegmented_Transcribe_UploadsCompressed(t *testing.T) {
+	var uploads []string
+	var mu sync.Mutex
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, header, err := r.FormFile("file")
+		require.NoError(t, err)
+		mu.Lock()
+		uploads = append(uploads, header.Filename)
+		mu.Unlock()
+		_, _ = io.WriteString(w, serverSRT)
+	}))
+	defer server.Close()
+
+	// Ten minutes without silence, and a 1 MB upload limit.
+	mock := executor.NewMockCommander()
+	mock.OnCommand("ffmpeg -hide_banner -nostdin -nostats -i /tmp/in/meeting.mp3 -af silencedetect=noise=-50dB:d=5.000 -f null -",
+		&executor.CommandResult{Stderr: "  Duration: 00:10:00.00, start: 0.000000\n"}, nil)
+	cfg := httpConfig(server.URL)
+	cfg.MaxUploadMB = 1
+	audio := segmentedAudioConfig()
+	audio.SegmentMinutes = 0
+	stt := transcriber.NewSegmented(newTranscriber(t, cfg, mock), executor.NewFFmpegExecutor(mock, "ffmpeg"), audio)
+
+	// The mock writes nothing, so the segments ffmpeg would extract are
+	// created beforehand.
+	dir := t.TempDir()
+	work := filepath.Join(dir, "abc123.segments")
+	require.NoError(t, os.MkdirAll(work, 0o755))
+	for i := 1; i <= 5; i++ {
+		require.NoError(t, os.WriteFile(filepath.Join(work, fmt.Sprintf("abc123.part%02d.mp3", i)), []byte("ID3"), 0o644))
+	}
+
+	_, err := stt.Transcribe(context.Background(), "/tmp/in/meeting.mp3", filepath.Join(dir, "abc123"))
+	require.NoError(t, err)
+
+	assert.Contains(t, mock.Calls,
+		"ffmpeg -hide_banner -nostdin -loglevel error -y -ss 0.000 -t 157.286 -i /tmp/in/meeting.mp3 -af loudnorm -c:a libmp3lame -b:a 48k -ar 16000 -ac 1 "+filepath.Join(work, "abc123.part01.mp3"),
+		"segments are MP3, cut short enough to fit the upload limit")
+	assert.ElementsMatch(t, []string{"abc123.part01.mp3", "abc123.part02.mp3", "abc123.part03.mp3", "abc123.part04.mp3", "abc123.part05.mp3"}, uploads)
+}
+
+func TestSegmented_Transcribe_SegmentFails(t *testing.T) {
+	dir := t.TempDir()
+	inner := &fakeTranscriber{fail: "part02", srts: map[string]string{}}
+	stt := transcriber.NewSegmented(inner, executor.NewFFmpegExecutor(ffmpegMock(), "ffmpeg"), segmentedAudioConfig())
+
+	_, err := stt.Transcribe(context.Background(), "/tmp/in/meeting.mp3", filepath.Join(dir, "abc123"))
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "segment 2: whisper crashed")
+	assert.NoFileExists(t, filepath.Join(dir, "abc123.srt"))
+}
+
+func TestSegmented_Check(t *testing.T) {
+	mock := executor.NewMockCommander()
+	mock.OnCommand("ffmpeg -version", nil, fmt.Errorf("executable file not found"))
+	stt := transcriber.NewSegmented(&fakeTranscriber{}, executor.NewFFmpegExecutor(mock, "ffmpeg"), segmentedAudioConfig())
+
+	err := stt.Check(context.Background())
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "ffmpeg is not runnable")
+}
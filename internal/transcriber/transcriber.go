@@ -0,0 +1,60 @@
+// Package transcriber turns meeting audio into a timed transcript when a
+// recording has no usable captions. Backends either run a local Whisper CLI
+// (openai-whisper, whisper.cpp, or faster-whisper) through an
+// executor.Commander, or post the audio to an OpenAI-compatible
+// POST /v1/audio/transcriptions endpoint, which covers OpenAI itself and
+// self-hosted servers such as faster-whisper-server, LocalAI, and vLLM.
+package transcriber
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/AvogadroSG1/civic-summary/internal/domain"
+	"github.com/AvogadroSG1/civic-summary/internal/executor"
+)
+
+// Transcriber transcribes an audio or video file.
+type Transcriber interface {
+	// Transcribe writes a transcript of audioPath and returns the file's
+	// path. outputBase is the path without extension (e.g. /tmp/video123);
+	// backends that name their output after the input write it beside
+	// outputBase instead.
+	Transcribe(ctx context.Context, audioPath, outputBase string) (string, error)
+
+	// Check verifies that the backend can run: that its CLI starts, or that
+	// its endpoint answers and accepts the API key. It transcribes nothing.
+	Check(ctx context.Context) error
+
+	// Describe returns a short "backend/model" label for logs and status
+	// output. It never includes the API key.
+	Describe() string
+}
+
+// New builds a Transcriber for a resolved config. CLI backends run through
+// commander. The HTTP backend reads its API key, if any, from the environment
+// variable named by cfg.APIKeyEnv.
+func New(cfg domain.TranscriberConfig, commander executor.Commander) (Transcriber, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("transcriber: %w", err)
+	}
+
+	switch cfg.Backend {
+	case domain.TranscriberOpenAIWhisper, domain.TranscriberFasterWhisper:
+		return newWhisperCLI(cfg, commander), nil
+	case domain.TranscriberWhisperCpp:
+		return newWhisperCpp(cfg, commander), nil
+	case domain.TranscriberHTTP:
+		apiKey := ""
+		if cfg.APIKeyEnv != "" {
+			if apiKey = os.Getenv(cfg.APIKeyEnv); apiKey == "" {
+				return nil, fmt.Errorf("transcriber: %s is not set; export it with your transcription API key", cfg.APIKeyEnv)
+			}
+		}
+		return newHTTPTranscriber(cfg, apiKey, &http.Client{Timeout: cfg.Timeout()}), nil
+	default:
+		return nil, fmt.Errorf("transcriber: no backend configured")
+	}
+}